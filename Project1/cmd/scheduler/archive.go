@@ -0,0 +1,421 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+
+	"github.com/jh125486/CSCE4600/Project1/render"
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+	"github.com/jh125486/CSCE4600/Project1/workload"
+)
+
+// defaultArchiveDir is where archive keeps runs unless -dir says otherwise.
+const defaultArchiveDir = "scheduler-archive"
+
+var ErrUnknownArchivedRun = fmt.Errorf("%w: no such archived run", scheduler.ErrInvalidArgs)
+
+type (
+	// archivedRun is one invocation of the schedulers, saved as dir/<id>.json. Each result
+	// carries its provenance: the input's hash, the parameters and the algorithm versions.
+	archivedRun struct {
+		ID         int                `json:"id"`
+		Label      string             `json:"label,omitempty"`
+		RecordedAt time.Time          `json:"recorded_at"`
+		Version    string             `json:"version"`
+		Revision   string             `json:"revision,omitempty"`
+		Args       []string           `json:"args"`
+		Results    []scheduler.Result `json:"results"`
+	}
+	// archiveConfig holds the parsed archive command line.
+	archiveConfig struct {
+		config
+		dir   string
+		label string
+		list  bool
+	}
+	// compareRunsConfig holds the parsed compare-runs command line.
+	compareRunsConfig struct {
+		dir  string
+		a, b string
+	}
+)
+
+// parseArchiveFlags parses `scheduler archive [flags] workload...`.
+func parseArchiveFlags(errW io.Writer, args []string) (archiveConfig, error) {
+	ac := archiveConfig{config: defaultConfig(), dir: defaultArchiveDir}
+
+	fs := flag.NewFlagSet("scheduler archive", flag.ContinueOnError)
+	fs.SetOutput(errW)
+	fs.Usage = func() {
+		_, _ = fmt.Fprintln(errW, "usage: scheduler archive [flags] workload...")
+		_, _ = fmt.Fprintln(errW, "Runs the schedulers over the workloads and archives the results with their provenance, for compare-runs.")
+		fs.PrintDefaults()
+	}
+	fs.StringVar(&ac.dir, "dir", defaultArchiveDir, "archive directory")
+	fs.StringVar(&ac.label, "label", "", "label to remember the run by, such as a branch or policy name")
+	fs.BoolVar(&ac.list, "list", false, "list the archived runs instead of running")
+	fs.Func("algorithms", "comma-separated algorithms to run (default all)", func(s string) (err error) {
+		ac.algorithms, err = parseAlgorithms(s)
+		return err
+	})
+	fs.Func("policy", "also run the scheduling policy in this script (repeatable)",
+		func(s string) error { return ac.addCustom(s, loadPolicy) })
+	fs.Func("hierarchy", "also run the scheduler hierarchy in this YAML file (repeatable)",
+		func(s string) error { return ac.addCustom(s, loadHierarchy) })
+	fs.IntVar(&ac.seeds, "seeds", 1, "run each generated (gen:) workload with this many consecutive seeds")
+	fs.Func("input-format", "workload format: auto or "+workload.KnownFormats(), func(s string) (err error) {
+		ac.inputFormat, err = workload.ParseFormat(s)
+		return err
+	})
+	fs.Func("time-unit", "unit of the workload times: ticks, ms or s (default ticks)", func(s string) (err error) {
+		ac.timeUnit, err = scheduler.ParseTimeUnit(s)
+		return err
+	})
+	fs.IntVar(&ac.workers, "workers", defaultWorkers, "number of workloads simulated in parallel")
+	if err := fs.Parse(args); err != nil {
+		return ac, err
+	}
+	ac.args = fs.Args()
+
+	var err error
+	switch {
+	case ac.list && len(ac.args) > 0:
+		err = fmt.Errorf("%w: -list takes no workloads", scheduler.ErrInvalidArgs)
+	case !ac.list && len(ac.args) == 0:
+		err = fmt.Errorf("%w: must give a workload to archive", scheduler.ErrInvalidArgs)
+	case ac.seeds < 1:
+		err = fmt.Errorf("%w: -seeds must be at least 1", scheduler.ErrInvalidArgs)
+	}
+	for _, arg := range ac.args {
+		if err == nil && workload.IsGenerated(arg) {
+			_, err = workload.ParseGeneratedSpec(arg)
+		}
+	}
+	if err != nil {
+		_, _ = fmt.Fprintln(errW, err)
+	}
+
+	return ac, err
+}
+
+// run simulates the workloads and archives the results as the next run, or lists the archive.
+func (ac archiveConfig) run(stdout, errW io.Writer) error {
+	if ac.list {
+		return outputArchive(stdout, ac.dir)
+	}
+
+	opts := ac.options(errW)
+	inputs := ac.inputs()
+	results, err := runPool(len(inputs), ac.workers, func(i int) ([]scheduler.Result, error) {
+		return ac.runWorkload(inputs[i], opts, nil)
+	})
+	if err != nil {
+		return err
+	}
+	run, err := archiveResults(ac.dir, archivedRun{
+		Label:      ac.label,
+		RecordedAt: time.Now().UTC(),
+		Version:    scheduler.Version,
+		Revision:   vcsRevision(),
+		Args:       ac.args,
+		Results:    results,
+	})
+	if err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(stdout, "Archived run %d (%d results) in %s\n", run.ID, len(run.Results), ac.dir)
+
+	return nil
+}
+
+// archivedRunIDs are the ids of the runs in dir, in ascending order.
+func archivedRunIDs(dir string) ([]int, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading archive", err)
+	}
+	var ids []int
+	for _, e := range entries {
+		id, err := strconv.Atoi(strings.TrimSuffix(e.Name(), ".json"))
+		if err == nil && !e.IsDir() && strings.HasSuffix(e.Name(), ".json") {
+			ids = append(ids, id)
+		}
+	}
+	sort.Ints(ids)
+
+	return ids, nil
+}
+
+// archiveResults saves run in dir under the next id, which it returns in run.ID.
+func archiveResults(dir string, run archivedRun) (archivedRun, error) {
+	ids, err := archivedRunIDs(dir)
+	if err != nil {
+		return run, err
+	}
+	run.ID = 1
+	if len(ids) > 0 {
+		run.ID = ids[len(ids)-1] + 1
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return run, fmt.Errorf("%w: creating archive", err)
+	}
+	b, err := json.MarshalIndent(run, "", "  ")
+	if err != nil {
+		return run, err
+	}
+	// O_EXCL so that two archivers racing for the same id cannot overwrite each other.
+	f, err := os.OpenFile(filepath.Join(dir, strconv.Itoa(run.ID)+".json"), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return run, fmt.Errorf("%w: archiving run", err)
+	}
+	if _, err := f.Write(append(b, '\n')); err != nil {
+		_ = f.Close()
+		return run, fmt.Errorf("%w: archiving run", err)
+	}
+
+	return run, f.Close()
+}
+
+// loadArchivedRun reads the run named by ref: an id, latest or previous (the one before latest).
+func loadArchivedRun(dir, ref string) (archivedRun, error) {
+	var run archivedRun
+	ids, err := archivedRunIDs(dir)
+	if err != nil {
+		return run, err
+	}
+	id, err := strconv.Atoi(ref)
+	switch {
+	case ref == "latest" && len(ids) > 0:
+		id, err = ids[len(ids)-1], nil
+	case ref == "previous" && len(ids) > 1:
+		id, err = ids[len(ids)-2], nil
+	}
+	if err != nil {
+		return run, fmt.Errorf("%w: %q in %s", ErrUnknownArchivedRun, ref, dir)
+	}
+	b, err := os.ReadFile(filepath.Join(dir, strconv.Itoa(id)+".json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return run, fmt.Errorf("%w: %q in %s", ErrUnknownArchivedRun, ref, dir)
+	}
+	if err != nil {
+		return run, fmt.Errorf("%w: reading archived run", err)
+	}
+	if err := json.Unmarshal(b, &run); err != nil {
+		return run, fmt.Errorf("%w: archived run %d: %v", scheduler.ErrInvalidArgs, id, err)
+	}
+
+	return run, nil
+}
+
+// outputArchive lists the runs in dir.
+func outputArchive(w io.Writer, dir string) error {
+	ids, err := archivedRunIDs(dir)
+	if err != nil {
+		return err
+	}
+	render.OutputTitle(w, "Archive "+dir)
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"ID", "Label", "Recorded", "Revision", "Results", "Workloads"})
+	table.SetAutoWrapText(false)
+	for _, id := range ids {
+		run, err := loadArchivedRun(dir, strconv.Itoa(id))
+		if err != nil {
+			return err
+		}
+		table.Append([]string{strconv.Itoa(run.ID), run.Label, run.RecordedAt.Format(time.RFC3339), shortRevision(run.Revision),
+			strconv.Itoa(len(run.Results)), strings.Join(run.Args, " ")})
+	}
+	table.Render()
+
+	return nil
+}
+
+// shortRevision abbreviates a VCS revision the way git does; an unknown revision is "-".
+func shortRevision(rev string) string {
+	hash, dirty, found := strings.Cut(rev, "+")
+	switch {
+	case rev == "":
+		return "-"
+	case len(hash) > 12:
+		hash = hash[:12]
+	}
+	if found {
+		hash += "+" + dirty
+	}
+
+	return hash
+}
+
+// parseCompareRunsFlags parses `scheduler compare-runs [flags] run-a run-b`.
+func parseCompareRunsFlags(errW io.Writer, args []string) (compareRunsConfig, error) {
+	cc := compareRunsConfig{dir: defaultArchiveDir}
+
+	fs := flag.NewFlagSet("scheduler compare-runs", flag.ContinueOnError)
+	fs.SetOutput(errW)
+	fs.Usage = func() {
+		_, _ = fmt.Fprintln(errW, "usage: scheduler compare-runs [flags] run-a run-b")
+		_, _ = fmt.Fprintln(errW, "Compares two archived runs, given by id, latest or previous, metric by metric.")
+		fs.PrintDefaults()
+	}
+	fs.StringVar(&cc.dir, "dir", defaultArchiveDir, "archive directory")
+	if err := fs.Parse(args); err != nil {
+		return cc, err
+	}
+	if fs.NArg() != 2 {
+		err := fmt.Errorf("%w: must give two archived runs to compare", scheduler.ErrInvalidArgs)
+		_, _ = fmt.Fprintln(errW, err)
+		return cc, err
+	}
+	cc.a, cc.b = fs.Arg(0), fs.Arg(1)
+
+	return cc, nil
+}
+
+// run loads both runs and writes the comparison.
+func (cc compareRunsConfig) run(stdout, _ io.Writer) error {
+	a, err := loadArchivedRun(cc.dir, cc.a)
+	if err != nil {
+		return err
+	}
+	b, err := loadArchivedRun(cc.dir, cc.b)
+	if err != nil {
+		return err
+	}
+	outputRunComparison(stdout, compareRuns(a, b))
+
+	return nil
+}
+
+type (
+	// runComparison is how run b differs from run a.
+	runComparison struct {
+		a, b    archivedRun
+		pairs   []runPair
+		onlyA   []string // workload/algorithm of results only run a has
+		onlyB   []string
+		changes []string // differences in provenance that explain differing metrics
+	}
+	// runPair is the same algorithm over the same workload in both runs.
+	runPair struct {
+		workload, algorithm string
+		a, b                scheduler.Result
+	}
+)
+
+// compareRuns pairs the results of a and b by workload and algorithm, in a's order.
+func compareRuns(a, b archivedRun) runComparison {
+	key := func(r scheduler.Result) string { return r.Input + "/" + r.Algorithm }
+	c := runComparison{a: a, b: b}
+	inB := make(map[string]scheduler.Result, len(b.Results))
+	for _, r := range b.Results {
+		inB[key(r)] = r
+	}
+	paired := make(map[string]bool, len(a.Results))
+	for _, ra := range a.Results {
+		rb, ok := inB[key(ra)]
+		if !ok {
+			c.onlyA = append(c.onlyA, key(ra))
+			continue
+		}
+		paired[key(ra)] = true
+		c.pairs = append(c.pairs, runPair{workload: ra.Input, algorithm: ra.Algorithm, a: ra, b: rb})
+		c.changes = append(c.changes, provenanceChanges(key(ra), ra.Algorithm, ra.Provenance, rb.Provenance)...)
+	}
+	for _, rb := range b.Results {
+		if !paired[key(rb)] {
+			c.onlyB = append(c.onlyB, key(rb))
+		}
+	}
+	if a.Revision != b.Revision {
+		c.changes = append([]string{fmt.Sprintf("revision %s → %s", shortRevision(a.Revision), shortRevision(b.Revision))}, c.changes...)
+	}
+	if a.Version != b.Version {
+		c.changes = append([]string{fmt.Sprintf("version %s → %s", a.Version, b.Version)}, c.changes...)
+	}
+
+	return c
+}
+
+// provenanceChanges lists how the provenance of one paired result changed.
+func provenanceChanges(key, algorithm string, a, b *scheduler.Provenance) []string {
+	if a == nil || b == nil {
+		return nil
+	}
+	var changes []string
+	if a.InputSHA256 != b.InputSHA256 {
+		changes = append(changes, key+": input changed")
+	}
+	if va, vb := a.Algorithms[algorithm], b.Algorithms[algorithm]; va != vb {
+		changes = append(changes, fmt.Sprintf("%s: algorithm version %s → %s", key, va, vb))
+	}
+	names := make([]string, 0, len(a.Parameters)+len(b.Parameters))
+	for name := range a.Parameters {
+		names = append(names, name)
+	}
+	for name := range b.Parameters {
+		if _, ok := a.Parameters[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if a.Parameters[name] != b.Parameters[name] {
+			changes = append(changes, fmt.Sprintf("%s: %s %q → %q", key, name, a.Parameters[name], b.Parameters[name]))
+		}
+	}
+
+	return changes
+}
+
+// outputRunComparison prints both runs' metadata, what changed between them and, for each paired
+// result, every metric in a and b with the change.
+func outputRunComparison(w io.Writer, c runComparison) {
+	render.OutputTitle(w, fmt.Sprintf("Compare runs %d → %d", c.a.ID, c.b.ID))
+	for _, run := range []archivedRun{c.a, c.b} {
+		label := ""
+		if run.Label != "" {
+			label = " (" + run.Label + ")"
+		}
+		_, _ = fmt.Fprintf(w, "Run %d%s: recorded %s, revision %s\n", run.ID, label, run.RecordedAt.Format(time.RFC3339), shortRevision(run.Revision))
+	}
+	for _, change := range c.changes {
+		_, _ = fmt.Fprintf(w, "  changed: %s\n", change)
+	}
+	for _, key := range c.onlyA {
+		_, _ = fmt.Fprintf(w, "  only in run %d: %s\n", c.a.ID, key)
+	}
+	for _, key := range c.onlyB {
+		_, _ = fmt.Fprintf(w, "  only in run %d: %s\n", c.b.ID, key)
+	}
+
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Workload", "Algorithm", "Metric", fmt.Sprintf("Run %d", c.a.ID), fmt.Sprintf("Run %d", c.b.ID), "Change", "Change %"})
+	table.SetAutoWrapText(false)
+	table.SetAutoMergeCellsByColumnIndex([]int{0, 1})
+	f := func(v float64) string { return strconv.FormatFloat(v, 'f', 2, 64) }
+	for _, p := range c.pairs {
+		for _, m := range monteCarloMetrics() {
+			va, vb := resultMetrics[m](p.a), resultMetrics[m](p.b)
+			pct := "-"
+			if va != 0 {
+				pct = strconv.FormatFloat((vb-va)/va*100, 'f', 1, 64) + "%"
+			}
+			table.Append([]string{p.workload, p.algorithm, m, f(va), f(vb), f(vb - va), pct})
+		}
+	}
+	table.Render()
+}
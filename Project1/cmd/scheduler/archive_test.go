@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_archive_compareRuns(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	archive := filepath.Join(dir, "archive")
+	input := filepath.Join(dir, "w.csv")
+	run := func(sub interface {
+		run(stdout, errW io.Writer) error
+	}, err error) string {
+		require.NoError(t, err)
+		var stdout bytes.Buffer
+		require.NoError(t, sub.run(&stdout, io.Discard))
+		return stdout.String()
+	}
+
+	require.NoError(t, os.WriteFile(input, []byte("1,5,0,1\n2,3,1,2\n"), 0o644))
+	assert.Equal(t, "Archived run 1 (2 results) in "+archive+"\n",
+		run(parseArchiveFlags(io.Discard, []string{"-dir", archive, "-label", "before", "-algorithms", "fcfs,sjf", input})))
+	require.NoError(t, os.WriteFile(input, []byte("1,5,0,1\n2,9,1,2\n"), 0o644))
+	assert.Equal(t, "Archived run 2 (1 results) in "+archive+"\n",
+		run(parseArchiveFlags(io.Discard, []string{"-dir", archive, "-algorithms", "fcfs", input})))
+
+	list := run(parseArchiveFlags(io.Discard, []string{"-dir", archive, "-list"}))
+	assert.Contains(t, list, "before")
+	assert.Regexp(t, `\|\s+2\s+\|`, list)
+
+	out := run(parseCompareRunsFlags(io.Discard, []string{"-dir", archive, "previous", "latest"}))
+	assert.Contains(t, out, "Compare runs 1 → 2")
+	assert.Contains(t, out, "Run 1 (before): recorded ")
+	assert.Contains(t, out, "changed: "+input+"/fcfs: input changed")
+	assert.Contains(t, out, "only in run 1: "+input+"/sjf")
+	// P2's burst grew by 6, so the makespan did too.
+	assert.Regexp(t, `makespan\s+\|\s+8\.00\s+\|\s+14\.00\s+\|\s+6\.00\s+\|\s+75\.0%`, out)
+
+	_, err := loadArchivedRun(archive, "3")
+	assert.ErrorIs(t, err, ErrUnknownArchivedRun)
+	_, err = loadArchivedRun(filepath.Join(dir, "none"), "latest")
+	assert.ErrorIs(t, err, ErrUnknownArchivedRun)
+}
+
+func Test_parseArchiveFlags(t *testing.T) {
+	t.Parallel()
+	for _, args := range [][]string{nil, {"-list", "w.csv"}, {"-seeds", "0", "w.csv"}, {"gen:nope"}} {
+		_, err := parseArchiveFlags(io.Discard, args)
+		assert.Error(t, err, args)
+	}
+	_, err := parseCompareRunsFlags(io.Discard, []string{"1"})
+	assert.Error(t, err)
+}
+
+func Test_shortRevision(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "-", shortRevision(""))
+	assert.Equal(t, "abc", shortRevision("abc"))
+	assert.Equal(t, "0123456789ab", shortRevision("0123456789abcdef"))
+	assert.Equal(t, "0123456789ab+dirty", shortRevision("0123456789abcdef+dirty"))
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"runtime"
+	"sync"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+// defaultWorkers is the default size of the batch worker pool: one per host core.
+var defaultWorkers = runtime.NumCPU()
+
+// runPool runs n independent tasks on a pool of worker goroutines and returns their results
+// concatenated in task order, so output is deterministic regardless of scheduling.
+// If any task fails, the error of the lowest-numbered failing task is returned.
+func runPool(n, workers int, task func(i int) ([]scheduler.Result, error)) ([]scheduler.Result, error) {
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > n {
+		workers = n
+	}
+	var (
+		results = make([][]scheduler.Result, n)
+		errs    = make([]error, n)
+		jobs    = make(chan int)
+		wg      sync.WaitGroup
+	)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i], errs[i] = task(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	var all []scheduler.Result
+	for i := range results {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		all = append(all, results[i]...)
+	}
+
+	return all, nil
+}
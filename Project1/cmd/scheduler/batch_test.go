@@ -0,0 +1,77 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+func Test_runPool(t *testing.T) {
+	t.Parallel()
+	errBoom := errors.New("boom")
+	tests := []struct {
+		name    string
+		n       int
+		workers int
+		failOn  map[int]bool
+		want    []string
+		wantErr error
+	}{
+		{
+			name:    "ordered",
+			n:       5,
+			workers: 3,
+			want:    []string{"0a", "0b", "1a", "1b", "2a", "2b", "3a", "3b", "4a", "4b"},
+		},
+		{
+			name:    "more workers than tasks",
+			n:       1,
+			workers: 8,
+			want:    []string{"0a", "0b"},
+		},
+		{
+			name:    "zero workers still runs",
+			n:       2,
+			workers: 0,
+			want:    []string{"0a", "0b", "1a", "1b"},
+		},
+		{
+			name:    "error",
+			n:       4,
+			workers: 2,
+			failOn:  map[int]bool{2: true, 3: true},
+			wantErr: errBoom,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := runPool(tt.n, tt.workers, func(i int) ([]scheduler.Result, error) {
+				if tt.failOn[i] {
+					return nil, fmt.Errorf("%w: task %d", errBoom, i)
+				}
+				return []scheduler.Result{{Title: fmt.Sprintf("%da", i)}, {Title: fmt.Sprintf("%db", i)}}, nil
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if want := "boom: task 2"; err.Error() != want {
+					t.Errorf("error = %q, want %q", err, want)
+				}
+				return
+			}
+			titles := make([]string, len(got))
+			for i := range got {
+				titles[i] = got[i].Title
+			}
+			if !reflect.DeepEqual(titles, tt.want) {
+				t.Errorf("runPool() = %v, want %v", titles, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sync"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+var errCheckpointMismatch = fmt.Errorf("%w: checkpoint was written by a different job", scheduler.ErrInvalidArgs)
+
+type (
+	// checkpoint is an append-only NDJSON file of the workloads a long job has finished: a
+	// header line identifying the job, then one line per workload with its results. Lines are
+	// written as each workload finishes, so a job that is stopped, or moved to another machine
+	// with the file, resumes from the last finished workload instead of starting over.
+	checkpoint struct {
+		mu   sync.Mutex
+		f    *os.File
+		done map[string][]scheduler.Result
+	}
+	// checkpointJob identifies a job; resuming with any other job is refused rather than
+	// mixing results from different parameters or scheduler versions.
+	checkpointJob struct {
+		Command    string            `json:"command"`
+		Args       []string          `json:"args"`
+		Runs       int               `json:"runs"`
+		Version    string            `json:"version"`
+		Algorithms map[string]string `json:"algorithms"`
+	}
+	// checkpointEntry is one finished workload.
+	checkpointEntry struct {
+		Input   string             `json:"input"`
+		Results []scheduler.Result `json:"results"`
+	}
+)
+
+// openCheckpoint opens the checkpoint at path for job, creating it if needed and loading the
+// workloads it already holds. A final line cut short by an interrupted write is dropped.
+func openCheckpoint(path string, job checkpointJob) (*checkpoint, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("%w: opening checkpoint", err)
+	}
+	c := &checkpoint{f: f, done: make(map[string][]scheduler.Result)}
+	if err := c.load(job); err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return c, nil
+}
+
+func (c *checkpoint) load(job checkpointJob) error {
+	var (
+		r      = bufio.NewReader(c.f)
+		good   int64 // offset just past the last complete line
+		header bool
+	)
+	for {
+		line, err := r.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			// Anything after the last newline is a write that never finished.
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if !header {
+			var got checkpointJob
+			if err := json.Unmarshal(line, &got); err != nil {
+				return fmt.Errorf("%w: bad header: %v", errCheckpointMismatch, err)
+			}
+			if !reflect.DeepEqual(got, job) {
+				return errCheckpointMismatch
+			}
+			header = true
+		} else {
+			var e checkpointEntry
+			if err := json.Unmarshal(line, &e); err != nil {
+				return fmt.Errorf("%w: corrupt checkpoint line: %v", scheduler.ErrInvalidArgs, err)
+			}
+			c.done[e.Input] = e.Results
+		}
+		good += int64(len(line))
+	}
+	if err := c.f.Truncate(good); err != nil {
+		return err
+	}
+	if _, err := c.f.Seek(good, io.SeekStart); err != nil {
+		return err
+	}
+	if !header {
+		return c.write(job)
+	}
+
+	return nil
+}
+
+// results are the saved results of input, if it finished before.
+func (c *checkpoint) results(input string) ([]scheduler.Result, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	rs, ok := c.done[input]
+
+	return rs, ok
+}
+
+// save records that input finished with results.
+func (c *checkpoint) save(input string, results []scheduler.Result) error {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.done[input] = results
+
+	return c.write(checkpointEntry{Input: input, Results: results})
+}
+
+// write appends v as one line and syncs it to disk.
+func (c *checkpoint) write(v any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if _, err := c.f.Write(append(b, '\n')); err != nil {
+		return fmt.Errorf("%w: writing checkpoint", err)
+	}
+
+	return c.f.Sync()
+}
+
+func (c *checkpoint) Close() error {
+	if c == nil {
+		return nil
+	}
+
+	return c.f.Close()
+}
+
+// checkpointJob identifies the job run by this config under command.
+func (c config) checkpointJob(command string) checkpointJob {
+	algorithms := make(map[string]string)
+	for _, s := range c.schedulers() {
+		algorithms[s.Name] = s.Version
+	}
+
+	return checkpointJob{
+		Command:    command,
+		Args:       append([]string{}, c.args...),
+		Runs:       c.seeds,
+		Version:    scheduler.Version,
+		Algorithms: algorithms,
+	}
+}
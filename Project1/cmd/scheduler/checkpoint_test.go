@@ -0,0 +1,56 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+func Test_monteCarloConfig_run_checkpoint(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "job.ckpt")
+	run := func(args ...string) (string, error) {
+		mc, err := parseMonteCarloFlags(io.Discard, append([]string{"-checkpoint", path, "-workers", "2"}, args...))
+		require.NoError(t, err)
+		var stdout bytes.Buffer
+		err = mc.run(&stdout, io.Discard)
+		return stdout.String(), err
+	}
+	lines := func() []string {
+		b, err := os.ReadFile(path)
+		require.NoError(t, err)
+		return strings.SplitAfter(strings.TrimSuffix(string(b), "\n"), "\n")
+	}
+
+	want, err := run("-m", "3", "gen:batch?n=10")
+	require.NoError(t, err)
+	saved := lines()
+	require.Len(t, saved, 1+3, "a header and a line per workload")
+	assert.Contains(t, saved[0], `"command":"montecarlo"`)
+
+	// Resuming after the last workload was cut short mid-write reruns only that workload.
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(saved[:3], "")+saved[3][:len(saved[3])/2]), 0o644))
+	got, err := run("-m", "3", "gen:batch?n=10")
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+	assert.Len(t, lines(), 1+3)
+
+	// A finished workload is never simulated again.
+	tampered := strings.Replace(strings.Join(lines(), ""), `"average_wait":`, `"average_wait":1e6`+`,"x":`, 1)
+	require.NoError(t, os.WriteFile(path, []byte(tampered), 0o644))
+	got, err = run("-m", "3", "gen:batch?n=10")
+	require.NoError(t, err)
+	assert.NotEqual(t, want, got, "the saved results are used")
+
+	_, err = run("-m", "4", "gen:batch?n=10")
+	assert.ErrorIs(t, err, errCheckpointMismatch)
+	assert.ErrorIs(t, err, scheduler.ErrInvalidArgs)
+}
@@ -0,0 +1,297 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/jh125486/CSCE4600/Project1/render"
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+	"github.com/jh125486/CSCE4600/Project1/workload"
+)
+
+type (
+	// experiment is a declarative grid of runs, read from YAML:
+	//
+	//	name: quantum-study
+	//	runs: 10                     # seeds per generated workload (default 1)
+	//	algorithms: [fcfs, sjf]      # default all
+	//	policies: [aging.policy]
+	//	hierarchies: [classes.yaml]
+	//	quanta: [1, 2, 4]            # default each hierarchy's own
+	//	cpus: [1]                    # default 1
+	//	workloads: [processes.csv, "gen:poisson?n=100"]
+	//
+	// Every combination of quantum, CPU count and workload runs every scheduler. Relative
+	// paths are relative to the experiment file.
+	experiment struct {
+		Name        string   `yaml:"name"`
+		Runs        int      `yaml:"runs"`
+		Algorithms  []string `yaml:"algorithms"`
+		Policies    []string `yaml:"policies"`
+		Hierarchies []string `yaml:"hierarchies"`
+		Quanta      []int64  `yaml:"quanta"`
+		CPUs        []int    `yaml:"cpus"`
+		Workloads   []string `yaml:"workloads"`
+	}
+	// experimentConfig holds the parsed experiment command line and the grid it describes.
+	experimentConfig struct {
+		config
+		name     string
+		out      string
+		cpus     []int
+		variants []experimentVariant
+	}
+	// experimentVariant is the configuration for one value of the quantum axis; quantum is 0
+	// when the experiment gives no quanta.
+	experimentVariant struct {
+		quantum int64
+		config  config
+	}
+	// experimentRow is one scheduler's run over one workload in one cell of the grid.
+	experimentRow struct {
+		quantum int64
+		cpus    int
+		result  scheduler.Result
+	}
+)
+
+// parseExperimentFlags parses `scheduler experiment [flags] experiment.yaml`.
+func parseExperimentFlags(errW io.Writer, args []string) (experimentConfig, error) {
+	ec := experimentConfig{config: defaultConfig(), out: render.StdoutPath}
+
+	fs := flag.NewFlagSet("scheduler experiment", flag.ContinueOnError)
+	fs.SetOutput(errW)
+	fs.Usage = func() {
+		_, _ = fmt.Fprintln(errW, "usage: scheduler experiment [flags] experiment.yaml")
+		_, _ = fmt.Fprintln(errW, "Runs every scheduler over the grid of quanta, CPU counts and workloads the file describes and writes one CSV row per run.")
+		fs.PrintDefaults()
+	}
+	fs.StringVar(&ec.out, "o", render.StdoutPath, "write the dataset to this path (- for stdout)")
+	fs.IntVar(&ec.workers, "workers", defaultWorkers, "number of grid cells simulated in parallel")
+	if err := fs.Parse(args); err != nil {
+		return ec, err
+	}
+
+	err := fmt.Errorf("%w: must give one experiment file", scheduler.ErrInvalidArgs)
+	if fs.NArg() == 1 {
+		err = ec.load(fs.Arg(0))
+	}
+	if err != nil {
+		_, _ = fmt.Fprintln(errW, err)
+	}
+
+	return ec, err
+}
+
+// load reads the experiment at path and expands its quantum axis into variants.
+func (ec *experimentConfig) load(path string) error {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var exp experiment
+	dec := yaml.NewDecoder(bytes.NewReader(src))
+	dec.KnownFields(true)
+	if err := dec.Decode(&exp); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("%w: %s: %v", scheduler.ErrInvalidArgs, path, err)
+	}
+	if err := exp.check(); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	dir := filepath.Dir(path)
+	rel := func(p string) string {
+		if filepath.IsAbs(p) || workload.IsGenerated(p) || p == workload.StdinPath {
+			return p
+		}
+		return filepath.Join(dir, p)
+	}
+	ec.name = exp.Name
+	ec.seeds = exp.Runs
+	ec.algorithms = exp.Algorithms
+	ec.cpus = exp.CPUs
+	for _, w := range exp.Workloads {
+		ec.args = append(ec.args, rel(w))
+	}
+	for _, q := range exp.Quanta {
+		v := experimentVariant{quantum: q, config: ec.config}
+		for _, p := range exp.Policies {
+			if err := v.config.addCustom(rel(p), loadPolicy); err != nil {
+				return err
+			}
+		}
+		for _, h := range exp.Hierarchies {
+			if err := v.config.addCustom(rel(h), loadHierarchyQuantum(q)); err != nil {
+				return err
+			}
+		}
+		ec.variants = append(ec.variants, v)
+	}
+
+	return nil
+}
+
+// check validates the experiment and fills in its defaults.
+func (exp *experiment) check() error {
+	if exp.Name == "" {
+		exp.Name = "experiment"
+	}
+	if exp.Runs == 0 {
+		exp.Runs = 1
+	}
+	if len(exp.CPUs) == 0 {
+		exp.CPUs = []int{1}
+	}
+	if len(exp.Quanta) == 0 {
+		exp.Quanta = []int64{0}
+	}
+
+	var err error
+	switch {
+	case exp.Runs < 0:
+		err = errors.New("runs must be at least 1")
+	case len(exp.Workloads) == 0:
+		err = errors.New("must list at least one workload")
+	}
+	for i, name := range exp.Algorithms {
+		var names []string
+		if err == nil {
+			names, err = parseAlgorithms(name)
+		}
+		if err == nil {
+			exp.Algorithms[i] = names[0]
+		}
+	}
+	for _, q := range exp.Quanta {
+		if err == nil && q < 0 {
+			err = fmt.Errorf("quantum must be positive, got %d", q)
+		}
+	}
+	for _, n := range exp.CPUs {
+		// The simulator models one CPU; the axis is in the schema so that datasets keep
+		// their shape once it models more.
+		if err == nil && n != 1 {
+			err = fmt.Errorf("cpus: the simulator models a single CPU, got %d", n)
+		}
+	}
+	for _, w := range exp.Workloads {
+		if err == nil && workload.IsGenerated(w) {
+			_, err = workload.ParseGeneratedSpec(w)
+		}
+	}
+	if err != nil && !errors.Is(err, scheduler.ErrInvalidArgs) && !errors.Is(err, workload.ErrInvalidGenerator) {
+		err = fmt.Errorf("%w: %v", scheduler.ErrInvalidArgs, err)
+	}
+
+	return err
+}
+
+// loadHierarchyQuantum is loadHierarchy with the hierarchy's quantum replaced by quantum,
+// unless that is 0.
+func loadHierarchyQuantum(quantum int64) func(name string, src []byte) (scheduler.Algorithm, error) {
+	return func(name string, src []byte) (scheduler.Algorithm, error) {
+		h, err := scheduler.ParseHierarchy(name, bytes.NewReader(src))
+		if err == nil && quantum != 0 {
+			h, err = scheduler.NewHierarchy(name, quantum, h.Root)
+		}
+		if err != nil {
+			return scheduler.Algorithm{}, err
+		}
+
+		return h.Algorithm(), nil
+	}
+}
+
+// run simulates every cell of the grid on the worker pool and writes the dataset.
+func (ec experimentConfig) run(stdout, errW io.Writer) error {
+	type cell struct {
+		variant experimentVariant
+		cpus    int
+		input   string
+	}
+	var cells []cell
+	for _, v := range ec.variants {
+		for _, n := range ec.cpus {
+			for _, input := range ec.inputs() {
+				cells = append(cells, cell{variant: v, cpus: n, input: input})
+			}
+		}
+	}
+
+	opts := ec.options(errW)
+	rows := make([][]experimentRow, len(cells))
+	if _, err := runPool(len(cells), ec.workers, func(i int) ([]scheduler.Result, error) {
+		c := cells[i]
+		results, err := c.variant.config.runWorkload(c.input, opts, nil)
+		for _, r := range results {
+			rows[i] = append(rows[i], experimentRow{quantum: c.variant.quantum, cpus: c.cpus, result: r})
+		}
+		return nil, err
+	}); err != nil {
+		return err
+	}
+	var all []experimentRow
+	for _, r := range rows {
+		all = append(all, r...)
+	}
+
+	if ec.out == render.StdoutPath {
+		return writeExperiment(stdout, ec.name, all)
+	}
+	f, err := os.Create(ec.out)
+	if err != nil {
+		return fmt.Errorf("%w: creating dataset", err)
+	}
+	if err := writeExperiment(f, ec.name, all); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("%w: writing dataset", err)
+	}
+
+	return f.Close()
+}
+
+// writeExperiment writes one CSV row per run with the grid coordinates and every metric.
+// The quantum column is empty when the experiment gives no quanta.
+func writeExperiment(w io.Writer, name string, rows []experimentRow) error {
+	metrics := monteCarloMetrics()
+	cw := csv.NewWriter(w)
+	header := []string{"experiment", "workload", "seed", "algorithm", "version", "quantum", "cpus"}
+	if err := cw.Write(append(header, metrics...)); err != nil {
+		return err
+	}
+	record := make([]string, len(header)+len(metrics))
+	for _, row := range rows {
+		r := row.result
+		record[0], record[1], record[2], record[3] = name, workload.Group(r.Input), "", r.Algorithm
+		if spec, err := workload.ParseGeneratedSpec(r.Input); err == nil {
+			record[2] = strconv.FormatInt(spec.Seed, 10)
+		}
+		record[4] = ""
+		if r.Provenance != nil {
+			record[4] = r.Provenance.Algorithms[r.Algorithm]
+		}
+		record[5] = ""
+		if row.quantum != 0 {
+			record[5] = strconv.FormatInt(row.quantum, 10)
+		}
+		record[6] = strconv.Itoa(row.cpus)
+		for i, m := range metrics {
+			record[len(header)+i] = strconv.FormatFloat(resultMetrics[m](r), 'g', -1, 64)
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+
+	return cw.Error()
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseExperimentFlags(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		src     string
+		wantErr string
+	}{
+		{name: "minimal", src: "workloads: [w.csv]\n"},
+		{name: "no workloads", src: "name: x\n", wantErr: "must list at least one workload"},
+		{name: "unknown field", src: "workloads: [w.csv]\nquantum: 2\n", wantErr: "field quantum not found"},
+		{name: "unknown algorithm", src: "algorithms: [cfs]\nworkloads: [w.csv]\n", wantErr: `unknown algorithm "cfs"`},
+		{name: "negative quantum", src: "quanta: [-1]\nworkloads: [w.csv]\n", wantErr: "quantum must be positive"},
+		{name: "several cpus", src: "cpus: [1, 2]\nworkloads: [w.csv]\n", wantErr: "single CPU"},
+		{name: "bad generator", src: "workloads: [\"gen:nope\"]\n", wantErr: "nope"},
+		{name: "missing hierarchy", src: "hierarchies: [none.yaml]\nworkloads: [w.csv]\n", wantErr: "none.yaml"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			path := filepath.Join(t.TempDir(), "exp.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tt.src), 0o644))
+			ec, err := parseExperimentFlags(io.Discard, []string{path})
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "experiment", ec.name)
+			assert.Equal(t, []string{filepath.Join(filepath.Dir(path), "w.csv")}, ec.args)
+			assert.Equal(t, []int{1}, ec.cpus)
+			require.Len(t, ec.variants, 1)
+			assert.Zero(t, ec.variants[0].quantum)
+		})
+	}
+
+	_, err := parseExperimentFlags(io.Discard, nil)
+	assert.Error(t, err)
+}
+
+func Test_experimentConfig_run(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	write := func(name, src string) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(src), 0o644))
+	}
+	write("tree.yaml", "policy: rr\n")
+	write("w.csv", "1,0,5,1\n2,1,3,2\n")
+	write("exp.yaml", `name: study
+runs: 2
+algorithms: [FCFS, sjf]
+hierarchies: [tree.yaml]
+quanta: [1, 3]
+workloads: [w.csv, "gen:batch?n=4"]
+`)
+	out := filepath.Join(dir, "out.csv")
+	ec, err := parseExperimentFlags(io.Discard, []string{"-o", out, "-workers", "2", filepath.Join(dir, "exp.yaml")})
+	require.NoError(t, err)
+	var stdout bytes.Buffer
+	require.NoError(t, ec.run(&stdout, io.Discard))
+	assert.Empty(t, stdout.String())
+
+	f, err := os.Open(out)
+	require.NoError(t, err)
+	defer f.Close()
+	rows, err := csv.NewReader(f).ReadAll()
+	require.NoError(t, err)
+	// 2 quanta × (1 file + 2 seeds) × 3 schedulers, after the header.
+	require.Len(t, rows, 1+2*3*3)
+	assert.Equal(t, []string{"experiment", "workload", "seed", "algorithm", "version", "quantum", "cpus"}, rows[0][:7])
+	assert.Equal(t, []string{"study", filepath.Join(dir, "w.csv"), "", "fcfs", "3", "1", "1"}, rows[1][:7])
+	assert.Equal(t, []string{"study", "gen:batch?n=4", "2", "tree", rows[9][4], "1", "1"}, rows[9][:7])
+	assert.Equal(t, "3", rows[10][5])
+	assert.NotEqual(t, rows[3][4], rows[12][4], "the hierarchy's version changes with its quantum")
+}
@@ -0,0 +1,860 @@
+// Command scheduler simulates CPU scheduling algorithms over workload files and reports each
+// schedule, with subcommands for Monte Carlo runs, load sweeps, reports on saved results and
+// response-time analysis. Run it with -h for the flags.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+
+	"github.com/jh125486/CSCE4600/Project1/render"
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+	"github.com/jh125486/CSCE4600/Project1/workload"
+)
+
+func main() {
+	if len(os.Args) > 1 {
+		var (
+			sub interface {
+				run(stdout, errW io.Writer) error
+			}
+			err error
+		)
+		switch os.Args[1] {
+		case "montecarlo":
+			sub, err = parseMonteCarloFlags(os.Stderr, os.Args[2:])
+		case "sweep":
+			sub, err = parseSweepFlags(os.Stderr, os.Args[2:])
+		case "report":
+			sub, err = parseReportFlags(os.Stderr, os.Args[2:])
+		case "rta":
+			sub, err = parseRTAFlags(os.Stderr, os.Args[2:])
+		case "archive":
+			sub, err = parseArchiveFlags(os.Stderr, os.Args[2:])
+		case "compare-runs":
+			sub, err = parseCompareRunsFlags(os.Stderr, os.Args[2:])
+		case "experiment":
+			sub, err = parseExperimentFlags(os.Stderr, os.Args[2:])
+		case "step":
+			sub, err = parseStepFlags(os.Stderr, os.Args[2:])
+		}
+		if err != nil {
+			os.Exit(2)
+		}
+		if sub != nil {
+			if err := sub.run(os.Stdout, os.Stderr); err != nil {
+				log.Fatal(err)
+			}
+			return
+		}
+	}
+
+	// CLI flags
+	cfg, err := parseFlags(os.Stderr, os.Args[1:])
+	if err != nil {
+		os.Exit(2)
+	}
+
+	// CLI args
+	if len(cfg.args) == 0 {
+		log.Fatal(fmt.Errorf("%w: must give a scheduling file to process", scheduler.ErrInvalidArgs))
+	}
+
+	if cfg.dryRun {
+		for _, path := range cfg.inputs() {
+			processes, _, err := cfg.loadWorkload(path)
+			if err != nil {
+				log.Fatal(err)
+			}
+			outputDryRun(os.Stdout, cfg, path, processes)
+		}
+		return
+	}
+
+	var streams *render.StreamOutputs
+	if cfg.stream {
+		if streams, err = render.NewStreamOutputs(os.Stdout, cfg.outputs()); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	// Simulate every workload, in parallel when several are given.
+	var (
+		opts   = cfg.options(os.Stderr)
+		hook   = newWebhook(cfg.webhook, os.Stderr)
+		inputs = cfg.inputs()
+	)
+	results, err := runPool(len(inputs), cfg.workers, func(i int) ([]scheduler.Result, error) {
+		results, err := cfg.runWorkload(inputs[i], opts, streams)
+		hook.notify(inputs[i], results, err)
+		return results, err
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	if streams != nil {
+		if err := streams.Close(); err != nil {
+			log.Fatal(err)
+		}
+	} else {
+		for _, out := range cfg.outputs() {
+			if err := render.WriteResults(os.Stdout, out, results, cfg.timeUnit); err != nil {
+				log.Fatal(err)
+			}
+		}
+	}
+	if cfg.seeds > 1 {
+		outputSeedComparison(os.Stdout, results, cfg.timeUnit)
+	}
+	outputMM1Baseline(os.Stdout, results, cfg.timeUnit)
+	outputPredictionAccuracy(os.Stdout, results, cfg.timeUnit)
+	outputOverload(os.Stdout, results, cfg.timeUnit)
+	outputSLA(os.Stdout, results, cfg.timeUnit)
+	outputPreemption(os.Stdout, results, cfg.timeUnit)
+	outputResources(os.Stdout, results, cfg.timeUnit)
+	outputMemory(os.Stdout, results, cfg.timeUnit)
+	outputBackfill(os.Stdout, results, cfg.timeUnit)
+	outputShares(os.Stdout, results)
+	if cfg.overhead {
+		outputOverhead(os.Stdout, results, cfg.coresPerNode)
+	}
+	if len(cfg.plots) > 0 {
+		if err := render.WritePlots(cfg.plotDir, cfg.plots, results, cfg.timeUnit); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if cfg.store != "" {
+		if err := storeResults(cfg.store, results, time.Now()); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if checkThresholds(os.Stderr, cfg.failIf, results) {
+		os.Exit(exitThresholdFailed)
+	}
+}
+
+// loadWorkload reads and parses a workload file, returning its processes and SHA-256.
+// A path of "-" reads the workload from stdin, e.g. piped from docker stats, and gen: paths
+// are generated workloads. Either is checked to fit in the int64 times the schedulers use,
+// with the --reservations, after --timescale.
+func (c config) loadWorkload(path string) ([]scheduler.Process, string, error) {
+	var (
+		processes []scheduler.Process
+		sum       string
+		err       error
+	)
+	if workload.IsGenerated(path) {
+		// Generate names the argument in its errors, and the IDs it gives are unique already.
+		if processes, sum, err = workload.Generate(path); err != nil {
+			return nil, "", err
+		}
+	} else if processes, sum, err = c.importWorkload(path); err != nil {
+		return nil, "", err
+	}
+	if c.timescale != 1 {
+		err = workload.ScaleArrivals(processes, c.timescale)
+	}
+	if err == nil {
+		err = workload.CheckTimeRange(processes, c.reservations...)
+	}
+	if err == nil && !workload.IsGenerated(path) {
+		if c.renumberPIDs {
+			workload.Renumber(processes)
+		} else {
+			err = workload.CheckUniquePIDs(processes)
+		}
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", path, err)
+	}
+
+	return processes, sum, nil
+}
+
+// importWorkload reads a workload file, or stdin for "-", in the --input-format and sanitizes it
+// by the --invalid policy. Errors past opening the file name the path.
+func (c config) importWorkload(path string) ([]scheduler.Process, string, error) {
+	var r io.Reader = os.Stdin
+	if path != workload.StdinPath {
+		f, closeFile, err := openProcessingFile(os.Args[0], path)
+		if err != nil {
+			return nil, "", err
+		}
+		defer closeFile()
+		r = f
+	}
+
+	input := workload.NewHasher(r)
+	processes, err := workload.ImporterFor(c.inputFormat, path)(input)
+	if err == nil {
+		processes, err = workload.Sanitize(c.warnings, path, processes, c.invalid)
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("%s: %w", path, err)
+	}
+
+	return processes, input.Sum(), nil
+}
+
+// runWorkload runs every scheduler over the workload at path.
+// When streams is non-nil, rows are written to it as they are produced rather than kept in the results.
+func (c config) runWorkload(path string, opts []scheduler.Option, streams *render.StreamOutputs) ([]scheduler.Result, error) {
+	processes, sum, err := c.loadWorkload(path)
+	if err != nil {
+		return nil, err
+	}
+
+	provenance := c.provenance(path, sum)
+	selected := c.schedulers()
+	if len(c.reservations) > 0 {
+		opts = append(opts[:len(opts):len(opts)], scheduler.WithReservations(c.reservations...))
+	}
+	controls := c.preemptionOptions()
+	opts = append(opts[:len(opts):len(opts)], controls...)
+	results := make([]scheduler.Result, 0, len(selected))
+	for _, s := range selected {
+		so := opts
+		var sink *render.StreamSink
+		if streams != nil {
+			if sink, err = streams.Sink(path, s.Name); err != nil {
+				return nil, err
+			}
+			so = append(so[:len(so):len(so)], scheduler.WithSink(sink))
+		}
+		// SLAs are checked outermost, so processes turned away by overload miss theirs.
+		middleware := []scheduler.Middleware{scheduler.CheckSLA()}
+		if c.overload.policy != "" {
+			middleware = append(middleware, scheduler.Overload(c.overload.policy, c.overload.threshold))
+		}
+		if len(controls) > 0 {
+			middleware = append(middleware, scheduler.PreemptionEffect())
+		}
+		r := scheduler.Chain(s.Simulate, middleware...)(s.Title, processes, so...)
+		r.Algorithm = s.Name
+		r.Input = path
+		r.Provenance = &provenance
+		if sink != nil {
+			sink.Summary(r)
+		}
+		results = append(results, r)
+	}
+
+	return results, nil
+}
+
+// config holds the parsed command line.
+type config struct {
+	progress         bool
+	dryRun           bool
+	timeUnit         scheduler.TimeUnit
+	out              stringList
+	format           render.Format
+	failIf           []threshold
+	workers          int
+	stream           bool
+	compact          bool
+	inputFormat      string
+	store            string
+	plots            []string
+	plotDir          string
+	webhook          string
+	seeds            int
+	overhead         bool
+	sparklines       bool
+	ganttStyle       render.GanttStyle
+	coresPerNode     int
+	renumberPIDs     bool
+	timescale        float64
+	overload         overloadFlag
+	pool             scheduler.Resources     // from --resources
+	reservations     []scheduler.Reservation // from --reservations
+	minGranularity   int64
+	seed             int64
+	tieBreak         scheduler.TieBreak
+	preemptionBudget budgetFlag
+	invalid          workload.InvalidPolicy
+	warnings         io.Writer
+	algorithms       []string
+	custom           []scheduler.Algorithm // from --policy, --hierarchy, --predictor, --dynamic-rr, --mlfq, --srr, --memory, --sporadic, --switch-cost, --aging and --resources
+	args             []string
+}
+
+func defaultConfig() config {
+	return config{
+		timeUnit:    scheduler.TimeUnitTicks,
+		format:      render.FormatText,
+		workers:     defaultWorkers,
+		inputFormat: workload.FormatAuto,
+		plotDir:     render.DefaultPlotDir,
+		seeds:       1,
+		timescale:   1,
+		invalid:     workload.InvalidError,
+		warnings:    io.Discard,
+	}
+}
+
+func parseFlags(errW io.Writer, args []string) (config, error) {
+	cfg := defaultConfig()
+	cfg.warnings = errW
+	fs := flag.NewFlagSet("scheduler", flag.ContinueOnError)
+	fs.SetOutput(errW)
+	fs.BoolVar(&cfg.progress, "progress", false, "report simulation progress to stderr")
+	fs.BoolVar(&cfg.dryRun, "dry-run", false, "print the parsed workload and simulation parameters, then exit")
+	fs.Func("time-unit", "unit of input times: ticks, ms or s (default ticks)", func(s string) (err error) {
+		cfg.timeUnit, err = scheduler.ParseTimeUnit(s)
+		return err
+	})
+	for _, name := range []string{"o", "out"} {
+		fs.Var(&cfg.out, name, "output path, repeatable; {algo} and {format} are expanded, - is stdout (default -)")
+	}
+	fs.Func("fail-if", "exit with status 3 if a condition such as avg_wait>50 holds for any scheduler; repeatable",
+		func(s string) error {
+			t, err := parseThreshold(s)
+			if err != nil {
+				return err
+			}
+			cfg.failIf = append(cfg.failIf, t)
+			return nil
+		})
+	fs.IntVar(&cfg.workers, "workers", defaultWorkers, "number of workload files simulated in parallel")
+	fs.BoolVar(&cfg.stream, "stream", false, "write csv/ndjson rows as they are produced instead of buffering whole runs")
+	fs.BoolVar(&cfg.compact, "compact-gantt", false, "merge consecutive Gantt slices of the same process on the same CPU")
+	fs.Func("input-format", "workload format (- reads stdin): auto (by extension: .yaml/.yml is k8s, .arrow/.arrows is arrow, else csv), "+workload.KnownFormats(),
+		func(s string) (err error) {
+			cfg.inputFormat, err = workload.ParseFormat(s)
+			return err
+		})
+	fs.StringVar(&cfg.store, "store", "", "append every run to this SQLite database (or .sql script)")
+	fs.Func("plot", "also write plots of each workload's results to --plot-dir: gif, gnuplot, heatmap, migrations or plotly (repeatable)",
+		func(s string) error {
+			kind, err := render.ParsePlot(s)
+			cfg.plots = append(cfg.plots, kind)
+			return err
+		})
+	fs.StringVar(&cfg.plotDir, "plot-dir", render.DefaultPlotDir, "directory --plot writes into")
+	fs.Func("webhook", "POST a JSON summary to this http(s) URL as each workload finishes",
+		func(s string) error {
+			if u, err := url.Parse(s); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return fmt.Errorf("%w: webhook must be an http or https URL, got %q", scheduler.ErrInvalidArgs, s)
+			}
+			cfg.webhook = s
+			return nil
+		})
+	fs.BoolVar(&cfg.overhead, "overhead", false, "also print each scheduler's preemptions and CPU migrations, in total and per process")
+	fs.Func("gantt-style", "text Gantt chart style: classic, or blocks for block characters sized by duration (default classic)", func(s string) (err error) {
+		cfg.ganttStyle, err = render.ParseGanttStyle(s)
+		return err
+	})
+	fs.BoolVar(&cfg.sparklines, "sparklines", false, "print a utilization sparkline per CPU beneath each text Gantt chart")
+	fs.IntVar(&cfg.coresPerNode, "cores-per-node", 0, "group CPUs into nodes of this many cores for --overhead's cross-node migrations (default one node)")
+	fs.BoolVar(&cfg.renumberPIDs, "renumber-pids", false, "number processes 1..n in input order instead of rejecting workloads with duplicate IDs")
+	fs.Func("timescale", "multiply every arrival time by this factor, e.g. 0.01 to replay a day-long trace quickly (default 1)",
+		func(s string) error {
+			f, err := strconv.ParseFloat(s, 64)
+			if err != nil || workload.ScaleArrivals(nil, f) != nil {
+				return fmt.Errorf("%w: got %q", workload.ErrInvalidTimescale, s)
+			}
+			cfg.timescale = f
+			return nil
+		})
+	fs.Var(&cfg.overload, "overload", "when queued work would exceed threshold time units, reject, defer or shed (lowest priority first) arriving work, given as policy:threshold, e.g. shed:100")
+	fs.Func("min-granularity", "let a preempted process run at least this many time units once dispatched (preemptive schedulers; default 0, none)",
+		func(s string) error {
+			g, err := strconv.ParseInt(s, 10, 64)
+			if err != nil || g < 0 {
+				return fmt.Errorf("%w: --min-granularity must be a non-negative integer, got %q", scheduler.ErrInvalidArgs, s)
+			}
+			cfg.minGranularity = g
+			return nil
+		})
+	fs.Var(&cfg.preemptionBudget, "preemption-budget", "let each process be preempted at most n times in any interval time units, given as n/interval, e.g. 2/10 (preemptive schedulers)")
+	fs.Func("invalid", "handle rows with negative times or priorities outside 0.."+strconv.Itoa(workload.MaxPriority)+": error, clamp or skip (default error)",
+		func(s string) (err error) {
+			cfg.invalid, err = workload.ParseInvalidPolicy(s)
+			return err
+		})
+	fs.Func("policy", "also run the scheduling policy in this script, named after the file (repeatable)",
+		func(s string) error { return cfg.addCustom(s, loadPolicy) })
+	fs.Func("hierarchy", "also run the scheduler hierarchy in this YAML file, named after the file (repeatable)",
+		func(s string) error { return cfg.addCustom(s, loadHierarchy) })
+	fs.Func("predictor", "also run shortest-job-first on bursts predicted by this predictor: "+scheduler.PredictorNames()+", or ema:<alpha> (repeatable)",
+		func(s string) error {
+			a, err := scheduler.PredictedSJF(s)
+			if err != nil {
+				return err
+			}
+			return cfg.addAlgorithm(a)
+		})
+	fs.Func("dynamic-rr", "also run round-robin with a quantum recomputed every cycle by this strategy: "+scheduler.QuantumStrategyNames()+" (repeatable)",
+		func(s string) error {
+			a, err := scheduler.DynamicRR(s)
+			if err != nil {
+				return err
+			}
+			return cfg.addAlgorithm(a)
+		})
+	fs.Func("mlfq", "also run a multilevel feedback queue with these per-level quanta, highest first, and an optional boost period, e.g. 10,10,10:50 (repeatable)",
+		func(s string) error {
+			c, err := scheduler.ParseMLFQ(s)
+			if err != nil {
+				return err
+			}
+			a, err := scheduler.MLFQ(c)
+			if err != nil {
+				return err
+			}
+			return cfg.addAlgorithm(a)
+		})
+	fs.Func("srr", "also run selfish round-robin with these priority growth rates for new and accepted processes, e.g. 2,1 (repeatable)",
+		func(s string) error {
+			c, err := scheduler.ParseSRR(s)
+			if err != nil {
+				return err
+			}
+			a, err := scheduler.SelfishRR(c)
+			if err != nil {
+				return err
+			}
+			return cfg.addAlgorithm(a)
+		})
+	fs.Func("memory", "also run round-robin behind a long-term scheduler admitting processes into this much memory, and optionally swapping after this long, e.g. 1024:20 (repeatable)",
+		func(s string) error {
+			c, err := scheduler.ParseMemory(s)
+			if err != nil {
+				return err
+			}
+			a, err := scheduler.MemoryAdmission(c)
+			if err != nil {
+				return err
+			}
+			return cfg.addAlgorithm(a)
+		})
+	fs.Func("sporadic", "also run periodic tasks at rate-monotonic priority with a sporadic server of this budget and period for the aperiodic processes, e.g. 2:10 (repeatable)",
+		func(s string) error {
+			c, err := scheduler.ParseSporadic(s)
+			if err != nil {
+				return err
+			}
+			a, err := scheduler.SporadicServer(c)
+			if err != nil {
+				return err
+			}
+			return cfg.addAlgorithm(a)
+		})
+	fs.Func("switch-cost", "also run shortest-job-first that only preempts for a process with more than this much less remaining time, e.g. 2 (repeatable)",
+		func(s string) error {
+			cost, err := strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				return fmt.Errorf("%w: the switch cost must be an integer, got %q", scheduler.ErrInvalidArgs, s)
+			}
+			a, err := scheduler.SwitchAwareSJF(cost)
+			if err != nil {
+				return err
+			}
+			return cfg.addAlgorithm(a)
+		})
+	fs.Func("aging", "also run round-robin within priority levels, promoting a process up a level once it has waited this long at one, e.g. 20 (repeatable)",
+		func(s string) error {
+			after, err := strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				return fmt.Errorf("%w: the promotion timer must be an integer, got %q", scheduler.ErrInvalidAging, s)
+			}
+			a, err := scheduler.AgingPriorityRR(after)
+			if err != nil {
+				return err
+			}
+			return cfg.addAlgorithm(a)
+		})
+	fs.Func("resources", "also run the batch schedulers on a pool of this many of each resource, e.g. cpu=8,gpu=2",
+		func(s string) error {
+			pool, err := scheduler.ParseResources(s)
+			if err == nil && pool[scheduler.ResourceCPU] < 1 {
+				err = fmt.Errorf("%w: the pool needs at least one CPU, got %q", scheduler.ErrInvalidResources, s)
+			}
+			if err != nil {
+				return err
+			}
+			cfg.pool = pool
+			for _, a := range batchSchedulers(pool) {
+				if err := cfg.addAlgorithm(a); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	fs.Func("reservations", "block out CPUs of the --resources pool in advance with the start,duration,cpus rows of this CSV file (repeatable)",
+		func(s string) error {
+			rs, err := loadReservations(s)
+			cfg.reservations = append(cfg.reservations, rs...)
+			return err
+		})
+	fs.Func("algorithms", "comma-separated algorithms to run (default all)", func(s string) (err error) {
+		cfg.algorithms, err = parseAlgorithms(s)
+		return err
+	})
+	fs.Int64Var(&cfg.seed, "seed", 0, "seed the random drawings of the randomized schedulers, lottery and random, for reproducible runs")
+	fs.Func("tie-break", "order processes FCFS or SJF rank equal by: pid, priority or input (default input order for FCFS, arrival then PID for SJF)",
+		func(s string) (err error) {
+			cfg.tieBreak, err = scheduler.ParseTieBreak(s)
+			return err
+		})
+	fs.IntVar(&cfg.seeds, "seeds", 1, "run each generated (gen:) workload with this many consecutive seeds and compare the algorithms' mean ± 95% CI")
+	fs.Func("format", "output format when the path has no .txt/.json/.csv/.tidy.csv/.ndjson/.parquet/.trace.parquet/.arrows/.md/.xlsx/.pdf/.html extension: text, json, csv, tidy, ndjson, parquet, parquet-trace, arrow, mermaid, xlsx, pdf or html (default text)",
+		func(s string) (err error) {
+			cfg.format, err = render.ParseFormat(s)
+			return err
+		})
+	if err := fs.Parse(args); err != nil {
+		return cfg, err
+	}
+	if cfg.stream && cfg.overhead {
+		err := fmt.Errorf("%w: --overhead needs the Gantt slices, which --stream does not keep", scheduler.ErrInvalidArgs)
+		_, _ = fmt.Fprintln(errW, err)
+		return cfg, err
+	}
+	if cfg.stream && cfg.store != "" {
+		err := fmt.Errorf("%w: --store needs per-process rows, which --stream does not keep", scheduler.ErrInvalidArgs)
+		_, _ = fmt.Fprintln(errW, err)
+		return cfg, err
+	}
+	if cfg.stream {
+		for _, t := range cfg.failIf {
+			if perProcessMetrics[t.metric] {
+				err := fmt.Errorf("%w: --fail-if %s needs per-process rows, which --stream does not keep", scheduler.ErrInvalidArgs, t.expr)
+				_, _ = fmt.Fprintln(errW, err)
+				return cfg, err
+			}
+		}
+	}
+	cfg.args = fs.Args()
+	if len(cfg.reservations) > 0 && cfg.pool[scheduler.ResourceCPU] == 0 {
+		err := fmt.Errorf("%w: --reservations needs --resources, as only the batch schedulers plan around them", scheduler.ErrInvalidArgs)
+		_, _ = fmt.Fprintln(errW, err)
+		return cfg, err
+	}
+	if cfg.seeds < 1 {
+		err := fmt.Errorf("%w: --seeds must be at least 1", scheduler.ErrInvalidArgs)
+		_, _ = fmt.Fprintln(errW, err)
+		return cfg, err
+	}
+	if cfg.coresPerNode < 0 {
+		err := fmt.Errorf("%w: --cores-per-node must not be negative", scheduler.ErrInvalidArgs)
+		_, _ = fmt.Fprintln(errW, err)
+		return cfg, err
+	}
+	for _, arg := range cfg.args {
+		if workload.IsGenerated(arg) {
+			if _, err := workload.ParseGeneratedSpec(arg); err != nil {
+				_, _ = fmt.Fprintln(errW, err)
+				return cfg, err
+			}
+		}
+	}
+
+	return cfg, nil
+}
+
+// schedulers are the selected schedulers, in registry order; all of them unless some were chosen.
+// Any --policy, --hierarchy, --predictor, --dynamic-rr, --mlfq, --srr, --memory, --sporadic, --switch-cost, --aging and --resources schedulers follow, in the order given.
+func (c config) schedulers() []scheduler.Algorithm {
+	if len(c.algorithms) == 0 {
+		return append(scheduler.Algorithms[:len(scheduler.Algorithms):len(scheduler.Algorithms)], c.custom...)
+	}
+	selected := make([]scheduler.Algorithm, 0, len(c.algorithms)+len(c.custom))
+	for _, s := range scheduler.Algorithms {
+		for _, name := range c.algorithms {
+			if s.Name == name {
+				selected = append(selected, s)
+				break
+			}
+		}
+	}
+
+	return append(selected, c.custom...)
+}
+
+// addCustom loads a scheduler defined in the file at path, which must not share its name
+// with another scheduler.
+func (c *config) addCustom(path string, load func(name string, src []byte) (scheduler.Algorithm, error)) error {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	// Custom schedulers are named after their file without its extension.
+	a, err := load(strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)), src)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	if err := c.addAlgorithm(a); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	return nil
+}
+
+// addAlgorithm adds a to the custom schedulers, unless its name is already taken.
+func (c *config) addAlgorithm(a scheduler.Algorithm) error {
+	for _, known := range c.schedulers() {
+		if known.Name == a.Name {
+			return fmt.Errorf("%w: name %q is already taken by another scheduler", scheduler.ErrInvalidArgs, a.Name)
+		}
+	}
+	c.custom = append(c.custom, a)
+
+	return nil
+}
+
+// loadPolicy parses a --policy script.
+func loadPolicy(name string, src []byte) (scheduler.Algorithm, error) {
+	p, err := scheduler.ParsePolicy(name, string(src))
+	if err != nil {
+		return scheduler.Algorithm{}, err
+	}
+
+	return p.Algorithm(), nil
+}
+
+// loadHierarchy parses a --hierarchy file.
+func loadHierarchy(name string, src []byte) (scheduler.Algorithm, error) {
+	h, err := scheduler.ParseHierarchy(name, bytes.NewReader(src))
+	if err != nil {
+		return scheduler.Algorithm{}, err
+	}
+
+	return h.Algorithm(), nil
+}
+
+// parseAlgorithms parses a comma-separated list of scheduler names.
+func parseAlgorithms(s string) ([]string, error) {
+	var names []string
+	for _, name := range strings.Split(s, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		known := false
+		for _, sc := range scheduler.Algorithms {
+			known = known || sc.Name == name
+		}
+		if !known {
+			all := make([]string, len(scheduler.Algorithms))
+			for i := range scheduler.Algorithms {
+				all[i] = scheduler.Algorithms[i].Name
+			}
+			return nil, fmt.Errorf("%w: unknown algorithm %q (known: %s)", scheduler.ErrInvalidArgs, name, strings.Join(all, ", "))
+		}
+		names = append(names, name)
+	}
+
+	return names, nil
+}
+
+// inputs are the workloads to simulate: each argument once, except generated workloads,
+// which run once per seed. A generated workload's seeds start at its own seed parameter (or 1).
+func (c config) inputs() []string {
+	inputs := make([]string, 0, len(c.args))
+	for _, arg := range c.args {
+		if !workload.IsGenerated(arg) {
+			inputs = append(inputs, arg)
+			continue
+		}
+		spec, _ := workload.ParseGeneratedSpec(arg) // validated by parseFlags
+		for i := 0; i < c.seeds; i++ {
+			inputs = append(inputs, spec.String())
+			spec.Seed++
+		}
+	}
+
+	return inputs
+}
+
+// outputs are where and how results are written. Each -o flag adds a destination,
+// so one run can write text to stdout and JSON/CSV to files. When comparing seeds, the
+// comparison replaces the default stdout output.
+func (c config) outputs() []render.Output {
+	patterns := c.out
+	if len(patterns) == 0 && c.seeds <= 1 {
+		patterns = stringList{render.StdoutPath}
+	}
+	outs := make([]render.Output, len(patterns))
+	for i, p := range patterns {
+		outs[i] = render.Output{Pattern: p, Format: render.FormatForPath(p, c.format), Text: render.TextOptions{Gantt: c.ganttStyle, Sparklines: c.sparklines}}
+	}
+
+	return outs
+}
+
+// stringList is a repeatable string flag.
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(s string) error {
+	*l = append(*l, s)
+	return nil
+}
+
+// provenance describes a run of the configured schedulers over an input with the given hash.
+func (c config) provenance(input, inputSHA256 string) scheduler.Provenance {
+	selected := c.schedulers()
+	algorithms := make(map[string]string, len(selected))
+	for _, s := range selected {
+		algorithms[s.Name] = s.Version
+	}
+
+	p := scheduler.Provenance{
+		Tool:        "scheduler",
+		Version:     scheduler.Version,
+		Revision:    vcsRevision(),
+		Input:       input,
+		InputSHA256: inputSHA256,
+		Parameters: map[string]string{
+			"quantum":       strconv.Itoa(scheduler.DefaultQuantum),
+			"time_unit":     string(c.timeUnit),
+			"compact_gantt": strconv.FormatBool(c.compact),
+			"renumber_pids": strconv.FormatBool(c.renumberPIDs),
+			"timescale":     strconv.FormatFloat(c.timescale, 'g', -1, 64),
+			"invalid":       string(c.invalid),
+		},
+		Algorithms: algorithms,
+	}
+	if c.overload.policy != "" {
+		p.Parameters["overload"] = c.overload.String()
+	}
+	if len(c.reservations) > 0 {
+		p.Parameters["reservations"] = formatReservations(c.reservations)
+	}
+	if c.seed != 0 {
+		p.Parameters["seed"] = strconv.FormatInt(c.seed, 10)
+	}
+	if c.tieBreak != scheduler.TieBreakDefault {
+		p.Parameters["tie_break"] = string(c.tieBreak)
+	}
+	if c.minGranularity > 0 {
+		p.Parameters["min_granularity"] = strconv.FormatInt(c.minGranularity, 10)
+	}
+	if c.preemptionBudget.n > 0 {
+		p.Parameters["preemption_budget"] = c.preemptionBudget.String()
+	}
+
+	return p
+}
+
+// options converts the parsed flags into scheduler options.
+// Diagnostics such as progress lines are written to errW.
+func (c config) options(errW io.Writer) []scheduler.Option {
+	opts := []scheduler.Option{scheduler.WithTimeUnit(c.timeUnit)}
+	if c.compact {
+		opts = append(opts, scheduler.WithCompactGantt())
+	}
+	if c.progress {
+		opts = append(opts, scheduler.WithProgress(errW))
+	}
+	if c.seed != 0 {
+		opts = append(opts, scheduler.WithSeed(c.seed))
+	}
+	if c.tieBreak != scheduler.TieBreakDefault {
+		opts = append(opts, scheduler.WithTieBreak(c.tieBreak))
+	}
+
+	return opts
+}
+
+func openProcessingFile(args ...string) (*os.File, func(), error) {
+	if len(args) != 2 {
+		return nil, nil, fmt.Errorf("%w: must give a scheduling file to process", scheduler.ErrInvalidArgs)
+	}
+	// Read in CSV process CSV file
+	f, err := os.Open(args[1])
+	if err != nil {
+		return nil, nil, fmt.Errorf("%v: error opening scheduling file", err)
+	}
+	closeFn := func() {
+		if err := f.Close(); err != nil {
+			log.Fatalf("%v: error closing scheduling file", err)
+		}
+	}
+
+	return f, closeFn, nil
+}
+
+// outputDryRun prints the workload as the schedulers will see it, along with the
+// effective simulation parameters.
+func outputDryRun(w io.Writer, cfg config, input string, processes []scheduler.Process) {
+	render.OutputTitle(w, "Dry run")
+	_, _ = fmt.Fprintln(w, "Parameters")
+	_, _ = fmt.Fprintf(w, "  input:      %s\n", input)
+	selected := cfg.schedulers()
+	titles := make([]string, len(selected))
+	for i := range selected {
+		titles[i] = selected[i].Title
+	}
+	_, _ = fmt.Fprintf(w, "  schedulers: %s\n", strings.Join(titles, "; "))
+	_, _ = fmt.Fprintf(w, "  RR quantum: %d\n", scheduler.DefaultQuantum)
+	_, _ = fmt.Fprintf(w, "  time unit:  %s\n", cfg.timeUnit)
+	if cfg.timescale != 1 {
+		_, _ = fmt.Fprintf(w, "  timescale:  %g\n", cfg.timescale)
+	}
+	if cfg.overload.policy != "" {
+		_, _ = fmt.Fprintf(w, "  overload:   %s\n", cfg.overload.String())
+	}
+	for _, r := range cfg.reservations {
+		_, _ = fmt.Fprintf(w, "  reserved:   %s\n", r)
+	}
+	if cfg.seed != 0 {
+		_, _ = fmt.Fprintf(w, "  seed:       %d\n", cfg.seed)
+	}
+	if cfg.tieBreak != scheduler.TieBreakDefault {
+		_, _ = fmt.Fprintf(w, "  tie-break:  %s\n", cfg.tieBreak.Describe())
+	}
+	if cfg.minGranularity > 0 {
+		_, _ = fmt.Fprintf(w, "  min slice:  %d\n", cfg.minGranularity)
+	}
+	if cfg.preemptionBudget.n > 0 {
+		_, _ = fmt.Fprintf(w, "  budget:     %s preemptions\n", cfg.preemptionBudget.String())
+	}
+	for _, out := range cfg.outputs() {
+		_, _ = fmt.Fprintf(w, "  output:     %s (%s)\n", out.Pattern, out.Format)
+	}
+	_, _ = fmt.Fprintf(w, "  workers:    %d\n", cfg.workers)
+	for _, t := range cfg.failIf {
+		_, _ = fmt.Fprintf(w, "  fail if:    %s\n", t.expr)
+	}
+	_, _ = fmt.Fprintf(w, "  progress:   %t\n", cfg.progress)
+	_, _ = fmt.Fprintln(w)
+
+	_, _ = fmt.Fprintf(w, "Workload (%d processes)\n", len(processes))
+	named := false
+	for i := range processes {
+		named = named || processes[i].Name != ""
+	}
+	table := tablewriter.NewWriter(w)
+	header := []string{"ID", "Priority", cfg.timeUnit.Label("Burst"), cfg.timeUnit.Label("Arrival")}
+	if named {
+		header = append(header, "Name")
+	}
+	table.SetHeader(header)
+	for i := range processes {
+		row := []string{
+			fmt.Sprint(processes[i].ProcessID),
+			fmt.Sprint(processes[i].Priority),
+			fmt.Sprint(processes[i].BurstDuration),
+			fmt.Sprint(processes[i].ArrivalTime),
+		}
+		if named {
+			row = append(row, processes[i].Name)
+		}
+		table.Append(row)
+	}
+	table.Render()
+}
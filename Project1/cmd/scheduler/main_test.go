@@ -0,0 +1,613 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jh125486/CSCE4600/Project1/render"
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+	"github.com/jh125486/CSCE4600/Project1/workload"
+)
+
+func Test_openProcessingFile1(t *testing.T) {
+	tmpFile, tErr := os.CreateTemp(t.TempDir(), "")
+	if tErr != nil {
+		t.Fatal(tErr)
+	}
+
+	type args struct {
+		args []string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *os.File
+		wantErr bool
+	}{
+		{
+			name: "success",
+			args: args{
+				args: []string{"binary_name", tmpFile.Name()},
+			},
+			want: tmpFile,
+		},
+		{
+			name: "not enough args",
+			args: args{
+				args: []string{"binary_name"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "bad file",
+			args: args{
+				args: []string{"binary_name", "bad_file_name"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, closeFn, err := openProcessingFile(tt.args.args...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("openProcessingFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if got == nil {
+				t.Fatal("file is unexpectedly nil")
+			}
+			if closeFn == nil {
+				t.Fatal("closeFn is unexpectedly nil")
+			}
+			t.Cleanup(closeFn)
+
+			f1, err := os.Stat(got.Name())
+			if err != nil {
+				t.Fatalf("Could not stat file: %v", got)
+			}
+			f2, err := os.Stat(tt.want.Name())
+			if err != nil {
+				t.Fatalf("Could not stat file: %v", tt.want)
+			}
+
+			if !os.SameFile(f1, f2) {
+				t.Fatal("files are not the same")
+			}
+		})
+	}
+}
+
+func Test_parseFlags(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		args    []string
+		want    func(c *config)
+		wantErr bool
+	}{
+		{
+			name: "file only",
+			args: []string{"processes.csv"},
+			want: func(c *config) {},
+		},
+		{
+			name: "progress",
+			args: []string{"--progress", "processes.csv"},
+			want: func(c *config) { c.progress = true },
+		},
+		{
+			name: "dry run",
+			args: []string{"--dry-run", "processes.csv"},
+			want: func(c *config) { c.dryRun = true },
+		},
+		{
+			name: "time unit",
+			args: []string{"--time-unit", "ms", "processes.csv"},
+			want: func(c *config) { c.timeUnit = scheduler.TimeUnitMillis },
+		},
+		{
+			name:    "bad time unit",
+			args:    []string{"--time-unit", "hours", "processes.csv"},
+			wantErr: true,
+		},
+		{
+			name: "output and format",
+			args: []string{"-o", "results/{algo}.out", "--format", "json", "processes.csv"},
+			want: func(c *config) { c.out, c.format = stringList{"results/{algo}.out"}, render.FormatJSON },
+		},
+		{
+			name: "multiple outputs",
+			args: []string{"-o", "-", "--out", "results/{algo}.json", "-o", "all.csv", "processes.csv"},
+			want: func(c *config) { c.out = stringList{"-", "results/{algo}.json", "all.csv"} },
+		},
+		{
+			name: "workers",
+			args: []string{"--workers", "2", "processes.csv"},
+			want: func(c *config) { c.workers = 2 },
+		},
+		{
+			name:    "bad format",
+			args:    []string{"--format", "xml", "processes.csv"},
+			wantErr: true,
+		},
+		{
+			name: "input format",
+			args: []string{"--input-format", "K8S", "processes.csv"},
+			want: func(c *config) { c.inputFormat = "k8s" },
+		},
+		{
+			name: "store",
+			args: []string{"--store", "results.db", "processes.csv"},
+			want: func(c *config) { c.store = "results.db" },
+		},
+		{
+			name:    "store while streaming",
+			args:    []string{"--store", "results.db", "--stream", "processes.csv"},
+			wantErr: true,
+		},
+		{
+			name: "plots",
+			args: []string{"--plot", "gnuplot", "--plot-dir", "out", "processes.csv"},
+			want: func(c *config) { c.plots, c.plotDir = []string{"gnuplot"}, "out" },
+		},
+		{
+			name:    "bad plot",
+			args:    []string{"--plot", "excel", "processes.csv"},
+			wantErr: true,
+		},
+		{
+			name: "webhook",
+			args: []string{"--webhook", "https://hooks.example.com/T000", "processes.csv"},
+			want: func(c *config) { c.webhook = "https://hooks.example.com/T000" },
+		},
+		{
+			name:    "bad webhook",
+			args:    []string{"--webhook", "hooks.example.com", "processes.csv"},
+			wantErr: true,
+		},
+		{
+			name: "seeds",
+			args: []string{"--seeds", "10", "gen:poisson?n=50"},
+			want: func(c *config) { c.seeds, c.args = 10, []string{"gen:poisson?n=50"} },
+		},
+		{
+			name:    "no seeds",
+			args:    []string{"--seeds", "0", "gen:poisson"},
+			wantErr: true,
+		},
+		{
+			name:    "bad generated workload",
+			args:    []string{"gen:poisson?lambda=2"},
+			wantErr: true,
+		},
+		{
+			name: "overhead",
+			args: []string{"--overhead", "--cores-per-node", "4", "processes.csv"},
+			want: func(c *config) { c.overhead, c.coresPerNode = true, 4 },
+		},
+		{
+			name:    "overhead while streaming",
+			args:    []string{"--overhead", "--stream", "processes.csv"},
+			wantErr: true,
+		},
+		{
+			name:    "negative cores per node",
+			args:    []string{"--cores-per-node", "-1", "processes.csv"},
+			wantErr: true,
+		},
+		{
+			name: "renumber pids",
+			args: []string{"--renumber-pids", "processes.csv"},
+			want: func(c *config) { c.renumberPIDs = true },
+		},
+		{
+			name: "timescale",
+			args: []string{"--timescale", "0.01", "processes.csv"},
+			want: func(c *config) { c.timescale = 0.01 },
+		},
+		{
+			name:    "bad timescale",
+			args:    []string{"--timescale", "0", "processes.csv"},
+			wantErr: true,
+		},
+		{
+			name: "overload",
+			args: []string{"--overload", "shed:100", "processes.csv"},
+			want: func(c *config) { c.overload = overloadFlag{policy: scheduler.OverloadShed, threshold: 100} },
+		},
+		{
+			name:    "bad overload policy",
+			args:    []string{"--overload", "drop:100", "processes.csv"},
+			wantErr: true,
+		},
+		{
+			name:    "bad overload threshold",
+			args:    []string{"--overload", "defer:0", "processes.csv"},
+			wantErr: true,
+		},
+		{
+			name: "invalid values",
+			args: []string{"--invalid", "clamp", "processes.csv"},
+			want: func(c *config) { c.invalid = workload.InvalidClamp },
+		},
+		{
+			name:    "bad invalid values policy",
+			args:    []string{"--invalid", "ignore", "processes.csv"},
+			wantErr: true,
+		},
+		{
+			name: "sparklines",
+			args: []string{"--sparklines", "processes.csv"},
+			want: func(c *config) { c.sparklines = true },
+		},
+		{
+			name: "block Gantt charts",
+			args: []string{"--gantt-style", "blocks", "processes.csv"},
+			want: func(c *config) { c.ganttStyle = render.GanttBlocks },
+		},
+		{
+			name:    "bad Gantt style",
+			args:    []string{"--gantt-style", "ascii", "processes.csv"},
+			wantErr: true,
+		},
+		{
+			name:    "missing policy",
+			args:    []string{"--policy", "missing.policy", "processes.csv"},
+			wantErr: true,
+		},
+		{
+			name:    "bad input format",
+			args:    []string{"--input-format", "toml", "processes.csv"},
+			wantErr: true,
+		},
+		{
+			name: "algorithms",
+			args: []string{"--algorithms", "SJF, fcfs", "processes.csv"},
+			want: func(c *config) { c.algorithms = []string{"sjf", "fcfs"} },
+		},
+		{
+			name:    "unknown algorithm",
+			args:    []string{"--algorithms", "cfs", "processes.csv"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown flag",
+			args:    []string{"--bogus", "processes.csv"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseFlags(io.Discard, tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseFlags() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			want := defaultConfig()
+			want.args = []string{"processes.csv"}
+			tt.want(&want)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("parseFlags() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func Test_config_addCustom(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	write := func(name, src string) string {
+		p := filepath.Join(dir, name)
+		if err := os.WriteFile(p, []byte(src), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+
+	cfg, err := parseFlags(io.Discard, []string{
+		"--policy", write("srtf.policy", "key = remaining\n"),
+		"--hierarchy", write("tree.yaml", "policy: sjf\n"),
+		"processes.csv",
+	})
+	if err != nil {
+		t.Fatalf("parseFlags() error = %v", err)
+	}
+	selected := cfg.schedulers()
+	if len(selected) != len(scheduler.Algorithms)+2 {
+		t.Fatalf("schedulers() = %+v, want the registry then srtf and tree", selected)
+	}
+	processes := []scheduler.Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 6, BurstDuration: 6, Priority: 3},
+	}
+	want := scheduler.SJF("test", processes)
+	for i, title := range []string{"Policy srtf", "Hierarchy tree"} {
+		a := selected[len(scheduler.Algorithms)+i]
+		if a.Title != title {
+			t.Errorf("scheduler title = %q, want %q", a.Title, title)
+		}
+		if got := a.Simulate("test", processes); !reflect.DeepEqual(got.Processes, want.Processes) {
+			t.Errorf("%s Simulate() = %+v, want %+v", a.Name, got.Processes, want.Processes)
+		}
+	}
+
+	for _, tt := range []struct{ flag, name, src, want string }{
+		{"--policy", "bad.policy", "key = wait\n", `unknown name "wait"`},
+		{"--policy", "fcfs.policy", "key = arrival\n", `name "fcfs" is already taken`},
+		{"--hierarchy", "bad.yaml", "policy: cfs\n", `unknown policy "cfs"`},
+	} {
+		_, err := parseFlags(io.Discard, []string{tt.flag, write(tt.name, tt.src), "processes.csv"})
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("parseFlags(%s %s) error = %v, want %s", tt.flag, tt.name, err, tt.want)
+		}
+	}
+}
+
+func Test_outputDryRun(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	cfg := defaultConfig()
+	cfg.dryRun, cfg.args = true, []string{"in.csv"}
+	outputDryRun(&w, cfg, "in.csv", []scheduler.Process{
+		{ProcessID: 1, BurstDuration: 5, ArrivalTime: 0, Priority: 2},
+		{ProcessID: 2, BurstDuration: 9, ArrivalTime: 3},
+	})
+	got := w.String()
+	for _, want := range []string{
+		"input:      in.csv",
+		"schedulers: First-come, first-serve; Shortest-job-first; Non-preemptive shortest-job-first; Priority; Multilevel queue",
+		"Workload (2 processes)",
+		"|  1 |        2 |     5 |       0 |",
+		"|  2 |        0 |     9 |       3 |",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("outputDryRun() missing %q in:\n%s", want, got)
+		}
+	}
+}
+
+func Test_config_outputs(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		cfg  config
+		want []render.Output
+	}{
+		{
+			name: "default stdout",
+			cfg:  defaultConfig(),
+			want: []render.Output{{Pattern: "-", Format: render.FormatText}},
+		},
+		{
+			name: "tee",
+			cfg:  config{out: stringList{"-", "results/{algo}.json", "all.csv"}, format: render.FormatText},
+			want: []render.Output{
+				{Pattern: "-", Format: render.FormatText},
+				{Pattern: "results/{algo}.json", Format: render.FormatJSON},
+				{Pattern: "all.csv", Format: render.FormatCSV},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.cfg.outputs(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("outputs() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_config_inputs(t *testing.T) {
+	t.Parallel()
+	cfg := defaultConfig()
+	cfg.seeds = 3
+	cfg.args = []string{"a.csv", "gen:batch?n=5&seed=10"}
+	want := []string{"a.csv", "gen:batch?n=5&seed=10", "gen:batch?n=5&seed=11", "gen:batch?n=5&seed=12"}
+	if got := cfg.inputs(); !reflect.DeepEqual(got, want) {
+		t.Errorf("inputs() = %v, want %v", got, want)
+	}
+	if got := cfg.outputs(); len(got) != 0 {
+		t.Errorf("outputs() = %v, want none: the seed comparison replaces the default output", got)
+	}
+}
+
+func Test_config_loadWorkload_timescale(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "trace.csv")
+	if err := os.WriteFile(path, []byte("1,5,0,1\n2,3,3600,1\n3,4,86400,1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg := defaultConfig()
+	cfg.timescale = 0.001
+	processes, _, err := cfg.loadWorkload(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []int64
+	for _, p := range processes {
+		got = append(got, p.ArrivalTime)
+	}
+	if want := []int64{0, 4, 86}; !reflect.DeepEqual(got, want) {
+		t.Errorf("arrivals = %v, want %v", got, want)
+	}
+	if p := cfg.provenance(path, ""); p.Parameters["timescale"] != "0.001" {
+		t.Errorf("provenance timescale = %q", p.Parameters["timescale"])
+	}
+}
+
+func Test_config_loadWorkload_generated(t *testing.T) {
+	t.Parallel()
+	// A generated workload is checked like a file: here its schedule could not start until a
+	// reservation that ends at the largest time.
+	cfg := defaultConfig()
+	cfg.reservations = []scheduler.Reservation{{Start: math.MaxInt64 - 1, Duration: 1, CPUs: 1}}
+	const path = "gen:batch?n=5&seed=1"
+	if _, _, err := cfg.loadWorkload(path); !errors.Is(err, workload.ErrTimeOverflow) || !strings.HasPrefix(err.Error(), path+": ") {
+		t.Errorf("loadWorkload() error = %v, want %v naming %s", err, workload.ErrTimeOverflow, path)
+	}
+	cfg.reservations = nil
+	if processes, _, err := cfg.loadWorkload(path); err != nil || len(processes) != 5 {
+		t.Errorf("loadWorkload() = %d processes, %v, want 5", len(processes), err)
+	}
+}
+
+func Test_parseFlags_mlfq(t *testing.T) {
+	t.Parallel()
+	cfg, err := parseFlags(io.Discard, []string{"--mlfq", "10,10,10", "--mlfq", "10,20:50", "processes.csv"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	custom := cfg.schedulers()[len(scheduler.Algorithms):]
+	if len(custom) != 2 || custom[0].Name != "mlfq-10,10,10" || custom[1].Name != "mlfq-10,20:50" {
+		t.Errorf("--mlfq schedulers = %+v", custom)
+	}
+	if _, err := parseFlags(io.Discard, []string{"--mlfq", "10,0", "processes.csv"}); err == nil || !strings.Contains(err.Error(), "quantum must be positive") {
+		t.Errorf("--mlfq 10,0 error = %v", err)
+	}
+	if _, err := parseFlags(io.Discard, []string{"--mlfq", "5", "--mlfq", "5", "processes.csv"}); err == nil || !strings.Contains(err.Error(), `"mlfq-5" is already taken`) {
+		t.Errorf("--mlfq twice error = %v", err)
+	}
+}
+
+func Test_parseFlags_dynamicRR(t *testing.T) {
+	t.Parallel()
+	cfg, err := parseFlags(io.Discard, []string{"--dynamic-rr", "median", "processes.csv"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if custom := cfg.schedulers()[len(scheduler.Algorithms):]; len(custom) != 1 || custom[0].Name != "rr-median" {
+		t.Errorf("--dynamic-rr schedulers = %+v", custom)
+	}
+	if _, err := parseFlags(io.Discard, []string{"--dynamic-rr", "mode", "processes.csv"}); err == nil || !strings.Contains(err.Error(), "unknown quantum strategy") {
+		t.Errorf("--dynamic-rr mode error = %v", err)
+	}
+}
+
+func Test_parseFlags_tieBreak(t *testing.T) {
+	t.Parallel()
+	cfg, err := parseFlags(io.Discard, []string{"--tie-break", "priority", "processes.csv"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p := cfg.provenance("processes.csv", ""); cfg.tieBreak != scheduler.TieBreakPriority || p.Parameters["tie_break"] != "priority" {
+		t.Errorf("--tie-break priority = %q, provenance %q", cfg.tieBreak, p.Parameters["tie_break"])
+	}
+	var out strings.Builder
+	outputDryRun(&out, cfg, "processes.csv", nil)
+	if !strings.Contains(out.String(), "  tie-break:  highest priority\n") {
+		t.Errorf("dry run = %s\nwant the tie-break", out.String())
+	}
+	if _, err := parseFlags(io.Discard, []string{"--tie-break", "random", "processes.csv"}); err == nil || !strings.Contains(err.Error(), "tie-break must be") {
+		t.Errorf("--tie-break random error = %v", err)
+	}
+}
+
+func Test_parseFlags_srr(t *testing.T) {
+	t.Parallel()
+	cfg, err := parseFlags(io.Discard, []string{"--srr", "3,1", "processes.csv"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if custom := cfg.schedulers()[len(scheduler.Algorithms):]; len(custom) != 1 || custom[0].Name != "srr-3,1" {
+		t.Errorf("--srr schedulers = %+v", custom)
+	}
+	if _, err := parseFlags(io.Discard, []string{"--srr", "3", "processes.csv"}); err == nil || !strings.Contains(err.Error(), "want the rates a,b") {
+		t.Errorf("--srr 3 error = %v", err)
+	}
+}
+
+func Test_parseFlags_sporadic(t *testing.T) {
+	t.Parallel()
+	cfg, err := parseFlags(io.Discard, []string{"--sporadic", "2:10", "processes.csv"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if custom := cfg.schedulers()[len(scheduler.Algorithms):]; len(custom) != 1 || custom[0].Name != "ss-2:10" {
+		t.Errorf("--sporadic schedulers = %+v", custom)
+	}
+	if _, err := parseFlags(io.Discard, []string{"--sporadic", "12:10", "processes.csv"}); err == nil || !strings.Contains(err.Error(), "must not exceed the period") {
+		t.Errorf("--sporadic 12:10 error = %v", err)
+	}
+}
+
+func Test_parseFlags_switchCost(t *testing.T) {
+	t.Parallel()
+	cfg, err := parseFlags(io.Discard, []string{"--switch-cost", "2", "processes.csv"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if custom := cfg.schedulers()[len(scheduler.Algorithms):]; len(custom) != 1 || custom[0].Name != "sjf-switch-2" {
+		t.Errorf("--switch-cost schedulers = %+v", custom)
+	}
+	for _, arg := range []string{"two", "-1"} {
+		if _, err := parseFlags(io.Discard, []string{"--switch-cost", arg, "processes.csv"}); err == nil || !strings.Contains(err.Error(), "switch cost") {
+			t.Errorf("--switch-cost %s error = %v", arg, err)
+		}
+	}
+}
+
+func Test_parseFlags_aging(t *testing.T) {
+	t.Parallel()
+	cfg, err := parseFlags(io.Discard, []string{"--aging", "20", "processes.csv"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if custom := cfg.schedulers()[len(scheduler.Algorithms):]; len(custom) != 1 || custom[0].Name != "aging-20" {
+		t.Errorf("--aging schedulers = %+v", custom)
+	}
+	if _, err := parseFlags(io.Discard, []string{"--aging", "0", "processes.csv"}); err == nil || !strings.Contains(err.Error(), "promotion timer must be positive") {
+		t.Errorf("--aging 0 error = %v", err)
+	}
+}
+
+func Test_config_seed(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "shares.csv")
+	if err := os.WriteFile(path, []byte("1,40,0,0,0,0,0,0,300\n2,40,0,0,0,0,0,0,100\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := parseFlags(io.Discard, []string{"--seed", "42", path})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.algorithms = []string{"lottery"}
+	run := func() scheduler.Result {
+		results, err := cfg.runWorkload(path, cfg.options(io.Discard), nil)
+		if err != nil || len(results) != 1 {
+			t.Fatalf("runWorkload() = %v, %v", results, err)
+		}
+		return results[0]
+	}
+	first := run()
+	if got := first.Provenance.Parameters["seed"]; got != "42" {
+		t.Errorf("seed parameter = %q, want 42", got)
+	}
+	if first.Tickets == nil || first.Tickets.Seed != 42 || first.Tickets.Shares[0].Tickets != 300 {
+		t.Errorf("Tickets = %+v", first.Tickets)
+	}
+	if !reflect.DeepEqual(first.Gantt, run().Gantt) {
+		t.Error("runs with the same --seed differ")
+	}
+	var w bytes.Buffer
+	outputDryRun(&w, cfg, path, nil)
+	if !strings.Contains(w.String(), "  seed:       42\n") {
+		t.Errorf("outputDryRun() = %q, want the seed", w.String())
+	}
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"io"
+	"strconv"
+
+	"github.com/olekukonko/tablewriter"
+
+	"github.com/jh125486/CSCE4600/Project1/render"
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+	"github.com/jh125486/CSCE4600/Project1/workload"
+)
+
+// outputMemory prints, for each --memory scheduler, its average wait split into the time
+// processes waited to be admitted into memory and the time they waited ready for the CPU, and
+// how often the swapper suspended a process. It prints nothing without --memory.
+func outputMemory(w io.Writer, results []scheduler.Result, unit scheduler.TimeUnit) {
+	var rows [][]string
+	f := func(v float64) string { return strconv.FormatFloat(v, 'f', 2, 64) }
+	for _, r := range results {
+		if m := r.Memory; m != nil {
+			rows = append(rows, []string{workload.Group(r.Input), r.Algorithm, strconv.FormatInt(m.Size, 10),
+				f(r.AveWait), f(m.AveAdmissionDelay), f(m.AveCPUWait), strconv.Itoa(m.Swaps)})
+		}
+	}
+	if len(rows) == 0 {
+		return
+	}
+
+	render.OutputTitle(w, "Memory admission")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Workload", "Algorithm", "Memory", unit.Label("Average wait"),
+		unit.Label("Average admission delay"), unit.Label("Average CPU wait"), "Swaps"})
+	table.SetAutoWrapText(false)
+	table.SetAutoMergeCellsByColumnIndex([]int{0})
+	table.AppendBulk(rows)
+	table.Render()
+}
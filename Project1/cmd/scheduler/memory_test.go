@@ -0,0 +1,45 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+func Test_outputMemory(t *testing.T) {
+	t.Parallel()
+	var out bytes.Buffer
+	outputMemory(&out, []scheduler.Result{{Algorithm: "fcfs"}}, scheduler.TimeUnitTicks)
+	assert.Empty(t, out.String(), "no scheduler admitted by memory")
+
+	results := []scheduler.Result{
+		{Algorithm: "fcfs", Input: "processes.csv"},
+		{Algorithm: "mem-10:2", Input: "processes.csv", AveWait: 3, Memory: &scheduler.MemoryReport{
+			Size: 10, Swaps: 2, AveAdmissionDelay: 2.25, AveCPUWait: 0.75,
+		}},
+	}
+	outputMemory(&out, results, scheduler.TimeUnitTicks)
+	assert.Contains(t, out.String(), "Memory admission")
+	assert.Contains(t, out.String(), "| mem-10:2  |     10 |         3.00 |                    2.25 |             0.75 |     2 |")
+	assert.NotContains(t, out.String(), "| fcfs ")
+}
+
+func Test_parseFlags_memory(t *testing.T) {
+	t.Parallel()
+	cfg, err := parseFlags(io.Discard, []string{"--memory", "1024", "--memory", "1024:20", "processes.csv"})
+	require.NoError(t, err)
+	var names []string
+	for _, a := range cfg.schedulers() {
+		names = append(names, a.Name)
+	}
+	assert.Subset(t, names, []string{"mem-1024", "mem-1024:20"})
+
+	_, err = parseFlags(io.Discard, []string{"--memory", "0", "processes.csv"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "memory size must be positive")
+}
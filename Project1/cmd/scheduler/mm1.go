@@ -0,0 +1,123 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+
+	"github.com/olekukonko/tablewriter"
+
+	"github.com/jh125486/CSCE4600/Project1/render"
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+	"github.com/jh125486/CSCE4600/Project1/workload"
+)
+
+// mm1 is the analytic steady state of an M/M/1 queue with arrival rate lambda and
+// service rate mu: utilization, mean wait in the queue, mean queue length and mean
+// time in the system. An unstable queue (rho >= 1) grows without bound.
+type mm1 struct {
+	lambda, mu float64
+	rho        float64
+	wait       float64 // Wq
+	queue      float64 // Lq
+	turnaround float64 // W
+}
+
+func newMM1(meanGap, meanBurst float64) mm1 {
+	q := mm1{lambda: 1 / meanGap, mu: 1 / meanBurst}
+	q.rho = q.lambda / q.mu
+	if q.rho >= 1 {
+		q.wait, q.queue, q.turnaround = math.Inf(1), math.Inf(1), math.Inf(1)
+		return q
+	}
+	q.wait = q.rho / (q.mu - q.lambda)
+	q.queue = q.lambda * q.wait
+	q.turnaround = 1 / (q.mu - q.lambda)
+
+	return q
+}
+
+// mm1Observed are the simulated counterparts of the M/M/1 predictions for one FCFS run.
+// Utilization and queue length are time averages over the run, up to the last exit.
+func mm1Observed(r scheduler.Result) (rho, wait, queue, turnaround float64) {
+	var busy, waiting, end int64
+	for _, p := range r.Processes {
+		busy += p.Burst
+		waiting += p.Wait
+		if p.Exit > end {
+			end = p.Exit
+		}
+	}
+	if end == 0 {
+		return math.NaN(), r.AveWait, math.NaN(), r.AveTurnaround
+	}
+
+	return float64(busy) / float64(end), r.AveWait, float64(waiting) / float64(end), r.AveTurnaround
+}
+
+// outputMM1Baseline prints, for each Poisson workload, the analytic M/M/1 predictions next to
+// the simulated FCFS results (FCFS is the M/M/1 service discipline). It prints nothing when no
+// Poisson workload was run with FCFS.
+func outputMM1Baseline(w io.Writer, results []scheduler.Result, unit scheduler.TimeUnit) {
+	var (
+		workloads []string
+		samples   = make(map[string]*[4][]float64)
+	)
+	for _, r := range results {
+		if r.Algorithm != "fcfs" || !workload.IsGenerated(r.Input) {
+			continue
+		}
+		if spec, err := workload.ParseGeneratedSpec(r.Input); err != nil || spec.Kind != "poisson" {
+			continue
+		}
+		g := workload.Group(r.Input)
+		s, ok := samples[g]
+		if !ok {
+			s = new([4][]float64)
+			samples[g] = s
+			workloads = append(workloads, g)
+		}
+		rho, wait, queue, turnaround := mm1Observed(r)
+		s[0], s[1] = append(s[0], rho), append(s[1], wait)
+		s[2], s[3] = append(s[2], queue), append(s[3], turnaround)
+	}
+	if len(workloads) == 0 {
+		return
+	}
+
+	render.OutputTitle(w, "M/M/1 baseline")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Workload", "Metric", "M/M/1", "Simulated FCFS", "Error"})
+	table.SetAutoWrapText(false)
+	table.SetAutoMergeCellsByColumnIndex([]int{0})
+	f := func(v float64) string { return strconv.FormatFloat(v, 'f', 2, 64) }
+	for _, g := range workloads {
+		spec, _ := workload.ParseGeneratedSpec(g)
+		q := newMM1(spec.Params["gap"], spec.Params["burst"])
+		s := samples[g]
+		for i, m := range []struct {
+			name     string
+			analytic float64
+		}{
+			{"Utilization ρ", q.rho},
+			{unit.Label("Mean wait Wq"), q.wait},
+			{"Mean queue length Lq", q.queue},
+			{unit.Label("Mean turnaround W"), q.turnaround},
+		} {
+			sim := newSampleStats(s[i])
+			table.Append([]string{g, m.name, f(m.analytic), sim.String(), relativeError(sim.mean, m.analytic)})
+		}
+	}
+	table.Render()
+	_, _ = fmt.Fprintln(w, "M/M/1 values are steady-state predictions; short runs, integer arrivals and bursts of at least 1 shift the simulation.")
+}
+
+// relativeError formats how far a simulated value is from its prediction, as a percentage.
+func relativeError(simulated, predicted float64) string {
+	if predicted == 0 || math.IsInf(predicted, 0) || math.IsNaN(simulated) {
+		return "-"
+	}
+
+	return fmt.Sprintf("%+.1f%%", (simulated-predicted)/predicted*100)
+}
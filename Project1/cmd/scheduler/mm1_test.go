@@ -0,0 +1,51 @@
+package main
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+func Test_newMM1(t *testing.T) {
+	t.Parallel()
+	q := newMM1(10, 6)
+	assert.InDelta(t, 0.6, q.rho, 1e-9)
+	assert.InDelta(t, 9, q.wait, 1e-9)
+	assert.InDelta(t, 0.9, q.queue, 1e-9)
+	assert.InDelta(t, 15, q.turnaround, 1e-9)
+
+	unstable := newMM1(5, 5)
+	assert.Equal(t, 1.0, unstable.rho)
+	assert.True(t, math.IsInf(unstable.wait, 1))
+}
+
+func Test_outputMM1Baseline(t *testing.T) {
+	t.Parallel()
+	run := scheduler.Result{
+		Algorithm: "fcfs",
+		Input:     "gen:poisson?burst=6&gap=10&n=2&seed=1",
+		AveWait:   1,
+		Processes: []scheduler.ProcessResult{
+			{ID: 1, Burst: 4, Arrival: 0, Wait: 0, Exit: 4},
+			{ID: 2, Burst: 4, Arrival: 2, Wait: 2, Exit: 8},
+		},
+	}
+	rho, wait, queue, _ := mm1Observed(run)
+	assert.Equal(t, 1.0, rho)
+	assert.Equal(t, 1.0, wait)
+	assert.Equal(t, 0.25, queue)
+
+	var out bytes.Buffer
+	outputMM1Baseline(&out, []scheduler.Result{run, {Algorithm: "fcfs", Input: "gen:batch?n=2&seed=1"}}, scheduler.TimeUnitTicks)
+	assert.Contains(t, out.String(), "gen:poisson?burst=6&gap=10&n=2")
+	assert.Contains(t, out.String(), "+66.7%")
+	assert.NotContains(t, out.String(), "gen:batch")
+
+	out.Reset()
+	outputMM1Baseline(&out, []scheduler.Result{{Algorithm: "sjf", Input: run.Input}}, scheduler.TimeUnitTicks)
+	assert.Empty(t, out.String(), "only FCFS is compared")
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/olekukonko/tablewriter"
+
+	"github.com/jh125486/CSCE4600/Project1/render"
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+	"github.com/jh125486/CSCE4600/Project1/workload"
+)
+
+// defaultMonteCarloRuns is how many workloads montecarlo draws from each family by default.
+const defaultMonteCarloRuns = 100
+
+// monteCarloConfig holds the parsed montecarlo command line.
+type monteCarloConfig struct {
+	config
+	raw        string
+	checkpoint string
+}
+
+// parseMonteCarloFlags parses `scheduler montecarlo [flags] gen:family?params...`.
+func parseMonteCarloFlags(errW io.Writer, args []string) (monteCarloConfig, error) {
+	mc := monteCarloConfig{config: defaultConfig()}
+	mc.seeds = defaultMonteCarloRuns
+
+	fs := flag.NewFlagSet("scheduler montecarlo", flag.ContinueOnError)
+	fs.SetOutput(errW)
+	fs.Usage = func() {
+		_, _ = fmt.Fprintln(errW, "usage: scheduler montecarlo [flags] gen:<family>?<params>...")
+		_, _ = fmt.Fprintln(errW, "Draws -m random workloads from each family and summarizes the distribution of every metric per algorithm.")
+		fs.PrintDefaults()
+	}
+	fs.IntVar(&mc.seeds, "m", defaultMonteCarloRuns, "number of random workloads drawn from each family (consecutive seeds)")
+	fs.Func("algorithms", "comma-separated algorithms to run (default all)", func(s string) (err error) {
+		mc.algorithms, err = parseAlgorithms(s)
+		return err
+	})
+	fs.StringVar(&mc.raw, "raw", "", "also write the raw per-run metrics as CSV to this path (- for stdout)")
+	fs.Func("time-unit", "unit of the generated times: ticks, ms or s (default ticks)", func(s string) (err error) {
+		mc.timeUnit, err = scheduler.ParseTimeUnit(s)
+		return err
+	})
+	fs.IntVar(&mc.workers, "workers", defaultWorkers, "number of workloads simulated in parallel")
+	fs.StringVar(&mc.checkpoint, "checkpoint", "", "save each finished workload to this file and skip those it already holds, so a stopped run resumes")
+	if err := fs.Parse(args); err != nil {
+		return mc, err
+	}
+	mc.args = fs.Args()
+
+	var err error
+	switch {
+	case mc.seeds < 1:
+		err = fmt.Errorf("%w: -m must be at least 1", scheduler.ErrInvalidArgs)
+	case len(mc.args) == 0:
+		err = fmt.Errorf("%w: must give a workload family such as gen:poisson?n=100", workload.ErrInvalidGenerator)
+	}
+	for _, arg := range mc.args {
+		if err != nil {
+			break
+		}
+		if !workload.IsGenerated(arg) {
+			err = fmt.Errorf("%w: %q is not a generated workload (gen:...)", workload.ErrInvalidGenerator, arg)
+		} else {
+			_, err = workload.ParseGeneratedSpec(arg)
+		}
+	}
+	if err != nil {
+		_, _ = fmt.Fprintln(errW, err)
+	}
+
+	return mc, err
+}
+
+// run simulates every drawn workload and writes the summary, and any raw data, to stdout.
+func (mc monteCarloConfig) run(stdout, errW io.Writer) error {
+	var (
+		opts   = mc.options(errW)
+		inputs = mc.inputs()
+		saved  *checkpoint
+		err    error
+	)
+	if mc.checkpoint != "" {
+		if saved, err = openCheckpoint(mc.checkpoint, mc.checkpointJob("montecarlo")); err != nil {
+			return err
+		}
+	}
+	results, err := runPool(len(inputs), mc.workers, func(i int) ([]scheduler.Result, error) {
+		if rs, ok := saved.results(inputs[i]); ok {
+			return rs, nil
+		}
+		rs, err := mc.runWorkload(inputs[i], opts, nil)
+		if err != nil {
+			return nil, err
+		}
+		return rs, saved.save(inputs[i], rs)
+	})
+	if cerr := saved.Close(); err == nil && cerr != nil {
+		err = fmt.Errorf("%w: closing checkpoint", cerr)
+	}
+	if err != nil {
+		return err
+	}
+	outputMonteCarlo(stdout, results, mc.timeUnit)
+	if mc.raw == "" {
+		return nil
+	}
+
+	if mc.raw == render.StdoutPath {
+		return writeMonteCarloRaw(stdout, results)
+	}
+	f, err := os.Create(mc.raw)
+	if err != nil {
+		return fmt.Errorf("%w: creating raw output", err)
+	}
+	if err := writeMonteCarloRaw(f, results); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("%w: writing raw output", err)
+	}
+
+	return f.Close()
+}
+
+// monteCarloMetrics are the metric names the summary reports, in a stable order.
+func monteCarloMetrics() []string {
+	names := make([]string, 0, len(resultMetrics))
+	for name := range resultMetrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// outputMonteCarlo prints the distribution of each metric across the drawn workloads,
+// per family and algorithm.
+func outputMonteCarlo(w io.Writer, results []scheduler.Result, unit scheduler.TimeUnit) {
+	type key struct{ workload, algorithm string }
+	var (
+		keys    []key
+		metrics = monteCarloMetrics()
+		samples = make(map[key][][]float64)
+	)
+	for _, r := range results {
+		k := key{workload.Group(r.Input), r.Algorithm}
+		s, ok := samples[k]
+		if !ok {
+			s = make([][]float64, len(metrics))
+			keys = append(keys, k)
+		}
+		for i, m := range metrics {
+			s[i] = append(s[i], resultMetrics[m](r))
+		}
+		samples[k] = s
+	}
+
+	render.OutputTitle(w, "Monte Carlo")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Workload", "Algorithm", "Metric", "Runs", "Mean", "SD", "Min", "P50", "P95", "Max"})
+	table.SetAutoWrapText(false)
+	table.SetAutoMergeCellsByColumnIndex([]int{0, 1})
+	f := func(v float64) string { return strconv.FormatFloat(v, 'f', 2, 64) }
+	for _, k := range keys {
+		for i, m := range metrics {
+			d := newDistribution(samples[k][i])
+			table.Append([]string{k.workload, k.algorithm, m, strconv.Itoa(d.n),
+				f(d.mean), f(d.sd), f(d.min), f(d.p50), f(d.p95), f(d.max)})
+		}
+	}
+	table.Render()
+	if unit != scheduler.TimeUnitTicks {
+		_, _ = fmt.Fprintf(w, "Times are in %s; throughput is jobs per %s.\n", unit, unit)
+	}
+}
+
+// writeMonteCarloRaw writes one CSV row per drawn workload and algorithm with every metric.
+func writeMonteCarloRaw(w io.Writer, results []scheduler.Result) error {
+	metrics := monteCarloMetrics()
+	cw := csv.NewWriter(w)
+	if err := cw.Write(append([]string{"workload", "seed", "algorithm"}, metrics...)); err != nil {
+		return err
+	}
+	row := make([]string, 3+len(metrics))
+	for _, r := range results {
+		row[0], row[1], row[2] = workload.Group(r.Input), "", r.Algorithm
+		if spec, err := workload.ParseGeneratedSpec(r.Input); err == nil {
+			row[1] = strconv.FormatInt(spec.Seed, 10)
+		}
+		for i, m := range metrics {
+			row[3+i] = strconv.FormatFloat(resultMetrics[m](r), 'g', -1, 64)
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+
+	return cw.Error()
+}
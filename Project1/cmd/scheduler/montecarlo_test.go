@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+func Test_parseMonteCarloFlags(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		args    []string
+		want    func(mc *monteCarloConfig)
+		wantErr bool
+	}{
+		{
+			name: "defaults",
+			args: []string{"gen:poisson"},
+			want: func(mc *monteCarloConfig) {},
+		},
+		{
+			name: "flags",
+			args: []string{"-m", "5", "-algorithms", "sjf, fcfs", "-raw", "runs.csv", "-time-unit", "ms", "-checkpoint", "job.ckpt", "gen:batch?n=3"},
+			want: func(mc *monteCarloConfig) {
+				mc.seeds, mc.algorithms, mc.raw, mc.timeUnit = 5, []string{"sjf", "fcfs"}, "runs.csv", scheduler.TimeUnitMillis
+				mc.checkpoint = "job.ckpt"
+				mc.args = []string{"gen:batch?n=3"}
+			},
+		},
+		{name: "no family", args: nil, wantErr: true},
+		{name: "file", args: []string{"processes.csv"}, wantErr: true},
+		{name: "bad family", args: []string{"gen:zipf"}, wantErr: true},
+		{name: "bad algorithm", args: []string{"-algorithms", "nosuch", "gen:batch"}, wantErr: true},
+		{name: "no runs", args: []string{"-m", "0", "gen:batch"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseMonteCarloFlags(io.Discard, tt.args)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			want := monteCarloConfig{config: defaultConfig()}
+			want.seeds = defaultMonteCarloRuns
+			want.args = []string{"gen:poisson"}
+			tt.want(&want)
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func Test_monteCarloConfig_run(t *testing.T) {
+	t.Parallel()
+	raw := filepath.Join(t.TempDir(), "runs.csv")
+	mc, err := parseMonteCarloFlags(io.Discard, []string{"-m", "4", "-algorithms", "fcfs,sjf", "-raw", raw, "-workers", "2",
+		"gen:batch?n=10", "gen:lognormal?n=10&cv=0.5"})
+	require.NoError(t, err)
+
+	var stdout bytes.Buffer
+	require.NoError(t, mc.run(&stdout, io.Discard))
+	assert.Contains(t, stdout.String(), "gen:lognormal?burst=8&cv=0.5&gap=10&n=10")
+	assert.Contains(t, stdout.String(), "avg_wait")
+
+	f, err := os.Open(raw)
+	require.NoError(t, err)
+	defer f.Close()
+	rows, err := csv.NewReader(f).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 1+2*4*2, "a row per family, seed and algorithm")
+	assert.Equal(t, "workload,seed,algorithm,avg_response,avg_turnaround,avg_wait,deadline_misses,incomplete,makespan,max_lateness,max_response,max_turnaround,max_wait,sla_violations,throughput", strings.Join(rows[0], ","))
+	assert.Equal(t, []string{"gen:batch?n=10", "1", "fcfs"}, rows[1][:3])
+	assert.Equal(t, []string{"gen:batch?n=10", "2", "sjf"}, rows[4][:3])
+}
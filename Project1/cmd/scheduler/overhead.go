@@ -0,0 +1,143 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/olekukonko/tablewriter"
+
+	"github.com/jh125486/CSCE4600/Project1/render"
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+type (
+	// overhead counts the scheduling events of a run that cost more than the work itself:
+	// preemptions, migrations between CPUs and, of those, migrations between nodes.
+	overhead struct {
+		preemptions, migrations, crossNode int
+		processes                          map[int64]*processOverhead
+	}
+	// processOverhead is one process's share of a run's overhead.
+	processOverhead struct {
+		preemptions, migrations, crossNode int
+	}
+)
+
+// newOverhead counts the overhead events in a Gantt chart. A process is preempted when it
+// resumes after a gap or after another process ran on its CPU, and migrates when it resumes
+// on a different CPU. CPUs are grouped into nodes of
+// coresPerNode consecutive CPUs; 0 puts every CPU on one node.
+func newOverhead(gantt []scheduler.TimeSlice, coresPerNode int) overhead {
+	o := overhead{processes: make(map[int64]*processOverhead)}
+	ordered := append([]scheduler.TimeSlice(nil), scheduler.BusySlices(gantt)...)
+	sort.SliceStable(ordered, func(i, j int) bool { return ordered[i].Start < ordered[j].Start })
+
+	var (
+		last    = make(map[int64]scheduler.TimeSlice) // each process's previous slice
+		lastPID = make(map[int]int64)                 // the process that last ran on each CPU
+		node    = func(cpu int) int {
+			if coresPerNode <= 0 {
+				return 0
+			}
+			return cpu / coresPerNode
+		}
+	)
+	for _, s := range ordered {
+		p := o.processes[s.PID]
+		if p == nil {
+			p = &processOverhead{}
+			o.processes[s.PID] = p
+		}
+		if prev, ok := last[s.PID]; ok {
+			// Resuming after a gap or after another process ran on the old CPU is a preemption.
+			if prev.Stop < s.Start || lastPID[prev.CPU] != s.PID {
+				p.preemptions++
+				o.preemptions++
+			}
+			if prev.CPU != s.CPU {
+				p.migrations++
+				o.migrations++
+				if node(prev.CPU) != node(s.CPU) {
+					p.crossNode++
+					o.crossNode++
+				}
+			}
+		}
+		last[s.PID] = s
+		lastPID[s.CPU] = s.PID
+	}
+
+	return o
+}
+
+// outputOverhead prints each scheduler's overhead totals, then the counts of every process that
+// was preempted or migrated, so policies can be compared on overhead as well as latency.
+func outputOverhead(w io.Writer, results []scheduler.Result, coresPerNode int) {
+	render.OutputTitle(w, "Scheduling overhead")
+	totals := tablewriter.NewWriter(w)
+	totals.SetHeader([]string{"Input", "Algorithm", "Preemptions", "Migrations", "Cross-node", "Most preempted", "Most migrated"})
+	totals.SetAutoWrapText(false)
+	totals.SetAutoMergeCellsByColumnIndex([]int{0})
+
+	type row struct {
+		input, algorithm string
+		o                overhead
+	}
+	var rows []row
+	for _, r := range results {
+		o := newOverhead(r.Gantt, coresPerNode)
+		rows = append(rows, row{r.Input, r.Algorithm, o})
+		totals.Append([]string{r.Input, r.Algorithm, strconv.Itoa(o.preemptions), strconv.Itoa(o.migrations),
+			strconv.Itoa(o.crossNode),
+			mostOverhead(o, func(p *processOverhead) int { return p.preemptions }),
+			mostOverhead(o, func(p *processOverhead) int { return p.migrations })})
+	}
+	totals.Render()
+
+	var lines [][]string
+	for _, r := range rows {
+		pids := make([]int64, 0, len(r.o.processes))
+		for pid, p := range r.o.processes {
+			if p.preemptions > 0 || p.migrations > 0 {
+				pids = append(pids, pid)
+			}
+		}
+		sort.Slice(pids, func(i, j int) bool { return pids[i] < pids[j] })
+		for _, pid := range pids {
+			p := r.o.processes[pid]
+			lines = append(lines, []string{r.input, r.algorithm, strconv.FormatInt(pid, 10),
+				strconv.Itoa(p.preemptions), strconv.Itoa(p.migrations), strconv.Itoa(p.crossNode)})
+		}
+	}
+	if len(lines) == 0 {
+		_, _ = fmt.Fprintln(w, "No process was preempted or migrated.")
+		return
+	}
+	_, _ = fmt.Fprintln(w, "\nPreempted or migrated processes")
+	perProcess := tablewriter.NewWriter(w)
+	perProcess.SetHeader([]string{"Input", "Algorithm", "ID", "Preemptions", "Migrations", "Cross-node"})
+	perProcess.SetAutoWrapText(false)
+	perProcess.SetAutoMergeCellsByColumnIndex([]int{0, 1})
+	perProcess.AppendBulk(lines)
+	perProcess.Render()
+}
+
+// mostOverhead names the process with the highest count, lowest PID on ties, as "P3 (4)".
+func mostOverhead(o overhead, count func(p *processOverhead) int) string {
+	var (
+		pid  int64
+		best int
+	)
+	for id, p := range o.processes {
+		if c := count(p); c > best || c == best && c > 0 && id < pid {
+			pid, best = id, c
+		}
+	}
+	if best == 0 {
+		return "-"
+	}
+
+	return fmt.Sprintf("P%d (%d)", pid, best)
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+func Test_newOverhead(t *testing.T) {
+	t.Parallel()
+	gantt := []scheduler.TimeSlice{
+		{PID: 1, CPU: 0, Start: 0, Stop: 2},
+		{PID: 2, CPU: 0, Start: 2, Stop: 4},
+		{PID: 1, CPU: 0, Start: 4, Stop: 5}, // preempted by 2
+		{PID: 1, CPU: 0, Start: 5, Stop: 6}, // uncompacted continuation
+		{PID: 1, CPU: 3, Start: 6, Stop: 7}, // migrated straight to another node
+		{PID: 2, CPU: 1, Start: 8, Stop: 9}, // preempted (gap) and migrated on its node
+	}
+	tests := []struct {
+		name         string
+		coresPerNode int
+		want         overhead
+	}{
+		{
+			name: "one node",
+			want: overhead{preemptions: 2, migrations: 2, processes: map[int64]*processOverhead{
+				1: {preemptions: 1, migrations: 1},
+				2: {preemptions: 1, migrations: 1},
+			}},
+		},
+		{
+			name:         "two cores per node",
+			coresPerNode: 2,
+			want: overhead{preemptions: 2, migrations: 2, crossNode: 1, processes: map[int64]*processOverhead{
+				1: {preemptions: 1, migrations: 1, crossNode: 1},
+				2: {preemptions: 1, migrations: 1},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, newOverhead(gantt, tt.coresPerNode))
+		})
+	}
+}
+
+func Test_outputOverhead(t *testing.T) {
+	t.Parallel()
+	var out bytes.Buffer
+	outputOverhead(&out, []scheduler.Result{
+		{Input: "a.csv", Algorithm: "rr", Gantt: []scheduler.TimeSlice{{PID: 1, Stop: 1}, {PID: 2, Start: 1, Stop: 2}, {PID: 1, Start: 2, Stop: 3}}},
+		{Input: "a.csv", Algorithm: "sjf"},
+	}, 0)
+	assert.Contains(t, out.String(), "| a.csv | rr        |           1 |          0 |          0 | P1 (1)         | -             |")
+	assert.Contains(t, out.String(), "|       | sjf       |           0 |          0 |          0 | -              | -             |")
+	assert.Contains(t, out.String(), "Preempted or migrated processes")
+
+	out.Reset()
+	outputOverhead(&out, []scheduler.Result{{Algorithm: "sjf"}}, 0)
+	assert.Contains(t, out.String(), "No process was preempted or migrated.")
+}
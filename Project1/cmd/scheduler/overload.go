@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+
+	"github.com/jh125486/CSCE4600/Project1/render"
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+	"github.com/jh125486/CSCE4600/Project1/workload"
+)
+
+// overloadFlag is the --overload flag, policy:threshold; its policy is empty when not given.
+type overloadFlag struct {
+	policy    scheduler.OverloadPolicy
+	threshold int64
+}
+
+func (f *overloadFlag) String() string {
+	if f.policy == "" {
+		return ""
+	}
+
+	return string(f.policy) + ":" + strconv.FormatInt(f.threshold, 10)
+}
+
+func (f *overloadFlag) Set(s string) error {
+	name, threshold, ok := strings.Cut(s, ":")
+	if !ok {
+		return fmt.Errorf("%w: --overload must be policy:threshold, got %q", scheduler.ErrInvalidArgs, s)
+	}
+	policy, err := scheduler.ParseOverloadPolicy(name)
+	if err != nil {
+		return err
+	}
+	n, err := strconv.ParseInt(threshold, 10, 64)
+	if err != nil || n <= 0 {
+		return fmt.Errorf("%w: --overload threshold must be a positive integer, got %q", scheduler.ErrInvalidArgs, threshold)
+	}
+	f.policy, f.threshold = policy, n
+
+	return nil
+}
+
+// maxListedPIDs is how many PIDs outputOverload lists in a cell before eliding the rest.
+const maxListedPIDs = 8
+
+// outputOverload prints, for each workload that ran under --overload, the processes turned away
+// or held back. Admission is decided before scheduling, so it is the same for every scheduler and
+// is printed once per workload. It prints nothing without --overload.
+func outputOverload(w io.Writer, results []scheduler.Result, unit scheduler.TimeUnit) {
+	var (
+		rows [][]string
+		seen = make(map[string]bool)
+	)
+	for _, r := range results {
+		if r.Overload == nil || seen[r.Input] {
+			continue
+		}
+		seen[r.Input] = true
+		o := r.Overload
+		mean := "-"
+		if len(o.Deferred) > 0 {
+			mean = strconv.FormatFloat(float64(o.DeferredDelay)/float64(len(o.Deferred)), 'f', 2, 64)
+		}
+		rows = append(rows, []string{workload.Group(r.Input), string(o.Policy), strconv.FormatInt(o.Threshold, 10),
+			strconv.FormatInt(o.PeakLoad, 10), listPIDs(o.Rejected), listPIDs(o.Deferred), mean, listPIDs(o.Shed)})
+	}
+	if len(rows) == 0 {
+		return
+	}
+
+	render.OutputTitle(w, "Overload")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Workload", "Policy", unit.Label("Threshold"), unit.Label("Peak load"),
+		"Rejected", "Deferred", unit.Label("Mean deferral"), "Shed"})
+	table.SetAutoWrapText(false)
+	table.AppendBulk(rows)
+	table.Render()
+}
+
+// listPIDs formats a count of processes and their PIDs, as "2: P3, P7", or "0".
+func listPIDs(pids []int64) string {
+	if len(pids) == 0 {
+		return "0"
+	}
+	names := make([]string, 0, maxListedPIDs+1)
+	for i, pid := range pids {
+		if i == maxListedPIDs {
+			names = append(names, "…")
+			break
+		}
+		names = append(names, "P"+strconv.FormatInt(pid, 10))
+	}
+
+	return strconv.Itoa(len(pids)) + ": " + strings.Join(names, ", ")
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+func Test_config_runWorkload_overload(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "burst.csv")
+	require.NoError(t, os.WriteFile(path, []byte("1,5,0,2\n2,9,3,1\n3,6,6,3\n"), 0o644))
+	cfg := defaultConfig()
+	cfg.algorithms = []string{"fcfs", "sjf"}
+	require.NoError(t, cfg.overload.Set("reject:10"))
+
+	results, err := cfg.runWorkload(path, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	for _, r := range results {
+		require.NotNil(t, r.Overload, r.Algorithm)
+		assert.Equal(t, []int64{2}, r.Overload.Rejected, r.Algorithm)
+		assert.Equal(t, 1, r.Incomplete, r.Algorithm)
+	}
+	assert.Equal(t, "reject:10", results[0].Provenance.Parameters["overload"])
+
+	var out bytes.Buffer
+	outputOverload(&out, results, scheduler.TimeUnitTicks)
+	assert.Contains(t, out.String(), "Overload")
+	assert.Contains(t, out.String(), "1: P2")
+	assert.Equal(t, 1, bytes.Count(out.Bytes(), []byte("reject")), "one row per workload")
+}
+
+func Test_outputOverload(t *testing.T) {
+	t.Parallel()
+	var out bytes.Buffer
+	outputOverload(&out, []scheduler.Result{{Algorithm: "fcfs"}}, scheduler.TimeUnitTicks)
+	assert.Empty(t, out.String(), "nothing without --overload")
+
+	outputOverload(&out, []scheduler.Result{{Algorithm: "fcfs", Input: "w.csv", Overload: &scheduler.OverloadReport{
+		Policy: scheduler.OverloadDefer, Threshold: 50, Deferred: []int64{4, 9}, DeferredDelay: 7, PeakLoad: 50,
+	}}}, scheduler.TimeUnitTicks)
+	assert.Contains(t, out.String(), "2: P4, P9")
+	assert.Contains(t, out.String(), "3.50")
+}
+
+func Test_listPIDs(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "0", listPIDs(nil))
+	assert.Equal(t, "10: P1, P2, P3, P4, P5, P6, P7, P8, …", listPIDs([]int64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}))
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"io"
+	"strconv"
+
+	"github.com/olekukonko/tablewriter"
+
+	"github.com/jh125486/CSCE4600/Project1/render"
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+	"github.com/jh125486/CSCE4600/Project1/workload"
+)
+
+// outputPredictionAccuracy prints how close each predicting scheduler's burst predictions came
+// to the actual bursts, so predictors can be compared on the same workload. It prints nothing
+// when no scheduler predicted bursts.
+func outputPredictionAccuracy(w io.Writer, results []scheduler.Result, unit scheduler.TimeUnit) {
+	var rows [][]string
+	f := func(v float64) string { return strconv.FormatFloat(v, 'f', 2, 64) }
+	for _, r := range results {
+		if p := r.Prediction; p != nil {
+			rows = append(rows, []string{workload.Group(r.Input), r.Algorithm, strconv.Itoa(p.Predictions),
+				f(p.MeanError), f(p.MeanAbsError), f(p.RMSError)})
+		}
+	}
+	if len(rows) == 0 {
+		return
+	}
+
+	render.OutputTitle(w, "Burst prediction accuracy")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Workload", "Algorithm", "Predictions",
+		unit.Label("Mean error"), unit.Label("Mean abs error"), unit.Label("RMS error")})
+	table.SetAutoWrapText(false)
+	table.SetAutoMergeCellsByColumnIndex([]int{0})
+	table.AppendBulk(rows)
+	table.Render()
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+func Test_outputPredictionAccuracy(t *testing.T) {
+	t.Parallel()
+	var out bytes.Buffer
+	outputPredictionAccuracy(&out, []scheduler.Result{{Algorithm: "sjf"}}, scheduler.TimeUnitTicks)
+	assert.Empty(t, out.String(), "no scheduler predicted bursts")
+
+	results := []scheduler.Result{
+		{Algorithm: "sjf", Input: "processes.csv"},
+		{Algorithm: "sjf-ema", Input: "processes.csv", Prediction: &scheduler.PredictionAccuracy{
+			Predictions: 4, MeanError: -1.5, MeanAbsError: 2.25, RMSError: 3,
+		}},
+	}
+	outputPredictionAccuracy(&out, results, scheduler.TimeUnitTicks)
+	assert.Contains(t, out.String(), "Burst prediction accuracy")
+	assert.Contains(t, out.String(), "sjf-ema")
+	assert.Contains(t, out.String(), "-1.50")
+	assert.Contains(t, out.String(), "2.25")
+	assert.NotContains(t, out.String(), "| sjf ")
+}
+
+func Test_parseFlags_predictor(t *testing.T) {
+	t.Parallel()
+	var errW bytes.Buffer
+	cfg, err := parseFlags(&errW, []string{"--predictor", "ema", "--predictor", "last", "--predictor", "ema:0.2", "processes.csv"})
+	require.NoError(t, err)
+	var names []string
+	for _, a := range cfg.schedulers() {
+		names = append(names, a.Name)
+	}
+	assert.Subset(t, names, []string{"sjf-ema", "sjf-last", "sjf-ema:0.2"})
+
+	_, err = parseFlags(&errW, []string{"--predictor", "oracle", "processes.csv"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown predictor")
+
+	_, err = parseFlags(&errW, []string{"--predictor", "ema", "--predictor", "ema", "processes.csv"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `name "sjf-ema" is already taken`)
+}
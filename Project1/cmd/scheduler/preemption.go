@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+
+	"github.com/jh125486/CSCE4600/Project1/render"
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+	"github.com/jh125486/CSCE4600/Project1/workload"
+)
+
+// budgetFlag is the --preemption-budget flag, n/interval; n is 0 when not given.
+type budgetFlag struct {
+	n        int
+	interval int64
+}
+
+func (f *budgetFlag) String() string {
+	if f.n == 0 {
+		return ""
+	}
+
+	return strconv.Itoa(f.n) + "/" + strconv.FormatInt(f.interval, 10)
+}
+
+func (f *budgetFlag) Set(s string) error {
+	count, interval, ok := strings.Cut(s, "/")
+	if !ok {
+		return fmt.Errorf("%w: --preemption-budget must be n/interval, got %q", scheduler.ErrInvalidArgs, s)
+	}
+	n, err := strconv.Atoi(count)
+	if err != nil || n <= 0 {
+		return fmt.Errorf("%w: --preemption-budget count must be a positive integer, got %q", scheduler.ErrInvalidArgs, count)
+	}
+	d, err := strconv.ParseInt(interval, 10, 64)
+	if err != nil || d <= 0 {
+		return fmt.Errorf("%w: --preemption-budget interval must be a positive integer, got %q", scheduler.ErrInvalidArgs, interval)
+	}
+	f.n, f.interval = n, d
+
+	return nil
+}
+
+// preemptionOptions are the scheduler options for --min-granularity and --preemption-budget.
+func (c config) preemptionOptions() []scheduler.Option {
+	var opts []scheduler.Option
+	if c.minGranularity > 0 {
+		opts = append(opts, scheduler.WithMinGranularity(c.minGranularity))
+	}
+	if c.preemptionBudget.n > 0 {
+		opts = append(opts, scheduler.WithPreemptionBudget(c.preemptionBudget.n, c.preemptionBudget.interval))
+	}
+
+	return opts
+}
+
+// outputPreemption prints, for each scheduler that preempts, its context switches, preemptions
+// and average response and wait without and with --min-granularity and --preemption-budget, to
+// show what the controls trade. It prints nothing without them.
+func outputPreemption(w io.Writer, results []scheduler.Result, unit scheduler.TimeUnit) {
+	var (
+		rows     [][]string
+		controls string
+	)
+	for _, r := range results {
+		p := r.Preemption
+		if p == nil || p.Without.Preemptions == 0 && p.With.Preemptions == 0 {
+			continue
+		}
+		controls = p.String()
+		rows = append(rows, []string{workload.Group(r.Input), r.Algorithm,
+			fmt.Sprintf("%d → %d", p.Without.ContextSwitches, p.With.ContextSwitches),
+			fmt.Sprintf("%d → %d", p.Without.Preemptions, p.With.Preemptions),
+			fmt.Sprintf("%.2f → %.2f", p.Without.AveResponse, p.With.AveResponse),
+			fmt.Sprintf("%.2f → %.2f", p.Without.AveWait, p.With.AveWait)})
+	}
+	if len(rows) == 0 {
+		return
+	}
+
+	render.OutputTitle(w, "Preemption controls ("+controls+")")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Workload", "Algorithm", "Context switches", "Preemptions",
+		unit.Label("Average response"), unit.Label("Average wait")})
+	table.SetAutoWrapText(false)
+	table.SetAutoMergeCellsByColumnIndex([]int{0})
+	table.AppendBulk(rows)
+	table.Render()
+	_, _ = io.WriteString(w, "Each cell is without → with the controls; schedulers that never preempt are left out.\n")
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+func Test_budgetFlag_Set(t *testing.T) {
+	t.Parallel()
+	var f budgetFlag
+	assert.Empty(t, f.String())
+	require.NoError(t, f.Set("2/10"))
+	assert.Equal(t, budgetFlag{n: 2, interval: 10}, f)
+	assert.Equal(t, "2/10", f.String())
+	for _, bad := range []string{"2", "0/10", "x/10", "2/0", "2/-5"} {
+		assert.ErrorIs(t, f.Set(bad), scheduler.ErrInvalidArgs, bad)
+	}
+}
+
+func Test_config_runWorkload_preemption(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "long.csv")
+	require.NoError(t, os.WriteFile(path, []byte("1,10,0\n2,2,1\n3,2,4\n4,1,6\n"), 0o644))
+	var errW bytes.Buffer
+	_, err := parseFlags(&errW, []string{"--min-granularity", "-1", path})
+	assert.ErrorContains(t, err, "--min-granularity")
+	cfg, err := parseFlags(&errW, []string{"--min-granularity", "5", "--preemption-budget", "2/10", path})
+	require.NoError(t, err)
+	cfg.algorithms = []string{"fcfs", "sjf"}
+
+	results, err := cfg.runWorkload(path, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	require.NotNil(t, results[1].Preemption)
+	assert.Equal(t, 2, results[1].Preemption.Without.Preemptions)
+	assert.Equal(t, 1, results[1].Preemption.With.Preemptions)
+	assert.Equal(t, "5", results[1].Provenance.Parameters["min_granularity"])
+	assert.Equal(t, "2/10", results[1].Provenance.Parameters["preemption_budget"])
+
+	var out bytes.Buffer
+	outputPreemption(&out, results, scheduler.TimeUnitTicks)
+	assert.Contains(t, out.String(), "Preemption controls (min granularity 5, 2 preemptions per 10)")
+	assert.Contains(t, out.String(), "6 → 5")
+	assert.NotContains(t, out.String(), "fcfs", "FCFS never preempts")
+	out.Reset()
+	outputDryRun(&out, cfg, path, nil)
+	assert.Contains(t, out.String(), "  min slice:  5\n")
+	assert.Contains(t, out.String(), "  budget:     2/10 preemptions\n")
+}
+
+func Test_outputPreemption(t *testing.T) {
+	t.Parallel()
+	var out bytes.Buffer
+	outputPreemption(&out, []scheduler.Result{{Algorithm: "sjf"}}, scheduler.TimeUnitTicks)
+	assert.Empty(t, out.String(), "nothing without the controls")
+}
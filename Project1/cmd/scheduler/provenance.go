@@ -0,0 +1,27 @@
+package main
+
+import "runtime/debug"
+
+// vcsRevision is the VCS revision the binary was built from, if Go recorded one.
+func vcsRevision() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	var rev, modified string
+	for _, s := range info.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			rev = s.Value
+		case "vcs.modified":
+			if s.Value == "true" {
+				modified = "+dirty"
+			}
+		}
+	}
+	if rev == "" {
+		return ""
+	}
+
+	return rev + modified
+}
@@ -0,0 +1,430 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+
+	"github.com/jh125486/CSCE4600/Project1/render"
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+// Defaults for the report subcommand.
+const (
+	defaultReportTop     = 5
+	defaultReportBuckets = 20
+)
+
+var ErrInvalidStoredResult = fmt.Errorf("%w: stored results must be a .json output or a --store SQLite database", scheduler.ErrInvalidArgs)
+
+// reportConfig holds the parsed report command line.
+type reportConfig struct {
+	path      string
+	runID     int64
+	algorithm string
+	top       int
+	buckets   int
+	timeUnit  scheduler.TimeUnit
+}
+
+// parseReportFlags parses `scheduler report [flags] results.json|results.db`.
+func parseReportFlags(errW io.Writer, args []string) (reportConfig, error) {
+	rc := reportConfig{top: defaultReportTop, buckets: defaultReportBuckets, timeUnit: scheduler.TimeUnitTicks}
+
+	fs := flag.NewFlagSet("scheduler report", flag.ContinueOnError)
+	fs.SetOutput(errW)
+	fs.Usage = func() {
+		_, _ = fmt.Fprintln(errW, "usage: scheduler report [flags] results.json|results.db")
+		_, _ = fmt.Fprintln(errW, "Analyzes stored results in depth: worst processes, starvation, utilization over time and priorities.")
+		fs.PrintDefaults()
+	}
+	fs.Int64Var(&rc.runID, "run", 0, "SQLite run id to report on (default every run of the latest batch)")
+	fs.StringVar(&rc.algorithm, "algorithm", "", "only report on this algorithm")
+	fs.IntVar(&rc.top, "top", defaultReportTop, "number of worst processes and starvation intervals listed")
+	fs.IntVar(&rc.buckets, "buckets", defaultReportBuckets, "number of time buckets in the utilization timeline")
+	fs.Func("time-unit", "unit of the stored times: ticks, ms or s (default ticks)", func(s string) (err error) {
+		rc.timeUnit, err = scheduler.ParseTimeUnit(s)
+		return err
+	})
+	if err := fs.Parse(args); err != nil {
+		return rc, err
+	}
+
+	var err error
+	switch {
+	case fs.NArg() != 1:
+		err = fmt.Errorf("%w: must give one stored result file", scheduler.ErrInvalidArgs)
+	case rc.top < 1 || rc.buckets < 1:
+		err = fmt.Errorf("%w: -top and -buckets must be at least 1", scheduler.ErrInvalidArgs)
+	}
+	if err != nil {
+		_, _ = fmt.Fprintln(errW, err)
+		return rc, err
+	}
+	rc.path = fs.Arg(0)
+
+	return rc, nil
+}
+
+// run loads the stored results and writes a report for each to stdout.
+func (rc reportConfig) run(stdout, _ io.Writer) error {
+	results, err := loadStoredResults(rc.path, rc.runID)
+	if err != nil {
+		return err
+	}
+	n := 0
+	for _, r := range results {
+		if rc.algorithm != "" && r.Algorithm != rc.algorithm {
+			continue
+		}
+		outputReport(stdout, newRunReport(r, rc.top, rc.buckets), rc.timeUnit)
+		n++
+	}
+	if n == 0 {
+		return fmt.Errorf("%w: no stored results in %s to report on", ErrInvalidStoredResult, rc.path)
+	}
+
+	return nil
+}
+
+// loadStoredResults reads results written by --output *.json or stored with --store.
+// run selects one stored run; 0 selects every run of the latest batch.
+func loadStoredResults(path string, run int64) ([]scheduler.Result, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("%w: reading stored results", err)
+		}
+		var results []scheduler.Result
+		if b = bytes.TrimSpace(b); len(b) > 0 && b[0] == '{' {
+			results = make([]scheduler.Result, 1)
+			err = json.Unmarshal(b, &results[0])
+		} else {
+			err = json.Unmarshal(b, &results)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrInvalidStoredResult, path, err)
+		}
+		return results, nil
+	case ".db", ".sqlite", ".sqlite3":
+		return loadStoredRuns(path, run)
+	default:
+		return nil, fmt.Errorf("%w: got %s", ErrInvalidStoredResult, path)
+	}
+}
+
+// loadStoredRuns reads runs and their schedule rows back from a results store.
+// The store keeps no Gantt slices, so the reports of stored runs approximate execution.
+func loadStoredRuns(path string, run int64) ([]scheduler.Result, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("%w: opening results store", err)
+	}
+	where := "batch = (SELECT batch FROM runs ORDER BY id DESC LIMIT 1)"
+	if run > 0 {
+		where = "id = " + strconv.FormatInt(run, 10)
+	}
+	tables, err := storeTables(path)
+	if err != nil {
+		return nil, err
+	}
+	var runs []struct {
+		ID int64 `json:"id"`
+		scheduler.Result
+	}
+	if err := sqliteJSON(path, "SELECT "+storedColumns(tables, "runs", "id", "input", "algorithm", "title",
+		"average_wait", "average_turnaround", "throughput", "average_response", "incomplete")+
+		" FROM runs WHERE "+where+" ORDER BY id", &runs); err != nil {
+		return nil, err
+	}
+	var processes []struct {
+		Run int64 `json:"run"`
+		scheduler.ProcessResult
+	}
+	if err := sqliteJSON(path, "SELECT "+storedColumns(tables, "run_processes", "run", "id", "priority", "burst",
+		"arrival", "wait", "turnaround", "exit", "response", "status")+
+		" FROM run_processes WHERE run IN (SELECT id FROM runs WHERE "+where+") ORDER BY rowid", &processes); err != nil {
+		return nil, err
+	}
+
+	results := make([]scheduler.Result, len(runs))
+	index := make(map[int64]int, len(runs))
+	for i, r := range runs {
+		results[i], index[r.ID] = r.Result, i
+	}
+	for _, p := range processes {
+		if i, ok := index[p.Run]; ok {
+			results[i].Processes = append(results[i].Processes, p.ProcessResult)
+		}
+	}
+
+	return results, nil
+}
+
+// sqliteJSON runs a query with the SQLite shell and decodes its JSON rows into v.
+// The shell prints nothing for an empty result, which leaves v empty.
+func sqliteJSON(path, query string, v any) error {
+	var stderr bytes.Buffer
+	cmd := exec.Command(sqliteCommand, "-bail", "-readonly", "-json", path, query)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return fmt.Errorf("%w: querying %s: %s", err, path, strings.TrimSpace(stderr.String()))
+	}
+	if len(bytes.TrimSpace(out)) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(out, v); err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrInvalidStoredResult, path, err)
+	}
+
+	return nil
+}
+
+type (
+	// runReport is the in-depth analysis of one result.
+	runReport struct {
+		scheduler.Result
+		exact       bool // execution comes from the Gantt slices rather than being inferred
+		cpus        int
+		makespan    int64
+		utilization float64
+		worst       []scheduler.ProcessResult
+		starvation  []starvationInterval
+		timeline    []utilizationBucket
+		priorities  []priorityBreakdown
+	}
+	// starvationInterval is a stretch during which a process was ready but not running.
+	starvationInterval struct {
+		PID         int64
+		Start, Stop int64
+	}
+	// utilizationBucket is the share of CPU time in use during [Start, Stop).
+	utilizationBucket struct {
+		Start, Stop int64
+		Busy        float64
+	}
+	// priorityBreakdown summarizes the processes of one priority.
+	priorityBreakdown struct {
+		Priority            int64
+		Count               int
+		AveWait, AveTurn    float64
+		MaxWait, WorstPID   int64
+		ShareOfTotalWaiting float64
+	}
+)
+
+func newRunReport(r scheduler.Result, top, buckets int) runReport {
+	rep := runReport{Result: r}
+	slices := scheduler.BusySlices(r.Gantt)
+	rep.exact = len(r.Gantt) > 0
+	if !rep.exact {
+		// Without slices, assume each process ran uninterrupted until its exit.
+		slices = make([]scheduler.TimeSlice, len(r.Processes))
+		for i, p := range r.Processes {
+			slices[i] = scheduler.TimeSlice{PID: p.ID, Start: p.Exit - p.Burst, Stop: p.Exit}
+		}
+	}
+
+	var busy int64
+	for _, s := range slices {
+		busy += s.Stop - s.Start
+		if s.Stop > rep.makespan {
+			rep.makespan = s.Stop
+		}
+		if s.CPU+1 > rep.cpus {
+			rep.cpus = s.CPU + 1
+		}
+	}
+	for _, p := range r.Processes {
+		if p.Exit > rep.makespan {
+			rep.makespan = p.Exit
+		}
+	}
+	if rep.makespan > 0 && rep.cpus > 0 {
+		rep.utilization = float64(busy) / float64(rep.makespan*int64(rep.cpus))
+	}
+
+	rep.worst = append([]scheduler.ProcessResult(nil), r.Processes...)
+	sort.SliceStable(rep.worst, func(i, j int) bool { return rep.worst[i].Wait > rep.worst[j].Wait })
+	if len(rep.worst) > top {
+		rep.worst = rep.worst[:top]
+	}
+	rep.starvation = starvationIntervals(r.Processes, slices)
+	if len(rep.starvation) > top {
+		rep.starvation = rep.starvation[:top]
+	}
+	rep.timeline = utilizationTimeline(slices, rep.makespan, rep.cpus, buckets)
+	rep.priorities = breakdownByPriority(r.Processes)
+
+	return rep
+}
+
+// starvationIntervals finds every stretch between a process's arrival and exit during which
+// it did not run, longest first.
+func starvationIntervals(processes []scheduler.ProcessResult, slices []scheduler.TimeSlice) []starvationInterval {
+	byPID := make(map[int64][]scheduler.TimeSlice, len(processes))
+	for _, s := range slices {
+		byPID[s.PID] = append(byPID[s.PID], s)
+	}
+	var intervals []starvationInterval
+	for _, p := range processes {
+		ran := byPID[p.ID]
+		sort.Slice(ran, func(i, j int) bool { return ran[i].Start < ran[j].Start })
+		cursor := p.Arrival
+		for _, s := range ran {
+			if s.Start > cursor {
+				intervals = append(intervals, starvationInterval{PID: p.ID, Start: cursor, Stop: s.Start})
+			}
+			if s.Stop > cursor {
+				cursor = s.Stop
+			}
+		}
+	}
+	sort.SliceStable(intervals, func(i, j int) bool {
+		return intervals[i].Stop-intervals[i].Start > intervals[j].Stop-intervals[j].Start
+	})
+
+	return intervals
+}
+
+// utilizationTimeline splits [0, makespan) into buckets and measures how busy the CPUs were in each.
+// A nil timeline means nothing ran.
+func utilizationTimeline(slices []scheduler.TimeSlice, makespan int64, cpus, buckets int) []utilizationBucket {
+	if makespan <= 0 || cpus <= 0 {
+		return nil
+	}
+	width := (makespan + int64(buckets) - 1) / int64(buckets)
+	var timeline []utilizationBucket
+	for start := int64(0); start < makespan; start += width {
+		b := utilizationBucket{Start: start, Stop: start + width}
+		if b.Stop > makespan {
+			b.Stop = makespan
+		}
+		var busy int64
+		for _, s := range slices {
+			lo, hi := s.Start, s.Stop
+			if lo < b.Start {
+				lo = b.Start
+			}
+			if hi > b.Stop {
+				hi = b.Stop
+			}
+			if hi > lo {
+				busy += hi - lo
+			}
+		}
+		// Inferred execution can overlap where a process was really preempted.
+		if b.Busy = float64(busy) / float64((b.Stop-b.Start)*int64(cpus)); b.Busy > 1 {
+			b.Busy = 1
+		}
+		timeline = append(timeline, b)
+	}
+
+	return timeline
+}
+
+// breakdownByPriority groups processes by priority, most important (lowest number) first.
+func breakdownByPriority(processes []scheduler.ProcessResult) []priorityBreakdown {
+	var (
+		groups    = make(map[int64]*priorityBreakdown)
+		totalWait int64
+	)
+	for _, p := range processes {
+		if !p.Completed() {
+			// Like the run's averages, the breakdown covers completed processes only.
+			continue
+		}
+		g, ok := groups[p.Priority]
+		if !ok {
+			g = &priorityBreakdown{Priority: p.Priority, MaxWait: p.Wait, WorstPID: p.ID}
+			groups[p.Priority] = g
+		}
+		g.Count++
+		g.AveWait += float64(p.Wait)
+		g.AveTurn += float64(p.Turnaround)
+		if p.Wait > g.MaxWait {
+			g.MaxWait, g.WorstPID = p.Wait, p.ID
+		}
+		totalWait += p.Wait
+	}
+	breakdown := make([]priorityBreakdown, 0, len(groups))
+	for _, g := range groups {
+		if totalWait > 0 {
+			g.ShareOfTotalWaiting = g.AveWait / float64(totalWait)
+		}
+		g.AveWait /= float64(g.Count)
+		g.AveTurn /= float64(g.Count)
+		breakdown = append(breakdown, *g)
+	}
+	sort.Slice(breakdown, func(i, j int) bool { return breakdown[i].Priority < breakdown[j].Priority })
+
+	return breakdown
+}
+
+// outputReport prints a run report as text.
+func outputReport(w io.Writer, rep runReport, unit scheduler.TimeUnit) {
+	f := func(v float64) string { return strconv.FormatFloat(v, 'f', 2, 64) }
+	d := func(v int64) string { return strconv.FormatInt(v, 10) }
+	newTable := func(header ...string) *tablewriter.Table {
+		table := tablewriter.NewWriter(w)
+		table.SetHeader(header)
+		table.SetAutoWrapText(false)
+		return table
+	}
+
+	render.OutputTitle(w, "Report: "+rep.Title)
+	if rep.Input != "" {
+		_, _ = fmt.Fprintf(w, "Input: %s\n", rep.Input)
+	}
+	_, _ = fmt.Fprintf(w, "%d processes on %d CPU(s); makespan %d; utilization %.1f%%; average wait %s; average turnaround %s\n",
+		len(rep.Processes), rep.cpus, rep.makespan, rep.utilization*100, f(rep.AveWait), f(rep.AveTurnaround))
+	if !rep.exact {
+		_, _ = fmt.Fprintln(w, "No Gantt slices were stored: execution is assumed uninterrupted up to each exit.")
+	}
+
+	_, _ = fmt.Fprintln(w, "\nWorst processes by wait")
+	table := newTable("ID", "Priority", unit.Label("Burst"), unit.Label("Arrival"), unit.Label("Wait"), unit.Label("Turnaround"), "Slowdown")
+	for _, p := range rep.worst {
+		slowdown := "-"
+		if p.Burst > 0 {
+			slowdown = f(float64(p.Turnaround) / float64(p.Burst))
+		}
+		table.Append([]string{d(p.ID), d(p.Priority), d(p.Burst), d(p.Arrival), d(p.Wait), d(p.Turnaround), slowdown})
+	}
+	table.Render()
+
+	_, _ = fmt.Fprintln(w, "\nLongest starvation intervals (ready but not running)")
+	table = newTable("ID", unit.Label("From"), unit.Label("To"), unit.Label("Length"))
+	for _, s := range rep.starvation {
+		table.Append([]string{d(s.PID), d(s.Start), d(s.Stop), d(s.Stop - s.Start)})
+	}
+	table.Render()
+
+	_, _ = fmt.Fprintln(w, "\n"+unit.Label("Utilization timeline"))
+	const barWidth = 40
+	for _, b := range rep.timeline {
+		filled := int(b.Busy*barWidth + 0.5)
+		_, _ = fmt.Fprintf(w, "%8d-%-8d |%s%s| %5.1f%%\n", b.Start, b.Stop,
+			strings.Repeat("█", filled), strings.Repeat(" ", barWidth-filled), b.Busy*100)
+	}
+
+	_, _ = fmt.Fprintln(w, "\nBy priority")
+	table = newTable("Priority", "Processes", unit.Label("Average wait"), unit.Label("Max wait"), "Worst ID",
+		unit.Label("Average turnaround"), "Share of waiting")
+	for _, g := range rep.priorities {
+		table.Append([]string{d(g.Priority), strconv.Itoa(g.Count), f(g.AveWait), d(g.MaxWait), d(g.WorstPID),
+			f(g.AveTurn), fmt.Sprintf("%.1f%%", g.ShareOfTotalWaiting*100)})
+	}
+	table.Render()
+}
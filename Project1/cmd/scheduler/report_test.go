@@ -0,0 +1,113 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jh125486/CSCE4600/Project1/render"
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+func reportTestResult() scheduler.Result {
+	return scheduler.Result{
+		Algorithm: "rr",
+		Title:     "Round-robin",
+		Gantt: []scheduler.TimeSlice{
+			{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 2, Stop: 4},
+			{PID: 1, Start: 4, Stop: 6}, {PID: 2, Start: 8, Stop: 10},
+		},
+		Processes: []scheduler.ProcessResult{
+			{ID: 1, Priority: 1, Burst: 4, Arrival: 0, Wait: 2, Turnaround: 6, Exit: 6},
+			{ID: 2, Priority: 2, Burst: 4, Arrival: 1, Wait: 5, Turnaround: 9, Exit: 10},
+		},
+	}
+}
+
+func Test_parseReportFlags(t *testing.T) {
+	t.Parallel()
+	got, err := parseReportFlags(io.Discard, []string{"-run", "3", "-top", "2", "results.db"})
+	require.NoError(t, err)
+	assert.Equal(t, reportConfig{path: "results.db", runID: 3, top: 2, buckets: defaultReportBuckets, timeUnit: scheduler.TimeUnitTicks}, got)
+
+	for _, args := range [][]string{nil, {"a.json", "b.json"}, {"-top", "0", "a.json"}, {"-buckets", "0", "a.json"}} {
+		_, err := parseReportFlags(io.Discard, args)
+		assert.ErrorIs(t, err, scheduler.ErrInvalidArgs, args)
+	}
+}
+
+func Test_newRunReport(t *testing.T) {
+	t.Parallel()
+	rep := newRunReport(reportTestResult(), 1, 5)
+	assert.True(t, rep.exact)
+	assert.Equal(t, int64(10), rep.makespan)
+	assert.InDelta(t, 0.8, rep.utilization, 1e-9)
+	assert.Equal(t, []scheduler.ProcessResult{reportTestResult().Processes[1]}, rep.worst)
+	assert.Equal(t, []starvationInterval{{PID: 2, Start: 4, Stop: 8}}, rep.starvation)
+	assert.Equal(t, []utilizationBucket{
+		{Start: 0, Stop: 2, Busy: 1}, {Start: 2, Stop: 4, Busy: 1}, {Start: 4, Stop: 6, Busy: 1},
+		{Start: 6, Stop: 8, Busy: 0}, {Start: 8, Stop: 10, Busy: 1},
+	}, rep.timeline)
+	assert.Equal(t, []priorityBreakdown{
+		{Priority: 1, Count: 1, AveWait: 2, AveTurn: 6, MaxWait: 2, WorstPID: 1, ShareOfTotalWaiting: 2.0 / 7},
+		{Priority: 2, Count: 1, AveWait: 5, AveTurn: 9, MaxWait: 5, WorstPID: 2, ShareOfTotalWaiting: 5.0 / 7},
+	}, rep.priorities)
+
+	noGantt := reportTestResult()
+	noGantt.Gantt = nil
+	rep = newRunReport(noGantt, 5, 5)
+	assert.False(t, rep.exact)
+	assert.Equal(t, []starvationInterval{{PID: 2, Start: 1, Stop: 6}, {PID: 1, Start: 0, Stop: 2}}, rep.starvation)
+
+	var out bytes.Buffer
+	outputReport(&out, rep, scheduler.TimeUnitTicks)
+	assert.Contains(t, out.String(), "Report: Round-robin")
+	assert.Contains(t, out.String(), "execution is assumed uninterrupted")
+}
+
+func Test_loadStoredResults(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	one, many := filepath.Join(dir, "one.json"), filepath.Join(dir, "many.json")
+	var b bytes.Buffer
+	require.NoError(t, render.WriteJSON(&b, reportTestResult()))
+	require.NoError(t, os.WriteFile(one, b.Bytes(), 0o644))
+	b.Reset()
+	require.NoError(t, render.WriteJSONArray(&b, []scheduler.Result{reportTestResult(), reportTestResult()}))
+	require.NoError(t, os.WriteFile(many, b.Bytes(), 0o644))
+
+	got, err := loadStoredResults(one, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []scheduler.Result{reportTestResult()}, got)
+	got, err = loadStoredResults(many, 0)
+	require.NoError(t, err)
+	assert.Len(t, got, 2)
+
+	_, err = loadStoredResults(filepath.Join(dir, "results.csv"), 0)
+	assert.ErrorIs(t, err, ErrInvalidStoredResult)
+
+	if _, err := exec.LookPath(sqliteCommand); err != nil {
+		t.Skipf("%s not installed", sqliteCommand)
+	}
+	db := filepath.Join(dir, "results.db")
+	second := storeTestResults()[0]
+	second.Algorithm = "sjf"
+	require.NoError(t, storeResults(db, storeTestResults(), storeTime))
+	require.NoError(t, storeResults(db, append(storeTestResults(), second), storeTime.Add(1)))
+	got, err = loadStoredResults(db, 0)
+	require.NoError(t, err)
+	require.Len(t, got, 2, "the runs of the latest batch")
+	assert.Equal(t, "sjf", got[1].Algorithm)
+	assert.Equal(t, storeTestResults()[0].Processes, got[1].Processes)
+	got, err = loadStoredResults(db, 1)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, "fcfs", got[0].Algorithm)
+	assert.Equal(t, 2.0, got[0].AveWait)
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+
+	"github.com/jh125486/CSCE4600/Project1/render"
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+	"github.com/jh125486/CSCE4600/Project1/workload"
+)
+
+// batchSchedulers are the schedulers --resources runs on a pool.
+func batchSchedulers(pool scheduler.Resources) []scheduler.Algorithm {
+	return []scheduler.Algorithm{scheduler.BatchFCFS(pool), scheduler.BatchEASY(pool), scheduler.BatchDRF(pool),
+		scheduler.BatchFairShare(pool)}
+}
+
+// loadReservations reads the reservations in the CSV file at path.
+func loadReservations(path string) ([]scheduler.Reservation, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	rs, err := workload.LoadReservations(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return rs, nil
+}
+
+// formatReservations lists reservations for provenance, e.g. "4 CPUs from 100 to 120; 1 CPU from 0 to 5".
+func formatReservations(rs []scheduler.Reservation) string {
+	parts := make([]string, len(rs))
+	for i, r := range rs {
+		parts[i] = r.String()
+	}
+
+	return strings.Join(parts, "; ")
+}
+
+// outputResources prints how each batch scheduler used its pool: each resource's utilization,
+// the share of it reserved and the time processes spent queued for want of it. It prints nothing without --resources.
+func outputResources(w io.Writer, results []scheduler.Result, unit scheduler.TimeUnit) {
+	var rows [][]string
+	for _, r := range results {
+		if r.Resources == nil {
+			continue
+		}
+		for _, u := range r.Resources.Usage {
+			rows = append(rows, []string{workload.Group(r.Input), r.Algorithm, u.Resource, strconv.FormatInt(u.Capacity, 10),
+				strconv.FormatFloat(100*u.Utilization, 'f', 1, 64) + "%", strconv.FormatFloat(100*u.Reserved, 'f', 1, 64) + "%",
+				strconv.FormatInt(u.Wait, 10),
+				strconv.FormatInt(r.Resources.BlockedWait, 10)})
+		}
+	}
+	if len(rows) == 0 {
+		return
+	}
+
+	render.OutputTitle(w, "Resource usage")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Workload", "Algorithm", "Resource", "Capacity", "Utilization", "Reserved",
+		unit.Label("Wait for resource"), unit.Label("Resource-induced wait")})
+	table.SetAutoWrapText(false)
+	table.SetAutoMergeCellsByColumnIndex([]int{0, 1})
+	table.AppendBulk(rows)
+	table.Render()
+	_, _ = io.WriteString(w, "Wait for resource counts the time processes spent queued while too little of it was free; "+
+		"resource-induced wait counts that time once per process, whichever resources were short.\n")
+}
+
+// shareWidth is the most columns a dominant share sparkline spans.
+const shareWidth = 40
+
+// outputShares prints each user's dominant share of the pool under each batch scheduler: its
+// mean and peak, and a sparkline of it over the run, so DRF can be compared with CPU fair
+// sharing. It prints nothing without --resources.
+func outputShares(w io.Writer, results []scheduler.Result) {
+	var rows [][]string
+	for _, r := range results {
+		if r.Resources == nil {
+			continue
+		}
+		var end int64
+		for _, p := range r.Processes {
+			if p.Exit > end {
+				end = p.Exit
+			}
+		}
+		for _, u := range r.Resources.Shares {
+			rows = append(rows, []string{workload.Group(r.Input), r.Algorithm, u.User,
+				fmt.Sprintf("%.1f%%", 100*u.Mean), fmt.Sprintf("%.1f%%", 100*u.Peak),
+				"|" + render.Sparkline(shareColumns(u.Steps, end, shareWidth)) + "|"})
+		}
+	}
+	if len(rows) == 0 {
+		return
+	}
+
+	render.OutputTitle(w, "Dominant shares")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Workload", "Algorithm", "User", "Mean", "Peak", "Over time"})
+	table.SetAutoWrapText(false)
+	table.SetAutoMergeCellsByColumnIndex([]int{0, 1})
+	table.AppendBulk(rows)
+	table.Render()
+	_, _ = io.WriteString(w, "A user's dominant share is the largest fraction of any one resource its running processes hold; "+
+		"batch-drf evens these out, batch-fair evens out CPUs alone.\n")
+}
+
+// shareColumns divides the time from 0 to end into at most width equal columns and returns the
+// time-weighted mean share in each.
+func shareColumns(steps []scheduler.ShareStep, end int64, width int) []float64 {
+	if end <= 0 {
+		return nil
+	}
+	if int64(width) > end {
+		width = int(end)
+	}
+	column := float64(end) / float64(width)
+	columns := make([]float64, width)
+	for i, step := range steps {
+		start, stop := float64(step.Time), float64(end)
+		if i+1 < len(steps) {
+			stop = float64(steps[i+1].Time)
+		}
+		for c := int(start / column); c < width && float64(c)*column < stop; c++ {
+			lo, hi := float64(c)*column, float64(c+1)*column
+			if start > lo {
+				lo = start
+			}
+			if stop < hi {
+				hi = stop
+			}
+			if hi > lo {
+				columns[c] += step.Share * (hi - lo) / column
+			}
+		}
+	}
+
+	return columns
+}
+
+// outputBackfill compares EASY backfilling with plain batch FCFS on each workload: how many
+// processes it backfilled and how much that raised the CPU utilization and cut the average
+// wait. It prints nothing without --resources.
+func outputBackfill(w io.Writer, results []scheduler.Result, unit scheduler.TimeUnit) {
+	fcfs := make(map[string]scheduler.Result)
+	for _, r := range results {
+		if r.Algorithm == "batch-fcfs" && r.Resources != nil {
+			fcfs[r.Input] = r
+		}
+	}
+	var rows [][]string
+	for _, easy := range results {
+		base, ok := fcfs[easy.Input]
+		if easy.Algorithm != "batch-easy" || easy.Resources == nil || !ok {
+			continue
+		}
+		change := "-"
+		if base.AveWait > 0 {
+			change = fmt.Sprintf("%+.1f%%", 100*(easy.AveWait-base.AveWait)/base.AveWait)
+		}
+		rows = append(rows, []string{workload.Group(easy.Input), strconv.Itoa(easy.Resources.Backfilled),
+			fmt.Sprintf("%.1f%%", 100*cpuUtilization(base)), fmt.Sprintf("%.1f%%", 100*cpuUtilization(easy)),
+			fmt.Sprintf("%.2f", base.AveWait), fmt.Sprintf("%.2f", easy.AveWait), change})
+	}
+	if len(rows) == 0 {
+		return
+	}
+
+	render.OutputTitle(w, "EASY backfilling versus FCFS")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Workload", "Backfilled", "FCFS CPU utilization", "EASY CPU utilization",
+		unit.Label("FCFS average wait"), unit.Label("EASY average wait"), "Wait change"})
+	table.SetAutoWrapText(false)
+	table.AppendBulk(rows)
+	table.Render()
+}
+
+// cpuUtilization is the share of a batch scheduler's CPUs in use over its run.
+func cpuUtilization(r scheduler.Result) float64 {
+	for _, u := range r.Resources.Usage {
+		if u.Resource == scheduler.ResourceCPU.String() {
+			return u.Utilization
+		}
+	}
+
+	return 0
+}
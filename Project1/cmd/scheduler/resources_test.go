@@ -0,0 +1,148 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+func Test_parseFlags_resources(t *testing.T) {
+	t.Parallel()
+	var errW bytes.Buffer
+	cfg, err := parseFlags(&errW, []string{"--resources", "cpu=4,gpu=1", "processes.csv"})
+	require.NoError(t, err)
+	require.Len(t, cfg.custom, 4)
+	assert.Equal(t, "batch-fcfs", cfg.custom[0].Name)
+	assert.Equal(t, "batch-easy", cfg.custom[1].Name)
+	assert.Equal(t, "batch-drf", cfg.custom[2].Name)
+	assert.Equal(t, "batch-fair", cfg.custom[3].Name)
+
+	for _, bad := range []string{"gpu=2", "cpu=two", "tpu=1"} {
+		_, err := parseFlags(&errW, []string{"--resources", bad, "processes.csv"})
+		assert.ErrorContains(t, err, "resources", bad)
+	}
+}
+
+func Test_outputResources(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "jobs.csv")
+	// P2 needs both CPUs and waits 4 for P1 to release one.
+	require.NoError(t, os.WriteFile(path, []byte("1,4,0,1,0,0,1,1\n2,3,0,1,0,0,2\n"), 0o644))
+	cfg := defaultConfig()
+	cfg.algorithms = []string{"fcfs"}
+	pool, err := scheduler.ParseResources("cpu=2,gpu=1")
+	require.NoError(t, err)
+	cfg.custom = batchSchedulers(pool)
+	results, err := cfg.runWorkload(path, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, results, 5)
+
+	var out bytes.Buffer
+	outputResources(&out, results, scheduler.TimeUnitTicks)
+	assert.Contains(t, out.String(), "Resource usage")
+	assert.Contains(t, out.String(), "batch-fcfs")
+	assert.Contains(t, out.String(), "| cpu      |        2 |")
+	assert.NotContains(t, out.String(), "| fcfs", "single-CPU schedulers have no pool")
+
+	out.Reset()
+	outputResources(&out, results[:1], scheduler.TimeUnitTicks)
+	assert.Empty(t, out.String())
+}
+
+func Test_outputShares(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "jobs.csv")
+	// One user per priority: half the GPUs for priority 1, half the CPUs for priority 2.
+	require.NoError(t, os.WriteFile(path, []byte("1,4,0,1,0,0,1,1\n2,4,0,2,0,0,2\n"), 0o644))
+	cfg := defaultConfig()
+	cfg.algorithms = []string{"fcfs"}
+	pool, err := scheduler.ParseResources("cpu=4,gpu=2")
+	require.NoError(t, err)
+	cfg.custom = batchSchedulers(pool)
+	results, err := cfg.runWorkload(path, nil, nil)
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	outputShares(&out, results)
+	assert.Contains(t, out.String(), "Dominant shares")
+	assert.Contains(t, out.String(), "batch-drf")
+	assert.Contains(t, out.String(), "| priority 1 | 50.0% | 50.0% | |▄▄▄▄|")
+
+	out.Reset()
+	outputShares(&out, results[:1])
+	assert.Empty(t, out.String())
+}
+
+func Test_shareColumns(t *testing.T) {
+	t.Parallel()
+	steps := []scheduler.ShareStep{{Time: 0, Share: 1}, {Time: 3, Share: 0.5}, {Time: 4, Share: 0}}
+	assert.Equal(t, []float64{1, 0.75, 0}, shareColumns(steps, 6, 3))
+	assert.Nil(t, shareColumns(steps, 0, 3))
+}
+
+func Test_outputBackfill(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "jobs.swf")
+	// Job 2 needs all four CPUs until job 1 completes at 5; EASY backfills job 3 before then.
+	require.NoError(t, os.WriteFile(path, []byte("; MaxProcs: 4\n1 0 0 5 2\n2 0 0 2 4\n3 1 0 3 1\n"), 0o644))
+	cfg := defaultConfig()
+	cfg.algorithms = []string{"fcfs"}
+	pool, err := scheduler.ParseResources("cpu=4")
+	require.NoError(t, err)
+	cfg.custom = batchSchedulers(pool)
+	results, err := cfg.runWorkload(path, nil, nil)
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	outputBackfill(&out, results, scheduler.TimeUnitTicks)
+	assert.Contains(t, out.String(), "EASY backfilling versus FCFS")
+	// FCFS waits are 0, 5 and 6; EASY's are 0, 5 and 0.
+	assert.Contains(t, out.String(), "|          1 |")
+	assert.Contains(t, out.String(), "| 52.5%                | 75.0%                |              3.67 |              1.67 | -54.5%      |")
+
+	out.Reset()
+	outputBackfill(&out, results[:2], scheduler.TimeUnitTicks)
+	assert.Empty(t, out.String(), "no EASY result to compare")
+}
+
+func Test_reservations(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	rsv, path := filepath.Join(dir, "maintenance.csv"), filepath.Join(dir, "jobs.csv")
+	require.NoError(t, os.WriteFile(rsv, []byte("3,4,2\n"), 0o644))
+	require.NoError(t, os.WriteFile(path, []byte("1,2,0\n2,3,0\n3,2,1\n"), 0o644))
+
+	var errW bytes.Buffer
+	_, err := parseFlags(&errW, []string{"--reservations", rsv, path})
+	assert.ErrorContains(t, err, "--reservations needs --resources")
+	_, err = parseFlags(&errW, []string{"--resources", "cpu=2", "--reservations", filepath.Join(dir, "missing.csv"), path})
+	assert.Error(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "bad.csv"), []byte("3,0,2\n"), 0o644))
+	_, err = parseFlags(&errW, []string{"--resources", "cpu=2", "--reservations", filepath.Join(dir, "bad.csv"), path})
+	assert.ErrorContains(t, err, "bad.csv")
+
+	cfg, err := parseFlags(&errW, []string{"--resources", "cpu=2", "--reservations", rsv, path})
+	require.NoError(t, err)
+	cfg.algorithms = []string{"fcfs"}
+	require.Equal(t, []scheduler.Reservation{{Start: 3, Duration: 4, CPUs: 2}}, cfg.reservations)
+	results, err := cfg.runWorkload(path, nil, nil)
+	require.NoError(t, err)
+	// On two CPUs P3 would run into the window from 3 to 7 and waits it out; FCFS on one CPU
+	// ignores it.
+	assert.Equal(t, int64(7), results[0].Processes[2].Exit)
+	assert.Equal(t, int64(9), results[1].Processes[2].Exit)
+	assert.Equal(t, "2 CPUs from 3 to 7", results[1].Provenance.Parameters["reservations"])
+
+	var out bytes.Buffer
+	outputResources(&out, results, scheduler.TimeUnitTicks)
+	assert.Contains(t, out.String(), "| 38.9%       | 44.4%    |")
+	out.Reset()
+	outputDryRun(&out, cfg, path, nil)
+	assert.Contains(t, out.String(), "  reserved:   2 CPUs from 3 to 7\n")
+}
@@ -0,0 +1,381 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+
+	"github.com/jh125486/CSCE4600/Project1/render"
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+	"github.com/jh125486/CSCE4600/Project1/workload"
+)
+
+// maxRTAHorizon caps the simulated time of a task set whose hyperperiod is larger.
+const maxRTAHorizon = 1_000_000
+
+var (
+	ErrInvalidTask   = fmt.Errorf("%w: invalid periodic task", workload.ErrInvalidProcess)
+	ErrInvalidPolicy = fmt.Errorf("%w: fixed-priority policy must be rm or dm", scheduler.ErrInvalidArgs)
+)
+
+// Fixed-priority policies for periodic task sets.
+const (
+	policyRM = "rm" // rate-monotonic: shorter period, higher priority
+	policyDM = "dm" // deadline-monotonic: shorter relative deadline, higher priority
+)
+
+// periodicTask is a task released every period at times 0, T, 2T, ..., each job needing
+// burst units of CPU on average, and at most wcet, and finishing within deadline of its release.
+type periodicTask struct {
+	id       int64
+	burst    int64
+	period   int64
+	deadline int64
+	wcet     int64
+}
+
+// loadTaskSet reads a periodic task set, one `id,burst,period[,deadline[,wcet]]` line per task,
+// where the deadline defaults to the period and the worst-case execution time to the burst.
+// Simulation runs every job for its average burst; analysis assumes its WCET.
+func loadTaskSet(r io.Reader) ([]periodicTask, error) {
+	var (
+		sc     = bufio.NewScanner(r)
+		tasks  []periodicTask
+		fields [][]byte
+		line   int
+	)
+	sc.Buffer(make([]byte, 0, 64*1024), workload.MaxLineLength)
+	for sc.Scan() {
+		line++
+		fields = workload.SplitFields(fields[:0], sc.Bytes())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) < 3 {
+			return nil, fmt.Errorf("%w: line %d: want id,burst,period[,deadline[,wcet]], got %d fields", ErrInvalidTask, line, len(fields))
+		}
+		var vals [5]int64
+		for i := 0; i < len(fields) && i < len(vals); i++ {
+			v, err := workload.ParseInt(fields[i])
+			if err != nil {
+				return nil, fmt.Errorf("%w: line %d: field %d %q: %v", ErrInvalidTask, line, i+1, fields[i], err)
+			}
+			vals[i] = v
+		}
+		t := periodicTask{id: vals[0], burst: vals[1], period: vals[2], deadline: vals[3], wcet: vals[4]}
+		if len(fields) < 4 {
+			t.deadline = t.period
+		}
+		if len(fields) < 5 {
+			t.wcet = t.burst
+		}
+		if t.burst < 1 || t.period < 1 || t.deadline < 1 {
+			return nil, fmt.Errorf("%w: line %d: burst, period and deadline must be positive", ErrInvalidTask, line)
+		}
+		if t.wcet < t.burst {
+			return nil, fmt.Errorf("%w: line %d: WCET %d is below the average burst %d", ErrInvalidTask, line, t.wcet, t.burst)
+		}
+		tasks = append(tasks, t)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("%w: reading task set", err)
+	}
+	if len(tasks) == 0 {
+		return nil, fmt.Errorf("%w: empty task set", ErrInvalidTask)
+	}
+
+	return tasks, nil
+}
+
+// priorityOrder returns the tasks highest priority first under policy, ties broken by id.
+func priorityOrder(tasks []periodicTask, policy string) []periodicTask {
+	ordered := append([]periodicTask(nil), tasks...)
+	key := func(t periodicTask) int64 { return t.period }
+	if policy == policyDM {
+		key = func(t periodicTask) int64 { return t.deadline }
+	}
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if ki, kj := key(ordered[i]), key(ordered[j]); ki != kj {
+			return ki < kj
+		}
+		return ordered[i].id < ordered[j].id
+	})
+
+	return ordered
+}
+
+// responseTime is the exact worst-case response time of ordered[i] under preemptive fixed
+// priorities: the fixed point of R = C_i + Σ_{j<i} ⌈R/T_j⌉·C_j with C the WCETs, valid for
+// deadlines no longer than periods. The iteration stops once R passes the deadline,
+// returning that R and false.
+func responseTime(ordered []periodicTask, i int) (int64, bool) {
+	t := ordered[i]
+	r := t.wcet
+	for j := 0; j < i; j++ {
+		r += ordered[j].wcet
+	}
+	for r <= t.deadline {
+		next := t.wcet
+		for _, hp := range ordered[:i] {
+			next += (r + hp.period - 1) / hp.period * hp.wcet
+		}
+		if next == r {
+			return r, true
+		}
+		r = next
+	}
+
+	return r, false
+}
+
+// observedResponse is what the simulation saw of one task's jobs.
+type observedResponse struct {
+	worst     int64
+	completed int
+	misses    int
+}
+
+// simulateFixedPriority runs the task set under preemptive fixed priorities (ordered highest
+// first) from a synchronous release at 0 until horizon, returning each task's observations
+// indexed like ordered. A job still unfinished at the horizon counts as a miss once its
+// deadline has passed.
+func simulateFixedPriority(ordered []periodicTask, horizon int64) []observedResponse {
+	type job struct{ release, remaining int64 }
+	var (
+		observed = make([]observedResponse, len(ordered))
+		pending  = make([][]job, len(ordered)) // FIFO of released jobs per task
+	)
+	for now := int64(0); now < horizon; now++ {
+		for i, t := range ordered {
+			if now%t.period == 0 {
+				pending[i] = append(pending[i], job{release: now, remaining: t.burst})
+			}
+		}
+		for i := range ordered {
+			if len(pending[i]) == 0 {
+				continue
+			}
+			j := &pending[i][0]
+			if j.remaining--; j.remaining == 0 {
+				response := now + 1 - j.release
+				if response > observed[i].worst {
+					observed[i].worst = response
+				}
+				if response > ordered[i].deadline {
+					observed[i].misses++
+				}
+				observed[i].completed++
+				pending[i] = pending[i][1:]
+			}
+			break
+		}
+	}
+	for i, t := range ordered {
+		for _, j := range pending[i] {
+			if j.release+t.deadline < horizon {
+				observed[i].misses++
+			}
+		}
+	}
+
+	return observed
+}
+
+// hyperperiod is the least common multiple of the periods, capped at limit.
+func hyperperiod(tasks []periodicTask, limit int64) int64 {
+	h := int64(1)
+	for _, t := range tasks {
+		a, b := h, t.period
+		for b != 0 {
+			a, b = b, a%b
+		}
+		if h/a > limit/t.period {
+			return limit
+		}
+		h = h / a * t.period
+	}
+	if h > limit {
+		return limit
+	}
+
+	return h
+}
+
+// rtaConfig holds the parsed rta command line.
+type rtaConfig struct {
+	path    string
+	policy  string
+	horizon int64
+}
+
+// parseRTAFlags parses `scheduler rta [flags] tasks.csv`.
+func parseRTAFlags(errW io.Writer, args []string) (rtaConfig, error) {
+	rc := rtaConfig{policy: policyRM}
+
+	fs := flag.NewFlagSet("scheduler rta", flag.ContinueOnError)
+	fs.SetOutput(errW)
+	fs.Usage = func() {
+		_, _ = fmt.Fprintln(errW, "usage: scheduler rta [flags] tasks.csv")
+		_, _ = fmt.Fprintln(errW, "Schedulability check and response-time analysis of a periodic task set (id,burst,period[,deadline[,wcet]]")
+		_, _ = fmt.Fprintln(errW, "per line) under RM or DM, next to the worst response times observed in a simulated schedule.")
+		fs.PrintDefaults()
+	}
+	fs.Func("policy", "fixed-priority policy: rm (rate-monotonic) or dm (deadline-monotonic) (default rm)", func(s string) error {
+		switch p := strings.ToLower(s); p {
+		case policyRM, policyDM:
+			rc.policy = p
+			return nil
+		default:
+			return fmt.Errorf("%w: got %q", ErrInvalidPolicy, s)
+		}
+	})
+	fs.Int64Var(&rc.horizon, "horizon", 0, "simulated time (default the hyperperiod, at most "+strconv.Itoa(maxRTAHorizon)+")")
+	if err := fs.Parse(args); err != nil {
+		return rc, err
+	}
+
+	var err error
+	switch {
+	case fs.NArg() != 1:
+		err = fmt.Errorf("%w: must give one task set file", scheduler.ErrInvalidArgs)
+	case rc.horizon < 0:
+		err = fmt.Errorf("%w: -horizon must not be negative", scheduler.ErrInvalidArgs)
+	}
+	if err != nil {
+		_, _ = fmt.Fprintln(errW, err)
+		return rc, err
+	}
+	rc.path = fs.Arg(0)
+
+	return rc, nil
+}
+
+// run analyzes and simulates the task set, writing the comparison to stdout.
+func (rc rtaConfig) run(stdout, _ io.Writer) error {
+	f, err := os.Open(rc.path)
+	if err != nil {
+		return fmt.Errorf("%w: opening task set", err)
+	}
+	defer f.Close()
+	tasks, err := loadTaskSet(f)
+	if err != nil {
+		return fmt.Errorf("%s: %w", rc.path, err)
+	}
+
+	horizon := rc.horizon
+	if horizon == 0 {
+		horizon = hyperperiod(tasks, maxRTAHorizon)
+	}
+	outputRTA(stdout, tasks, rc.policy, horizon)
+
+	return nil
+}
+
+// schedulability is the verdict of the pre-simulation checks on a task set's WCETs.
+// The utilization bounds are sufficient tests: passing one proves the set schedulable,
+// failing one proves nothing. Response-time analysis is exact.
+type schedulability struct {
+	utilization float64
+	// Liu & Layland's n(2^(1/n) - 1) and the hyperbolic Π(Uᵢ + 1) ≤ 2 bound, which only
+	// apply to rate-monotonic priorities with deadlines equal to periods.
+	boundsApply            bool
+	llBound                float64
+	liuLayland, hyperbolic bool
+	responseTimes          []int64 // indexed like the priority order; -1 when a deadline can be missed
+	schedulable            bool
+}
+
+func checkSchedulability(ordered []periodicTask, policy string) schedulability {
+	s := schedulability{boundsApply: policy == policyRM, schedulable: true}
+	product := 1.0
+	for _, t := range ordered {
+		u := float64(t.wcet) / float64(t.period)
+		s.utilization += u
+		product *= u + 1
+		s.boundsApply = s.boundsApply && t.deadline == t.period
+	}
+	n := float64(len(ordered))
+	s.llBound = n * (math.Pow(2, 1/n) - 1)
+	s.liuLayland = s.utilization <= s.llBound
+	s.hyperbolic = product <= 2
+	for i := range ordered {
+		r, ok := responseTime(ordered, i)
+		if !ok {
+			r, s.schedulable = -1, false
+		}
+		s.responseTimes = append(s.responseTimes, r)
+	}
+
+	return s
+}
+
+// outputSchedulability prints the pre-simulation verdict.
+func outputSchedulability(w io.Writer, s schedulability) {
+	render.OutputTitle(w, "Schedulability pre-check (WCET)")
+	pass := func(ok bool) string {
+		if ok {
+			return "pass"
+		}
+		return "inconclusive"
+	}
+	necessary := "pass"
+	if s.utilization > 1 {
+		necessary = "FAIL"
+	}
+	_, _ = fmt.Fprintf(w, "Utilization U = %.3f; U ≤ 1 (necessary): %s\n", s.utilization, necessary)
+	if s.boundsApply {
+		_, _ = fmt.Fprintf(w, "Liu & Layland bound U ≤ %.3f: %s\n", s.llBound, pass(s.liuLayland))
+		_, _ = fmt.Fprintf(w, "Hyperbolic bound Π(Uᵢ + 1) ≤ 2: %s\n", pass(s.hyperbolic))
+	} else {
+		_, _ = fmt.Fprintln(w, "Utilization bounds skipped: they need rate-monotonic priorities and deadlines equal to periods.")
+	}
+	verdict := "SCHEDULABLE"
+	if !s.schedulable {
+		verdict = "NOT SCHEDULABLE"
+	}
+	_, _ = fmt.Fprintf(w, "Response-time analysis: %s\n", verdict)
+}
+
+// outputRTA prints the schedulability pre-check, then simulates the task set and prints each
+// task's analytic worst-case response time next to the worst observed, flagging the tasks that
+// can miss their deadlines.
+func outputRTA(w io.Writer, tasks []periodicTask, policy string, horizon int64) {
+	ordered := priorityOrder(tasks, policy)
+	check := checkSchedulability(ordered, policy)
+	outputSchedulability(w, check)
+
+	var (
+		observed = simulateFixedPriority(ordered, horizon)
+		d        = func(v int64) string { return strconv.FormatInt(v, 10) }
+		failed   int
+	)
+	render.OutputTitle(w, "Response-time analysis ("+strings.ToUpper(policy)+")")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Task", "C", "WCET", "T", "D", "Priority", "Analytic R", "Observed R", "Jobs", "Misses", "Verdict"})
+	table.SetAutoWrapText(false)
+	for i, t := range ordered {
+		analytic, verdict := d(check.responseTimes[i]), "schedulable"
+		if check.responseTimes[i] < 0 {
+			analytic, verdict = "> "+d(t.deadline), "UNSCHEDULABLE"
+			failed++
+		}
+		table.Append([]string{d(t.id), d(t.burst), d(t.wcet), d(t.period), d(t.deadline), strconv.Itoa(i + 1),
+			analytic, d(observed[i].worst), strconv.Itoa(observed[i].completed), strconv.Itoa(observed[i].misses), verdict})
+	}
+	table.Render()
+	_, _ = fmt.Fprintf(w, "Simulated %d time units from a synchronous release (the critical instant), each job running its average burst C;\n", horizon)
+	_, _ = fmt.Fprintln(w, "the analytic response times assume every job runs its WCET.")
+	if failed > 0 {
+		_, _ = fmt.Fprintf(w, "%d of %d tasks can miss their deadlines.\n", failed, len(tasks))
+	} else {
+		_, _ = fmt.Fprintln(w, "All tasks meet their deadlines.")
+	}
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_loadTaskSet(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		input   string
+		want    []periodicTask
+		wantErr bool
+	}{
+		{
+			name:  "deadline defaults to period",
+			input: "1,1,4\n\n2,2,6,5\n",
+			want:  []periodicTask{{id: 1, burst: 1, period: 4, deadline: 4, wcet: 1}, {id: 2, burst: 2, period: 6, deadline: 5, wcet: 2}},
+		},
+		{
+			name:  "wcet",
+			input: "1,2,10,8,3\n",
+			want:  []periodicTask{{id: 1, burst: 2, period: 10, deadline: 8, wcet: 3}},
+		},
+		{name: "wcet below burst", input: "1,2,10,10,1\n", wantErr: true},
+		{name: "too few fields", input: "1,1\n", wantErr: true},
+		{name: "not a number", input: "1,x,4\n", wantErr: true},
+		{name: "zero period", input: "1,1,0\n", wantErr: true},
+		{name: "empty", input: "\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := loadTaskSet(strings.NewReader(tt.input))
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidTask)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_responseTime(t *testing.T) {
+	t.Parallel()
+	tasks := []periodicTask{
+		{id: 3, burst: 3, period: 12, deadline: 12, wcet: 3},
+		{id: 1, burst: 1, period: 4, deadline: 4, wcet: 1},
+		{id: 2, burst: 2, period: 6, deadline: 3, wcet: 2},
+	}
+	rm := priorityOrder(tasks, policyRM)
+	assert.Equal(t, []int64{1, 2, 3}, []int64{rm[0].id, rm[1].id, rm[2].id})
+	dm := priorityOrder(tasks, policyDM)
+	assert.Equal(t, []int64{2, 1, 3}, []int64{dm[0].id, dm[1].id, dm[2].id})
+
+	for i, want := range []int64{1, 3, 10} {
+		r, ok := responseTime(rm, i)
+		assert.True(t, ok)
+		assert.Equal(t, want, r, "task %d", rm[i].id)
+	}
+	observed := simulateFixedPriority(rm, hyperperiod(rm, maxRTAHorizon))
+	assert.Equal(t, []observedResponse{{worst: 1, completed: 3}, {worst: 3, completed: 2}, {worst: 10, completed: 1}}, observed)
+
+	overloaded := []periodicTask{{id: 1, burst: 2, period: 5, deadline: 5, wcet: 2}, {id: 2, burst: 4, period: 7, deadline: 7, wcet: 4}}
+	_, ok := responseTime(overloaded, 1)
+	assert.False(t, ok)
+	observed = simulateFixedPriority(overloaded, 35)
+	assert.Equal(t, 8, int(observed[1].worst))
+	assert.Positive(t, observed[1].misses)
+}
+
+func Test_checkSchedulability(t *testing.T) {
+	t.Parallel()
+	tasks := []periodicTask{
+		{id: 1, burst: 1, period: 4, deadline: 4, wcet: 1},
+		{id: 2, burst: 1, period: 6, deadline: 6, wcet: 2},
+	}
+	s := checkSchedulability(tasks, policyRM)
+	assert.InDelta(t, 0.25+1.0/3, s.utilization, 1e-9)
+	assert.True(t, s.boundsApply)
+	assert.InDelta(t, 0.828, s.llBound, 1e-3)
+	assert.True(t, s.liuLayland)
+	assert.True(t, s.hyperbolic)
+	assert.Equal(t, []int64{1, 3}, s.responseTimes, "analysis uses the WCET")
+	assert.True(t, s.schedulable)
+
+	tasks[1].wcet, tasks[1].deadline = 5, 5
+	s = checkSchedulability(priorityOrder(tasks, policyDM), policyDM)
+	assert.False(t, s.boundsApply)
+	assert.Equal(t, []int64{1, -1}, s.responseTimes)
+	assert.False(t, s.schedulable)
+
+	var out strings.Builder
+	outputRTA(&out, tasks, policyDM, 12)
+	assert.Less(t, strings.Index(out.String(), "Response-time analysis: NOT SCHEDULABLE"), strings.Index(out.String(), "OBSERVED R"),
+		"the verdict comes before the simulation")
+	assert.Contains(t, out.String(), "Utilization bounds skipped")
+}
+
+func Test_hyperperiod(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, int64(12), hyperperiod([]periodicTask{{period: 4}, {period: 6}, {period: 12}}, 100))
+	assert.Equal(t, int64(100), hyperperiod([]periodicTask{{period: 97}, {period: 89}}, 100))
+	assert.Equal(t, int64(maxRTAHorizon), hyperperiod([]periodicTask{{period: 1 << 40}, {period: 1<<40 - 1}}, maxRTAHorizon))
+}
+
+func Test_parseRTAFlags(t *testing.T) {
+	t.Parallel()
+	got, err := parseRTAFlags(io.Discard, []string{"-policy", "DM", "-horizon", "50", "tasks.csv"})
+	require.NoError(t, err)
+	assert.Equal(t, rtaConfig{path: "tasks.csv", policy: policyDM, horizon: 50}, got)
+
+	for _, args := range [][]string{nil, {"-policy", "edf", "tasks.csv"}, {"-horizon", "-1", "tasks.csv"}} {
+		_, err := parseRTAFlags(io.Discard, args)
+		assert.Error(t, err, args)
+	}
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/olekukonko/tablewriter"
+
+	"github.com/jh125486/CSCE4600/Project1/render"
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+	"github.com/jh125486/CSCE4600/Project1/workload"
+)
+
+// outputSLA prints each scheduler's SLA violations, for workloads whose processes have SLA
+// targets: how many processes missed a target, by how much in total, and the worst offender.
+// It prints nothing when no workload had targets.
+func outputSLA(w io.Writer, results []scheduler.Result, unit scheduler.TimeUnit) {
+	var rows [][]string
+	for _, r := range results {
+		s := r.SLA
+		if s == nil {
+			continue
+		}
+		worst := "-"
+		if v := s.Worst; v != nil {
+			worst = fmt.Sprintf("P%d %s %d > %d", v.PID, v.Metric, v.Actual, v.Target)
+		}
+		rows = append(rows, []string{workload.Group(r.Input), r.Algorithm, strconv.Itoa(s.Targets),
+			strconv.Itoa(s.Violations), strconv.FormatFloat(100*float64(s.Violations)/float64(s.Targets), 'f', 1, 64) + "%",
+			strconv.Itoa(s.Missed), strconv.FormatInt(s.Magnitude, 10), worst})
+	}
+	if len(rows) == 0 {
+		return
+	}
+
+	render.OutputTitle(w, "SLA violations")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Workload", "Algorithm", "Targets", "Violations", "Violated",
+		"Not completed", unit.Label("Total overshoot"), "Worst offender"})
+	table.SetAutoWrapText(false)
+	table.SetAutoMergeCellsByColumnIndex([]int{0})
+	table.AppendBulk(rows)
+	table.Render()
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+func Test_outputSLA(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "sla.csv")
+	// P2's response target of 2 is missed under FCFS, which starts it at 5, but not under SJF.
+	require.NoError(t, os.WriteFile(path, []byte("1,5,0,2,10\n2,1,1,1,0,2\n3,6,3,3\n"), 0o644))
+	cfg := defaultConfig()
+	cfg.algorithms = []string{"fcfs", "sjf"}
+	results, err := cfg.runWorkload(path, nil, nil)
+	require.NoError(t, err)
+	require.NotNil(t, results[0].SLA)
+	assert.Equal(t, 1, results[0].SLA.Violations)
+	assert.Equal(t, 0, results[1].SLA.Violations)
+
+	var out bytes.Buffer
+	outputSLA(&out, results, scheduler.TimeUnitTicks)
+	assert.Contains(t, out.String(), "SLA violations")
+	assert.Contains(t, out.String(), "P2 response 4 > 2")
+	assert.Contains(t, out.String(), "50.0%")
+
+	out.Reset()
+	outputSLA(&out, []scheduler.Result{{Algorithm: "fcfs"}}, scheduler.TimeUnitTicks)
+	assert.Empty(t, out.String(), "nothing without SLA targets")
+}
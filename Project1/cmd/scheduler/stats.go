@@ -0,0 +1,166 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/olekukonko/tablewriter"
+
+	"github.com/jh125486/CSCE4600/Project1/render"
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+	"github.com/jh125486/CSCE4600/Project1/workload"
+)
+
+// tCritical95 is the two-sided 95% Student's t critical value by degrees of freedom (1-30);
+// larger samples use the normal approximation.
+var tCritical95 = [...]float64{
+	12.706, 4.303, 3.182, 2.776, 2.571, 2.447, 2.365, 2.306, 2.262, 2.228,
+	2.201, 2.179, 2.160, 2.145, 2.131, 2.120, 2.110, 2.101, 2.093, 2.086,
+	2.080, 2.074, 2.069, 2.064, 2.060, 2.056, 2.052, 2.048, 2.045, 2.042,
+}
+
+// sampleStats is the mean of a sample and the half-width of its 95% confidence interval,
+// which is NaN for fewer than two values.
+type sampleStats struct {
+	n    int
+	mean float64
+	ci95 float64
+}
+
+func newSampleStats(values []float64) sampleStats {
+	s := sampleStats{n: len(values), ci95: math.NaN()}
+	if s.n == 0 {
+		s.mean = math.NaN()
+		return s
+	}
+	for _, v := range values {
+		s.mean += v
+	}
+	s.mean /= float64(s.n)
+	if s.n < 2 {
+		return s
+	}
+	var ss float64
+	for _, v := range values {
+		ss += (v - s.mean) * (v - s.mean)
+	}
+	t := 1.96
+	if df := s.n - 1; df <= len(tCritical95) {
+		t = tCritical95[df-1]
+	}
+	s.ci95 = t * math.Sqrt(ss/float64(s.n-1)) / math.Sqrt(float64(s.n))
+
+	return s
+}
+
+func (s sampleStats) String() string {
+	if math.IsNaN(s.ci95) {
+		return strconv.FormatFloat(s.mean, 'f', 2, 64)
+	}
+
+	return fmt.Sprintf("%.2f ± %.2f", s.mean, s.ci95)
+}
+
+// seedComparison is one algorithm's metrics over every seed of one workload.
+type seedComparison struct {
+	workload, algorithm          string
+	wait, turnaround, throughput sampleStats
+}
+
+// compareSeeds groups results by workload (ignoring the seed) and algorithm, in first-seen order.
+func compareSeeds(results []scheduler.Result) []seedComparison {
+	type key struct{ workload, algorithm string }
+	var (
+		keys    []key
+		samples = make(map[key]*[3][]float64)
+	)
+	for _, r := range results {
+		k := key{workload.Group(r.Input), r.Algorithm}
+		s, ok := samples[k]
+		if !ok {
+			s = new([3][]float64)
+			samples[k] = s
+			keys = append(keys, k)
+		}
+		s[0] = append(s[0], r.AveWait)
+		s[1] = append(s[1], r.AveTurnaround)
+		s[2] = append(s[2], r.AveThroughput)
+	}
+
+	comparisons := make([]seedComparison, len(keys))
+	for i, k := range keys {
+		s := samples[k]
+		comparisons[i] = seedComparison{
+			workload:   k.workload,
+			algorithm:  k.algorithm,
+			wait:       newSampleStats(s[0]),
+			turnaround: newSampleStats(s[1]),
+			throughput: newSampleStats(s[2]),
+		}
+	}
+
+	return comparisons
+}
+
+// outputSeedComparison prints the mean ± 95% confidence interval of each metric per
+// workload and algorithm.
+func outputSeedComparison(w io.Writer, results []scheduler.Result, unit scheduler.TimeUnit) {
+	render.OutputTitle(w, "Seed comparison")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Workload", "Algorithm", "Runs",
+		unit.Label("Average wait"), unit.Label("Average turnaround"), "Throughput"})
+	table.SetAutoWrapText(false)
+	for _, c := range compareSeeds(results) {
+		table.Append([]string{c.workload, c.algorithm, strconv.Itoa(c.wait.n),
+			c.wait.String(), c.turnaround.String(), c.throughput.String()})
+	}
+	table.Render()
+	_, _ = fmt.Fprintln(w, "Values are mean ± half-width of the 95% confidence interval (Student's t).")
+}
+
+// distribution summarizes the spread of a sample: mean, standard deviation and quantiles.
+type distribution struct {
+	n                  int
+	mean, sd           float64
+	min, p50, p95, max float64
+}
+
+func newDistribution(values []float64) distribution {
+	d := distribution{n: len(values)}
+	if d.n == 0 {
+		nan := math.NaN()
+		d.mean, d.sd, d.min, d.p50, d.p95, d.max = nan, nan, nan, nan, nan, nan
+		return d
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	for _, v := range sorted {
+		d.mean += v
+	}
+	d.mean /= float64(d.n)
+	if d.n > 1 {
+		var ss float64
+		for _, v := range sorted {
+			ss += (v - d.mean) * (v - d.mean)
+		}
+		d.sd = math.Sqrt(ss / float64(d.n-1))
+	}
+	d.min, d.max = sorted[0], sorted[d.n-1]
+	d.p50, d.p95 = quantile(sorted, 0.5), quantile(sorted, 0.95)
+
+	return d
+}
+
+// quantile linearly interpolates the q-th quantile of sorted values.
+func quantile(sorted []float64, q float64) float64 {
+	pos := q * float64(len(sorted)-1)
+	i := int(pos)
+	if i+1 >= len(sorted) {
+		return sorted[len(sorted)-1]
+	}
+
+	return sorted[i] + (pos-float64(i))*(sorted[i+1]-sorted[i])
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+func Test_newSampleStats(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		values   []float64
+		wantMean float64
+		wantCI   float64
+		want     string
+	}{
+		{name: "empty", wantMean: math.NaN(), wantCI: math.NaN(), want: "NaN"},
+		{name: "single", values: []float64{4}, wantMean: 4, wantCI: math.NaN(), want: "4.00"},
+		// s = 1, t(2) = 4.303, so the half-width is 4.303 / sqrt(3).
+		{name: "three", values: []float64{1, 2, 3}, wantMean: 2, wantCI: 4.303 / math.Sqrt(3), want: "2.00 ± 2.48"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := newSampleStats(tt.values)
+			assert.Equal(t, len(tt.values), got.n)
+			if math.IsNaN(tt.wantMean) {
+				assert.True(t, math.IsNaN(got.mean))
+			} else {
+				assert.InDelta(t, tt.wantMean, got.mean, 1e-9)
+			}
+			if math.IsNaN(tt.wantCI) {
+				assert.True(t, math.IsNaN(got.ci95))
+			} else {
+				assert.InDelta(t, tt.wantCI, got.ci95, 1e-9)
+			}
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
+
+func Test_compareSeeds(t *testing.T) {
+	t.Parallel()
+	results := []scheduler.Result{
+		{Input: "gen:batch?n=5&seed=1", Algorithm: "fcfs", AveWait: 1},
+		{Input: "gen:batch?n=5&seed=1", Algorithm: "sjf", AveWait: 5},
+		{Input: "gen:batch?n=5&seed=2", Algorithm: "fcfs", AveWait: 3},
+		{Input: "gen:batch?n=5&seed=2", Algorithm: "sjf", AveWait: 5},
+		{Input: "a.csv", Algorithm: "fcfs", AveWait: 7},
+	}
+	got := compareSeeds(results)
+	assert.Len(t, got, 3)
+	assert.Equal(t, "gen:batch?n=5", got[0].workload)
+	assert.Equal(t, 2, got[0].wait.n)
+	assert.InDelta(t, 2, got[0].wait.mean, 1e-9)
+	assert.InDelta(t, 0, got[1].wait.ci95, 1e-9)
+	assert.Equal(t, "a.csv", got[2].workload)
+
+	var b bytes.Buffer
+	outputSeedComparison(&b, results, scheduler.TimeUnitMillis)
+	assert.Contains(t, b.String(), "AVERAGE WAIT (MS)")
+	assert.Contains(t, b.String(), "2.00 ± 12.71")
+}
+
+func Test_newDistribution(t *testing.T) {
+	t.Parallel()
+	d := newDistribution([]float64{4, 1, 3, 2, 5})
+	assert.Equal(t, 5, d.n)
+	assert.InDelta(t, 3, d.mean, 1e-9)
+	assert.InDelta(t, math.Sqrt(2.5), d.sd, 1e-9)
+	assert.Equal(t, 1.0, d.min)
+	assert.Equal(t, 3.0, d.p50)
+	assert.InDelta(t, 4.8, d.p95, 1e-9)
+	assert.Equal(t, 5.0, d.max)
+
+	single := newDistribution([]float64{7})
+	assert.Equal(t, 7.0, single.p95)
+	assert.Zero(t, single.sd)
+	assert.True(t, math.IsNaN(newDistribution(nil).mean))
+}
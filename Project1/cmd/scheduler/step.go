@@ -0,0 +1,264 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+	"github.com/jh125486/CSCE4600/Project1/workload"
+)
+
+// stepConfig holds the parsed step command line.
+type stepConfig struct {
+	config
+	algorithm string
+	in        io.Reader
+}
+
+// parseStepFlags parses `scheduler step [flags] workload`.
+func parseStepFlags(errW io.Writer, args []string) (stepConfig, error) {
+	sc := stepConfig{config: defaultConfig(), algorithm: scheduler.Algorithms[0].Name, in: os.Stdin}
+
+	fs := flag.NewFlagSet("scheduler step", flag.ContinueOnError)
+	fs.SetOutput(errW)
+	fs.Usage = func() {
+		_, _ = fmt.Fprintln(errW, "usage: scheduler step [flags] workload")
+		_, _ = fmt.Fprintln(errW, "Steps forwards and backwards through one scheduler's events, reading commands from stdin; h lists them.")
+		fs.PrintDefaults()
+	}
+	fs.Func("algorithm", "algorithm to step through (default "+sc.algorithm+")", func(s string) error {
+		names, err := parseAlgorithms(s)
+		if err == nil && len(names) != 1 {
+			err = fmt.Errorf("%w: -algorithm takes one algorithm", scheduler.ErrInvalidArgs)
+		}
+		if err == nil {
+			sc.algorithm = names[0]
+		}
+		return err
+	})
+	fs.Func("input-format", "workload format: auto or "+workload.KnownFormats(), func(s string) (err error) {
+		sc.inputFormat, err = workload.ParseFormat(s)
+		return err
+	})
+	if err := fs.Parse(args); err != nil {
+		return sc, err
+	}
+	sc.args = fs.Args()
+	sc.algorithms = []string{sc.algorithm}
+	if len(sc.args) != 1 {
+		err := fmt.Errorf("%w: must give one workload to step through", scheduler.ErrInvalidArgs)
+		_, _ = fmt.Fprintln(errW, err)
+		return sc, err
+	}
+
+	return sc, nil
+}
+
+type (
+	// stepEvent is one scheduling event in a run's history.
+	stepEvent struct {
+		time      int64
+		kind      string // arrived, dispatched, preempted, completed or idle
+		pid       int64
+		remaining int64 // burst left after a preemption
+		until     int64 // end of an idle period
+	}
+	// stepState is the queue state after an event: what is running, what is ready (with the
+	// burst each has left) and what has finished.
+	stepState struct {
+		running   int64 // 0 when idle
+		ready     map[int64]int64
+		completed []int64
+	}
+)
+
+// recordEvents simulates processes with s, returning every event in order.
+func recordEvents(s scheduler.Algorithm, processes []scheduler.Process, opts []scheduler.Option) []stepEvent {
+	var events []stepEvent
+	s.Simulate(s.Title, processes, append(opts[:len(opts):len(opts)], scheduler.WithHooks(scheduler.Hooks{
+		OnArrival: func(now int64, p scheduler.Process) {
+			events = append(events, stepEvent{time: now, kind: "arrived", pid: p.ProcessID, remaining: p.BurstDuration})
+		},
+		OnDispatch: func(now int64, p scheduler.Process, _ int) {
+			events = append(events, stepEvent{time: now, kind: "dispatched", pid: p.ProcessID})
+		},
+		OnPreempt: func(now int64, p scheduler.Process, _ int, remaining int64) {
+			events = append(events, stepEvent{time: now, kind: "preempted", pid: p.ProcessID, remaining: remaining})
+		},
+		OnComplete: func(now int64, r scheduler.ProcessResult) {
+			events = append(events, stepEvent{time: now, kind: "completed", pid: r.ID})
+		},
+		OnIdle: func(now, until int64) {
+			events = append(events, stepEvent{time: now, kind: "idle", until: until})
+		},
+	}))...)
+
+	return events
+}
+
+// stateAt replays the history up to and including event i. Replaying the recorded events
+// rather than keeping a copy of the state per event keeps memory linear in the run's length.
+func stateAt(events []stepEvent, i int) stepState {
+	st := stepState{ready: make(map[int64]int64)}
+	for _, e := range events[:i+1] {
+		switch e.kind {
+		case "arrived":
+			st.ready[e.pid] = e.remaining
+		case "dispatched":
+			delete(st.ready, e.pid)
+			st.running = e.pid
+		case "preempted":
+			st.ready[e.pid] = e.remaining
+			st.running = 0
+		case "completed":
+			delete(st.ready, e.pid)
+			if st.running == e.pid {
+				st.running = 0
+			}
+			st.completed = append(st.completed, e.pid)
+		}
+	}
+
+	return st
+}
+
+func (e stepEvent) String() string {
+	switch e.kind {
+	case "idle":
+		return fmt.Sprintf("@%d: idle until %d", e.time, e.until)
+	case "preempted":
+		return fmt.Sprintf("@%d: P%d preempted with %d left", e.time, e.pid, e.remaining)
+	default:
+		return fmt.Sprintf("@%d: P%d %s", e.time, e.pid, e.kind)
+	}
+}
+
+// run loads the workload, simulates it once and then steps through its events as commanded.
+func (sc stepConfig) run(stdout, errW io.Writer) error {
+	processes, _, err := sc.loadWorkload(sc.args[0])
+	if err != nil {
+		return err
+	}
+	s := sc.schedulers()[0]
+	events := recordEvents(s, processes, sc.options(errW))
+	if len(events) == 0 {
+		_, _ = fmt.Fprintln(stdout, "No processes to schedule.")
+		return nil
+	}
+
+	_, _ = fmt.Fprintf(stdout, "%s: %d events. Commands: n(ext) and p(rev) [count], t <time>, e <event>, l(ist), q(uit).\n", s.Title, len(events))
+	i := 0
+	outputStep(stdout, events, i)
+	in := bufio.NewScanner(sc.in)
+	for {
+		_, _ = fmt.Fprint(stdout, "> ")
+		if !in.Scan() {
+			_, _ = fmt.Fprintln(stdout)
+			return in.Err()
+		}
+		fields := strings.Fields(in.Text())
+		cmd, arg := "n", ""
+		if len(fields) > 0 {
+			cmd = fields[0]
+		}
+		if len(fields) > 1 {
+			arg = fields[1]
+		}
+		switch cmd {
+		case "n", "next", "p", "prev":
+			count := int64(1)
+			if arg != "" {
+				if count, err = strconv.ParseInt(arg, 10, 64); err != nil || count < 1 {
+					_, _ = fmt.Fprintf(stdout, "bad count %q\n", arg)
+					continue
+				}
+			}
+			if cmd[0] == 'p' {
+				count = -count
+			}
+			i = clampEvent(int64(i)+count, len(events))
+		case "t", "time":
+			t, err := strconv.ParseInt(arg, 10, 64)
+			if err != nil {
+				_, _ = fmt.Fprintf(stdout, "bad time %q\n", arg)
+				continue
+			}
+			// The last event at or before t, or the first event if t is before them all.
+			i = sort.Search(len(events), func(j int) bool { return events[j].time > t }) - 1
+			if i < 0 {
+				i = 0
+			}
+		case "e", "event":
+			n, err := strconv.ParseInt(arg, 10, 64)
+			if err != nil {
+				_, _ = fmt.Fprintf(stdout, "bad event %q\n", arg)
+				continue
+			}
+			i = clampEvent(n-1, len(events))
+		case "l", "list":
+			for j, e := range events {
+				marker := " "
+				if j == i {
+					marker = ">"
+				}
+				_, _ = fmt.Fprintf(stdout, "%s %3d %s\n", marker, j+1, e)
+			}
+			continue
+		case "q", "quit":
+			return nil
+		default:
+			_, _ = fmt.Fprintln(stdout, "commands: n(ext) [count], p(rev) [count], t <time>, e <event>, l(ist), q(uit); an empty line is next")
+			continue
+		}
+		outputStep(stdout, events, i)
+	}
+}
+
+func clampEvent(i int64, n int) int {
+	switch {
+	case i < 0:
+		return 0
+	case i >= int64(n):
+		return n - 1
+	default:
+		return int(i)
+	}
+}
+
+// outputStep prints event i and the queue state it leaves.
+func outputStep(w io.Writer, events []stepEvent, i int) {
+	st := stateAt(events, i)
+	_, _ = fmt.Fprintf(w, "event %d/%d %s\n", i+1, len(events), events[i])
+	running := "-"
+	if st.running != 0 {
+		running = "P" + strconv.FormatInt(st.running, 10)
+	}
+	pids := make([]int64, 0, len(st.ready))
+	for pid := range st.ready {
+		pids = append(pids, pid)
+	}
+	sort.Slice(pids, func(a, b int) bool { return pids[a] < pids[b] })
+	ready := make([]string, len(pids))
+	for j, pid := range pids {
+		ready[j] = fmt.Sprintf("P%d (%d left)", pid, st.ready[pid])
+	}
+	done := make([]string, len(st.completed))
+	for j, pid := range st.completed {
+		done[j] = "P" + strconv.FormatInt(pid, 10)
+	}
+	_, _ = fmt.Fprintf(w, "  running: %s\n  ready:   %s\n  done:    %s\n", running, orDash(ready), orDash(done))
+}
+
+func orDash(items []string) string {
+	if len(items) == 0 {
+		return "-"
+	}
+
+	return strings.Join(items, ", ")
+}
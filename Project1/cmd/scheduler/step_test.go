@@ -0,0 +1,62 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+func Test_parseStepFlags(t *testing.T) {
+	t.Parallel()
+	sc, err := parseStepFlags(io.Discard, []string{"-algorithm", "sjf", "w.csv"})
+	require.NoError(t, err)
+	assert.Equal(t, "sjf", sc.algorithm)
+	assert.Equal(t, []string{"sjf"}, sc.algorithms)
+	assert.Equal(t, []string{"w.csv"}, sc.args)
+
+	_, err = parseStepFlags(io.Discard, []string{"-algorithm", "fcfs,sjf", "w.csv"})
+	assert.Error(t, err)
+	_, err = parseStepFlags(io.Discard, nil)
+	assert.ErrorIs(t, err, scheduler.ErrInvalidArgs)
+}
+
+func Test_stepConfig_run(t *testing.T) {
+	t.Parallel()
+	sc, err := parseStepFlags(io.Discard, []string{"-algorithm", "sjf", "../../example_processes.csv"})
+	require.NoError(t, err)
+	sc.in = strings.NewReader("e 3\nn\np\nt 100\np 100\nq\n")
+	var stdout bytes.Buffer
+	require.NoError(t, sc.run(&stdout, io.Discard))
+
+	steps := strings.Split(stdout.String(), "> ")
+	require.Len(t, steps, 7, "the start and one step per command")
+	assert.Contains(t, steps[1], "event 3/")
+	assert.Contains(t, steps[1], "ready:   P2 (9 left)")
+	assert.Contains(t, steps[2], "event 4/")
+	// Stepping back shows the same state as before, without simulating again.
+	assert.Equal(t, steps[1], steps[3])
+	assert.Contains(t, steps[4], "running: -")
+	assert.Contains(t, steps[4], "done:    P1, P3, P2")
+	assert.Contains(t, steps[5], "event 1/")
+}
+
+func Test_stateAt(t *testing.T) {
+	t.Parallel()
+	events := []stepEvent{
+		{time: 0, kind: "arrived", pid: 1, remaining: 4},
+		{time: 0, kind: "dispatched", pid: 1},
+		{time: 1, kind: "arrived", pid: 2, remaining: 1},
+		{time: 1, kind: "preempted", pid: 1, remaining: 3},
+		{time: 1, kind: "dispatched", pid: 2},
+		{time: 2, kind: "completed", pid: 2},
+	}
+	assert.Equal(t, stepState{running: 1, ready: map[int64]int64{2: 1}}, stateAt(events, 2))
+	assert.Equal(t, stepState{running: 2, ready: map[int64]int64{1: 3}}, stateAt(events, 4))
+	assert.Equal(t, stepState{ready: map[int64]int64{1: 3}, completed: []int64{2}}, stateAt(events, 5))
+}
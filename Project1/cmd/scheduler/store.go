@@ -0,0 +1,227 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+// sqliteCommand is the SQLite shell that --store pipes its SQL into, so the tool needs no cgo driver.
+var sqliteCommand = "sqlite3"
+
+// storeSchema is the results store schema. Columns are only ever added, never renamed or
+// dropped, so queries written against one version keep working against later ones.
+const storeSchema = `CREATE TABLE IF NOT EXISTS runs (
+	id                 INTEGER PRIMARY KEY,
+	batch              TEXT NOT NULL,
+	recorded_at        TEXT NOT NULL,
+	tool               TEXT,
+	version            TEXT,
+	revision           TEXT,
+	input              TEXT,
+	input_sha256       TEXT,
+	algorithm          TEXT NOT NULL,
+	algorithm_version  TEXT,
+	title              TEXT,
+	average_wait       REAL,
+	average_turnaround REAL,
+	throughput         REAL,
+	average_response   REAL,
+	incomplete         INTEGER
+);
+CREATE TABLE IF NOT EXISTS run_parameters (
+	run   INTEGER NOT NULL REFERENCES runs(id),
+	name  TEXT NOT NULL,
+	value TEXT NOT NULL,
+	PRIMARY KEY (run, name)
+);
+CREATE TABLE IF NOT EXISTS run_processes (
+	run        INTEGER NOT NULL REFERENCES runs(id),
+	id         INTEGER NOT NULL,
+	priority   INTEGER,
+	burst      INTEGER,
+	arrival    INTEGER,
+	wait       INTEGER,
+	turnaround INTEGER,
+	exit       INTEGER,
+	response   INTEGER,
+	status     TEXT
+);
+CREATE INDEX IF NOT EXISTS run_processes_run ON run_processes(run);
+`
+
+// storeColumns are the columns added to storeSchema after its tables were first created, which
+// a database created before them lacks until ALTER TABLE adds them.
+var storeColumns = []struct{ table, column, decl string }{
+	{"runs", "average_response", "REAL"},
+	{"runs", "incomplete", "INTEGER"},
+	{"run_processes", "response", "INTEGER"},
+	{"run_processes", "status", "TEXT"},
+}
+
+// storeResults appends results to the SQLite database at path, one row in runs per scheduler run.
+// Runs stored by the same invocation share a batch. A path ending in .sql appends the SQL script
+// instead, for loading later with `sqlite3 results.db < results.sql`.
+func storeResults(path string, results []scheduler.Result, now time.Time) error {
+	var script bytes.Buffer
+	if strings.EqualFold(filepath.Ext(path), ".sql") {
+		writeStoreSQL(&script, results, now, nil)
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			return fmt.Errorf("%w: opening results store", err)
+		}
+		if _, err := script.WriteTo(f); err != nil {
+			_ = f.Close()
+			return fmt.Errorf("%w: writing results store", err)
+		}
+		return f.Close()
+	}
+
+	migrations, err := storeMigrations(path)
+	if err != nil {
+		return err
+	}
+	writeStoreSQL(&script, results, now, migrations)
+	var stderr bytes.Buffer
+	cmd := exec.Command(sqliteCommand, "-bail", path)
+	cmd.Stdin = &script
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%w: storing results in %s: %s", err, path, strings.TrimSpace(stderr.String()))
+	}
+
+	return nil
+}
+
+// storeTables are the columns of each table of the SQLite database at path.
+func storeTables(path string) (map[string]map[string]bool, error) {
+	var columns []struct {
+		Table  string `json:"t"`
+		Column string `json:"c"`
+	}
+	if err := sqliteJSON(path, "SELECT m.name AS t, p.name AS c FROM sqlite_master m, pragma_table_info(m.name) p "+
+		"WHERE m.type = 'table'", &columns); err != nil {
+		return nil, err
+	}
+	tables := make(map[string]map[string]bool)
+	for _, c := range columns {
+		if tables[c.Table] == nil {
+			tables[c.Table] = make(map[string]bool)
+		}
+		tables[c.Table][c.Column] = true
+	}
+
+	return tables, nil
+}
+
+// storedColumns are the columns of table to select from a database with tables, with NULL in
+// place of any of storeColumns the database predates.
+func storedColumns(tables map[string]map[string]bool, table string, columns ...string) string {
+	have := tables[table]
+	for i, column := range columns {
+		for _, c := range storeColumns {
+			if c.table == table && c.column == column && !have[column] {
+				columns[i] = "NULL AS " + column
+			}
+		}
+	}
+
+	return strings.Join(columns, ", ")
+}
+
+// storeMigrations are the ALTER TABLE statements that bring the existing database at path up to
+// storeSchema. SQLite has no ADD COLUMN IF NOT EXISTS, so the columns are read first; a database
+// that does not exist yet gets the whole schema and needs none.
+func storeMigrations(path string) ([]string, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, nil
+	}
+	tables, err := storeTables(path)
+	if err != nil {
+		return nil, err
+	}
+	var migrations []string
+	for _, c := range storeColumns {
+		if have, ok := tables[c.table]; ok && !have[c.column] {
+			migrations = append(migrations, fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s;\n", c.table, c.column, c.decl))
+		}
+	}
+
+	return migrations, nil
+}
+
+// writeStoreSQL writes the schema, the migrations and the inserts for results as a single
+// transaction.
+func writeStoreSQL(w io.Writer, results []scheduler.Result, now time.Time, migrations []string) {
+	bw := bufio.NewWriter(w)
+	defer bw.Flush()
+
+	batch := sqlString(now.UTC().Format(time.RFC3339Nano))
+	_, _ = bw.WriteString("BEGIN;\n")
+	_, _ = bw.WriteString(storeSchema)
+	for _, m := range migrations {
+		_, _ = bw.WriteString(m)
+	}
+	for _, r := range results {
+		p := r.Provenance
+		if p == nil {
+			p = &scheduler.Provenance{}
+		}
+		_, _ = fmt.Fprintf(bw, "INSERT INTO runs (batch, recorded_at, tool, version, revision, input, input_sha256, "+
+			"algorithm, algorithm_version, title, average_wait, average_turnaround, throughput, average_response, incomplete) "+
+			"VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %d);\n",
+			batch, batch, sqlString(p.Tool), sqlString(p.Version), sqlString(p.Revision),
+			sqlString(r.Input), sqlString(p.InputSHA256), sqlString(r.Algorithm),
+			sqlString(p.Algorithms[r.Algorithm]), sqlString(r.Title),
+			sqlReal(r.AveWait), sqlReal(r.AveTurnaround), sqlReal(r.AveThroughput), sqlReal(r.AveResponse), r.Incomplete)
+
+		const run = "(SELECT max(id) FROM runs)"
+		names := make([]string, 0, len(p.Parameters))
+		for name := range p.Parameters {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			_, _ = fmt.Fprintf(bw, "INSERT INTO run_parameters (run, name, value) VALUES (%s, %s, %s);\n",
+				run, sqlString(name), sqlString(p.Parameters[name]))
+		}
+		for _, pr := range r.Processes {
+			_, _ = fmt.Fprintf(bw, "INSERT INTO run_processes (run, id, priority, burst, arrival, wait, turnaround, exit, response, status) "+
+				"VALUES (%s, %d, %d, %d, %d, %d, %d, %d, %d, %s);\n",
+				run, pr.ID, pr.Priority, pr.Burst, pr.Arrival, pr.Wait, pr.Turnaround, pr.Exit, pr.Response, sqlStatus(pr.Status))
+		}
+	}
+	_, _ = bw.WriteString("COMMIT;\n")
+}
+
+func sqlString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// sqlStatus is a process row's status, NULL for a process that completed.
+func sqlStatus(s scheduler.Status) string {
+	if s == "" {
+		return "NULL"
+	}
+
+	return sqlString(string(s))
+}
+
+func sqlReal(f float64) string {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return "NULL"
+	}
+
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
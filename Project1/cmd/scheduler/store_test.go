@@ -0,0 +1,136 @@
+package main
+
+import (
+	"bytes"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+var storeTime = time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+
+func storeTestResults() []scheduler.Result {
+	return []scheduler.Result{{
+		Algorithm: "fcfs",
+		Input:     "o'brien.csv",
+		Title:     "First-come, first-serve",
+		Processes: []scheduler.ProcessResult{
+			{ID: 1, Priority: 2, Burst: 5, Wait: 0, Turnaround: 5, Exit: 5},
+			{ID: 2, Priority: 1, Burst: 3, Arrival: 1, Wait: 4, Turnaround: 7, Response: 4, Exit: 8},
+			{ID: 3, Burst: 9, Arrival: 2, Exit: 8, Status: scheduler.StatusKilled},
+		},
+		AveWait:       2,
+		AveTurnaround: 6,
+		AveResponse:   2,
+		AveThroughput: 0.25,
+		Incomplete:    1,
+		Provenance: &scheduler.Provenance{
+			Tool:        "scheduler",
+			Version:     scheduler.Version,
+			Input:       "o'brien.csv",
+			InputSHA256: "abc",
+			Parameters:  map[string]string{"time_unit": "ticks", "quantum": "2"},
+			Algorithms:  map[string]string{"fcfs": "1"},
+		},
+	}}
+}
+
+func Test_writeStoreSQL(t *testing.T) {
+	t.Parallel()
+	var b bytes.Buffer
+	writeStoreSQL(&b, storeTestResults(), storeTime, nil)
+	got := b.String()
+
+	assert.True(t, strings.HasPrefix(got, "BEGIN;\n"), got)
+	assert.True(t, strings.HasSuffix(got, "COMMIT;\n"), got)
+	assert.Contains(t, got, "CREATE TABLE IF NOT EXISTS runs")
+	assert.Contains(t, got, "VALUES ('2024-03-01T08:00:00Z', '2024-03-01T08:00:00Z', 'scheduler', '1.0.0', '', "+
+		"'o''brien.csv', 'abc', 'fcfs', '1', 'First-come, first-serve', 2, 6, 0.25, 2, 1);")
+	assert.Contains(t, got, "VALUES ((SELECT max(id) FROM runs), 'quantum', '2');\n"+
+		"INSERT INTO run_parameters (run, name, value) VALUES ((SELECT max(id) FROM runs), 'time_unit', 'ticks');")
+	assert.Contains(t, got, "VALUES ((SELECT max(id) FROM runs), 2, 1, 3, 1, 4, 7, 8, 4, NULL);")
+	assert.Contains(t, got, "VALUES ((SELECT max(id) FROM runs), 3, 0, 9, 2, 0, 0, 8, 0, 'killed');")
+	assert.NotContains(t, got, "ALTER TABLE")
+}
+
+func Test_sqlReal(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "1.5", sqlReal(1.5))
+	assert.Equal(t, "NULL", sqlReal(math.NaN()))
+}
+
+func Test_storeResults_script(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "results.sql")
+	require.NoError(t, storeResults(path, storeTestResults(), storeTime))
+	require.NoError(t, storeResults(path, storeTestResults(), storeTime))
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, 2, strings.Count(string(b), "INSERT INTO runs"))
+}
+
+func Test_storeResults_sqlite(t *testing.T) {
+	t.Parallel()
+	if _, err := exec.LookPath(sqliteCommand); err != nil {
+		t.Skipf("%s not installed", sqliteCommand)
+	}
+	path := filepath.Join(t.TempDir(), "results.db")
+	require.NoError(t, storeResults(path, storeTestResults(), storeTime))
+	require.NoError(t, storeResults(path, storeTestResults(), storeTime.Add(time.Hour)))
+
+	out, err := exec.Command(sqliteCommand, path,
+		"SELECT count(*), count(DISTINCT batch) FROM runs;",
+		"SELECT r.id, sum(p.wait) FROM runs r JOIN run_processes p ON p.run = r.id GROUP BY r.id;",
+		"SELECT value FROM run_parameters WHERE run = 2 AND name = 'quantum';",
+		"SELECT average_response, incomplete FROM runs WHERE id = 2;",
+		"SELECT id, response, coalesce(status, '-') FROM run_processes WHERE run = 2 ORDER BY id;",
+	).Output()
+	require.NoError(t, err)
+	assert.Equal(t, "2|2\n1|4\n2|4\n2\n2.0|1\n1|0|-\n2|4|-\n3|0|killed\n", string(out))
+}
+
+func Test_storeResults_migrates(t *testing.T) {
+	t.Parallel()
+	if _, err := exec.LookPath(sqliteCommand); err != nil {
+		t.Skipf("%s not installed", sqliteCommand)
+	}
+	// A store from before average_response, incomplete, response and status were added.
+	path := filepath.Join(t.TempDir(), "results.db")
+	old := exec.Command(sqliteCommand, "-bail", path)
+	old.Stdin = strings.NewReader(`CREATE TABLE runs (
+	id INTEGER PRIMARY KEY, batch TEXT NOT NULL, recorded_at TEXT NOT NULL, tool TEXT, version TEXT,
+	revision TEXT, input TEXT, input_sha256 TEXT, algorithm TEXT NOT NULL, algorithm_version TEXT,
+	title TEXT, average_wait REAL, average_turnaround REAL, throughput REAL);
+CREATE TABLE run_processes (
+	run INTEGER NOT NULL REFERENCES runs(id), id INTEGER NOT NULL, priority INTEGER, burst INTEGER,
+	arrival INTEGER, wait INTEGER, turnaround INTEGER, exit INTEGER);
+INSERT INTO runs (batch, recorded_at, algorithm, average_wait) VALUES ('old', 'old', 'sjf', 1);
+INSERT INTO run_processes (run, id, wait) VALUES (1, 1, 1);
+`)
+	require.NoError(t, old.Run())
+	got, err := loadStoredResults(path, 1)
+	require.NoError(t, err, "a store that predates the columns still reads")
+	require.Len(t, got, 1)
+	assert.Equal(t, []scheduler.ProcessResult{{ID: 1, Wait: 1}}, got[0].Processes)
+
+	require.NoError(t, storeResults(path, storeTestResults(), storeTime))
+	require.NoError(t, storeResults(path, storeTestResults(), storeTime.Add(time.Hour)))
+
+	out, err := exec.Command(sqliteCommand, path,
+		"SELECT id, algorithm, coalesce(average_response, '-'), coalesce(incomplete, '-') FROM runs ORDER BY id;",
+		"SELECT run, id, coalesce(response, '-'), coalesce(status, '-') FROM run_processes WHERE run < 3 ORDER BY run, id;",
+	).Output()
+	require.NoError(t, err)
+	assert.Equal(t, "1|sjf|-|-\n2|fcfs|2.0|1\n3|fcfs|2.0|1\n"+
+		"1|1|-|-\n2|1|0|-\n2|2|4|-\n2|3|0|killed\n", string(out))
+}
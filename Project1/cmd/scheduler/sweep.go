@@ -0,0 +1,228 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+
+	"github.com/jh125486/CSCE4600/Project1/render"
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+	"github.com/jh125486/CSCE4600/Project1/workload"
+)
+
+// Defaults for the sensitivity sweep: each list is one axis of the load-regime grid.
+const (
+	defaultSweepProcesses = 100
+	defaultSweepRuns      = 10
+	defaultSweepMetric    = "avg_wait"
+	defaultSweepGaps      = "2,5,10,20"
+	defaultSweepBursts    = "4,8,16"
+	defaultSweepCVs       = "0.5,1,2"
+)
+
+// higherIsBetter marks the metrics where the largest value wins; for the rest the smallest does.
+var higherIsBetter = map[string]bool{"throughput": true}
+
+// sweepConfig holds the parsed sweep command line.
+type sweepConfig struct {
+	config
+	processes int
+	gaps      []float64
+	bursts    []float64
+	cvs       []float64
+	metric    string
+}
+
+// parseSweepFlags parses `scheduler sweep [flags]`.
+func parseSweepFlags(errW io.Writer, args []string) (sweepConfig, error) {
+	sc := sweepConfig{config: defaultConfig(), processes: defaultSweepProcesses, metric: defaultSweepMetric}
+	sc.seeds = defaultSweepRuns
+	sc.gaps, _ = parseFloatList(defaultSweepGaps)
+	sc.bursts, _ = parseFloatList(defaultSweepBursts)
+	sc.cvs, _ = parseFloatList(defaultSweepCVs)
+
+	fs := flag.NewFlagSet("scheduler sweep", flag.ContinueOnError)
+	fs.SetOutput(errW)
+	fs.Usage = func() {
+		_, _ = fmt.Fprintln(errW, "usage: scheduler sweep [flags]")
+		_, _ = fmt.Fprintln(errW, "Runs every algorithm over a grid of arrival rates, burst means and burst variances and reports which wins where.")
+		fs.PrintDefaults()
+	}
+	fs.IntVar(&sc.processes, "n", defaultSweepProcesses, "processes per workload")
+	fs.IntVar(&sc.seeds, "m", defaultSweepRuns, "random workloads per grid cell (consecutive seeds)")
+	floats := func(name, usage string, dst *[]float64) {
+		fs.Func(name, usage, func(s string) (err error) {
+			*dst, err = parseFloatList(s)
+			return err
+		})
+	}
+	floats("gap", "comma-separated mean inter-arrival times; the arrival rate is 1/gap (default "+defaultSweepGaps+")", &sc.gaps)
+	floats("burst", "comma-separated mean burst durations (default "+defaultSweepBursts+")", &sc.bursts)
+	floats("cv", "comma-separated burst coefficients of variation, 0 for constant bursts (default "+defaultSweepCVs+")", &sc.cvs)
+	fs.StringVar(&sc.metric, "metric", defaultSweepMetric, "metric that decides the winner of each cell")
+	fs.Func("algorithms", "comma-separated algorithms to run (default all)", func(s string) (err error) {
+		sc.algorithms, err = parseAlgorithms(s)
+		return err
+	})
+	fs.IntVar(&sc.workers, "workers", defaultWorkers, "number of workloads simulated in parallel")
+	if err := fs.Parse(args); err != nil {
+		return sc, err
+	}
+
+	var err error
+	switch {
+	case fs.NArg() > 0:
+		err = fmt.Errorf("%w: sweep takes no arguments, got %q", scheduler.ErrInvalidArgs, fs.Args())
+	case sc.processes < 1:
+		err = fmt.Errorf("%w: -n must be at least 1", scheduler.ErrInvalidArgs)
+	case sc.seeds < 1:
+		err = fmt.Errorf("%w: -m must be at least 1", scheduler.ErrInvalidArgs)
+	case resultMetrics[sc.metric] == nil:
+		err = fmt.Errorf("%w: unknown metric %q (known: %s)", scheduler.ErrInvalidArgs, sc.metric, strings.Join(monteCarloMetrics(), ", "))
+	}
+	if err != nil {
+		_, _ = fmt.Fprintln(errW, err)
+		return sc, err
+	}
+	for _, g := range sc.gaps {
+		for _, b := range sc.bursts {
+			for _, cv := range sc.cvs {
+				sc.args = append(sc.args, sweepWorkload(sc.processes, g, b, cv))
+			}
+		}
+	}
+
+	return sc, nil
+}
+
+// parseFloatList parses a comma-separated list of non-negative numbers.
+func parseFloatList(s string) ([]float64, error) {
+	var fs []float64
+	for _, v := range strings.Split(s, ",") {
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil || f < 0 || math.IsInf(f, 0) {
+			return nil, fmt.Errorf("%w: %q is not a non-negative number", scheduler.ErrInvalidArgs, v)
+		}
+		fs = append(fs, f)
+	}
+
+	return fs, nil
+}
+
+// sweepWorkload is the generated workload family for one grid cell.
+func sweepWorkload(n int, gap, burst, cv float64) string {
+	spec := workload.GeneratedSpec{Kind: "lognormal", Params: map[string]float64{
+		"n": float64(n), "gap": gap, "burst": burst, "cv": cv,
+	}}
+
+	return spec.Workload()
+}
+
+// run simulates every cell of the grid and writes the matrix report to stdout.
+func (sc sweepConfig) run(stdout, errW io.Writer) error {
+	var (
+		opts   = sc.options(errW)
+		inputs = sc.inputs()
+	)
+	results, err := runPool(len(inputs), sc.workers, func(i int) ([]scheduler.Result, error) {
+		return sc.runWorkload(inputs[i], opts, nil)
+	})
+	if err != nil {
+		return err
+	}
+	outputSweep(stdout, sc.sweep(results))
+
+	return nil
+}
+
+// sweepCell is one load regime of the sweep: the mean of the metric per algorithm
+// and the algorithm with the best mean.
+type sweepCell struct {
+	gap, burst, cv float64
+	means          map[string]sampleStats
+	winner         string
+}
+
+// sweepReport is the matrix of cells, in grid order, and the algorithms compared.
+type sweepReport struct {
+	metric     string
+	algorithms []string
+	cells      []sweepCell
+}
+
+func (sc sweepConfig) sweep(results []scheduler.Result) sweepReport {
+	samples := make(map[string]map[string][]float64, len(sc.args))
+	for _, r := range results {
+		w := workload.Group(r.Input)
+		if samples[w] == nil {
+			samples[w] = make(map[string][]float64)
+		}
+		samples[w][r.Algorithm] = append(samples[w][r.Algorithm], resultMetrics[sc.metric](r))
+	}
+
+	report := sweepReport{metric: sc.metric}
+	for _, s := range sc.schedulers() {
+		report.algorithms = append(report.algorithms, s.Name)
+	}
+	for _, g := range sc.gaps {
+		for _, b := range sc.bursts {
+			for _, cv := range sc.cvs {
+				cell := sweepCell{gap: g, burst: b, cv: cv, means: make(map[string]sampleStats)}
+				best := 0.0
+				for _, algo := range report.algorithms {
+					s := newSampleStats(samples[sweepWorkload(sc.processes, g, b, cv)][algo])
+					cell.means[algo] = s
+					better := s.mean < best
+					if higherIsBetter[sc.metric] {
+						better = s.mean > best
+					}
+					if cell.winner == "" || better {
+						cell.winner, best = algo, s.mean
+					}
+				}
+				report.cells = append(report.cells, cell)
+			}
+		}
+	}
+
+	return report
+}
+
+// outputSweep prints one row per load regime with each algorithm's mean metric and the winner,
+// then how many regimes each algorithm won.
+func outputSweep(w io.Writer, report sweepReport) {
+	render.OutputTitle(w, "Sensitivity: "+report.metric)
+	table := tablewriter.NewWriter(w)
+	table.SetHeader(append(append([]string{"Gap", "Rate", "Burst", "CV"}, report.algorithms...), "Winner"))
+	table.SetAutoWrapText(false)
+	table.SetAutoMergeCellsByColumnIndex([]int{0, 1})
+	g := func(v float64) string { return strconv.FormatFloat(v, 'g', 4, 64) }
+	wins := make(map[string]int)
+	for _, c := range report.cells {
+		rate := "∞"
+		if c.gap > 0 {
+			rate = g(1 / c.gap)
+		}
+		row := []string{g(c.gap), rate, g(c.burst), g(c.cv)}
+		for _, algo := range report.algorithms {
+			row = append(row, c.means[algo].String())
+		}
+		table.Append(append(row, c.winner))
+		wins[c.winner]++
+	}
+	table.Render()
+
+	algos := append([]string(nil), report.algorithms...)
+	sort.SliceStable(algos, func(i, j int) bool { return wins[algos[i]] > wins[algos[j]] })
+	parts := make([]string, len(algos))
+	for i, algo := range algos {
+		parts[i] = fmt.Sprintf("%s %d", algo, wins[algo])
+	}
+	_, _ = fmt.Fprintf(w, "Wins (of %d regimes): %s\n", len(report.cells), strings.Join(parts, ", "))
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+func Test_parseSweepFlags(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		args     []string
+		wantArgs []string
+		wantErr  bool
+	}{
+		{
+			name: "grid",
+			args: []string{"-n", "20", "-gap", "2, 10", "-burst", "8", "-cv", "0,1"},
+			wantArgs: []string{
+				"gen:lognormal?burst=8&cv=0&gap=2&n=20",
+				"gen:lognormal?burst=8&cv=1&gap=2&n=20",
+				"gen:lognormal?burst=8&cv=0&gap=10&n=20",
+				"gen:lognormal?burst=8&cv=1&gap=10&n=20",
+			},
+		},
+		{name: "arguments", args: []string{"processes.csv"}, wantErr: true},
+		{name: "bad list", args: []string{"-gap", "1,x"}, wantErr: true},
+		{name: "negative", args: []string{"-cv", "-1"}, wantErr: true},
+		{name: "no processes", args: []string{"-n", "0"}, wantErr: true},
+		{name: "no runs", args: []string{"-m", "0"}, wantErr: true},
+		{name: "bad metric", args: []string{"-metric", "latency"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseSweepFlags(io.Discard, tt.args)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantArgs, got.args)
+			assert.Equal(t, defaultSweepRuns, got.seeds)
+		})
+	}
+}
+
+func Test_sweepConfig_sweep(t *testing.T) {
+	t.Parallel()
+	sc, err := parseSweepFlags(io.Discard, []string{"-n", "5", "-gap", "1,4", "-burst", "2", "-cv", "0", "-algorithms", "fcfs,sjf"})
+	require.NoError(t, err)
+	cell := func(gap string, seed string, algo string, wait, throughput float64) scheduler.Result {
+		return scheduler.Result{
+			Input:         "gen:lognormal?burst=2&cv=0&gap=" + gap + "&n=5&seed=" + seed,
+			Algorithm:     algo,
+			AveWait:       wait,
+			AveThroughput: throughput,
+		}
+	}
+	results := []scheduler.Result{
+		cell("1", "1", "fcfs", 10, 1), cell("1", "1", "sjf", 4, 2),
+		cell("1", "2", "fcfs", 12, 1), cell("1", "2", "sjf", 6, 2),
+		cell("4", "1", "fcfs", 1, 3), cell("4", "1", "sjf", 2, 1),
+	}
+
+	report := sc.sweep(results)
+	require.Len(t, report.cells, 2)
+	assert.Equal(t, []string{"fcfs", "sjf"}, report.algorithms)
+	assert.Equal(t, "sjf", report.cells[0].winner)
+	assert.Equal(t, 5.0, report.cells[0].means["sjf"].mean)
+	assert.Equal(t, 2, report.cells[0].means["sjf"].n)
+	assert.Equal(t, "fcfs", report.cells[1].winner)
+
+	sc.metric = "throughput"
+	report = sc.sweep(results)
+	assert.Equal(t, "sjf", report.cells[0].winner, "higher throughput wins")
+	assert.Equal(t, "fcfs", report.cells[1].winner)
+
+	var out bytes.Buffer
+	outputSweep(&out, report)
+	assert.Contains(t, out.String(), "Sensitivity: throughput")
+	assert.Contains(t, out.String(), "Wins (of 2 regimes): fcfs 1, sjf 1")
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+// exitThresholdFailed is the exit code when a --fail-if condition holds for any result.
+const exitThresholdFailed = 3
+
+var ErrInvalidThreshold = fmt.Errorf("%w: threshold must look like <metric><op><value>, e.g. avg_wait>50", scheduler.ErrInvalidArgs)
+
+// resultMetrics are the metric names a threshold may refer to.
+var resultMetrics = map[string]func(r scheduler.Result) float64{
+	"avg_wait":       func(r scheduler.Result) float64 { return r.AveWait },
+	"avg_turnaround": func(r scheduler.Result) float64 { return r.AveTurnaround },
+	"avg_response":   func(r scheduler.Result) float64 { return r.AveResponse },
+	"throughput":     func(r scheduler.Result) float64 { return r.AveThroughput },
+	"incomplete":     func(r scheduler.Result) float64 { return float64(r.Incomplete) },
+	"max_wait": func(r scheduler.Result) float64 {
+		return maxProcessMetric(r, func(p scheduler.ProcessResult) int64 { return p.Wait })
+	},
+	"max_turnaround": func(r scheduler.Result) float64 {
+		return maxProcessMetric(r, func(p scheduler.ProcessResult) int64 { return p.Turnaround })
+	},
+	"max_response": func(r scheduler.Result) float64 {
+		return maxProcessMetric(r, func(p scheduler.ProcessResult) int64 { return p.Response })
+	},
+	"makespan": func(r scheduler.Result) float64 {
+		return maxProcessMetric(r, func(p scheduler.ProcessResult) int64 { return p.Exit })
+	},
+	"deadline_misses": func(r scheduler.Result) float64 {
+		var n int
+		for _, p := range r.Processes {
+			if p.MissedDeadline() {
+				n++
+			}
+		}
+		return float64(n)
+	},
+	"max_lateness": func(r scheduler.Result) float64 {
+		var m int64
+		for _, p := range r.Processes {
+			if l := p.Lateness(); l > m {
+				m = l
+			}
+		}
+		return float64(m)
+	},
+	"sla_violations": func(r scheduler.Result) float64 {
+		if r.SLA == nil {
+			return 0
+		}
+		return float64(r.SLA.Violations)
+	},
+}
+
+// perProcessMetrics are computed from the schedule rows rather than the run's averages.
+var perProcessMetrics = map[string]bool{
+	"max_wait": true, "max_turnaround": true, "max_response": true, "makespan": true,
+	"deadline_misses": true, "max_lateness": true,
+}
+
+// maxProcessMetric is the largest value of metric over the completed processes, which, as for
+// the averages, leave out processes that never completed.
+func maxProcessMetric(r scheduler.Result, metric func(p scheduler.ProcessResult) int64) float64 {
+	var (
+		m    int64
+		seen bool
+	)
+	for _, p := range r.Processes {
+		if !p.Completed() {
+			continue
+		}
+		if v := metric(p); !seen || v > m {
+			m, seen = v, true
+		}
+	}
+
+	return float64(m)
+}
+
+// Operators are listed so two-character operators match before their one-character prefixes.
+var thresholdOps = []struct {
+	op      string
+	compare func(a, b float64) bool
+}{
+	{">=", func(a, b float64) bool { return a >= b }},
+	{"<=", func(a, b float64) bool { return a <= b }},
+	{"==", func(a, b float64) bool { return a == b }},
+	{"!=", func(a, b float64) bool { return a != b }},
+	{">", func(a, b float64) bool { return a > b }},
+	{"<", func(a, b float64) bool { return a < b }},
+}
+
+// threshold is a failure condition such as avg_wait>50.
+type threshold struct {
+	expr    string
+	metric  string
+	value   float64
+	compare func(a, b float64) bool
+}
+
+func parseThreshold(s string) (threshold, error) {
+	expr := strings.ReplaceAll(s, " ", "")
+	for _, o := range thresholdOps {
+		i := strings.Index(expr, o.op)
+		if i < 0 {
+			continue
+		}
+		metric := expr[:i]
+		if _, ok := resultMetrics[metric]; !ok {
+			return threshold{}, fmt.Errorf("%w: unknown metric %q (known: %s)", ErrInvalidThreshold, metric, knownMetrics())
+		}
+		value, err := strconv.ParseFloat(expr[i+len(o.op):], 64)
+		if err != nil {
+			return threshold{}, fmt.Errorf("%w: %q: %v", ErrInvalidThreshold, s, err)
+		}
+
+		return threshold{expr: expr, metric: metric, value: value, compare: o.compare}, nil
+	}
+
+	return threshold{}, fmt.Errorf("%w: got %q", ErrInvalidThreshold, s)
+}
+
+func knownMetrics() string {
+	names := make([]string, 0, len(resultMetrics))
+	for name := range resultMetrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return strings.Join(names, ", ")
+}
+
+// failed reports whether the condition holds for r, along with the metric's value.
+func (t threshold) failed(r scheduler.Result) (bool, float64) {
+	v := resultMetrics[t.metric](r)
+	return t.compare(v, t.value), v
+}
+
+// checkThresholds writes a line to w for every result that meets a failure condition
+// and reports whether any did.
+func checkThresholds(w io.Writer, thresholds []threshold, results []scheduler.Result) bool {
+	failed := false
+	for _, r := range results {
+		for _, t := range thresholds {
+			if ok, v := t.failed(r); ok {
+				failed = true
+				_, _ = fmt.Fprintf(w, "FAIL %s: %s=%.2f (fail if %s)\n", r.Algorithm, t.metric, v, t.expr)
+			}
+		}
+	}
+
+	return failed
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+func Test_parseThreshold(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		in         string
+		wantMetric string
+		wantValue  float64
+		wantErr    error
+	}{
+		{in: "avg_wait>50", wantMetric: "avg_wait", wantValue: 50},
+		{in: "throughput <= 0.5", wantMetric: "throughput", wantValue: 0.5},
+		{in: "max_wait>=10", wantMetric: "max_wait", wantValue: 10},
+		{in: "deadline_misses>0", wantMetric: "deadline_misses"},
+		{in: "incomplete!=0", wantMetric: "incomplete"},
+		{in: "bogus>1", wantErr: scheduler.ErrInvalidArgs},
+		{in: "avg_wait>fifty", wantErr: scheduler.ErrInvalidArgs},
+		{in: "avg_wait", wantErr: scheduler.ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.in, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseThreshold(tt.in)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if got.metric != tt.wantMetric || got.value != tt.wantValue {
+				t.Errorf("parseThreshold() = %s %v, want %s %v", got.metric, got.value, tt.wantMetric, tt.wantValue)
+			}
+		})
+	}
+}
+
+func Test_checkThresholds(t *testing.T) {
+	t.Parallel()
+	results := []scheduler.Result{
+		{Algorithm: "fcfs", AveWait: 3.33, Processes: []scheduler.ProcessResult{{Wait: 0}, {Wait: 8}}},
+		{Algorithm: "sjf", AveWait: 2.67, Processes: []scheduler.ProcessResult{{Wait: 0}, {Wait: 2}}},
+	}
+	tests := []struct {
+		name       string
+		exprs      []string
+		wantFailed bool
+		wantOut    string
+	}{
+		{
+			name:  "passes",
+			exprs: []string{"avg_wait>5"},
+		},
+		{
+			name:       "one violation",
+			exprs:      []string{"avg_wait>3", "max_wait>=8"},
+			wantFailed: true,
+			wantOut:    "FAIL fcfs: avg_wait=3.33 (fail if avg_wait>3)\nFAIL fcfs: max_wait=8.00 (fail if max_wait>=8)\n",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			thresholds := make([]threshold, len(tt.exprs))
+			for i, e := range tt.exprs {
+				var err error
+				if thresholds[i], err = parseThreshold(e); err != nil {
+					t.Fatal(err)
+				}
+			}
+			var w bytes.Buffer
+			if got := checkThresholds(&w, thresholds, results); got != tt.wantFailed {
+				t.Errorf("checkThresholds() = %v, want %v", got, tt.wantFailed)
+			}
+			if got := w.String(); got != tt.wantOut {
+				t.Errorf("output = %q, want %q", got, tt.wantOut)
+			}
+		})
+	}
+}
+
+func Test_resultMetrics_makespan(t *testing.T) {
+	t.Parallel()
+	r := scheduler.Result{Processes: []scheduler.ProcessResult{{ID: 1, Exit: 2}, {ID: 2, Exit: 7, Status: scheduler.StatusKilled}}}
+	if got := resultMetrics["makespan"](r); got != 2 {
+		t.Errorf("makespan = %v, want 2, ignoring the killed process", got)
+	}
+}
+
+func Test_failIf_deadlineMisses(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "deadlines.csv")
+	if err := os.WriteFile(path, []byte("1,5,0,0,0,0,0,0,0,3\n2,1,0,0,0,0,0,0,0,20\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := parseFlags(io.Discard, []string{"--fail-if", "deadline_misses>0", "--fail-if", "max_lateness>5", path})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.algorithms = []string{"fcfs"}
+	results, err := cfg.runWorkload(path, cfg.options(io.Discard), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var w bytes.Buffer
+	if !checkThresholds(&w, cfg.failIf, results) {
+		t.Error("checkThresholds() = false, want P1 to miss its deadline")
+	}
+	if got, want := w.String(), "FAIL fcfs: deadline_misses=1.00 (fail if deadline_misses>0)\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+	if _, err := parseFlags(io.Discard, []string{"--stream", "--fail-if", "deadline_misses>0", path}); err == nil {
+		t.Error("--stream --fail-if deadline_misses>0 error = nil, want per-process rows needed")
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jh125486/CSCE4600/Project1/render"
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+// webhookTimeout bounds each notification, so a slow endpoint cannot stall a batch.
+const webhookTimeout = 10 * time.Second
+
+type (
+	// webhookPayload is the JSON POSTed when a workload finishes. Text is a one-line summary,
+	// which chat webhooks (Slack, Mattermost, ...) display as the message.
+	webhookPayload struct {
+		Text       string                `json:"text"`
+		Input      string                `json:"input"`
+		Status     string                `json:"status"`
+		Error      string                `json:"error,omitempty"`
+		Results    []webhookSummary      `json:"results,omitempty"`
+		Provenance *scheduler.Provenance `json:"provenance,omitempty"`
+	}
+	webhookSummary struct {
+		Algorithm string `json:"algorithm"`
+		render.NDJSONSummary
+	}
+)
+
+// webhook notifies a URL of finished workloads. Failures are reported to errW and never
+// fail the run. A nil *webhook is valid and notifies nothing.
+type webhook struct {
+	url    string
+	client *http.Client
+	errW   io.Writer
+}
+
+func newWebhook(url string, errW io.Writer) *webhook {
+	if url == "" {
+		return nil
+	}
+
+	return &webhook{url: url, client: &http.Client{Timeout: webhookTimeout}, errW: errW}
+}
+
+// notify POSTs the summary of a finished workload, or its error.
+func (h *webhook) notify(input string, results []scheduler.Result, runErr error) {
+	if h == nil {
+		return
+	}
+	body, err := json.Marshal(newWebhookPayload(input, results, runErr))
+	if err != nil {
+		_, _ = fmt.Fprintf(h.errW, "webhook: %v\n", err)
+		return
+	}
+	resp, err := h.client.Post(h.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		_, _ = fmt.Fprintf(h.errW, "webhook: %v\n", err)
+		return
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		_, _ = fmt.Fprintf(h.errW, "webhook: %s returned %s\n", h.url, resp.Status)
+	}
+}
+
+func newWebhookPayload(input string, results []scheduler.Result, runErr error) webhookPayload {
+	p := webhookPayload{Input: input, Status: "ok"}
+	if runErr != nil {
+		p.Status, p.Error = "error", runErr.Error()
+		p.Text = fmt.Sprintf("scheduler: %s failed: %v", input, runErr)
+		return p
+	}
+	parts := make([]string, len(results))
+	for i, r := range results {
+		s := render.SummaryRecord(r).NDJSONSummary
+		p.Results = append(p.Results, webhookSummary{Algorithm: r.Algorithm, NDJSONSummary: *s})
+		parts[i] = fmt.Sprintf("%s avg wait %.2f, turnaround %.2f", r.Algorithm, r.AveWait, r.AveTurnaround)
+		if p.Provenance == nil {
+			p.Provenance = r.Provenance
+		}
+	}
+	p.Text = fmt.Sprintf("scheduler: %s finished: %s", input, strings.Join(parts, "; "))
+
+	return p
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jh125486/CSCE4600/Project1/render"
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+func Test_webhook_notify(t *testing.T) {
+	t.Parallel()
+	provenance := &scheduler.Provenance{Tool: "scheduler", Input: "a.csv"}
+	results := []scheduler.Result{
+		{Algorithm: "fcfs", Title: "First-come, first-serve", AveWait: 2, AveTurnaround: 6.5, AveThroughput: 0.25, Provenance: provenance},
+		{Algorithm: "sjf", Title: "Shortest-job-first", AveWait: 1, AveTurnaround: 5, Provenance: provenance},
+	}
+	tests := []struct {
+		name     string
+		status   int
+		results  []scheduler.Result
+		runErr   error
+		want     webhookPayload
+		wantErrW string
+	}{
+		{
+			name:    "finished",
+			status:  http.StatusOK,
+			results: results,
+			want: webhookPayload{
+				Text:   "scheduler: a.csv finished: fcfs avg wait 2.00, turnaround 6.50; sjf avg wait 1.00, turnaround 5.00",
+				Input:  "a.csv",
+				Status: "ok",
+				Results: []webhookSummary{
+					{Algorithm: "fcfs", NDJSONSummary: render.NDJSONSummary{Title: "First-come, first-serve", AveWait: 2, AveTurnaround: 6.5, AveThroughput: 0.25}},
+					{Algorithm: "sjf", NDJSONSummary: render.NDJSONSummary{Title: "Shortest-job-first", AveWait: 1, AveTurnaround: 5}},
+				},
+				Provenance: provenance,
+			},
+		},
+		{
+			name:   "failed",
+			status: http.StatusOK,
+			runErr: errors.New("a.csv: invalid process"),
+			want: webhookPayload{
+				Text:   "scheduler: a.csv failed: a.csv: invalid process",
+				Input:  "a.csv",
+				Status: "error",
+				Error:  "a.csv: invalid process",
+			},
+		},
+		{
+			name:     "endpoint error",
+			status:   http.StatusBadGateway,
+			results:  results[:1],
+			want:     newWebhookPayload("a.csv", results[:1], nil),
+			wantErrW: "502 Bad Gateway",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var got webhookPayload
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+				b, _ := io.ReadAll(r.Body)
+				assert.NoError(t, json.Unmarshal(b, &got))
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+
+			var errW bytes.Buffer
+			newWebhook(srv.URL, &errW).notify("a.csv", tt.results, tt.runErr)
+			assert.Equal(t, tt.want, got)
+			if tt.wantErrW == "" {
+				assert.Empty(t, errW.String())
+			} else {
+				assert.Contains(t, errW.String(), tt.wantErrW)
+			}
+		})
+	}
+}
+
+func Test_webhook_unreachable(t *testing.T) {
+	t.Parallel()
+	srv := httptest.NewServer(http.NotFoundHandler())
+	url := srv.URL
+	srv.Close()
+
+	var errW bytes.Buffer
+	newWebhook(url, &errW).notify("a.csv", nil, nil)
+	assert.Contains(t, errW.String(), "webhook:")
+
+	require.Nil(t, newWebhook("", &errW))
+	(*webhook)(nil).notify("a.csv", nil, nil)
+}
@@ -0,0 +1,202 @@
+// Package arrow reads and writes the Arrow IPC streaming format: an encapsulated Schema
+// message, then one RecordBatch message per batch, then an end-of-stream marker. Only what the
+// simulator produces and consumes is supported: non-nullable signed integer and UTF-8 columns
+// without compression or dictionaries.
+package arrow
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrMalformed is returned for an Arrow IPC stream that cannot be decoded.
+var ErrMalformed = errors.New("malformed Arrow IPC data")
+
+// Arrow IPC constants.
+const (
+	Continuation    = 0xffffffff
+	MetadataV5      = 4
+	HeaderSchema    = 1
+	HeaderBatch     = 3
+	TypeInt         = 2
+	TypeUtf8        = 5
+	FileMagic       = "ARROW1"
+	BufferAlignment = 8
+)
+
+// Column is one column of a record batch: int64 values or UTF-8 strings.
+type Column struct {
+	Name    string
+	Ints    []int64
+	Strings []string
+	UTF8    bool
+}
+
+// Writer writes an Arrow IPC stream of record batches sharing one schema.
+type Writer struct {
+	w      *bufio.Writer
+	schema []Column
+	wrote  bool
+}
+
+// NewWriter returns a Writer of record batches with the given schema; the columns carry only
+// their names and types.
+func NewWriter(w io.Writer, schema ...Column) *Writer {
+	return &Writer{w: bufio.NewWriter(w), schema: schema}
+}
+
+// writeSchema writes the schema message before the first batch.
+func (a *Writer) writeSchema() error {
+	if a.wrote {
+		return nil
+	}
+	a.wrote = true
+
+	return a.message(a.schemaMessage(), nil)
+}
+
+func (a *Writer) schemaMessage() fbTable {
+	fields := make(fbTables, len(a.schema))
+	for i, c := range a.schema {
+		typ, typeID := fbObject(fbTable{fbInt32(64), fbBool(true)}), uint8(TypeInt)
+		if c.UTF8 {
+			typ, typeID = fbTable{}, TypeUtf8
+		}
+		// Field: name, nullable, type_type, type, dictionary, children.
+		fields[i] = fbTable{fbString(c.Name), fbBool(false), fbUint8(typeID), typ, nil, fbTables{}}
+	}
+
+	// Message: version, header_type, header, bodyLength.
+	return fbTable{fbInt16(MetadataV5), fbUint8(HeaderSchema), fbTable{nil, fields}, fbInt64(0)}
+}
+
+// WriteBatch writes one record batch; every column must have the same number of values.
+func (a *Writer) WriteBatch(columns []Column) error {
+	if err := a.writeSchema(); err != nil {
+		return err
+	}
+
+	var (
+		body    []byte
+		nodes   []byte
+		buffers []byte
+		rows    int
+	)
+	buffer := func(data []byte) {
+		buffers = binary.LittleEndian.AppendUint64(buffers, uint64(len(body)))
+		buffers = binary.LittleEndian.AppendUint64(buffers, uint64(len(data)))
+		body = append(body, data...)
+		for len(body)%BufferAlignment != 0 {
+			body = append(body, 0)
+		}
+	}
+	for _, c := range columns {
+		rows = len(c.Ints)
+		if c.UTF8 {
+			rows = len(c.Strings)
+		}
+		nodes = binary.LittleEndian.AppendUint64(nodes, uint64(rows))
+		nodes = binary.LittleEndian.AppendUint64(nodes, 0) // null count
+		buffer(nil)                                        // validity, omitted as nothing is null
+		if c.UTF8 {
+			offsets := make([]byte, 0, 4*(rows+1))
+			var data []byte
+			offsets = binary.LittleEndian.AppendUint32(offsets, 0)
+			for _, s := range c.Strings {
+				data = append(data, s...)
+				offsets = binary.LittleEndian.AppendUint32(offsets, uint32(len(data)))
+			}
+			buffer(offsets)
+			buffer(data)
+			continue
+		}
+		values := make([]byte, 0, 8*rows)
+		for _, v := range c.Ints {
+			values = binary.LittleEndian.AppendUint64(values, uint64(v))
+		}
+		buffer(values)
+	}
+
+	// RecordBatch: length, nodes, buffers.
+	batch := fbTable{fbInt64(int64(rows)), fbStructs{size: 16, align: 8, data: nodes}, fbStructs{size: 16, align: 8, data: buffers}}
+	return a.message(fbTable{fbInt16(MetadataV5), fbUint8(HeaderBatch), batch, fbInt64(int64(len(body)))}, body)
+}
+
+// message writes an encapsulated message: continuation marker, metadata length, metadata
+// padded to 8 bytes, then the body.
+func (a *Writer) message(m fbTable, body []byte) error {
+	var b fbBuilder
+	meta := b.finish(m)
+	for (8+len(meta))%BufferAlignment != 0 {
+		meta = append(meta, 0)
+	}
+	var prefix [8]byte
+	binary.LittleEndian.PutUint32(prefix[:], Continuation)
+	binary.LittleEndian.PutUint32(prefix[4:], uint32(len(meta)))
+	_, _ = a.w.Write(prefix[:])
+	_, _ = a.w.Write(meta)
+	_, err := a.w.Write(body)
+
+	return err
+}
+
+// Close writes the end-of-stream marker (and the schema, if no batch was written).
+func (a *Writer) Close() error {
+	if err := a.writeSchema(); err != nil {
+		return err
+	}
+	var eos [8]byte
+	binary.LittleEndian.PutUint32(eos[:], Continuation)
+	_, _ = a.w.Write(eos[:])
+
+	return a.w.Flush()
+}
+
+// maxArrowMetadata bounds a message's metadata, which is only ever a few hundred bytes.
+const maxArrowMetadata = 1 << 20
+
+// ReadMessage reads one encapsulated IPC message, returning io.EOF at the end of the stream.
+// Oversized metadata is ErrMalformed; otherwise malformed metadata panics with ErrBounds when
+// read, which the caller recovers.
+func ReadMessage(r io.Reader) (meta, body []byte, err error) {
+	var prefix [4]byte
+	if _, err := io.ReadFull(r, prefix[:]); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil, io.EOF
+		}
+		return nil, nil, fmt.Errorf("%w: reading Arrow IPC", err)
+	}
+	size := binary.LittleEndian.Uint32(prefix[:])
+	if size == Continuation {
+		if _, err := io.ReadFull(r, prefix[:]); err != nil {
+			return nil, nil, fmt.Errorf("%w: reading Arrow IPC", err)
+		}
+		size = binary.LittleEndian.Uint32(prefix[:])
+	}
+	if size == 0 {
+		return nil, nil, io.EOF
+	}
+	if size > maxArrowMetadata {
+		return nil, nil, fmt.Errorf("%w: message metadata of %d bytes", ErrMalformed, size)
+	}
+	meta = make([]byte, size)
+	if _, err := io.ReadFull(r, meta); err != nil {
+		return nil, nil, fmt.Errorf("%w: reading Arrow IPC", err)
+	}
+
+	m := Reader(meta)
+	if n := m.Scalar(m.Root(), 3, 8); n > 0 {
+		// Read through a limit rather than allocating n up front, so a corrupt length fails fast.
+		if body, err = io.ReadAll(io.LimitReader(r, n)); err != nil {
+			return nil, nil, fmt.Errorf("%w: reading Arrow IPC", err)
+		}
+		if int64(len(body)) != n {
+			return nil, nil, fmt.Errorf("%w: reading Arrow IPC", io.ErrUnexpectedEOF)
+		}
+	}
+
+	return meta, body, nil
+}
@@ -0,0 +1,264 @@
+package arrow
+
+import (
+	"encoding/binary"
+	"errors"
+	"sort"
+)
+
+// This file holds just enough FlatBuffers to read and write Arrow IPC metadata without a
+// dependency. The builder writes front to back: a table is laid out before the strings,
+// vectors and tables it refers to, so every offset points forward as the format requires.
+
+type (
+	// fbObject is anything a FlatBuffers offset can refer to.
+	fbObject interface {
+		write(b *fbBuilder) int
+	}
+	// fbTable is a table; the index is the field ID and nil fields are absent.
+	fbTable []fbField
+	// fbField is a table field: an inline scalar of size bytes, or an offset to ref.
+	fbField  interface{}
+	fbScalar struct {
+		size int
+		v    uint64
+	}
+	// fbString is a string.
+	fbString string
+	// fbTables is a vector of tables.
+	fbTables []fbObject
+	// fbStructs is a vector of structs of size bytes, whose elements start aligned to align.
+	fbStructs struct {
+		size  int
+		align int
+		data  []byte
+	}
+)
+
+func fbBool(v bool) fbScalar {
+	if v {
+		return fbScalar{size: 1, v: 1}
+	}
+	return fbScalar{size: 1}
+}
+
+func fbUint8(v uint8) fbScalar { return fbScalar{size: 1, v: uint64(v)} }
+
+func fbInt16(v int16) fbScalar { return fbScalar{size: 2, v: uint64(v)} }
+
+func fbInt32(v int32) fbScalar { return fbScalar{size: 4, v: uint64(uint32(v))} }
+
+func fbInt64(v int64) fbScalar { return fbScalar{size: 8, v: uint64(v)} }
+
+type fbBuilder struct {
+	buf []byte
+}
+
+// finish lays out root and returns the finished buffer.
+func (b *fbBuilder) finish(root fbObject) []byte {
+	b.buf = append(b.buf[:0], 0, 0, 0, 0)
+	pos := root.write(b)
+	binary.LittleEndian.PutUint32(b.buf, uint32(pos))
+
+	return b.buf
+}
+
+func (b *fbBuilder) align(n int) {
+	for len(b.buf)%n != 0 {
+		b.buf = append(b.buf, 0)
+	}
+}
+
+func (b *fbBuilder) reserve(n int) int {
+	pos := len(b.buf)
+	b.buf = append(b.buf, make([]byte, n)...)
+
+	return pos
+}
+
+// patch points the offset at pos to the object at target.
+func (b *fbBuilder) patch(pos, target int) {
+	binary.LittleEndian.PutUint32(b.buf[pos:], uint32(target-pos))
+}
+
+func (t fbTable) write(b *fbBuilder) int {
+	// Lay the fields out largest first after the vtable offset, so each is naturally aligned.
+	type slot struct{ id, size, off int }
+	slots := make([]slot, 0, len(t))
+	for id, f := range t {
+		switch f := f.(type) {
+		case nil:
+		case fbScalar:
+			slots = append(slots, slot{id: id, size: f.size})
+		default:
+			slots = append(slots, slot{id: id, size: 4})
+		}
+	}
+	sort.SliceStable(slots, func(i, j int) bool { return slots[i].size > slots[j].size })
+	size := 4
+	for i := range slots {
+		for size%slots[i].size != 0 {
+			size++
+		}
+		slots[i].off = size
+		size += slots[i].size
+	}
+
+	b.align(2)
+	vtable := b.reserve(4 + 2*len(t))
+	binary.LittleEndian.PutUint16(b.buf[vtable:], uint16(4+2*len(t)))
+	binary.LittleEndian.PutUint16(b.buf[vtable+2:], uint16(size))
+	for _, s := range slots {
+		binary.LittleEndian.PutUint16(b.buf[vtable+4+2*s.id:], uint16(s.off))
+	}
+
+	b.align(8)
+	table := b.reserve(size)
+	binary.LittleEndian.PutUint32(b.buf[table:], uint32(int32(table-vtable)))
+	for _, s := range slots {
+		if f, ok := t[s.id].(fbScalar); ok {
+			for i := 0; i < f.size; i++ {
+				b.buf[table+s.off+i] = byte(f.v >> (8 * i))
+			}
+		}
+	}
+	for _, s := range slots {
+		if ref, ok := t[s.id].(fbObject); ok {
+			b.patch(table+s.off, ref.write(b))
+		}
+	}
+
+	return table
+}
+
+func (s fbString) write(b *fbBuilder) int {
+	b.align(4)
+	pos := b.reserve(4)
+	binary.LittleEndian.PutUint32(b.buf[pos:], uint32(len(s)))
+	b.buf = append(append(b.buf, s...), 0)
+
+	return pos
+}
+
+func (v fbTables) write(b *fbBuilder) int {
+	b.align(4)
+	pos := b.reserve(4 + 4*len(v))
+	binary.LittleEndian.PutUint32(b.buf[pos:], uint32(len(v)))
+	for i, t := range v {
+		elem := pos + 4 + 4*i
+		b.patch(elem, t.write(b))
+	}
+
+	return pos
+}
+
+func (v fbStructs) write(b *fbBuilder) int {
+	b.align(4)
+	for (len(b.buf)+4)%v.align != 0 {
+		b.buf = append(b.buf, 0)
+	}
+	pos := b.reserve(4)
+	binary.LittleEndian.PutUint32(b.buf[pos:], uint32(len(v.data)/v.size))
+	b.buf = append(b.buf, v.data...)
+
+	return pos
+}
+
+// ErrBounds is the panic value of a read outside the buffer.
+var ErrBounds = errors.New("flatbuffer offset out of range")
+
+// Reader reads tables from a FlatBuffers buffer. Out-of-range offsets panic with
+// ErrBounds, which the decoding entry point recovers into an error.
+type Reader []byte
+
+// Check panics with ErrBounds unless n bytes from pos lie inside the buffer.
+func (r Reader) Check(pos, n int) {
+	if pos < 0 || n < 0 || pos+n > len(r) {
+		panic(ErrBounds)
+	}
+}
+
+// Uint16 reads a little-endian uint16 at pos.
+func (r Reader) Uint16(pos int) int {
+	r.Check(pos, 2)
+	return int(binary.LittleEndian.Uint16(r[pos:]))
+}
+
+// Uint32 reads a little-endian uint32 at pos.
+func (r Reader) Uint32(pos int) int {
+	r.Check(pos, 4)
+	return int(binary.LittleEndian.Uint32(r[pos:]))
+}
+
+// Int64 reads a little-endian int64 at pos.
+func (r Reader) Int64(pos int) int64 {
+	r.Check(pos, 8)
+	return int64(binary.LittleEndian.Uint64(r[pos:]))
+}
+
+// Root returns the position of the root table.
+func (r Reader) Root() int {
+	return r.Uint32(0)
+}
+
+// Field returns the position of a table field, or 0 if it is absent.
+func (r Reader) Field(table, id int) int {
+	vtable := table - int(int32(r.Uint32(table)))
+	if 4+2*id >= r.Uint16(vtable) {
+		return 0
+	}
+	off := r.Uint16(vtable + 4 + 2*id)
+	if off == 0 {
+		return 0
+	}
+
+	return table + off
+}
+
+// Ref follows the offset field at pos, returning 0 if the field is absent.
+func (r Reader) Ref(pos int) int {
+	if pos == 0 {
+		return 0
+	}
+	return pos + r.Uint32(pos)
+}
+
+// Vector returns the position of the first element of a vector field and its length.
+func (r Reader) Vector(table, id int) (int, int) {
+	pos := r.Ref(r.Field(table, id))
+	if pos == 0 {
+		return 0, 0
+	}
+
+	return pos + 4, r.Uint32(pos)
+}
+
+// Text returns a string field of a table, or "" if it is absent.
+func (r Reader) Text(table, id int) string {
+	pos := r.Ref(r.Field(table, id))
+	if pos == 0 {
+		return ""
+	}
+	n := r.Uint32(pos)
+	r.Check(pos+4, n)
+
+	return string(r[pos+4 : pos+4+n])
+}
+
+// Scalar returns a little-endian integer field of size bytes, or 0 if it is absent.
+func (r Reader) Scalar(table, id, size int) int64 {
+	pos := r.Field(table, id)
+	if pos == 0 {
+		return 0
+	}
+	r.Check(pos, size)
+	var v uint64
+	for i := 0; i < size; i++ {
+		v |= uint64(r[pos+i]) << (8 * i)
+	}
+	if shift := 64 - 8*size; shift > 0 { // sign-extend
+		return int64(v<<shift) >> shift
+	}
+
+	return int64(v)
+}
@@ -0,0 +1,25 @@
+package arrow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_fbBuilder(t *testing.T) {
+	t.Parallel()
+	var b fbBuilder
+	buf := b.finish(fbTable{fbInt16(-2), nil, fbString("hi"), fbInt64(1 << 40), fbTables{fbTable{fbBool(true)}}})
+	r := Reader(buf)
+	root := r.Root()
+	assert.Zero(t, root%8, "tables are 8-byte aligned")
+	assert.Equal(t, int64(-2), r.Scalar(root, 0, 2))
+	assert.Zero(t, r.Field(root, 1))
+	assert.Equal(t, "hi", r.Text(root, 2))
+	assert.Equal(t, int64(1<<40), r.Scalar(root, 3, 8))
+	assert.Zero(t, r.Field(root, 9), "fields past the vtable are absent")
+	start, n := r.Vector(root, 4)
+	require.Equal(t, 1, n)
+	assert.Equal(t, int64(1), r.Scalar(r.Ref(start), 0, 1))
+}
@@ -0,0 +1,41 @@
+package render
+
+import (
+	"io"
+
+	"github.com/jh125486/CSCE4600/Project1/internal/arrow"
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+// arrowResultSchema is the per-process result columns, the same as the CSV columns: the input,
+// algorithm and status are strings.
+var arrowResultSchema = func() []arrow.Column {
+	schema := make([]arrow.Column, len(csvHeader))
+	for i, name := range csvHeader {
+		schema[i] = arrow.Column{Name: name, UTF8: i < 2 || i == len(csvHeader)-1}
+	}
+	return schema
+}()
+
+// writeArrow renders results as an Arrow IPC stream with one record batch per result.
+func writeArrow(w io.Writer, results []scheduler.Result) error {
+	aw := arrow.NewWriter(w, arrowResultSchema...)
+	for _, r := range results {
+		columns := make([]arrow.Column, len(arrowResultSchema))
+		copy(columns, arrowResultSchema)
+		for _, p := range r.Processes {
+			columns[0].Strings = append(columns[0].Strings, r.Input)
+			columns[1].Strings = append(columns[1].Strings, r.Algorithm)
+			for i, v := range [...]int64{p.ID, p.Priority, p.Burst, p.Arrival, p.Wait, p.Turnaround, p.Exit, p.Response} {
+				columns[2+i].Ints = append(columns[2+i].Ints, v)
+			}
+			status := &columns[len(columns)-1]
+			status.Strings = append(status.Strings, string(p.Status))
+		}
+		if err := aw.WriteBatch(columns); err != nil {
+			return err
+		}
+	}
+
+	return aw.Close()
+}
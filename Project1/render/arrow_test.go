@@ -0,0 +1,41 @@
+package render
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jh125486/CSCE4600/Project1/internal/arrow"
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+	"github.com/jh125486/CSCE4600/Project1/workload"
+)
+
+func Test_writeArrow_roundTrip(t *testing.T) {
+	t.Parallel()
+	results := []scheduler.Result{
+		{Algorithm: "fcfs", Input: "a.csv", Processes: []scheduler.ProcessResult{
+			{ID: 1, Priority: 2, Burst: 5, Exit: 5, Turnaround: 5},
+			{ID: 2, Priority: 1, Burst: 3, Arrival: 1, Wait: 4, Turnaround: 7, Exit: 8},
+		}},
+		{Algorithm: "sjf", Input: "a.csv", Processes: []scheduler.ProcessResult{{ID: 7, Priority: -3, Burst: 1 << 40, Arrival: 9, Status: scheduler.StatusKilled}}},
+	}
+	var b bytes.Buffer
+	require.NoError(t, writeArrow(&b, results))
+	assert.Zero(t, b.Len()%arrow.BufferAlignment, "messages are 8-byte aligned")
+
+	load := workload.ImporterFor("arrow", "")
+	got, err := load(bytes.NewReader(b.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, []scheduler.Process{
+		{ProcessID: 1, Priority: 2, BurstDuration: 5},
+		{ProcessID: 2, Priority: 1, BurstDuration: 3, ArrivalTime: 1},
+		{ProcessID: 7, Priority: -3, BurstDuration: 1 << 40, ArrivalTime: 9},
+	}, got)
+
+	file := append([]byte(arrow.FileMagic+"\x00\x00"), b.Bytes()...)
+	got, err = load(bytes.NewReader(file))
+	require.NoError(t, err)
+	assert.Len(t, got, 3)
+}
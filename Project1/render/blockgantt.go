@@ -0,0 +1,164 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+// GanttStyle is how text output draws Gantt charts.
+type GanttStyle string
+
+const (
+	// GanttClassic is one fixed-width cell per slice, with start times beneath.
+	GanttClassic GanttStyle = "classic"
+	// GanttBlocks draws slices as runs of block characters sized by duration.
+	GanttBlocks GanttStyle = "blocks"
+)
+
+var ErrInvalidGanttStyle = fmt.Errorf("%w: Gantt style must be classic or blocks", scheduler.ErrInvalidArgs)
+
+// ParseGanttStyle parses a --gantt-style name, ignoring case.
+func ParseGanttStyle(s string) (GanttStyle, error) {
+	switch g := GanttStyle(strings.ToLower(s)); g {
+	case GanttClassic, GanttBlocks:
+		return g, nil
+	default:
+		return "", fmt.Errorf("%w: got %q", ErrInvalidGanttStyle, s)
+	}
+}
+
+// blockGanttWidth is the number of columns a block Gantt chart spans from 0 to the makespan.
+const blockGanttWidth = 60
+
+// blockShades alternate between neighbouring slices so their boundary shows without a separator.
+var blockShades = []rune("█▓▒")
+
+// reservedShade draws reserved time, lighter than any process.
+const reservedShade = '░'
+
+// outputBlockGantt prints the Gantt chart as block characters, one lane per CPU, each slice as
+// wide as its share of the makespan (at least one column) with its PID above it and the slice
+// boundary times below the last lane. Labels that would overlap the previous one are dropped.
+// Idle time is blank and reserved time is light shade.
+func outputBlockGantt(w io.Writer, gantt []scheduler.TimeSlice, unit scheduler.TimeUnit) {
+	_, _ = fmt.Fprintln(w, unit.Label("Gantt schedule"))
+	slices := chartSlices(gantt)
+	var (
+		cpus     int
+		makespan int64
+	)
+	for _, s := range gantt {
+		if s.Stop > makespan {
+			makespan = s.Stop
+		}
+	}
+	for _, s := range slices {
+		if s.CPU+1 > cpus {
+			cpus = s.CPU + 1
+		}
+	}
+	if makespan == 0 || cpus == 0 {
+		_, _ = fmt.Fprintln(w)
+		return
+	}
+	column := func(t int64) int {
+		return int(float64(t)*blockGanttWidth/float64(makespan) + 0.5)
+	}
+	prefix := func(cpu int) string {
+		if cpus == 1 {
+			return ""
+		}
+		if cpu < 0 {
+			return strings.Repeat(" ", len("CPU ")+len(strconv.Itoa(cpus-1))+1)
+		}
+		return fmt.Sprintf("CPU %-*d ", len(strconv.Itoa(cpus-1)), cpu)
+	}
+
+	var (
+		lanes = make([][]scheduler.TimeSlice, cpus)
+		times = make(map[int64]int) // the column of every slice boundary
+	)
+	for _, s := range slices {
+		lanes[s.CPU] = append(lanes[s.CPU], s)
+	}
+	for cpu, lane := range lanes {
+		sort.SliceStable(lane, func(i, j int) bool { return lane[i].Start < lane[j].Start })
+		var (
+			labels, bars textLine
+			end          int
+		)
+		for i, s := range lane {
+			start := column(s.Start)
+			if start < end {
+				start = end
+			}
+			end = column(s.Stop)
+			if end <= start {
+				end = start + 1
+			}
+			shade, label := blockShades[i%len(blockShades)], "P"+strconv.FormatInt(s.PID, 10)
+			if s.Reserved {
+				shade, label = reservedShade, "RSVD"
+			}
+			bars.put(start, strings.Repeat(string(shade), end-start))
+			labels.label(start, label)
+			if c, ok := times[s.Start]; !ok || start < c {
+				times[s.Start] = start
+			}
+			if c, ok := times[s.Stop]; !ok || end > c {
+				times[s.Stop] = end
+			}
+		}
+		_, _ = fmt.Fprintln(w, prefix(-1)+labels.String())
+		_, _ = fmt.Fprintln(w, prefix(cpu)+bars.String())
+	}
+
+	boundaries := make([]int64, 0, len(times))
+	for t := range times {
+		boundaries = append(boundaries, t)
+	}
+	sort.Slice(boundaries, func(i, j int) bool { return boundaries[i] < boundaries[j] })
+	var axis textLine
+	for _, t := range boundaries {
+		axis.label(times[t], strconv.FormatInt(t, 10))
+	}
+	_, _ = fmt.Fprintf(w, "%s%s\n\n", prefix(-1), axis.String())
+}
+
+// textLine is a line of monospace text built by placing strings at columns.
+type textLine struct {
+	cells []rune
+	next  int // the first column a label may start at
+}
+
+// put writes s starting at column col, padding with spaces as needed.
+func (l *textLine) put(col int, s string) {
+	for len(l.cells) < col {
+		l.cells = append(l.cells, ' ')
+	}
+	for i, r := range []rune(s) {
+		if col+i < len(l.cells) {
+			l.cells[col+i] = r
+		} else {
+			l.cells = append(l.cells, r)
+		}
+	}
+}
+
+// label writes s at column col unless it would run into the previous label.
+func (l *textLine) label(col int, s string) {
+	if col < l.next {
+		return
+	}
+	l.put(col, s)
+	l.next = col + len([]rune(s)) + 1
+}
+
+func (l *textLine) String() string {
+	return strings.TrimRight(string(l.cells), " ")
+}
@@ -0,0 +1,70 @@
+package render
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+func Test_outputBlockGantt(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		gantt []scheduler.TimeSlice
+		want  string
+	}{
+		{
+			name:  "proportional slices",
+			gantt: []scheduler.TimeSlice{{PID: 1, Start: 0, Stop: 30}, {PID: 2, Start: 30, Stop: 45}, {PID: 3, Start: 45, Stop: 60}},
+			want: "Gantt schedule\n" +
+				"P1                            P2             P3\n" +
+				"██████████████████████████████▓▓▓▓▓▓▓▓▓▓▓▓▓▓▓▒▒▒▒▒▒▒▒▒▒▒▒▒▒▒\n" +
+				"0                             30             45             60\n\n",
+		},
+		{
+			name:  "reserved window",
+			gantt: []scheduler.TimeSlice{{PID: 1, Start: 0, Stop: 30}, {Idle: true, Reserved: true, Start: 30, Stop: 60}},
+			want: "Gantt schedule\n" +
+				"P1                            RSVD\n" +
+				"██████████████████████████████░░░░░░░░░░░░░░░░░░░░░░░░░░░░░░\n" +
+				"0                             30                            60\n\n",
+		},
+		{
+			name: "idle gaps, short slices and a lane per CPU",
+			gantt: []scheduler.TimeSlice{{Idle: true, Start: 0, Stop: 10}, {PID: 1, Start: 10, Stop: 11}, {PID: 22, Start: 11, Stop: 12},
+				{PID: 3, CPU: 1, Start: 0, Stop: 60}},
+			want: "Gantt schedule (ms)\n" +
+				"                P1\n" +
+				"CPU 0           █▓\n" +
+				"      P3\n" +
+				"CPU 1 ████████████████████████████████████████████████████████████\n" +
+				"      0         10                                                60\n\n",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var b bytes.Buffer
+			unit := scheduler.TimeUnitTicks
+			if len(tt.gantt) > 3 {
+				unit = scheduler.TimeUnitMillis
+			}
+			outputBlockGantt(&b, tt.gantt, unit)
+			assert.Equal(t, tt.want, b.String())
+		})
+	}
+}
+
+func Test_writeTextWith_blocks(t *testing.T) {
+	t.Parallel()
+	r := scheduler.Result{Title: "FCFS", Gantt: []scheduler.TimeSlice{{PID: 1, Start: 0, Stop: 2}}, Processes: []scheduler.ProcessResult{{ID: 1, Burst: 2, Turnaround: 2, Exit: 2}}}
+	var classic, blocks bytes.Buffer
+	writeText(&classic, r, scheduler.TimeUnitTicks)
+	writeTextWith(&blocks, r, scheduler.TimeUnitTicks, TextOptions{Gantt: GanttBlocks})
+	assert.NotContains(t, classic.String(), "█")
+	assert.Contains(t, blocks.String(), "████")
+}
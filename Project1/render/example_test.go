@@ -0,0 +1,52 @@
+package render_test
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jh125486/CSCE4600/Project1/render"
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+var processes = []scheduler.Process{
+	{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+	{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+	{ProcessID: 3, ArrivalTime: 6, BurstDuration: 6, Priority: 3},
+}
+
+func ExampleWriteResults() {
+	r := scheduler.FCFS("First-come, first-serve", processes)
+	r.Algorithm, r.Input = "fcfs", "example.csv"
+	out := render.Output{Pattern: render.StdoutPath, Format: render.FormatCSV}
+	if err := render.WriteResults(os.Stdout, out, []scheduler.Result{r}, scheduler.TimeUnitTicks); err != nil {
+		fmt.Println(err)
+	}
+	// Output:
+	// input,algorithm,id,priority,burst,arrival,wait,turnaround,exit,response,status
+	// example.csv,fcfs,1,2,5,0,0,5,5,0,
+	// example.csv,fcfs,2,1,9,3,2,11,14,2,
+	// example.csv,fcfs,3,3,6,6,8,14,20,8,
+}
+
+func ExampleFCFSSchedule() {
+	render.FCFSSchedule(os.Stdout, "First-come, first-serve", processes, scheduler.WithTimeUnit(scheduler.TimeUnitMillis))
+	// Output:
+	// ----------------------------------------------
+	//             First-come, first-serve
+	// ----------------------------------------------
+	// Gantt schedule (ms)
+	// |   1   |   2   |   3   |
+	// 0	5	14	20
+	//
+	// Schedule table
+	// +----+----------+------------+--------------+-----------+-----------------+-----------------+
+	// | ID | PRIORITY | BURST (MS) | ARRIVAL (MS) | WAIT (MS) | TURNAROUND (MS) |    EXIT (MS)    |
+	// +----+----------+------------+--------------+-----------+-----------------+-----------------+
+	// |  1 |        2 |          5 |            0 |         0 |               5 |               5 |
+	// |  2 |        1 |          9 |            3 |         2 |              11 |              14 |
+	// |  3 |        3 |          6 |            6 |         8 |              14 |              20 |
+	// +----+----------+------------+--------------+-----------+-----------------+-----------------+
+	// |                                              AVERAGE  |     AVERAGE     |   THROUGHPUT    |
+	// |                                               3.33    |      10.00      | 150.00 JOBS/SEC |
+	// +----+----------+------------+--------------+-----------+-----------------+-----------------+
+}
@@ -0,0 +1,213 @@
+package render
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+// Animated GIF geometry, in pixels, and timing, in hundredths of a second.
+const (
+	gifWidth     = 640
+	gifLane      = 28
+	gifLeft      = 52 // room for the CPU labels
+	gifRight     = 12
+	gifTop       = 24 // room for the clock
+	gifAxis      = 24
+	gifMaxFrames = 200
+	gifDelay     = 50
+	gifHold      = 300 // the finished schedule stays up before the animation loops
+)
+
+// Palette indexes of the fixed colors; process colors follow.
+const (
+	gifBackground = iota
+	gifInk
+	gifGrid
+	gifProcesses
+)
+
+// gifGlyphs is a 3x5 pixel font covering the labels the animation draws: PIDs, CPU numbers
+// and times. Each row is three bits, most significant on the left.
+var gifGlyphs = map[rune][5]uint8{
+	'0': {7, 5, 5, 5, 7}, '1': {2, 6, 2, 2, 7}, '2': {7, 1, 7, 4, 7}, '3': {7, 1, 7, 1, 7},
+	'4': {5, 5, 7, 1, 1}, '5': {7, 4, 7, 1, 7}, '6': {7, 4, 7, 5, 7}, '7': {7, 1, 1, 1, 1},
+	'8': {7, 5, 7, 5, 7}, '9': {7, 5, 7, 1, 7}, 'P': {7, 5, 7, 4, 4}, 'C': {7, 4, 4, 4, 7},
+	'U': {5, 5, 5, 5, 7}, 't': {4, 7, 4, 4, 3}, '=': {0, 7, 0, 7, 0}, '-': {0, 0, 7, 0, 0},
+}
+
+// gifScale is how many pixels each font pixel covers.
+const gifScale = 2
+
+// gifTextWidth is the width in pixels of s in the GIF font.
+func gifTextWidth(s string) int {
+	return len(s) * 4 * gifScale
+}
+
+// gifText draws s with its top left corner at (x, y); characters missing from the font are
+// left blank.
+func gifText(img *image.Paletted, x, y int, s string, c uint8) {
+	for _, r := range s {
+		glyph := gifGlyphs[r]
+		for row, bits := range glyph {
+			for col := 0; col < 3; col++ {
+				if bits&(4>>col) != 0 {
+					gifFill(img, x+col*gifScale, y+row*gifScale, gifScale, gifScale, c)
+				}
+			}
+		}
+		x += 4 * gifScale
+	}
+}
+
+// gifFill fills a w by h rectangle with its top left corner at (x, y).
+func gifFill(img *image.Paletted, x, y, w, h int, c uint8) {
+	for py := y; py < y+h; py++ {
+		for px := x; px < x+w; px++ {
+			img.SetColorIndex(px, py, c)
+		}
+	}
+}
+
+// gifEvents are the times the animation shows: the start and every slice boundary up to the
+// makespan, thinned evenly to at most max frames, always keeping the last.
+func gifEvents(slices []scheduler.TimeSlice, max int) []int64 {
+	set := map[int64]bool{0: true}
+	for _, s := range slices {
+		set[s.Start] = true
+		set[s.Stop] = true
+	}
+	events := make([]int64, 0, len(set))
+	for t := range set {
+		events = append(events, t)
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i] < events[j] })
+	if len(events) <= max {
+		return events
+	}
+	thinned := make([]int64, max)
+	for i := range thinned {
+		thinned[i] = events[i*(len(events)-1)/(max-1)]
+	}
+
+	return thinned
+}
+
+// newGIF animates a Gantt chart, one frame per scheduling event: each frame shows the slices
+// run up to that time in one lane per CPU, with the current time marked and printed. It is nil
+// when the scheduler recorded no slices.
+func newGIF(gantt []scheduler.TimeSlice) *gif.GIF {
+	slices := scheduler.BusySlices(gantt)
+	if len(slices) == 0 {
+		return nil
+	}
+	var (
+		cpus     int
+		makespan int64
+		pids     = make(map[int64]uint8)
+		pal      = color.Palette{color.White, color.Black, color.Gray{Y: 0xb0}}
+	)
+	for _, s := range slices {
+		if s.CPU+1 > cpus {
+			cpus = s.CPU + 1
+		}
+		if s.Stop > makespan {
+			makespan = s.Stop
+		}
+		if _, ok := pids[s.PID]; !ok {
+			if len(pal) < 256 {
+				rgb := pidColor(s.PID)
+				pal = append(pal, color.RGBA{R: uint8(rgb[0] * 255), G: uint8(rgb[1] * 255), B: uint8(rgb[2] * 255), A: 0xff})
+			}
+			// Past 253 processes colors repeat.
+			pids[s.PID] = uint8(gifProcesses + (len(pids) % (len(pal) - gifProcesses)))
+		}
+	}
+	var (
+		height = gifTop + cpus*gifLane + gifAxis
+		bounds = image.Rect(0, 0, gifWidth, height)
+		scale  = float64(gifWidth-gifLeft-gifRight) / float64(makespan)
+		x      = func(t int64) int { return gifLeft + int(float64(t)*scale+0.5) }
+		bottom = gifTop + cpus*gifLane
+		step   = niceStep(makespan, 10)
+	)
+
+	// Everything but the slices and the clock is the same in every frame.
+	background := image.NewPaletted(bounds, pal)
+	gifFill(background, 0, 0, gifWidth, height, gifBackground)
+	for cpu := 0; cpu < cpus; cpu++ {
+		gifText(background, 4, gifTop+cpu*gifLane+(gifLane-5*gifScale)/2, "CPU"+strconv.Itoa(cpu), gifInk)
+	}
+	gifFill(background, gifLeft, bottom, gifWidth-gifLeft-gifRight, 1, gifInk)
+	for t := int64(0); t <= makespan; t += step {
+		label := strconv.FormatInt(t, 10)
+		gifFill(background, x(t), gifTop, 1, cpus*gifLane, gifGrid)
+		gifFill(background, x(t), bottom, 1, 4, gifInk)
+		gifText(background, x(t)-gifTextWidth(label)/2, bottom+7, label, gifInk)
+	}
+
+	anim := &gif.GIF{}
+	events := gifEvents(slices, gifMaxFrames)
+	for i, now := range events {
+		frame := image.NewPaletted(bounds, pal)
+		copy(frame.Pix, background.Pix)
+		for _, s := range slices {
+			if s.Start >= now {
+				continue
+			}
+			stop := s.Stop
+			if stop > now {
+				stop = now
+			}
+			y := gifTop + s.CPU*gifLane + 3
+			gifFill(frame, x(s.Start), y, x(stop)-x(s.Start), gifLane-6, pids[s.PID])
+			if label := "P" + strconv.FormatInt(s.PID, 10); gifTextWidth(label)+4 < x(stop)-x(s.Start) {
+				gifText(frame, (x(s.Start)+x(stop)-gifTextWidth(label))/2, y+(gifLane-6-5*gifScale)/2, label, gifInk)
+			}
+		}
+		gifFill(frame, x(now), gifTop-2, 1, cpus*gifLane+2, gifInk)
+		clock := "t=" + strconv.FormatInt(now, 10)
+		gifText(frame, gifWidth-gifRight-gifTextWidth(clock), 6, clock, gifInk)
+
+		delay := gifDelay
+		if i == len(events)-1 {
+			delay = gifHold
+		}
+		anim.Image = append(anim.Image, frame)
+		anim.Delay = append(anim.Delay, delay)
+	}
+
+	return anim
+}
+
+// writeGIFs writes name-{algo}.gif, an animation of the schedule being built, for every
+// scheduler that recorded slices.
+func writeGIFs(dir, name string, results []scheduler.Result, _ scheduler.TimeUnit) error {
+	for _, r := range results {
+		anim := newGIF(r.Gantt)
+		if anim == nil {
+			continue
+		}
+		if err := writeGIF(filepath.Join(dir, name+"-"+r.Algorithm+".gif"), anim); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeGIF(path string, anim *gif.GIF) error {
+	var b bytes.Buffer
+	if err := gif.EncodeAll(&b, anim); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, b.Bytes(), 0o644)
+}
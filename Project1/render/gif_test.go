@@ -0,0 +1,60 @@
+package render
+
+import (
+	"bytes"
+	"image/gif"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+func Test_gifEvents(t *testing.T) {
+	t.Parallel()
+	slices := []scheduler.TimeSlice{{PID: 1, Start: 2, Stop: 5}, {PID: 2, Start: 5, Stop: 9}, {PID: 1, CPU: 1, Start: 3, Stop: 9}}
+	assert.Equal(t, []int64{0, 2, 3, 5, 9}, gifEvents(slices, 10))
+	assert.Equal(t, []int64{0, 3, 9}, gifEvents(slices, 3), "thinning keeps the first and last events")
+}
+
+func Test_newGIF(t *testing.T) {
+	t.Parallel()
+	assert.Nil(t, newGIF([]scheduler.TimeSlice{{Idle: true, Start: 0, Stop: 3}}))
+
+	anim := newGIF([]scheduler.TimeSlice{{Idle: true, Start: 0, Stop: 2}, {PID: 1, Start: 2, Stop: 6}, {PID: 2, CPU: 1, Start: 4, Stop: 8}})
+	require.NotNil(t, anim)
+	require.Len(t, anim.Image, 5) // 0, 2, 4, 6, 8
+	assert.Equal(t, []int{gifDelay, gifDelay, gifDelay, gifDelay, gifHold}, anim.Delay)
+
+	last := anim.Image[len(anim.Image)-1]
+	assert.Equal(t, 2*gifLane+gifTop+gifAxis, last.Bounds().Dy(), "a lane per CPU")
+	scale := float64(gifWidth-gifLeft-gifRight) / 8
+	mid := func(start, stop int64, cpu int) (int, int) {
+		return gifLeft + int(float64(start+stop)/2*scale) + 20, gifTop + cpu*gifLane + 4
+	}
+	x, y := mid(2, 6, 0)
+	assert.Equal(t, uint8(gifProcesses), last.ColorIndexAt(x, y), "P1 runs on CPU 0")
+	x, y = mid(4, 8, 1)
+	assert.Equal(t, uint8(gifProcesses+1), last.ColorIndexAt(x, y), "P2 runs on CPU 1")
+	x, y = mid(4, 8, 1)
+	assert.Equal(t, uint8(gifBackground), anim.Image[1].ColorIndexAt(x, y), "P2 has not started at 2")
+}
+
+func Test_writeGIFs(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	results := []scheduler.Result{
+		{Algorithm: "fcfs", Gantt: []scheduler.TimeSlice{{PID: 1, Start: 0, Stop: 3}}},
+		{Algorithm: "sjf"},
+	}
+	require.NoError(t, writeGIFs(dir, "w", results, scheduler.TimeUnitTicks))
+	b, err := os.ReadFile(filepath.Join(dir, "w-fcfs.gif"))
+	require.NoError(t, err)
+	anim, err := gif.DecodeAll(bytes.NewReader(b))
+	require.NoError(t, err)
+	assert.Len(t, anim.Image, 2)
+	assert.NoFileExists(t, filepath.Join(dir, "w-sjf.gif"), "schedulers without slices get no animation")
+}
@@ -0,0 +1,268 @@
+package render
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+// heatmapBuckets is how many time buckets a CPU heatmap divides a run's makespan into.
+const heatmapBuckets = 60
+
+// heatmapGlyphs label processes in the text heatmap; later processes reuse them cyclically.
+const heatmapGlyphs = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+
+// heatmapShades show how busy a CPU was in a bucket, from idle to fully occupied.
+var heatmapShades = []rune(" ░▒▓█")
+
+type (
+	// cpuHeatmap is which processes occupied each CPU in each time bucket.
+	cpuHeatmap struct {
+		cpus, buckets int
+		width         int64
+		cells         [][]heatmapCell // [cpu][bucket]
+		pids          []int64         // every process that ran, in PID order
+		migrations    []migration
+	}
+	// heatmapCell is the time each process ran on one CPU during one bucket.
+	heatmapCell struct {
+		busy      int64
+		occupants map[int64]int64
+	}
+	// migration is a process resuming on a different CPU from the one it last ran on.
+	migration struct {
+		PID      int64
+		At       int64
+		From, To int
+	}
+)
+
+func newCPUHeatmap(gantt []scheduler.TimeSlice, buckets int) cpuHeatmap {
+	var (
+		hm       cpuHeatmap
+		makespan int64
+		seen     = make(map[int64]bool)
+	)
+	gantt = scheduler.BusySlices(gantt)
+	for _, s := range gantt {
+		if s.CPU+1 > hm.cpus {
+			hm.cpus = s.CPU + 1
+		}
+		if s.Stop > makespan {
+			makespan = s.Stop
+		}
+		if !seen[s.PID] {
+			seen[s.PID] = true
+			hm.pids = append(hm.pids, s.PID)
+		}
+	}
+	sort.Slice(hm.pids, func(i, j int) bool { return hm.pids[i] < hm.pids[j] })
+	if makespan <= 0 {
+		return hm
+	}
+	hm.width = (makespan + int64(buckets) - 1) / int64(buckets)
+	hm.buckets = int((makespan + hm.width - 1) / hm.width)
+	hm.cells = make([][]heatmapCell, hm.cpus)
+	for cpu := range hm.cells {
+		hm.cells[cpu] = make([]heatmapCell, hm.buckets)
+	}
+
+	hm.migrations = findMigrations(gantt)
+	for _, s := range gantt {
+		for b := s.Start / hm.width; b < int64(hm.buckets) && b*hm.width < s.Stop; b++ {
+			lo, hi := b*hm.width, (b+1)*hm.width
+			if s.Start > lo {
+				lo = s.Start
+			}
+			if s.Stop < hi {
+				hi = s.Stop
+			}
+			if hi <= lo {
+				continue
+			}
+			c := &hm.cells[s.CPU][b]
+			if c.occupants == nil {
+				c.occupants = make(map[int64]int64)
+			}
+			c.busy += hi - lo
+			c.occupants[s.PID] += hi - lo
+		}
+	}
+
+	return hm
+}
+
+// dominant is the process that ran longest in the cell, lowest PID on ties, and whether any ran.
+func (c heatmapCell) dominant() (int64, bool) {
+	var (
+		pid  int64
+		best int64 = -1
+	)
+	for p, t := range c.occupants {
+		if t > best || t == best && p < pid {
+			pid, best = p, t
+		}
+	}
+
+	return pid, best >= 0
+}
+
+// share is the fraction of the bucket the CPU was busy.
+func (hm cpuHeatmap) share(c heatmapCell) float64 {
+	return float64(c.busy) / float64(hm.width)
+}
+
+// cpuUtilization is the fraction of the run each CPU was busy.
+func (hm cpuHeatmap) cpuUtilization(cpu int) float64 {
+	var busy int64
+	for _, c := range hm.cells[cpu] {
+		busy += c.busy
+	}
+
+	return float64(busy) / float64(hm.width*int64(hm.buckets))
+}
+
+// glyph is the text heatmap label of a process.
+func (hm cpuHeatmap) glyph(pid int64) byte {
+	i := sort.Search(len(hm.pids), func(i int) bool { return hm.pids[i] >= pid })
+	return heatmapGlyphs[i%len(heatmapGlyphs)]
+}
+
+// writeHeatmapText renders the heatmap as two rows per CPU: the process that occupied each
+// bucket most, and how busy the CPU was, followed by the process legend and any migrations.
+func writeHeatmapText(w io.Writer, r scheduler.Result, hm cpuHeatmap, unit scheduler.TimeUnit) {
+	OutputTitle(w, r.Title+" CPU heatmap")
+	_, _ = fmt.Fprintf(w, "%s; each column is %d\n", unit.Label("Time"), hm.width)
+	for cpu := 0; cpu < hm.cpus; cpu++ {
+		var who, load strings.Builder
+		for _, c := range hm.cells[cpu] {
+			if pid, ok := c.dominant(); ok {
+				who.WriteByte(hm.glyph(pid))
+			} else {
+				who.WriteByte('.')
+			}
+			shade := int(hm.share(c)*float64(len(heatmapShades)-1) + 0.5)
+			load.WriteRune(heatmapShades[shade])
+		}
+		_, _ = fmt.Fprintf(w, "CPU %-3d |%s| %5.1f%%\n", cpu, who.String(), hm.cpuUtilization(cpu)*100)
+		_, _ = fmt.Fprintf(w, "        |%s|\n", load.String())
+	}
+	legend := make([]string, len(hm.pids))
+	for i, pid := range hm.pids {
+		legend[i] = fmt.Sprintf("%c=P%d", hm.glyph(pid), pid)
+	}
+	_, _ = fmt.Fprintf(w, "Processes: %s\n", strings.Join(legend, " "))
+	if len(hm.migrations) == 0 {
+		_, _ = fmt.Fprintln(w, "Migrations: none")
+		return
+	}
+	_, _ = fmt.Fprintf(w, "Migrations: %d\n", len(hm.migrations))
+	for _, m := range hm.migrations {
+		_, _ = fmt.Fprintf(w, "  P%d at %d: CPU %d -> CPU %d\n", m.PID, m.At, m.From, m.To)
+	}
+}
+
+var heatmapHTML = template.Must(template.New("heatmap").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; }
+table { border-collapse: collapse; }
+td { width: 12px; height: 24px; padding: 0; }
+td.cpu { width: auto; padding-right: 8px; white-space: nowrap; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p>Each column is {{.Width}} {{.Unit}}. Color is the process that ran longest in the bucket, opacity how busy the CPU was.</p>
+<table>
+{{range .Rows}}<tr><td class="cpu">CPU {{.CPU}} ({{printf "%.1f" .Utilization}}%)</td>{{range .Cells}}<td title="{{.Title}}" style="background: {{.Color}}"></td>{{end}}</tr>
+{{end}}</table>
+<p>Migrations: {{len .Migrations}}</p>
+{{if .Migrations}}<ul>
+{{range .Migrations}}<li>P{{.PID}} at {{.At}}: CPU {{.From}} &rarr; CPU {{.To}}</li>
+{{end}}</ul>{{end}}
+</body>
+</html>
+`))
+
+type (
+	heatmapPage struct {
+		Title      string
+		Width      int64
+		Unit       string
+		Rows       []heatmapRow
+		Migrations []migration
+	}
+	heatmapRow struct {
+		CPU         int
+		Utilization float64
+		Cells       []heatmapHTMLCell
+	}
+	heatmapHTMLCell struct {
+		Title string
+		Color template.CSS
+	}
+)
+
+// writeHeatmapHTML renders the heatmap as an HTML table, one row per CPU, with each cell's
+// occupants in its tooltip.
+func writeHeatmapHTML(w io.Writer, r scheduler.Result, hm cpuHeatmap, unit scheduler.TimeUnit) error {
+	page := heatmapPage{Title: r.Title + " CPU heatmap", Width: hm.width, Unit: string(unit), Migrations: hm.migrations}
+	for cpu := 0; cpu < hm.cpus; cpu++ {
+		row := heatmapRow{CPU: cpu, Utilization: hm.cpuUtilization(cpu) * 100}
+		for b, c := range hm.cells[cpu] {
+			start := int64(b) * hm.width
+			cell := heatmapHTMLCell{Title: fmt.Sprintf("%d-%d idle", start, start+hm.width), Color: "#f4f4f4"}
+			if pid, ok := c.dominant(); ok {
+				occupants := make([]string, 0, len(c.occupants))
+				for _, p := range hm.pids {
+					if t, ok := c.occupants[p]; ok {
+						occupants = append(occupants, fmt.Sprintf("P%d %d", p, t))
+					}
+				}
+				cell.Title = fmt.Sprintf("%d-%d: %s", start, start+hm.width, strings.Join(occupants, ", "))
+				hue := (pid * 137) % 360 // golden-angle spacing keeps neighbouring PIDs distinguishable
+				cell.Color = template.CSS(fmt.Sprintf("hsla(%d, 70%%, 45%%, %.2f)", hue, 0.2+0.8*hm.share(c)))
+			}
+			row.Cells = append(row.Cells, cell)
+		}
+		page.Rows = append(page.Rows, row)
+	}
+
+	return heatmapHTML.Execute(w, page)
+}
+
+// writeHeatmaps writes name-{algo}-heatmap.txt and name-{algo}-heatmap.html for every scheduler
+// that recorded slices, showing which process occupied each CPU over time.
+func writeHeatmaps(dir, name string, results []scheduler.Result, unit scheduler.TimeUnit) error {
+	for _, r := range results {
+		if len(r.Gantt) == 0 {
+			continue
+		}
+		hm := newCPUHeatmap(r.Gantt, heatmapBuckets)
+		base := filepath.Join(dir, name+"-"+r.Algorithm+"-heatmap")
+		var text strings.Builder
+		writeHeatmapText(&text, r, hm, unit)
+		if err := os.WriteFile(base+".txt", []byte(text.String()), 0o644); err != nil {
+			return err
+		}
+		var html strings.Builder
+		if err := writeHeatmapHTML(&html, r, hm, unit); err != nil {
+			return err
+		}
+		if err := os.WriteFile(base+".html", []byte(html.String()), 0o644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
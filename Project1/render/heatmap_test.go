@@ -0,0 +1,70 @@
+package render
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+// heatmapTestGantt runs three processes on two CPUs; P1 migrates to CPU 1 at 6.
+var heatmapTestGantt = []scheduler.TimeSlice{
+	{PID: 1, CPU: 0, Start: 0, Stop: 4},
+	{PID: 2, CPU: 1, Start: 0, Stop: 6},
+	{PID: 3, CPU: 0, Start: 4, Stop: 7},
+	{PID: 1, CPU: 1, Start: 6, Stop: 8},
+}
+
+func Test_newCPUHeatmap(t *testing.T) {
+	t.Parallel()
+	hm := newCPUHeatmap(heatmapTestGantt, 4)
+	assert.Equal(t, 2, hm.cpus)
+	assert.Equal(t, 4, hm.buckets)
+	assert.Equal(t, int64(2), hm.width)
+	assert.Equal(t, []int64{1, 2, 3}, hm.pids)
+	assert.Equal(t, []migration{{PID: 1, At: 6, From: 0, To: 1}}, hm.migrations)
+	assert.Equal(t, heatmapCell{busy: 1, occupants: map[int64]int64{3: 1}}, hm.cells[0][3])
+	assert.InDelta(t, 7.0/8, hm.cpuUtilization(0), 1e-9)
+
+	pid, ok := hm.cells[0][3].dominant()
+	assert.True(t, ok)
+	assert.Equal(t, int64(3), pid)
+	_, ok = heatmapCell{}.dominant()
+	assert.False(t, ok)
+
+	assert.Zero(t, newCPUHeatmap(nil, 4).buckets)
+}
+
+func Test_writeHeatmapText(t *testing.T) {
+	t.Parallel()
+	var b strings.Builder
+	writeHeatmapText(&b, scheduler.Result{Title: "Test"}, newCPUHeatmap(heatmapTestGantt, 4), scheduler.TimeUnitTicks)
+	out := b.String()
+	assert.Contains(t, out, "CPU 0   |AACC|  87.5%\n        |███▒|\n")
+	assert.Contains(t, out, "CPU 1   |BBBA| 100.0%\n")
+	assert.Contains(t, out, "Processes: A=P1 B=P2 C=P3\n")
+	assert.Contains(t, out, "Migrations: 1\n  P1 at 6: CPU 0 -> CPU 1\n")
+}
+
+func Test_writePlots_heatmap(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	results := []scheduler.Result{
+		{Algorithm: "fcfs", Input: "a.csv", Title: "FCFS", Gantt: heatmapTestGantt},
+		{Algorithm: "sjf", Input: "a.csv", Title: "SJF"},
+	}
+	require.NoError(t, WritePlots(dir, []string{"heatmap"}, results, scheduler.TimeUnitMillis))
+	assert.FileExists(t, filepath.Join(dir, "a-fcfs-heatmap.txt"))
+	assert.NoFileExists(t, filepath.Join(dir, "a-sjf-heatmap.html"), "sjf recorded no slices")
+
+	b, err := os.ReadFile(filepath.Join(dir, "a-fcfs-heatmap.html"))
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "Each column is 1 ms.")
+	assert.Contains(t, string(b), `title="7-8 idle"`)
+	assert.Contains(t, string(b), "P1 at 6: CPU 0 &rarr; CPU 1")
+}
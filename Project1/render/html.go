@@ -0,0 +1,396 @@
+package render
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+// The HTML report is one self-contained page: each scheduler's Gantt chart is drawn as SVG by a
+// small inline script from the slices embedded as JSON, so reports open offline with nothing to
+// fetch. Charts zoom with the mouse wheel, pan by dragging, show each slice's wait and remaining
+// time on hover and highlight a process across the chart and its table when clicked.
+
+var reportHTML = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; margin: 1em 0; }
+th, td { border: 1px solid #ccc; padding: 2px 8px; text-align: right; }
+tr.highlight td { background: #fff3b0; }
+.provenance { color: #555; font-size: small; }
+.gantt { border: 1px solid #ccc; cursor: grab; user-select: none; }
+.gantt rect.slice { stroke: #555; stroke-width: 0.5; }
+.gantt.highlighting rect.slice { opacity: 0.2; }
+.gantt.highlighting rect.slice.highlight { opacity: 1; }
+.gantt text { font-size: 10px; pointer-events: none; }
+#tooltip { position: fixed; display: none; background: #333; color: #fff; padding: 4px 8px; border-radius: 3px; font-size: small; pointer-events: none; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+{{range .Comparisons}}<section>
+<h2>Comparison of {{.Input}}</h2>
+<svg class="comparison" width="{{.Width}}" height="{{.Height}}">
+{{range .Ticks}}<line x1="{{.X1}}" x2="{{.X2}}" y1="{{.Y}}" y2="{{.Y}}" stroke="#ddd"/><text x="{{.X1}}" y="{{.Y}}" dx="-4" dy="3" text-anchor="end" font-size="10">{{.Label}}</text>
+{{end}}{{range .Bars}}<rect x="{{.X}}" y="{{.Y}}" width="{{.Width}}" height="{{.Height}}" fill="{{.Color}}"><title>{{.Title}}</title></rect>
+{{end}}{{range .Groups}}<text x="{{.X}}" y="{{.Y}}" text-anchor="middle" font-size="11">{{.Label}}</text>
+{{end}}{{range .Legend}}<rect x="{{.X}}" y="{{.Y}}" width="10" height="10" fill="{{.Color}}"/><text x="{{.X}}" y="{{.Y}}" dx="14" dy="9" font-size="11">{{.Label}}</text>
+{{end}}</svg>
+</section>
+{{end}}{{range .Sections}}<section>
+<h2>{{.Title}}</h2>
+{{if .Provenance}}<p class="provenance">{{range $i, $l := .Provenance}}{{if $i}}<br>{{end}}{{$l}}{{end}}</p>
+{{end}}{{if not .Rows}}<p>No processes to schedule.</p>
+{{else}}{{if .Chart}}<p>Scroll to zoom, drag to pan, double-click to reset, click a slice to highlight its process.</p>
+<svg class="gantt" id="gantt-{{.Index}}" data-chart="{{.Index}}" width="100%" height="{{.Height}}"></svg>
+{{end}}<table id="table-{{.Index}}">
+<tr>{{range .Header}}<th>{{.}}</th>{{end}}</tr>
+{{range .Rows}}<tr data-pid="{{.PID}}">{{range .Cells}}<td>{{.}}</td>{{end}}</tr>
+{{end}}</table>
+<p>Average wait {{printf "%.2f" .AveWait}}, average turnaround {{printf "%.2f" .AveTurnaround}}, average response {{printf "%.2f" .AveResponse}}, throughput {{.Throughput}}</p>
+{{if .Incomplete}}<p>{{.Incomplete}}</p>
+{{end}}{{end}}</section>
+{{end}}<div id="tooltip"></div>
+<script>
+const charts = {{.Charts}};
+const unit = {{.Unit}};
+const tooltip = document.getElementById("tooltip");
+const svgNS = "http://www.w3.org/2000/svg";
+const lane = 24, left = 48;
+
+function color(pid) { return "hsl(" + (pid * 137) % 360 + ", 60%, 75%)"; }
+
+function el(name, attrs, parent) {
+  const e = document.createElementNS(svgNS, name);
+  for (const k in attrs) e.setAttribute(k, attrs[k]);
+  parent.appendChild(e);
+  return e;
+}
+
+function niceStep(span, ticks) {
+  const raw = span / ticks;
+  if (raw <= 1) return 1;
+  const magnitude = Math.pow(10, Math.floor(Math.log10(raw)));
+  for (const m of [1, 2, 5, 10]) if (m * magnitude >= raw) return m * magnitude;
+  return 10 * magnitude;
+}
+
+function draw(svg, chart) {
+  while (svg.firstChild) svg.removeChild(svg.firstChild);
+  const width = svg.clientWidth || 800;
+  const scale = (width - left - 8) / (chart.view[1] - chart.view[0]);
+  const x = t => left + (t - chart.view[0]) * scale;
+  const clip = "clip-" + svg.dataset.chart;
+  const defs = el("defs", {}, svg);
+  el("rect", {x: left, y: 0, width: width - left, height: chart.cpus * lane}, el("clipPath", {id: clip}, defs));
+  for (let c = 0; c < chart.cpus; c++) {
+    el("text", {x: 4, y: c * lane + lane / 2 + 4}, svg).textContent = "CPU " + c;
+  }
+  const bars = el("g", {"clip-path": "url(#" + clip + ")"}, svg);
+  for (const s of chart.slices) {
+    if (s.stop < chart.view[0] || s.start > chart.view[1]) continue;
+    const w = (s.stop - s.start) * scale;
+    const r = el("rect", {x: x(s.start), y: s.cpu * lane + 2, width: w, height: lane - 4, fill: color(s.pid),
+      "class": "slice" + (s.pid === chart.highlight ? " highlight" : "")}, bars);
+    r.addEventListener("mousemove", e => {
+      tooltip.style.display = "block";
+      tooltip.style.left = e.clientX + 12 + "px";
+      tooltip.style.top = e.clientY + 12 + "px";
+      tooltip.textContent = "P" + s.pid + " on CPU " + s.cpu + ": " + s.start + "-" + s.stop + unit +
+        ", waited " + s.wait + unit + ", " + s.remaining + unit + " remaining";
+    });
+    r.addEventListener("mouseleave", () => { tooltip.style.display = "none"; });
+    r.addEventListener("click", e => {
+      e.stopPropagation();
+      chart.highlight = chart.highlight === s.pid ? null : s.pid;
+      for (const row of document.querySelectorAll("#table-" + svg.dataset.chart + " tr[data-pid]")) {
+        row.classList.toggle("highlight", Number(row.dataset.pid) === chart.highlight);
+      }
+      draw(svg, chart);
+    });
+    if (w > 24) {
+      el("text", {x: x(s.start) + w / 2 - 8, y: s.cpu * lane + lane / 2 + 4}, bars).textContent = "P" + s.pid;
+    }
+  }
+  svg.classList.toggle("highlighting", chart.highlight !== null);
+  const bottom = chart.cpus * lane;
+  const step = niceStep(chart.view[1] - chart.view[0], 10);
+  for (let t = Math.ceil(chart.view[0] / step) * step; t <= chart.view[1]; t += step) {
+    el("line", {x1: x(t), x2: x(t), y1: bottom, y2: bottom + 4, stroke: "#555"}, svg);
+    el("text", {x: x(t) - 4, y: bottom + 16}, svg).textContent = String(Math.round(t * 100) / 100);
+  }
+}
+
+for (const svg of document.querySelectorAll("svg.gantt")) {
+  const chart = charts[svg.dataset.chart];
+  chart.view = [0, chart.makespan];
+  chart.highlight = null;
+  svg.addEventListener("wheel", e => {
+    e.preventDefault();
+    const width = svg.clientWidth || 800;
+    const span = chart.view[1] - chart.view[0];
+    const at = chart.view[0] + Math.max(0, e.offsetX - left) / (width - left - 8) * span;
+    const next = Math.min(chart.makespan, Math.max(1, span * (e.deltaY < 0 ? 0.8 : 1.25)));
+    const start = Math.max(0, Math.min(chart.makespan - next, at - (at - chart.view[0]) * next / span));
+    chart.view = [start, start + next];
+    draw(svg, chart);
+  });
+  let drag = null;
+  svg.addEventListener("mousedown", e => { drag = {x: e.clientX, view: chart.view.slice()}; });
+  window.addEventListener("mouseup", () => { drag = null; });
+  window.addEventListener("mousemove", e => {
+    if (!drag) return;
+    const width = svg.clientWidth || 800;
+    const span = drag.view[1] - drag.view[0];
+    const shift = (drag.x - e.clientX) / (width - left - 8) * span;
+    const start = Math.max(0, Math.min(chart.makespan - span, drag.view[0] + shift));
+    chart.view = [start, start + span];
+    draw(svg, chart);
+  });
+  svg.addEventListener("dblclick", () => { chart.view = [0, chart.makespan]; draw(svg, chart); });
+  window.addEventListener("resize", () => draw(svg, chart));
+  draw(svg, chart);
+}
+</script>
+</body>
+</html>
+`))
+
+type (
+	htmlPage struct {
+		Title       string
+		Unit        string
+		Comparisons []htmlComparison
+		Sections    []htmlSection
+		Charts      []*htmlChart
+	}
+	// htmlComparison is a grouped bar chart of the averages of every scheduler run on one
+	// workload, laid out in SVG coordinates.
+	htmlComparison struct {
+		Input         string
+		Width, Height int
+		Ticks         []htmlTick
+		Bars          []htmlBar
+		Groups        []htmlLabel
+		Legend        []htmlLabel
+	}
+	htmlTick struct {
+		X1, X2, Y float64
+		Label     string
+	}
+	htmlBar struct {
+		X, Y, Width, Height float64
+		Color               template.CSS
+		Title               string
+	}
+	htmlLabel struct {
+		X, Y  float64
+		Label string
+		Color template.CSS
+	}
+	htmlSection struct {
+		Index         int
+		Title         string
+		Provenance    []string
+		Chart         bool
+		Height        int
+		Header        []string
+		Rows          []htmlRow
+		AveWait       float64
+		AveTurnaround float64
+		AveResponse   float64
+		Throughput    string
+		Incomplete    string
+	}
+	htmlRow struct {
+		PID   int64
+		Cells []string
+	}
+	// htmlChart is the data one interactive Gantt chart is drawn from.
+	htmlChart struct {
+		CPUs     int         `json:"cpus"`
+		Makespan int64       `json:"makespan"`
+		Slices   []htmlSlice `json:"slices"`
+	}
+	// htmlSlice is a busy slice with how long its process had waited by the slice's start and
+	// how much of its burst was left at the slice's end.
+	htmlSlice struct {
+		PID       int64 `json:"pid"`
+		CPU       int   `json:"cpu"`
+		Start     int64 `json:"start"`
+		Stop      int64 `json:"stop"`
+		Wait      int64 `json:"wait"`
+		Remaining int64 `json:"remaining"`
+	}
+)
+
+// newHTMLChart lays out a result's busy slices, in start order, for the report script. It is
+// nil when the scheduler recorded no slices.
+func newHTMLChart(r scheduler.Result) *htmlChart {
+	slices := append([]scheduler.TimeSlice(nil), scheduler.BusySlices(r.Gantt)...)
+	if len(slices) == 0 {
+		return nil
+	}
+	sort.SliceStable(slices, func(i, j int) bool { return slices[i].Start < slices[j].Start })
+	processes := make(map[int64]scheduler.ProcessResult, len(r.Processes))
+	for _, p := range r.Processes {
+		processes[p.ID] = p
+	}
+	var (
+		chart = &htmlChart{Slices: make([]htmlSlice, len(slices))}
+		ran   = make(map[int64]int64, len(r.Processes))
+	)
+	for i, s := range slices {
+		p := processes[s.PID]
+		wait := s.Start - p.Arrival - ran[s.PID]
+		ran[s.PID] += s.Stop - s.Start
+		chart.Slices[i] = htmlSlice{PID: s.PID, CPU: s.CPU, Start: s.Start, Stop: s.Stop, Wait: wait, Remaining: p.Burst - ran[s.PID]}
+		if s.CPU+1 > chart.CPUs {
+			chart.CPUs = s.CPU + 1
+		}
+		if s.Stop > chart.Makespan {
+			chart.Makespan = s.Stop
+		}
+	}
+
+	return chart
+}
+
+// comparisonMetrics are the averages compared across schedulers, with their bar colors.
+var comparisonMetrics = []struct {
+	name  string
+	color template.CSS
+	value func(scheduler.Result) float64
+}{
+	{"Average wait", "#4e79a7", func(r scheduler.Result) float64 { return r.AveWait }},
+	{"Average turnaround", "#f28e2b", func(r scheduler.Result) float64 { return r.AveTurnaround }},
+	{"Average response", "#59a14f", func(r scheduler.Result) float64 { return r.AveResponse }},
+}
+
+// newHTMLComparisons charts, for every workload run by more than one scheduler, each
+// scheduler's average wait, turnaround and response side by side, one group of bars per
+// scheduler, so the comparison reads at a glance.
+func newHTMLComparisons(results []scheduler.Result, unit scheduler.TimeUnit) []htmlComparison {
+	const (
+		width, height            = 640, 280
+		left, right, top, bottom = 56.0, 8.0, 32.0, 28.0
+		plotWidth, plotHeight    = width - left - right, height - top - bottom
+		barShare                 = 0.8 // of each group's width, the rest separating groups
+	)
+	var (
+		inputs  []string
+		byInput = make(map[string][]scheduler.Result)
+	)
+	for _, r := range results {
+		if _, ok := byInput[r.Input]; !ok {
+			inputs = append(inputs, r.Input)
+		}
+		byInput[r.Input] = append(byInput[r.Input], r)
+	}
+
+	var comparisons []htmlComparison
+	for _, input := range inputs {
+		runs := byInput[input]
+		if len(runs) < 2 {
+			continue
+		}
+		c := htmlComparison{Input: plotName(input), Width: width, Height: height}
+		var max float64
+		for _, r := range runs {
+			for _, m := range comparisonMetrics {
+				max = math.Max(max, m.value(r))
+			}
+		}
+		step := niceStep(int64(math.Ceil(max)), 5)
+		top := float64(step) * math.Ceil(max/float64(step))
+		if top == 0 {
+			top = 1
+		}
+		y := func(v float64) float64 { return round2(height - bottom - v/top*plotHeight) }
+		for t := int64(0); float64(t) <= top; t += step {
+			c.Ticks = append(c.Ticks, htmlTick{X1: left, X2: width - right, Y: y(float64(t)), Label: strconv.FormatInt(t, 10)})
+		}
+		group := plotWidth / float64(len(runs))
+		bar := group * barShare / float64(len(comparisonMetrics))
+		for i, r := range runs {
+			x := left + float64(i)*group + group*(1-barShare)/2
+			for j, m := range comparisonMetrics {
+				v := m.value(r)
+				c.Bars = append(c.Bars, htmlBar{X: round2(x + float64(j)*bar), Y: y(v), Width: round2(bar), Height: round2(y(0) - y(v)), Color: m.color,
+					Title: fmt.Sprintf("%s %s: %.2f", r.Algorithm, unit.Label(m.name), v)})
+			}
+			c.Groups = append(c.Groups, htmlLabel{X: round2(left + (float64(i)+0.5)*group), Y: height - bottom + 16, Label: r.Algorithm})
+		}
+		for j, m := range comparisonMetrics {
+			c.Legend = append(c.Legend, htmlLabel{X: left + float64(j)*160, Y: 8, Label: unit.Label(m.name), Color: m.color})
+		}
+		comparisons = append(comparisons, c)
+	}
+
+	return comparisons
+}
+
+// round2 rounds an SVG coordinate to two decimals, which keeps the markup short.
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// writeHTML renders results as a self-contained HTML report: bar charts comparing the schedulers
+// run on each workload, then for each result its provenance, an interactive Gantt chart and its
+// schedule table.
+func writeHTML(w io.Writer, results []scheduler.Result, unit scheduler.TimeUnit) error {
+	page := htmlPage{Title: "Scheduling report", Unit: " " + string(unit), Comparisons: newHTMLComparisons(results, unit)}
+	var last *scheduler.Provenance
+	for i, r := range results {
+		section := htmlSection{
+			Index: i, Title: r.Title, AveWait: r.AveWait, AveTurnaround: r.AveTurnaround, AveResponse: r.AveResponse,
+			Throughput: unit.Throughput(r.AveThroughput),
+			Header: []string{"ID", "Priority", unit.Label("Burst"), unit.Label("Arrival"), unit.Label("Wait"),
+				unit.Label("Turnaround"), unit.Label("Exit")},
+		}
+		if r.Provenance != nil && r.Provenance != last {
+			var prov strings.Builder
+			outputProvenance(&prov, *r.Provenance)
+			for _, l := range strings.Split(strings.TrimSpace(prov.String()), "\n")[1:] {
+				section.Provenance = append(section.Provenance, strings.TrimSpace(l))
+			}
+			last = r.Provenance
+		}
+		chart := newHTMLChart(r)
+		page.Charts = append(page.Charts, chart)
+		if chart != nil {
+			section.Chart = true
+			section.Height = chart.CPUs*24 + 24
+		}
+		var (
+			fields  []string
+			scratch []byte
+		)
+		for _, p := range r.Processes {
+			fields, scratch = appendRow(fields[:0], scratch, p)
+			row := htmlRow{PID: p.ID, Cells: append([]string(nil), fields...)}
+			if !p.Completed() {
+				row.Cells[len(row.Cells)-1] += fmt.Sprintf(" (%s)", p.Status)
+			}
+			section.Rows = append(section.Rows, row)
+		}
+		if r.Incomplete > 0 {
+			var note strings.Builder
+			outputIncomplete(&note, r)
+			section.Incomplete = strings.TrimSpace(note.String())
+		}
+		page.Sections = append(page.Sections, section)
+	}
+
+	return reportHTML.Execute(w, page)
+}
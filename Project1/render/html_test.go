@@ -0,0 +1,70 @@
+package render
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+func Test_newHTMLChart(t *testing.T) {
+	t.Parallel()
+	r := scheduler.Result{
+		Gantt: []scheduler.TimeSlice{{Idle: true, Start: 0, Stop: 1}, {PID: 1, Start: 1, Stop: 3}, {PID: 2, CPU: 1, Start: 2, Stop: 4},
+			{PID: 1, Start: 5, Stop: 7}},
+		Processes: []scheduler.ProcessResult{{ID: 1, Arrival: 1, Burst: 4}, {ID: 2, Arrival: 0, Burst: 2}},
+	}
+	assert.Equal(t, &htmlChart{CPUs: 2, Makespan: 7, Slices: []htmlSlice{
+		{PID: 1, Start: 1, Stop: 3, Wait: 0, Remaining: 2},
+		{PID: 2, CPU: 1, Start: 2, Stop: 4, Wait: 2, Remaining: 0},
+		{PID: 1, Start: 5, Stop: 7, Wait: 2, Remaining: 0},
+	}}, newHTMLChart(r))
+	assert.Nil(t, newHTMLChart(scheduler.Result{Processes: r.Processes}))
+}
+
+func Test_writeHTML(t *testing.T) {
+	t.Parallel()
+	results := []scheduler.Result{
+		{Algorithm: "fcfs", Title: "FCFS <first>", AveWait: 1.5,
+			Gantt:     []scheduler.TimeSlice{{PID: 1, Start: 0, Stop: 3}},
+			Processes: []scheduler.ProcessResult{{ID: 1, Burst: 3, Turnaround: 3, Exit: 3}}},
+		{Algorithm: "sjf", Title: "SJF", Incomplete: 1,
+			Processes: []scheduler.ProcessResult{{ID: 7, Exit: 2, Status: scheduler.StatusKilled}}},
+		{Algorithm: "rr", Title: "RR"},
+	}
+	var b strings.Builder
+	require.NoError(t, writeHTML(&b, results, scheduler.TimeUnitMillis))
+	page := b.String()
+
+	assert.Contains(t, page, "<h2>FCFS &lt;first&gt;</h2>")
+	assert.Contains(t, page, `<svg class="gantt" id="gantt-0" data-chart="0"`)
+	assert.NotContains(t, page, `id="gantt-1"`, "schedulers without slices get no chart")
+	assert.Contains(t, page, `const charts = [{"cpus":1,"makespan":3,"slices":[{"pid":1,"cpu":0,"start":0,"stop":3,"wait":0,"remaining":0}]},null,null];`)
+	assert.Contains(t, page, `<th>Wait (ms)</th>`)
+	assert.Contains(t, page, `<tr data-pid="7">`)
+	assert.Contains(t, page, `<td>2 (killed)</td>`)
+	assert.Contains(t, page, "No processes to schedule.")
+}
+
+func Test_newHTMLComparisons(t *testing.T) {
+	t.Parallel()
+	results := []scheduler.Result{
+		{Algorithm: "fcfs", Input: "dir/a.csv", AveWait: 4, AveTurnaround: 8, AveResponse: 4},
+		{Algorithm: "sjf", Input: "dir/a.csv", AveWait: 2, AveTurnaround: 6, AveResponse: 1},
+		{Algorithm: "fcfs", Input: "b.csv", AveWait: 1},
+	}
+	got := newHTMLComparisons(results, scheduler.TimeUnitTicks)
+	require.Len(t, got, 1, "workloads run by one scheduler have nothing to compare")
+	c := got[0]
+	assert.Equal(t, "a", c.Input)
+	require.Len(t, c.Bars, 6)
+	assert.Equal(t, "fcfs Average turnaround: 8.00", c.Bars[1].Title)
+	assert.Equal(t, "sjf Average response: 1.00", c.Bars[5].Title)
+	// The tallest bar reaches the top tick, and bars stand on the baseline.
+	assert.Equal(t, c.Ticks[len(c.Ticks)-1].Y, c.Bars[1].Y)
+	assert.Equal(t, c.Ticks[0].Y, c.Bars[1].Y+c.Bars[1].Height)
+	assert.Equal(t, []string{"fcfs", "sjf"}, []string{c.Groups[0].Label, c.Groups[1].Label})
+}
@@ -0,0 +1,62 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+// writeMermaid renders each result as a Markdown section holding a Mermaid gantt diagram, one
+// row per process, so schedules render as charts in GitHub and GitLab Markdown and docs sites.
+// Times are written as seconds since the epoch (dateFormat X), which gives an axis counting
+// ticks from 0. A result without slices, such as one of only zero bursts, gets a note instead.
+func writeMermaid(w io.Writer, results []scheduler.Result, unit scheduler.TimeUnit) error {
+	for i, r := range results {
+		if i > 0 {
+			if _, err := fmt.Fprintln(w); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintf(w, "## %s\n\n", r.Title); err != nil {
+			return err
+		}
+		slices := scheduler.BusySlices(r.Gantt)
+		if len(slices) == 0 {
+			if _, err := fmt.Fprintln(w, "_No Gantt slices were recorded._"); err != nil {
+				return err
+			}
+			continue
+		}
+		var b strings.Builder
+		b.WriteString("```mermaid\ngantt\n")
+		_, _ = fmt.Fprintf(&b, "    title %s\n", mermaidText(unit.Label(r.Title)))
+		b.WriteString("    dateFormat X\n    axisFormat %s\n")
+		// One section per process, in PID order, keeping each process's slices in time order.
+		byPID := append([]scheduler.TimeSlice(nil), slices...)
+		sort.SliceStable(byPID, func(i, j int) bool { return byPID[i].PID < byPID[j].PID })
+		for j, s := range byPID {
+			if j == 0 || s.PID != byPID[j-1].PID {
+				_, _ = fmt.Fprintf(&b, "    section P%d\n", s.PID)
+			}
+			label := fmt.Sprintf("P%d", s.PID)
+			if s.CPU > 0 {
+				label += fmt.Sprintf(" on CPU %d", s.CPU)
+			}
+			_, _ = fmt.Fprintf(&b, "    %s : %d, %d\n", label, s.Start, s.Stop)
+		}
+		b.WriteString("```\n")
+		if _, err := io.WriteString(w, b.String()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// mermaidText drops the characters that end or split a Mermaid gantt statement.
+func mermaidText(s string) string {
+	return strings.NewReplacer(":", " ", ";", " ", "#", " ", "\n", " ").Replace(s)
+}
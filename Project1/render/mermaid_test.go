@@ -0,0 +1,40 @@
+package render
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+func Test_writeMermaid(t *testing.T) {
+	t.Parallel()
+	results := []scheduler.Result{
+		{Title: "FCFS: test", Gantt: []scheduler.TimeSlice{
+			{Idle: true, Start: 0, Stop: 1},
+			{PID: 2, Start: 1, Stop: 3},
+			{PID: 1, Start: 3, Stop: 4},
+			{PID: 2, CPU: 1, Start: 4, Stop: 6},
+		}},
+		{Title: "SJF"},
+	}
+	var b strings.Builder
+	require.NoError(t, writeMermaid(&b, results, scheduler.TimeUnitMillis))
+	assert.Equal(t, "## FCFS: test\n\n"+
+		"```mermaid\n"+
+		"gantt\n"+
+		"    title FCFS  test (ms)\n"+
+		"    dateFormat X\n"+
+		"    axisFormat %s\n"+
+		"    section P1\n"+
+		"    P1 : 3, 4\n"+
+		"    section P2\n"+
+		"    P2 : 1, 3\n"+
+		"    P2 on CPU 1 : 4, 6\n"+
+		"```\n"+
+		"\n## SJF\n\n"+
+		"_No Gantt slices were recorded._\n", b.String())
+}
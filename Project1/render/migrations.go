@@ -0,0 +1,306 @@
+package render
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+// Layout of the migration chart: one lane per core, with the time axis scaled to chart width.
+const (
+	migrationChartWidth = 800
+	migrationLeft       = 60
+	migrationLane       = 28
+)
+
+// migrationView is where one scheduler placed each process over time, and how often it moved.
+type migrationView struct {
+	result     scheduler.Result
+	cpus       int
+	makespan   int64
+	slices     []scheduler.TimeSlice // busy slices, by start time
+	migrations []migration
+	pingPongs  int           // migrations straight back to the core the process just left
+	perPID     map[int64]int // migrations of each process
+	matrix     [][]int       // matrix[from][to] is how many migrations went from core from to core to
+}
+
+// findMigrations lists every process resuming on a different CPU from the one it last ran on,
+// in time order.
+func findMigrations(gantt []scheduler.TimeSlice) []migration {
+	ordered := append([]scheduler.TimeSlice(nil), scheduler.BusySlices(gantt)...)
+	sort.SliceStable(ordered, func(i, j int) bool { return ordered[i].Start < ordered[j].Start })
+	var (
+		migrations []migration
+		lastCPU    = make(map[int64]int)
+	)
+	for _, s := range ordered {
+		if prev, ok := lastCPU[s.PID]; ok && prev != s.CPU {
+			migrations = append(migrations, migration{PID: s.PID, At: s.Start, From: prev, To: s.CPU})
+		}
+		lastCPU[s.PID] = s.CPU
+	}
+
+	return migrations
+}
+
+func newMigrationView(r scheduler.Result) migrationView {
+	v := migrationView{result: r, cpus: 1, perPID: make(map[int64]int)}
+	v.slices = append(v.slices, scheduler.BusySlices(r.Gantt)...)
+	sort.SliceStable(v.slices, func(i, j int) bool { return v.slices[i].Start < v.slices[j].Start })
+	for _, s := range v.slices {
+		if s.CPU+1 > v.cpus {
+			v.cpus = s.CPU + 1
+		}
+		if s.Stop > v.makespan {
+			v.makespan = s.Stop
+		}
+	}
+	v.matrix = make([][]int, v.cpus)
+	for i := range v.matrix {
+		v.matrix[i] = make([]int, v.cpus)
+	}
+
+	v.migrations = findMigrations(v.slices)
+	last := make(map[int64]migration)
+	for _, m := range v.migrations {
+		if prev, ok := last[m.PID]; ok && prev.From == m.To && prev.To == m.From {
+			v.pingPongs++
+		}
+		last[m.PID] = m
+		v.perPID[m.PID]++
+		v.matrix[m.From][m.To]++
+	}
+
+	return v
+}
+
+// mostMigrated is the process that migrated most, lowest PID on ties, as "P1 (3)", or "-".
+func (v migrationView) mostMigrated() string {
+	var pid int64
+	best := 0
+	for p, n := range v.perPID {
+		if n > best || n == best && p < pid {
+			pid, best = p, n
+		}
+	}
+	if best == 0 {
+		return "-"
+	}
+
+	return fmt.Sprintf("P%d (%d)", pid, best)
+}
+
+// writeMigrationText summarizes each scheduler's migrations, then, for each that migrated,
+// how many migrations went between each pair of cores.
+func writeMigrationText(w io.Writer, views []migrationView) {
+	OutputTitle(w, "Migrations")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Algorithm", "Cores", "Migrations", "Ping-pongs", "Processes migrated", "Most migrated"})
+	table.SetAutoWrapText(false)
+	for _, v := range views {
+		table.Append([]string{v.result.Algorithm, strconv.Itoa(v.cpus), strconv.Itoa(len(v.migrations)),
+			strconv.Itoa(v.pingPongs), strconv.Itoa(len(v.perPID)), v.mostMigrated()})
+	}
+	table.Render()
+
+	for _, v := range views {
+		if len(v.migrations) == 0 {
+			continue
+		}
+		_, _ = fmt.Fprintf(w, "%s migrations, from core (row) to core (column):\n", v.result.Title)
+		matrix := tablewriter.NewWriter(w)
+		header := []string{"from \\ to"}
+		for cpu := 0; cpu < v.cpus; cpu++ {
+			header = append(header, strconv.Itoa(cpu))
+		}
+		matrix.SetHeader(header)
+		for from, row := range v.matrix {
+			cells := []string{strconv.Itoa(from)}
+			for _, n := range row {
+				cells = append(cells, strconv.Itoa(n))
+			}
+			matrix.Append(cells)
+		}
+		matrix.Render()
+	}
+}
+
+var migrationHTML = template.Must(template.New("migrations").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; }
+table { border-collapse: collapse; margin-bottom: 1em; }
+td, th { border: 1px solid #ccc; padding: 2px 8px; text-align: right; }
+svg text { font-size: 12px; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p>Each lane is a core; a process's slices share its color and a line joins them when it moves to another core, so migrations and ping-ponging stand out. Times are in {{.Unit}}.</p>
+<table>
+<tr><th>Algorithm</th><th>Cores</th><th>Migrations</th><th>Ping-pongs</th><th>Processes migrated</th><th>Most migrated</th></tr>
+{{range .Views}}<tr><td>{{.Algorithm}}</td><td>{{.CPUs}}</td><td>{{.Migrations}}</td><td>{{.PingPongs}}</td><td>{{.Migrated}}</td><td>{{.MostMigrated}}</td></tr>
+{{end}}</table>
+{{range .Views}}<h2>{{.Title}}</h2>
+<svg width="{{.Width}}" height="{{.Height}}">
+{{range .Lanes}}<text x="4" y="{{.Y}}">core {{.CPU}}</text>
+{{end}}{{range .Slices}}<rect x="{{.X}}" y="{{.Y}}" width="{{.W}}" height="{{.H}}" fill="{{.Color}}"><title>{{.Title}}</title></rect>
+{{end}}{{range .Moves}}<line x1="{{.X1}}" y1="{{.Y1}}" x2="{{.X2}}" y2="{{.Y2}}" stroke="{{.Color}}" stroke-width="2" stroke-dasharray="4 2"><title>{{.Title}}</title></line>
+{{end}}</svg>
+{{if .Heat}}<table>
+<tr><th>from \ to</th>{{range .Heat}}<th>{{.CPU}}</th>{{end}}</tr>
+{{range .Heat}}<tr><th>{{.CPU}}</th>{{range .Cells}}<td style="background: {{.Color}}">{{.Count}}</td>{{end}}</tr>
+{{end}}</table>{{end}}
+{{end}}</body>
+</html>
+`))
+
+type (
+	migrationPage struct {
+		Title string
+		Unit  string
+		Views []migrationChart
+	}
+	migrationChart struct {
+		Algorithm, Title, MostMigrated      string
+		CPUs, Migrations, PingPongs, Height int
+		Migrated, Width                     int
+		Lanes                               []migrationLaneLabel
+		Slices                              []migrationRect
+		Moves                               []migrationMove
+		Heat                                []migrationHeatRow // nil when nothing migrated
+	}
+	migrationLaneLabel struct {
+		CPU int
+		Y   int
+	}
+	migrationRect struct {
+		X, Y, W, H string
+		Color      template.CSS
+		Title      string
+	}
+	migrationMove struct {
+		X1, Y1, X2, Y2 string
+		Color          template.CSS
+		Title          string
+	}
+	migrationHeatRow struct {
+		CPU   int
+		Cells []migrationHeatCell
+	}
+	migrationHeatCell struct {
+		Count int
+		Color template.CSS
+	}
+)
+
+// chart lays v out as SVG coordinates.
+func (v migrationView) chart() migrationChart {
+	c := migrationChart{
+		Algorithm: v.result.Algorithm, Title: v.result.Title + " core placement", MostMigrated: v.mostMigrated(),
+		CPUs: v.cpus, Migrations: len(v.migrations), PingPongs: v.pingPongs, Migrated: len(v.perPID),
+		Width: migrationChartWidth, Height: v.cpus*migrationLane + 8,
+	}
+	scale := 0.0
+	if v.makespan > 0 {
+		scale = float64(migrationChartWidth-migrationLeft-8) / float64(v.makespan)
+	}
+	x := func(t int64) string { return strconv.FormatFloat(migrationLeft+float64(t)*scale, 'f', 1, 64) }
+	mid := func(cpu int) string { return strconv.Itoa(cpu*migrationLane + migrationLane/2 + 4) }
+	color := func(pid int64) template.CSS {
+		return template.CSS(fmt.Sprintf("hsl(%d, 70%%, 45%%)", (pid*137)%360)) // as in the heatmap
+	}
+	for cpu := 0; cpu < v.cpus; cpu++ {
+		c.Lanes = append(c.Lanes, migrationLaneLabel{CPU: cpu, Y: cpu*migrationLane + migrationLane/2 + 8})
+	}
+	last := make(map[int64]scheduler.TimeSlice)
+	for _, s := range v.slices {
+		c.Slices = append(c.Slices, migrationRect{
+			X: x(s.Start), Y: strconv.Itoa(s.CPU*migrationLane + 8), H: strconv.Itoa(migrationLane - 8),
+			W:     strconv.FormatFloat(float64(s.Stop-s.Start)*scale, 'f', 1, 64),
+			Color: color(s.PID), Title: fmt.Sprintf("P%d on core %d, %d-%d", s.PID, s.CPU, s.Start, s.Stop),
+		})
+		if prev, ok := last[s.PID]; ok && prev.CPU != s.CPU {
+			c.Moves = append(c.Moves, migrationMove{
+				X1: x(prev.Stop), Y1: mid(prev.CPU), X2: x(s.Start), Y2: mid(s.CPU), Color: color(s.PID),
+				Title: fmt.Sprintf("P%d at %d: core %d -> core %d", s.PID, s.Start, prev.CPU, s.CPU),
+			})
+		}
+		last[s.PID] = s
+	}
+
+	if len(v.migrations) == 0 {
+		return c
+	}
+	most := 0
+	for _, row := range v.matrix {
+		for _, n := range row {
+			if n > most {
+				most = n
+			}
+		}
+	}
+	for from, row := range v.matrix {
+		heat := migrationHeatRow{CPU: from}
+		for _, n := range row {
+			heat.Cells = append(heat.Cells, migrationHeatCell{
+				Count: n,
+				Color: template.CSS(fmt.Sprintf("rgba(220, 40, 40, %.2f)", float64(n)/float64(most))),
+			})
+		}
+		c.Heat = append(c.Heat, heat)
+	}
+
+	return c
+}
+
+func writeMigrationHTML(w io.Writer, title string, views []migrationView, unit scheduler.TimeUnit) error {
+	page := migrationPage{Title: title, Unit: string(unit)}
+	for _, v := range views {
+		page.Views = append(page.Views, v.chart())
+	}
+
+	return migrationHTML.Execute(w, page)
+}
+
+// writeMigrations writes name-migrations.txt and name-migrations.html, comparing how every
+// scheduler that recorded slices placed processes on cores: migration counts, ping-pongs back
+// to the previous core and a heat matrix of core-to-core moves per scheduler, and in the HTML a
+// chart of each process's placement over time with a lane per core.
+func writeMigrations(dir, name string, results []scheduler.Result, unit scheduler.TimeUnit) error {
+	var views []migrationView
+	for _, r := range results {
+		if len(r.Gantt) > 0 {
+			views = append(views, newMigrationView(r))
+		}
+	}
+	if len(views) == 0 {
+		return nil
+	}
+
+	base := filepath.Join(dir, name+"-migrations")
+	var text strings.Builder
+	writeMigrationText(&text, views)
+	if err := os.WriteFile(base+".txt", []byte(text.String()), 0o644); err != nil {
+		return err
+	}
+	var html strings.Builder
+	if err := writeMigrationHTML(&html, name+" migrations", views, unit); err != nil {
+		return err
+	}
+
+	return os.WriteFile(base+".html", []byte(html.String()), 0o644)
+}
@@ -0,0 +1,81 @@
+package render
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+// pingPongGantt has P1 bounce from core 0 to core 1 and back while P2 runs alongside.
+var pingPongGantt = []scheduler.TimeSlice{
+	{PID: 1, CPU: 0, Start: 0, Stop: 2},
+	{PID: 2, CPU: 1, Start: 0, Stop: 2},
+	{PID: 1, CPU: 1, Start: 2, Stop: 4},
+	{PID: 2, CPU: 0, Start: 2, Stop: 3},
+	{CPU: 0, Start: 3, Stop: 4, Idle: true},
+	{PID: 1, CPU: 0, Start: 4, Stop: 6},
+}
+
+func Test_newMigrationView(t *testing.T) {
+	t.Parallel()
+	v := newMigrationView(scheduler.Result{Algorithm: "rr", Gantt: pingPongGantt})
+	assert.Equal(t, 2, v.cpus)
+	assert.Equal(t, int64(6), v.makespan)
+	assert.Equal(t, []migration{
+		{PID: 1, At: 2, From: 0, To: 1},
+		{PID: 2, At: 2, From: 1, To: 0},
+		{PID: 1, At: 4, From: 1, To: 0},
+	}, v.migrations)
+	assert.Equal(t, 1, v.pingPongs)
+	assert.Equal(t, map[int64]int{1: 2, 2: 1}, v.perPID)
+	assert.Equal(t, [][]int{{0, 1}, {2, 0}}, v.matrix)
+	assert.Equal(t, "P1 (2)", v.mostMigrated())
+
+	single := newMigrationView(scheduler.Result{Gantt: []scheduler.TimeSlice{{PID: 1, Start: 0, Stop: 3}}})
+	assert.Equal(t, 1, single.cpus)
+	assert.Empty(t, single.migrations)
+	assert.Equal(t, "-", single.mostMigrated())
+}
+
+func Test_writeMigrationText(t *testing.T) {
+	t.Parallel()
+	var b strings.Builder
+	writeMigrationText(&b, []migrationView{
+		newMigrationView(scheduler.Result{Algorithm: "rr", Title: "Round-robin", Gantt: pingPongGantt}),
+		newMigrationView(scheduler.Result{Algorithm: "fcfs", Title: "FCFS", Gantt: heatmapTestGantt}),
+	})
+	out := b.String()
+	assert.Regexp(t, `\| rr\s+\|\s+2 \|\s+3 \|\s+1 \|\s+2 \| P1 \(2\)\s+\|`, out)
+	assert.Regexp(t, `\| fcfs\s+\|\s+2 \|\s+1 \|\s+0 \|\s+1 \| P1 \(1\)\s+\|`, out)
+	assert.Contains(t, out, "Round-robin migrations, from core (row) to core (column):")
+	assert.Regexp(t, `\|\s+1\s+\|\s+2 \|\s+0 \|`, out)
+}
+
+func Test_writePlots_migrations(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	results := []scheduler.Result{
+		{Algorithm: "rr", Input: "a.csv", Title: "Round-robin", Gantt: pingPongGantt},
+		{Algorithm: "sjf", Input: "a.csv", Title: "SJF"},
+		{Algorithm: "fcfs", Input: "b.csv", Title: "FCFS"},
+	}
+	require.NoError(t, WritePlots(dir, []string{"migrations"}, results, scheduler.TimeUnitTicks))
+	assert.FileExists(t, filepath.Join(dir, "a-migrations.txt"))
+	assert.NoFileExists(t, filepath.Join(dir, "b-migrations.html"), "no scheduler of b recorded slices")
+
+	b, err := os.ReadFile(filepath.Join(dir, "a-migrations.html"))
+	require.NoError(t, err)
+	html := string(b)
+	assert.Contains(t, html, "<h2>Round-robin core placement</h2>")
+	assert.NotContains(t, html, "SJF")
+	assert.Equal(t, 5, strings.Count(html, "<rect "), "idle slices are left out")
+	assert.Equal(t, 3, strings.Count(html, "<line "))
+	assert.Contains(t, html, "<title>P1 at 4: core 1 -&gt; core 0</title>")
+	assert.Contains(t, html, `<td style="background: rgba(220, 40, 40, 1.00)">2</td>`)
+}
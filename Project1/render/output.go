@@ -0,0 +1,195 @@
+// Package render writes scheduler results as text, JSON, CSV, NDJSON, Parquet, Arrow, Mermaid,
+// Excel, PDF and HTML, and plots them with gnuplot, Plotly, heatmaps and animated GIFs.
+//
+// WriteResults renders a batch of results to an Output, a path pattern and format; results can
+// instead be streamed to csv and ndjson outputs as they are produced through a StreamSink.
+// FCFSSchedule and its siblings run one scheduler and print its text report.
+package render
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+// Format is an output rendering.
+type Format string
+
+const (
+	FormatText   Format = "text"
+	FormatJSON   Format = "json"
+	FormatCSV    Format = "csv"
+	FormatNDJSON Format = "ndjson"
+	// FormatParquet holds the per-process rows and FormatParquetTrace the Gantt slices.
+	FormatParquet      Format = "parquet"
+	FormatParquetTrace Format = "parquet-trace"
+	// FormatTidy is long-format CSV with one metric value per row.
+	FormatTidy Format = "tidy"
+	// FormatArrow is an Arrow IPC stream with a record batch of per-process rows per result.
+	FormatArrow Format = "arrow"
+	// FormatMermaid is Markdown with a Mermaid gantt diagram per result.
+	FormatMermaid Format = "mermaid"
+	// FormatXLSX is an Excel workbook with a comparison sheet and charts, then a sheet per result.
+	FormatXLSX Format = "xlsx"
+	// FormatPDF is a printable report with a vector Gantt chart and schedule table per result.
+	FormatPDF Format = "pdf"
+	// FormatHTML is a self-contained HTML report with an interactive Gantt chart per result.
+	FormatHTML Format = "html"
+)
+
+// StdoutPath is the output path that means standard output.
+const StdoutPath = "-"
+
+var ErrInvalidFormat = fmt.Errorf("%w: format must be one of text, json, csv, ndjson, parquet, parquet-trace, arrow, tidy, mermaid, xlsx, pdf, html", scheduler.ErrInvalidArgs)
+
+// ParseFormat parses an output format name, ignoring case.
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(s)); f {
+	case FormatText, FormatJSON, FormatCSV, FormatNDJSON, FormatParquet, FormatParquetTrace, FormatArrow, FormatTidy, FormatMermaid, FormatXLSX, FormatPDF, FormatHTML:
+		return f, nil
+	default:
+		return "", fmt.Errorf("%w: got %q", ErrInvalidFormat, s)
+	}
+}
+
+// FormatForPath infers the format from a file extension, falling back to def.
+func FormatForPath(path string, def Format) Format {
+	switch lower := strings.ToLower(path); {
+	case strings.HasSuffix(lower, ".trace.parquet"):
+		return FormatParquetTrace
+	case strings.HasSuffix(lower, ".tidy.csv"):
+		return FormatTidy
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return FormatJSON
+	case ".csv":
+		return FormatCSV
+	case ".ndjson", ".jsonl":
+		return FormatNDJSON
+	case ".parquet":
+		return FormatParquet
+	case ".arrows":
+		return FormatArrow
+	case ".md", ".mmd":
+		return FormatMermaid
+	case ".xlsx":
+		return FormatXLSX
+	case ".pdf":
+		return FormatPDF
+	case ".html", ".htm":
+		return FormatHTML
+	case ".txt":
+		return FormatText
+	default:
+		return def
+	}
+}
+
+// Output is a destination for results. The pattern may contain {algo}, which gives each
+// scheduler its own file, {input}, which gives each workload file its own file, and
+// {format}, which expands to the format name.
+type Output struct {
+	Pattern string
+	Format  Format
+	// Text are the renderings text output uses.
+	Text TextOptions
+}
+
+// perResult reports whether the pattern can give each result a file of its own.
+func (o Output) perResult() bool {
+	return strings.Contains(o.Pattern, "{algo}")
+}
+
+func (o Output) path(r scheduler.Result) string {
+	input := strings.TrimSuffix(filepath.Base(r.Input), filepath.Ext(r.Input))
+	return strings.NewReplacer(
+		"{algo}", r.Algorithm,
+		"{input}", input,
+		"{format}", string(o.Format),
+	).Replace(o.Pattern)
+}
+
+// WriteResults renders results to every file the output pattern expands to; "-" is stdout.
+func WriteResults(stdout io.Writer, out Output, results []scheduler.Result, unit scheduler.TimeUnit) error {
+	var (
+		paths  []string
+		byPath = make(map[string][]scheduler.Result)
+	)
+	for _, r := range results {
+		p := out.path(r)
+		if _, ok := byPath[p]; !ok {
+			paths = append(paths, p)
+		}
+		byPath[p] = append(byPath[p], r)
+	}
+
+	for _, p := range paths {
+		if err := writeResultsTo(stdout, p, out, byPath[p], unit); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeResultsTo(stdout io.Writer, path string, out Output, results []scheduler.Result, unit scheduler.TimeUnit) (err error) {
+	w := stdout
+	if path != StdoutPath {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return fmt.Errorf("%w: creating output directory", err)
+		}
+		f, err := os.Create(path)
+		if err != nil {
+			return fmt.Errorf("%w: creating output file", err)
+		}
+		defer func() {
+			if cErr := f.Close(); cErr != nil && err == nil {
+				err = fmt.Errorf("%w: closing output file", cErr)
+			}
+		}()
+		w = f
+	}
+
+	switch out.Format {
+	case FormatJSON:
+		if out.perResult() && len(results) == 1 {
+			return WriteJSON(w, results[0])
+		}
+		return WriteJSONArray(w, results)
+	case FormatCSV:
+		return writeCSV(w, results)
+	case FormatNDJSON:
+		return writeNDJSON(w, results)
+	case FormatParquet:
+		return writeParquet(w, results)
+	case FormatParquetTrace:
+		return writeParquetTrace(w, results)
+	case FormatArrow:
+		return writeArrow(w, results)
+	case FormatTidy:
+		return writeTidyCSV(w, results)
+	case FormatMermaid:
+		return writeMermaid(w, results, unit)
+	case FormatXLSX:
+		return writeXLSX(w, results, unit)
+	case FormatPDF:
+		return writePDF(w, results, unit)
+	case FormatHTML:
+		return writeHTML(w, results, unit)
+	default:
+		var last *scheduler.Provenance
+		for _, r := range results {
+			if r.Provenance != nil && r.Provenance != last {
+				outputProvenance(w, *r.Provenance)
+				last = r.Provenance
+			}
+			writeTextWith(w, r, unit, out.Text)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,139 @@
+package render
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+func TestFormatForPath(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		path string
+		def  Format
+		want Format
+	}{
+		{path: "-", def: FormatText, want: FormatText},
+		{path: "-", def: FormatCSV, want: FormatCSV},
+		{path: "results/{algo}.json", def: FormatText, want: FormatJSON},
+		{path: "results.CSV", def: FormatText, want: FormatCSV},
+		{path: "results.txt", def: FormatJSON, want: FormatText},
+		{path: "results.out", def: FormatJSON, want: FormatJSON},
+		{path: "results/{algo}.parquet", def: FormatText, want: FormatParquet},
+		{path: "events.Trace.parquet", def: FormatText, want: FormatParquetTrace},
+		{path: "results.arrows", def: FormatText, want: FormatArrow},
+		{path: "docs/schedule.md", def: FormatText, want: FormatMermaid},
+		{path: "schedule.mmd", def: FormatText, want: FormatMermaid},
+		{path: "report.PDF", def: FormatText, want: FormatPDF},
+		{path: "report.htm", def: FormatText, want: FormatHTML},
+		{path: "plots/{algo}.tidy.csv", def: FormatText, want: FormatTidy},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+			if got := FormatForPath(tt.path, tt.def); got != tt.want {
+				t.Errorf("FormatForPath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteResults(t *testing.T) {
+	t.Parallel()
+	results := []scheduler.Result{
+		{Algorithm: "fcfs", Title: "First-come, first-serve", Processes: []scheduler.ProcessResult{{ID: 1, Burst: 5, Exit: 5, Turnaround: 5}}},
+		{Algorithm: "sjf", Title: "Shortest-job-first", Processes: []scheduler.ProcessResult{{ID: 1, Burst: 5, Exit: 5, Turnaround: 5}}},
+	}
+	batch := []scheduler.Result{
+		{Algorithm: "fcfs", Input: "workloads/a.csv"},
+		{Algorithm: "fcfs", Input: "b.csv"},
+		{Algorithm: "sjf", Input: "b.csv"},
+	}
+	tests := []struct {
+		name      string
+		results   []scheduler.Result
+		pattern   string
+		format    Format
+		wantFiles map[string]string
+		wantOut   string
+	}{
+		{
+			name:    "per algorithm JSON",
+			pattern: "results/{algo}.json",
+			format:  FormatJSON,
+			wantFiles: map[string]string{
+				"results/fcfs.json": `"algorithm": "fcfs"`,
+				"results/sjf.json":  `"algorithm": "sjf"`,
+			},
+		},
+		{
+			name:    "combined CSV",
+			pattern: "all.{format}",
+			format:  FormatCSV,
+			wantFiles: map[string]string{
+				"all.csv": "input,algorithm,id,priority,burst,arrival,wait,turnaround,exit,response,status\n,fcfs,1,0,5,0,0,5,5,0,\n,sjf,1,0,5,0,0,5,5,0,\n",
+			},
+		},
+		{
+			name:    "tidy CSV",
+			pattern: "long.csv",
+			format:  FormatTidy,
+			wantFiles: map[string]string{
+				"long.csv": "run_id,algorithm,pid,metric,value\n,fcfs,1,wait,0\n,fcfs,1,turnaround,5\n,fcfs,1,response,0\n,fcfs,1,exit,5\n" +
+					",fcfs,,average_wait,0\n,fcfs,,average_turnaround,0\n,fcfs,,average_response,0\n,fcfs,,throughput,0\n,sjf,1,wait,0\n",
+			},
+		},
+		{
+			name:    "per input and algorithm",
+			results: batch,
+			pattern: "{input}/{algo}.json",
+			format:  FormatJSON,
+			wantFiles: map[string]string{
+				"a/fcfs.json": `"input": "workloads/a.csv"`,
+				"b/sjf.json":  `"input": "b.csv"`,
+			},
+		},
+		{
+			name:    "stdout text",
+			pattern: StdoutPath,
+			format:  FormatText,
+			wantOut: "Shortest-job-first",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dir := t.TempDir()
+			pattern := tt.pattern
+			if pattern != StdoutPath {
+				pattern = filepath.Join(dir, pattern)
+			}
+			var stdout bytes.Buffer
+			rs := results
+			if tt.results != nil {
+				rs = tt.results
+			}
+			if err := WriteResults(&stdout, Output{Pattern: pattern, Format: tt.format}, rs, scheduler.TimeUnitTicks); err != nil {
+				t.Fatal(err)
+			}
+			for name, want := range tt.wantFiles {
+				b, err := os.ReadFile(filepath.Join(dir, name))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !strings.Contains(string(b), want) {
+					t.Errorf("%s = %q, want it to contain %q", name, b, want)
+				}
+			}
+			if !strings.Contains(stdout.String(), tt.wantOut) {
+				t.Errorf("stdout = %q, want it to contain %q", stdout.String(), tt.wantOut)
+			}
+		})
+	}
+}
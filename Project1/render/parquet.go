@@ -0,0 +1,301 @@
+package render
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+// This file is a minimal Parquet writer: one row group of required, flat columns, each stored as
+// a single uncompressed PLAIN-encoded data page. That is all results need, and it keeps the tool
+// free of a Parquet dependency while producing files pandas, DuckDB and Spark read directly.
+
+// parquetType is a Parquet physical type.
+type parquetType int32
+
+const (
+	parquetInt64     parquetType = 2
+	parquetByteArray parquetType = 6
+)
+
+// parquetMagic begins and ends every Parquet file.
+const parquetMagic = "PAR1"
+
+// Parquet enum values used in the file metadata.
+const (
+	parquetRequired     = 0 // FieldRepetitionType
+	parquetUTF8         = 0 // ConvertedType
+	parquetPlain        = 0 // Encoding
+	parquetRLE          = 3 // Encoding
+	parquetUncompressed = 0 // CompressionCodec
+	parquetDataPage     = 0 // PageType
+)
+
+// parquetColumn accumulates the PLAIN-encoded values of one column.
+type parquetColumn struct {
+	name string
+	typ  parquetType
+	data bytes.Buffer
+}
+
+// parquetTable is a table being built row by row, column value by column value.
+type parquetTable struct {
+	columns []*parquetColumn
+	rows    int64
+	scratch [8]byte
+}
+
+func newParquetTable(columns ...parquetColumn) *parquetTable {
+	t := &parquetTable{columns: make([]*parquetColumn, len(columns))}
+	for i := range columns {
+		t.columns[i] = &parquetColumn{name: columns[i].name, typ: columns[i].typ}
+	}
+
+	return t
+}
+
+func (t *parquetTable) int64(col int, v int64) {
+	binary.LittleEndian.PutUint64(t.scratch[:], uint64(v))
+	t.columns[col].data.Write(t.scratch[:])
+}
+
+func (t *parquetTable) string(col int, s string) {
+	binary.LittleEndian.PutUint32(t.scratch[:4], uint32(len(s)))
+	t.columns[col].data.Write(t.scratch[:4])
+	t.columns[col].data.WriteString(s)
+}
+
+// endRow marks the row as complete; every column must have had a value appended.
+func (t *parquetTable) endRow() {
+	t.rows++
+}
+
+// writeTo writes the table as a complete Parquet file.
+func (t *parquetTable) writeTo(w io.Writer) error {
+	var (
+		file    bytes.Buffer
+		offsets = make([]int64, len(t.columns))
+		sizes   = make([]int64, len(t.columns))
+	)
+	file.WriteString(parquetMagic)
+	for i, c := range t.columns {
+		var header thriftWriter
+		header.i32(1, parquetDataPage)
+		header.i32(2, int32(c.data.Len()))
+		header.i32(3, int32(c.data.Len()))
+		header.structBegin(5) // DataPageHeader
+		header.i32(1, int32(t.rows))
+		header.i32(2, parquetPlain)
+		header.i32(3, parquetRLE)
+		header.i32(4, parquetRLE)
+		header.structEnd()
+		header.stop()
+
+		offsets[i] = int64(file.Len())
+		sizes[i] = int64(len(header.buf) + c.data.Len())
+		file.Write(header.buf)
+		file.Write(c.data.Bytes())
+	}
+
+	var meta thriftWriter
+	meta.i32(1, 1) // version
+	meta.listBegin(2, thriftStruct, len(t.columns)+1)
+	meta.elemBegin()
+	meta.binary(4, "schema")
+	meta.i32(5, int32(len(t.columns)))
+	meta.elemEnd()
+	for _, c := range t.columns {
+		meta.elemBegin()
+		meta.i32(1, int32(c.typ))
+		meta.i32(3, parquetRequired)
+		meta.binary(4, c.name)
+		if c.typ == parquetByteArray {
+			meta.i32(6, parquetUTF8)
+		}
+		meta.elemEnd()
+	}
+	meta.i64(3, t.rows)
+	meta.listBegin(4, thriftStruct, 1)
+	meta.elemBegin() // RowGroup
+	meta.listBegin(1, thriftStruct, len(t.columns))
+	var total int64
+	for i, c := range t.columns {
+		meta.elemBegin() // ColumnChunk
+		meta.i64(2, offsets[i])
+		meta.structBegin(3) // ColumnMetaData
+		meta.i32(1, int32(c.typ))
+		meta.listBegin(2, thriftI32, 1)
+		meta.varint(zigzag(parquetPlain))
+		meta.listBegin(3, thriftBinary, 1)
+		meta.varint(uint64(len(c.name)))
+		meta.buf = append(meta.buf, c.name...)
+		meta.i32(4, parquetUncompressed)
+		meta.i64(5, t.rows)
+		meta.i64(6, sizes[i])
+		meta.i64(7, sizes[i])
+		meta.i64(9, offsets[i])
+		meta.structEnd()
+		meta.elemEnd()
+		total += sizes[i]
+	}
+	meta.i64(2, total)
+	meta.i64(3, t.rows)
+	meta.elemEnd()
+	meta.binary(6, "scheduler "+scheduler.Version)
+	meta.stop()
+
+	file.Write(meta.buf)
+	var footer [4]byte
+	binary.LittleEndian.PutUint32(footer[:], uint32(len(meta.buf)))
+	file.Write(footer[:])
+	file.WriteString(parquetMagic)
+
+	_, err := file.WriteTo(w)
+	return err
+}
+
+// Thrift compact protocol type codes.
+const (
+	thriftI32    = 5
+	thriftI64    = 6
+	thriftBinary = 8
+	thriftList   = 9
+	thriftStruct = 12
+)
+
+// thriftWriter encodes the Thrift compact protocol that Parquet metadata is serialized with.
+// Field IDs are delta-encoded against the previous field of the enclosing struct, so nested
+// structs push the last field ID onto a stack.
+type thriftWriter struct {
+	buf   []byte
+	last  int16
+	stack []int16
+}
+
+func (t *thriftWriter) field(id int16, typ byte) {
+	if delta := id - t.last; delta > 0 && delta <= 15 {
+		t.buf = append(t.buf, byte(delta)<<4|typ)
+	} else {
+		t.buf = append(t.buf, typ)
+		t.varint(zigzag(int64(id)))
+	}
+	t.last = id
+}
+
+func (t *thriftWriter) varint(v uint64) {
+	t.buf = binary.AppendUvarint(t.buf, v)
+}
+
+func zigzag(v int64) uint64 {
+	return uint64(v<<1) ^ uint64(v>>63)
+}
+
+func (t *thriftWriter) i32(id int16, v int32) {
+	t.field(id, thriftI32)
+	t.varint(zigzag(int64(v)))
+}
+
+func (t *thriftWriter) i64(id int16, v int64) {
+	t.field(id, thriftI64)
+	t.varint(zigzag(v))
+}
+
+func (t *thriftWriter) binary(id int16, s string) {
+	t.field(id, thriftBinary)
+	t.varint(uint64(len(s)))
+	t.buf = append(t.buf, s...)
+}
+
+// listBegin writes a list header; the caller then writes n elements of elemType.
+func (t *thriftWriter) listBegin(id int16, elemType byte, n int) {
+	t.field(id, thriftList)
+	if n < 15 {
+		t.buf = append(t.buf, byte(n)<<4|elemType)
+	} else {
+		t.buf = append(t.buf, 0xf0|elemType)
+		t.varint(uint64(n))
+	}
+}
+
+// structBegin starts a struct-typed field.
+func (t *thriftWriter) structBegin(id int16) {
+	t.field(id, thriftStruct)
+	t.elemBegin()
+}
+
+func (t *thriftWriter) structEnd() {
+	t.elemEnd()
+}
+
+// elemBegin starts a struct that is a list element, which has no field header.
+func (t *thriftWriter) elemBegin() {
+	t.stack = append(t.stack, t.last)
+	t.last = 0
+}
+
+func (t *thriftWriter) elemEnd() {
+	t.stop()
+	t.last = t.stack[len(t.stack)-1]
+	t.stack = t.stack[:len(t.stack)-1]
+}
+
+func (t *thriftWriter) stop() {
+	t.buf = append(t.buf, 0)
+}
+
+// writeParquet renders the per-process rows of results as Parquet, with the CSV columns.
+func writeParquet(w io.Writer, results []scheduler.Result) error {
+	t := newParquetTable(
+		parquetColumn{name: "input", typ: parquetByteArray},
+		parquetColumn{name: "algorithm", typ: parquetByteArray},
+		parquetColumn{name: "id", typ: parquetInt64},
+		parquetColumn{name: "priority", typ: parquetInt64},
+		parquetColumn{name: "burst", typ: parquetInt64},
+		parquetColumn{name: "arrival", typ: parquetInt64},
+		parquetColumn{name: "wait", typ: parquetInt64},
+		parquetColumn{name: "turnaround", typ: parquetInt64},
+		parquetColumn{name: "exit", typ: parquetInt64},
+		parquetColumn{name: "response", typ: parquetInt64},
+		parquetColumn{name: "status", typ: parquetByteArray},
+	)
+	for _, r := range results {
+		for _, p := range r.Processes {
+			t.string(0, r.Input)
+			t.string(1, r.Algorithm)
+			for i, v := range [...]int64{p.ID, p.Priority, p.Burst, p.Arrival, p.Wait, p.Turnaround, p.Exit, p.Response} {
+				t.int64(2+i, v)
+			}
+			t.string(10, string(p.Status))
+			t.endRow()
+		}
+	}
+
+	return t.writeTo(w)
+}
+
+// writeParquetTrace renders the Gantt slices of results as a Parquet event trace.
+func writeParquetTrace(w io.Writer, results []scheduler.Result) error {
+	t := newParquetTable(
+		parquetColumn{name: "input", typ: parquetByteArray},
+		parquetColumn{name: "algorithm", typ: parquetByteArray},
+		parquetColumn{name: "pid", typ: parquetInt64},
+		parquetColumn{name: "cpu", typ: parquetInt64},
+		parquetColumn{name: "start", typ: parquetInt64},
+		parquetColumn{name: "stop", typ: parquetInt64},
+	)
+	for _, r := range results {
+		for _, s := range scheduler.BusySlices(r.Gantt) {
+			t.string(0, r.Input)
+			t.string(1, r.Algorithm)
+			t.int64(2, s.PID)
+			t.int64(3, int64(s.CPU))
+			t.int64(4, s.Start)
+			t.int64(5, s.Stop)
+			t.endRow()
+		}
+	}
+
+	return t.writeTo(w)
+}
@@ -0,0 +1,169 @@
+package render
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+// thriftReader decodes the Thrift compact protocol into field-ID keyed maps, enough to check
+// the metadata the writer produces.
+type thriftReader struct {
+	t   *testing.T
+	buf []byte
+}
+
+func (r *thriftReader) byte() byte {
+	require.NotEmpty(r.t, r.buf)
+	b := r.buf[0]
+	r.buf = r.buf[1:]
+	return b
+}
+
+func (r *thriftReader) varint() uint64 {
+	v, n := binary.Uvarint(r.buf)
+	require.Positive(r.t, n)
+	r.buf = r.buf[n:]
+	return v
+}
+
+func (r *thriftReader) int() int64 {
+	v := r.varint()
+	return int64(v>>1) ^ -int64(v&1)
+}
+
+func (r *thriftReader) value(typ byte) any {
+	switch typ {
+	case thriftI32, thriftI64:
+		return r.int()
+	case thriftBinary:
+		n := r.varint()
+		s := string(r.buf[:n])
+		r.buf = r.buf[n:]
+		return s
+	case thriftList:
+		h := r.byte()
+		n, elem := uint64(h>>4), h&0x0f
+		if n == 15 {
+			n = r.varint()
+		}
+		list := make([]any, n)
+		for i := range list {
+			list[i] = r.value(elem)
+		}
+		return list
+	case thriftStruct:
+		return r.structure()
+	default:
+		r.t.Fatalf("unexpected thrift type %d", typ)
+		return nil
+	}
+}
+
+func (r *thriftReader) structure() map[int16]any {
+	fields := make(map[int16]any)
+	var last int16
+	for {
+		h := r.byte()
+		if h == 0 {
+			return fields
+		}
+		id := last + int16(h>>4)
+		if h>>4 == 0 {
+			id = int16(r.int())
+		}
+		fields[id] = r.value(h & 0x0f)
+		last = id
+	}
+}
+
+// readParquet decodes a file written by parquetTable into its column names and values.
+func readParquet(t *testing.T, b []byte) (names []string, columns [][]any) {
+	t.Helper()
+	require.Equal(t, parquetMagic, string(b[:4]))
+	require.Equal(t, parquetMagic, string(b[len(b)-4:]))
+	n := binary.LittleEndian.Uint32(b[len(b)-8:])
+	meta := (&thriftReader{t: t, buf: b[len(b)-8-int(n) : len(b)-8]}).structure()
+
+	schema := meta[2].([]any)
+	require.Equal(t, int64(len(schema)-1), schema[0].(map[int16]any)[5])
+	rows := meta[3].(int64)
+	chunks := meta[4].([]any)[0].(map[int16]any)[1].([]any)
+	for i, chunk := range chunks {
+		md := chunk.(map[int16]any)[3].(map[int16]any)
+		names = append(names, schema[i+1].(map[int16]any)[4].(string))
+		require.Equal(t, rows, md[5])
+
+		page := &thriftReader{t: t, buf: b[md[9].(int64):]}
+		header := page.structure()
+		data := page.buf[:header[3].(int64)]
+		var values []any
+		for j := int64(0); j < rows; j++ {
+			switch parquetType(md[1].(int64)) {
+			case parquetInt64:
+				values = append(values, int64(binary.LittleEndian.Uint64(data)))
+				data = data[8:]
+			case parquetByteArray:
+				l := binary.LittleEndian.Uint32(data)
+				values = append(values, string(data[4:4+l]))
+				data = data[4+l:]
+			}
+		}
+		assert.Empty(t, data)
+		columns = append(columns, values)
+	}
+
+	return names, columns
+}
+
+func Test_writeParquet(t *testing.T) {
+	t.Parallel()
+	results := []scheduler.Result{
+		{Algorithm: "fcfs", Input: "a.csv", Processes: []scheduler.ProcessResult{
+			{ID: 1, Priority: 2, Burst: 5, Exit: 5, Turnaround: 5},
+			{ID: 2, Priority: 1, Burst: 3, Arrival: 1, Wait: 4, Turnaround: 7, Response: 4, Exit: 8},
+		}},
+		{Algorithm: "sjf", Input: "a.csv", Processes: []scheduler.ProcessResult{{ID: 1, Burst: 9, Exit: 9, Status: scheduler.StatusRejected}}},
+	}
+	var b bytes.Buffer
+	require.NoError(t, writeParquet(&b, results))
+
+	names, columns := readParquet(t, b.Bytes())
+	assert.Equal(t, csvHeader, names)
+	assert.Equal(t, []any{"a.csv", "a.csv", "a.csv"}, columns[0])
+	assert.Equal(t, []any{"fcfs", "fcfs", "sjf"}, columns[1])
+	assert.Equal(t, []any{int64(5), int64(3), int64(9)}, columns[4])
+	assert.Equal(t, []any{int64(5), int64(8), int64(9)}, columns[8])
+	assert.Equal(t, []any{int64(0), int64(4), int64(0)}, columns[9])
+	assert.Equal(t, []any{"", "", "rejected"}, columns[10])
+}
+
+func Test_writeParquetTrace(t *testing.T) {
+	t.Parallel()
+	var gantt []scheduler.TimeSlice
+	for i := int64(0); i < 20; i++ {
+		gantt = append(gantt, scheduler.TimeSlice{PID: i%3 + 1, Start: i * 2, Stop: i*2 + 2})
+	}
+	var b bytes.Buffer
+	require.NoError(t, writeParquetTrace(&b, []scheduler.Result{{Algorithm: "rr", Input: "b.csv", Gantt: gantt}}))
+
+	names, columns := readParquet(t, b.Bytes())
+	assert.Equal(t, []string{"input", "algorithm", "pid", "cpu", "start", "stop"}, names)
+	require.Len(t, columns[2], 20)
+	assert.Equal(t, int64(3), columns[2][2])
+	assert.Equal(t, int64(40), columns[5][19])
+}
+
+func Test_parquetTable_empty(t *testing.T) {
+	t.Parallel()
+	var b bytes.Buffer
+	require.NoError(t, writeParquet(&b, nil))
+	names, columns := readParquet(t, b.Bytes())
+	assert.Len(t, names, len(csvHeader))
+	assert.Empty(t, columns[0])
+}
@@ -0,0 +1,335 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+// This file is a minimal PDF writer: A4 pages drawn with the standard Helvetica fonts, filled
+// rectangles and lines. That is all a printable report needs, and it keeps the tool free of a PDF
+// dependency while producing vector output any viewer or printer accepts.
+
+// A4 page geometry, in points.
+const (
+	pdfPageWidth  = 595.0
+	pdfPageHeight = 842.0
+	pdfMargin     = 50.0
+	pdfWidth      = pdfPageWidth - 2*pdfMargin
+)
+
+// pdfFont selects one of the two fonts every page has.
+type pdfFont string
+
+const (
+	pdfRegular pdfFont = "F1"
+	pdfBold    pdfFont = "F2"
+)
+
+// pdfDocument lays content out top to bottom, starting a new page when the next item would not fit.
+type pdfDocument struct {
+	pages []*bytes.Buffer
+	y     float64 // baseline of the next line on the current page
+}
+
+func newPDFDocument() *pdfDocument {
+	d := &pdfDocument{}
+	d.newPage()
+
+	return d
+}
+
+func (d *pdfDocument) newPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+	d.y = pdfPageHeight - pdfMargin
+}
+
+// need starts a new page unless height points remain above the bottom margin.
+func (d *pdfDocument) need(height float64) {
+	if d.y-height < pdfMargin {
+		d.newPage()
+	}
+}
+
+func (d *pdfDocument) page() *bytes.Buffer {
+	return d.pages[len(d.pages)-1]
+}
+
+// text draws s with its baseline at (x, y).
+func (d *pdfDocument) text(x, y float64, font pdfFont, size float64, s string) {
+	_, _ = fmt.Fprintf(d.page(), "0 g BT /%s %s Tf %s %s Td (%s) Tj ET\n", font, pdfNum(size), pdfNum(x), pdfNum(y), pdfString(s))
+}
+
+// line draws s at the left margin as the next line of the flow.
+func (d *pdfDocument) line(font pdfFont, size float64, s string) {
+	d.need(size * 1.4)
+	d.y -= size * 1.4
+	d.text(pdfMargin, d.y, font, size, s)
+}
+
+// rect fills a rectangle with its lower left corner at (x, y) and outlines it in grey.
+func (d *pdfDocument) rect(x, y, w, h float64, rgb [3]float64) {
+	_, _ = fmt.Fprintf(d.page(), "%s %s %s rg 0.4 G 0.5 w %s %s %s %s re B\n",
+		pdfNum(rgb[0]), pdfNum(rgb[1]), pdfNum(rgb[2]), pdfNum(x), pdfNum(y), pdfNum(w), pdfNum(h))
+}
+
+// rule draws a horizontal line across the content width at y.
+func (d *pdfDocument) rule(y float64) {
+	_, _ = fmt.Fprintf(d.page(), "0.6 G 0.5 w %s %s m %s %s l S\n", pdfNum(pdfMargin), pdfNum(y), pdfNum(pdfMargin+pdfWidth), pdfNum(y))
+}
+
+// table draws a header row and rows in equal-width columns, repeating the header on new pages.
+func (d *pdfDocument) table(header []string, rows [][]string) {
+	const size, height = 9.0, 14.0
+	width := pdfWidth / float64(len(header))
+	drawRow := func(cells []string, font pdfFont) {
+		d.y -= height
+		for i, c := range cells {
+			d.text(pdfMargin+float64(i)*width+2, d.y+4, font, size, c)
+		}
+	}
+	d.need(2 * height)
+	drawRow(header, pdfBold)
+	d.rule(d.y)
+	for _, row := range rows {
+		if d.y-height < pdfMargin {
+			d.newPage()
+			drawRow(header, pdfBold)
+			d.rule(d.y)
+		}
+		drawRow(row, pdfRegular)
+	}
+}
+
+// gantt draws the busy slices as one lane of boxes per CPU, labeled with their PIDs, above a
+// time axis.
+func (d *pdfDocument) gantt(slices []scheduler.TimeSlice, unit scheduler.TimeUnit) {
+	const lane, axis = 20.0, 24.0
+	var (
+		cpus     int
+		makespan int64
+	)
+	for _, s := range slices {
+		if s.CPU+1 > cpus {
+			cpus = s.CPU + 1
+		}
+		if s.Stop > makespan {
+			makespan = s.Stop
+		}
+	}
+	if makespan == 0 {
+		return
+	}
+	const labelWidth = 40.0
+	scale := (pdfWidth - labelWidth) / float64(makespan)
+	d.need(float64(cpus)*lane + axis + 10)
+	top := d.y - 6
+	for cpu := 0; cpu < cpus; cpu++ {
+		d.text(pdfMargin, top-float64(cpu+1)*lane+6, pdfRegular, 8, "CPU "+strconv.Itoa(cpu))
+	}
+	for _, s := range slices {
+		x := pdfMargin + labelWidth + float64(s.Start)*scale
+		w := float64(s.Stop-s.Start) * scale
+		y := top - float64(s.CPU+1)*lane
+		d.rect(x, y+2, w, lane-4, pidColor(s.PID))
+		if label := "P" + strconv.FormatInt(s.PID, 10); pdfTextWidth(label, 8) < w-2 {
+			d.text(x+(w-pdfTextWidth(label, 8))/2, y+lane/2-3, pdfRegular, 8, label)
+		}
+	}
+	bottom := top - float64(cpus)*lane
+	step := niceStep(makespan, 10)
+	for t := int64(0); t <= makespan; t += step {
+		x := pdfMargin + labelWidth + float64(t)*scale
+		_, _ = fmt.Fprintf(d.page(), "0.4 G 0.5 w %s %s m %s %s l S\n", pdfNum(x), pdfNum(bottom), pdfNum(x), pdfNum(bottom-3))
+		label := strconv.FormatInt(t, 10)
+		d.text(x-pdfTextWidth(label, 7)/2, bottom-11, pdfRegular, 7, label)
+	}
+	d.text(pdfMargin+labelWidth, bottom-21, pdfRegular, 7, unit.Label("Time"))
+	d.y = bottom - axis
+}
+
+// niceStep is a 1, 2 or 5 times a power of ten step giving at most about n ticks up to max.
+func niceStep(max int64, n int) int64 {
+	raw := float64(max) / float64(n)
+	if raw <= 1 {
+		return 1
+	}
+	magnitude := math.Pow(10, math.Floor(math.Log10(raw)))
+	for _, m := range []float64{1, 2, 5, 10} {
+		if step := m * magnitude; step >= raw {
+			return int64(step)
+		}
+	}
+
+	return int64(10 * magnitude)
+}
+
+// pidColor gives each process a distinct pastel fill, spacing neighbouring PIDs by the golden angle.
+func pidColor(pid int64) [3]float64 {
+	h := float64((pid*137)%360) / 60
+	const s, l = 0.6, 0.75
+	c := (1 - math.Abs(2*l-1)) * s
+	x := c * (1 - math.Abs(math.Mod(h, 2)-1))
+	var r, g, b float64
+	switch int(h) {
+	case 0:
+		r, g = c, x
+	case 1:
+		r, g = x, c
+	case 2:
+		g, b = c, x
+	case 3:
+		g, b = x, c
+	case 4:
+		r, b = x, c
+	default:
+		r, b = c, x
+	}
+	m := l - c/2
+
+	return [3]float64{r + m, g + m, b + m}
+}
+
+// pdfTextWidth approximates the width of Helvetica text, about half an em per character.
+func pdfTextWidth(s string, size float64) float64 {
+	return float64(len(s)) * size * 0.52
+}
+
+// pdfNum formats a coordinate or size compactly with two decimals at most.
+func pdfNum(v float64) string {
+	return strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64)
+}
+
+// pdfString escapes s as the body of a PDF literal string. The fonts use WinAnsiEncoding, so
+// characters outside Latin-1 print as '?'.
+func pdfString(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < ' ' || r > 0xff:
+			b.WriteByte('?')
+		case r > '~':
+			_, _ = fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
+
+// writePDF renders results as a printable report: for each result its title, run provenance,
+// Gantt chart and schedule table, then a comparison of every result's averages.
+func writePDF(w io.Writer, results []scheduler.Result, unit scheduler.TimeUnit) error {
+	d := newPDFDocument()
+	var last *scheduler.Provenance
+	for i, r := range results {
+		if i > 0 {
+			d.need(120)
+			d.y -= 16
+		}
+		d.line(pdfBold, 16, r.Title)
+		if r.Provenance != nil && r.Provenance != last {
+			var prov strings.Builder
+			outputProvenance(&prov, *r.Provenance)
+			for _, l := range strings.Split(strings.TrimSpace(prov.String()), "\n")[1:] {
+				d.line(pdfRegular, 7, strings.TrimSpace(l))
+			}
+			last = r.Provenance
+		}
+		if len(r.Processes) == 0 {
+			d.line(pdfRegular, 10, "No processes to schedule.")
+			continue
+		}
+		if slices := scheduler.BusySlices(r.Gantt); len(slices) > 0 {
+			d.y -= 6
+			d.gantt(slices, unit)
+		}
+		header := []string{"ID", "Priority", unit.Label("Burst"), unit.Label("Arrival"), unit.Label("Wait"),
+			unit.Label("Turnaround"), unit.Label("Exit")}
+		rows := make([][]string, 0, len(r.Processes))
+		var (
+			fields  []string
+			scratch []byte
+		)
+		for _, p := range r.Processes {
+			fields, scratch = appendRow(fields[:0], scratch, p)
+			row := append([]string(nil), fields...)
+			if !p.Completed() {
+				row[len(row)-1] += " (" + string(p.Status) + ")"
+			}
+			rows = append(rows, row)
+		}
+		d.y -= 4
+		d.table(header, rows)
+		d.line(pdfRegular, 9, fmt.Sprintf("Average wait %.2f, average turnaround %.2f, throughput %s",
+			r.AveWait, r.AveTurnaround, unit.Throughput(r.AveThroughput)))
+		if r.Incomplete > 0 {
+			var note strings.Builder
+			outputIncomplete(&note, r)
+			d.line(pdfRegular, 9, strings.TrimSpace(note.String()))
+		}
+	}
+
+	if len(results) > 1 {
+		d.need(80)
+		d.y -= 16
+		d.line(pdfBold, 16, "Comparison")
+		rows := make([][]string, len(results))
+		for i, r := range results {
+			rows[i] = []string{plotName(r.Input), r.Algorithm, strconv.FormatFloat(r.AveWait, 'f', 2, 64),
+				strconv.FormatFloat(r.AveTurnaround, 'f', 2, 64), unit.Throughput(r.AveThroughput)}
+		}
+		d.y -= 4
+		d.table([]string{"Input", "Algorithm", unit.Label("Average wait"), unit.Label("Average turnaround"), "Throughput"}, rows)
+	}
+
+	return d.writeTo(w)
+}
+
+// writeTo writes the document: the catalog, the page tree and fonts, then each page and its
+// content stream, followed by the cross-reference table.
+func (d *pdfDocument) writeTo(w io.Writer) error {
+	var (
+		out     bytes.Buffer
+		offsets []int
+	)
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		_, _ = fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// Objects 1-4 are fixed; each page n (from 0) is object 5+2n and its contents 6+2n.
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+2*i)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	for i, page := range d.pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			pdfNum(pdfPageWidth), pdfNum(pdfPageHeight), 6+2*i))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.Len(), page.String()))
+	}
+
+	xref := out.Len()
+	_, _ = fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		_, _ = fmt.Fprintf(&out, "%010d 00000 n \n", off)
+	}
+	_, _ = fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	_, err := w.Write(out.Bytes())
+
+	return err
+}
@@ -0,0 +1,82 @@
+package render
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+func Test_writePDF(t *testing.T) {
+	t.Parallel()
+	results := []scheduler.Result{
+		{Algorithm: "fcfs", Input: "a.csv", Title: "FCFS (first)", AveWait: 1.5, AveTurnaround: 4, AveThroughput: 0.25,
+			Gantt:     []scheduler.TimeSlice{{PID: 1, Start: 0, Stop: 3}, {PID: 2, Start: 3, Stop: 5}},
+			Processes: []scheduler.ProcessResult{{ID: 1, Burst: 3, Turnaround: 3, Exit: 3}, {ID: 2, Burst: 2, Wait: 3, Turnaround: 5, Exit: 5}}},
+		{Algorithm: "sjf", Input: "a.csv", Title: "SJF", Incomplete: 1,
+			Processes: []scheduler.ProcessResult{{ID: 1, Exit: 2, Status: scheduler.StatusKilled}}},
+	}
+	var b bytes.Buffer
+	require.NoError(t, writePDF(&b, results, scheduler.TimeUnitMillis))
+	doc := b.String()
+
+	assert.True(t, strings.HasPrefix(doc, "%PDF-1.4\n"))
+	assert.True(t, strings.HasSuffix(doc, "%%EOF\n"))
+	assert.Contains(t, doc, "(FCFS \\(first\\)) Tj")
+	assert.Contains(t, doc, "(P1) Tj")
+	assert.Contains(t, doc, "(Wait \\(ms\\)) Tj")
+	assert.Contains(t, doc, "(2 \\(killed\\)) Tj")
+	assert.Contains(t, doc, "(Comparison) Tj")
+	assert.Contains(t, doc, " re B\n", "the Gantt chart should be drawn as rectangles")
+
+	// Every cross-reference entry must point at its object.
+	start, err := strconv.Atoi(regexp.MustCompile(`startxref\n(\d+)`).FindStringSubmatch(doc)[1])
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(doc[start:], "xref\n"))
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllStringSubmatch(doc[start:], -1)
+	require.NotEmpty(t, entries)
+	for i, e := range entries {
+		off, err := strconv.Atoi(e[1])
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(doc[off:], strconv.Itoa(i+1)+" 0 obj\n"), "object %d", i+1)
+	}
+
+	var again bytes.Buffer
+	require.NoError(t, writePDF(&again, results, scheduler.TimeUnitMillis))
+	assert.Equal(t, b.Bytes(), again.Bytes(), "reports should be reproducible")
+}
+
+func Test_writePDF_paginates(t *testing.T) {
+	t.Parallel()
+	r := scheduler.Result{Algorithm: "fcfs", Title: "FCFS", Processes: make([]scheduler.ProcessResult, 200)}
+	for i := range r.Processes {
+		r.Processes[i].ID = int64(i + 1)
+	}
+	var b bytes.Buffer
+	require.NoError(t, writePDF(&b, []scheduler.Result{r}, scheduler.TimeUnitTicks))
+	pages := regexp.MustCompile(`/Count (\d+)`).FindStringSubmatch(b.String())
+	require.NotNil(t, pages)
+	n, err := strconv.Atoi(pages[1])
+	require.NoError(t, err)
+	assert.Greater(t, n, 1)
+	assert.Equal(t, n, strings.Count(b.String(), "(ID) Tj"), "the header should repeat on every page")
+}
+
+func Test_pdfString(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, `a\(b\)\\c`, pdfString(`a(b)\c`))
+	assert.Equal(t, `caf\351 ?`, pdfString("café ✓"))
+}
+
+func Test_niceStep(t *testing.T) {
+	t.Parallel()
+	for max, want := range map[int64]int64{5: 1, 10: 1, 11: 2, 45: 5, 100: 10, 1234: 200} {
+		assert.Equal(t, want, niceStep(max, 10), "max %d", max)
+	}
+}
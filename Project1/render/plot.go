@@ -0,0 +1,286 @@
+package render
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+	"github.com/jh125486/CSCE4600/Project1/workload"
+)
+
+// plotter writes plots of one workload's results into dir; name is the workload's base name.
+type plotter func(dir, name string, results []scheduler.Result, unit scheduler.TimeUnit) error
+
+// plotters are the plot kinds accepted by --plot.
+var plotters = map[string]plotter{
+	"gif":        writeGIFs,
+	"gnuplot":    writeGnuplot,
+	"heatmap":    writeHeatmaps,
+	"migrations": writeMigrations,
+	"plotly":     writePlotly,
+}
+
+// DefaultPlotDir is where --plot writes its files unless --plot-dir says otherwise.
+const DefaultPlotDir = "plots"
+
+var ErrInvalidPlot = fmt.Errorf("%w: unknown plot kind", scheduler.ErrInvalidArgs)
+
+// ParsePlot parses a --plot kind, ignoring case.
+func ParsePlot(s string) (string, error) {
+	s = strings.ToLower(s)
+	if _, ok := plotters[s]; !ok {
+		names := make([]string, 0, len(plotters))
+		for name := range plotters {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return "", fmt.Errorf("%w: %q (known: %s)", ErrInvalidPlot, s, strings.Join(names, ", "))
+	}
+
+	return s, nil
+}
+
+// WritePlots writes every requested plot kind for each workload in results, in input order.
+func WritePlots(dir string, kinds []string, results []scheduler.Result, unit scheduler.TimeUnit) error {
+	var (
+		inputs  []string
+		byInput = make(map[string][]scheduler.Result)
+	)
+	for _, r := range results {
+		if _, ok := byInput[r.Input]; !ok {
+			inputs = append(inputs, r.Input)
+		}
+		byInput[r.Input] = append(byInput[r.Input], r)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("%w: creating plot directory", err)
+	}
+	for _, input := range inputs {
+		for _, kind := range kinds {
+			if err := plotters[kind](dir, plotName(input), byInput[input], unit); err != nil {
+				return fmt.Errorf("%s plot of %s: %w", kind, input, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// plotName is the base name plot files are given for a workload, like {input} in -o patterns.
+func plotName(input string) string {
+	if input == workload.StdinPath || input == "" {
+		return "stdin"
+	}
+
+	return strings.TrimSuffix(filepath.Base(input), filepath.Ext(input))
+}
+
+// writeGnuplot writes name.dat, holding the average metrics then one Gantt block per
+// scheduler, and name.gp, a script that plots them as a bar chart and Gantt charts.
+func writeGnuplot(dir, name string, results []scheduler.Result, unit scheduler.TimeUnit) error {
+	var (
+		data, script strings.Builder
+		gantts       []scheduler.Result
+		dataFile     = name + ".dat"
+	)
+	_, _ = fmt.Fprintln(&data, "# algorithm average_wait average_turnaround throughput")
+	for _, r := range results {
+		_, _ = fmt.Fprintf(&data, "%q %g %g %g\n", r.Algorithm, r.AveWait, r.AveTurnaround, r.AveThroughput)
+		if len(r.Gantt) > 0 {
+			gantts = append(gantts, r)
+		}
+	}
+	for _, r := range gantts {
+		// Two blank lines start a new block, addressed in the script with index.
+		_, _ = fmt.Fprintf(&data, "\n\n# %s: pid start stop cpu\n", r.Algorithm)
+		for _, s := range scheduler.BusySlices(r.Gantt) {
+			_, _ = fmt.Fprintf(&data, "%d %d %d %d\n", s.PID, s.Start, s.Stop, s.CPU)
+		}
+	}
+
+	file := gnuplotString(dataFile)
+	_, _ = fmt.Fprintf(&script, `# Generated by scheduler %s. Run it from this directory:
+#   gnuplot -p %[2]s.gp                        interactive window
+#   gnuplot -e "out='%[2]s.pdf'" %[2]s.gp       PDF, e.g. for LaTeX
+if (exists("out")) {
+    set terminal pdfcairo size 8in,%din
+    set output out
+}
+set termoption noenhanced
+set multiplot layout %d,1
+
+set title "Average wait and turnaround"
+set ylabel %s
+set style data histograms
+set style histogram clustered gap 1
+set style fill solid 0.8 border -1
+set yrange [0:*]
+plot %s index 0 using 2:xtic(1) title "Average wait", '' index 0 using 3 title "Average turnaround"
+`, scheduler.Version, name, 3*(1+len(gantts)), 1+len(gantts), gnuplotString(unit.Label("Time")), file)
+
+	for i, r := range gantts {
+		var maxPID int64
+		for _, s := range scheduler.BusySlices(r.Gantt) {
+			if s.PID > maxPID {
+				maxPID = s.PID
+			}
+		}
+		_, _ = fmt.Fprintf(&script, `
+set title %s
+set xlabel %s
+set ylabel "Process"
+set ytics 1
+set yrange [0.5:%d.5]
+set xrange [0:*]
+set style fill solid 0.6 border -1
+plot %s index %d using (($2+$3)/2):1:2:3:($1-0.4):($1+0.4):1 with boxxyerror lc variable notitle
+unset xrange
+`, gnuplotString(r.Title+" Gantt chart"), gnuplotString(unit.Label("Time")), maxPID, file, i+1)
+	}
+	_, _ = fmt.Fprintln(&script, "\nunset multiplot")
+
+	if err := os.WriteFile(filepath.Join(dir, dataFile), []byte(data.String()), 0o644); err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(dir, name+".gp"), []byte(script.String()), 0o644)
+}
+
+// gnuplotString quotes s as a gnuplot single-quoted string, which has no escapes but ”.
+func gnuplotString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+type (
+	// plotlyFigure is a Plotly figure, as read by plotly.io.read_json or Plotly.newPlot.
+	plotlyFigure struct {
+		Data   []plotlyTrace `json:"data"`
+		Layout plotlyLayout  `json:"layout"`
+	}
+	plotlyTrace struct {
+		Type          string    `json:"type"`
+		Name          string    `json:"name,omitempty"`
+		Orientation   string    `json:"orientation,omitempty"`
+		X             any       `json:"x"`
+		Y             any       `json:"y"`
+		Base          []int64   `json:"base,omitempty"`
+		CustomData    [][]int64 `json:"customdata,omitempty"`
+		HoverTemplate string    `json:"hovertemplate,omitempty"`
+		YAxis         string    `json:"yaxis,omitempty"`
+	}
+	plotlyLayout struct {
+		Title   plotlyText  `json:"title"`
+		BarMode string      `json:"barmode,omitempty"`
+		XAxis   plotlyAxis  `json:"xaxis"`
+		YAxis   plotlyAxis  `json:"yaxis"`
+		YAxis2  *plotlyAxis `json:"yaxis2,omitempty"`
+	}
+	plotlyAxis struct {
+		Title      plotlyText `json:"title"`
+		AutoRange  string     `json:"autorange,omitempty"`
+		Overlaying string     `json:"overlaying,omitempty"`
+		Side       string     `json:"side,omitempty"`
+	}
+	plotlyText struct {
+		Text string `json:"text"`
+	}
+)
+
+// writePlotly writes Plotly figure JSON: name-metrics.plotly.json, a bar chart of the
+// average metrics, and name-{algo}-gantt.plotly.json, one Gantt timeline per scheduler that
+// recorded slices, with hover details for each slice.
+func writePlotly(dir, name string, results []scheduler.Result, unit scheduler.TimeUnit) error {
+	if err := writePlotlyFigure(filepath.Join(dir, name+"-metrics.plotly.json"), plotlyMetrics(results, unit)); err != nil {
+		return err
+	}
+	for _, r := range results {
+		if len(r.Gantt) == 0 {
+			continue
+		}
+		path := filepath.Join(dir, name+"-"+r.Algorithm+"-gantt.plotly.json")
+		if err := writePlotlyFigure(path, plotlyGantt(r, unit)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writePlotlyFigure(path string, fig plotlyFigure) error {
+	b, err := json.MarshalIndent(fig, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, append(b, '\n'), 0o644)
+}
+
+func plotlyMetrics(results []scheduler.Result, unit scheduler.TimeUnit) plotlyFigure {
+	var (
+		algorithms       = make([]string, len(results))
+		wait, turnaround = make([]float64, len(results)), make([]float64, len(results))
+		throughput       = make([]float64, len(results))
+	)
+	for i, r := range results {
+		algorithms[i] = r.Algorithm
+		wait[i], turnaround[i], throughput[i] = r.AveWait, r.AveTurnaround, r.AveThroughput
+	}
+
+	return plotlyFigure{
+		Data: []plotlyTrace{
+			{Type: "bar", Name: "Average wait", X: algorithms, Y: wait},
+			{Type: "bar", Name: "Average turnaround", X: algorithms, Y: turnaround},
+			{Type: "scatter", Name: "Throughput", X: algorithms, Y: throughput, YAxis: "y2"},
+		},
+		Layout: plotlyLayout{
+			Title:   plotlyText{Text: "Average metrics"},
+			BarMode: "group",
+			XAxis:   plotlyAxis{Title: plotlyText{Text: "Algorithm"}},
+			YAxis:   plotlyAxis{Title: plotlyText{Text: unit.Label("Time")}},
+			YAxis2:  &plotlyAxis{Title: plotlyText{Text: "Throughput"}, Overlaying: "y", Side: "right"},
+		},
+	}
+}
+
+// plotlyGantt draws a result's slices as horizontal bars, one trace per process so each
+// gets its own color and legend entry.
+func plotlyGantt(r scheduler.Result, unit scheduler.TimeUnit) plotlyFigure {
+	var (
+		traces []plotlyTrace
+		byPID  = make(map[int64]int)
+	)
+	for _, s := range scheduler.BusySlices(r.Gantt) {
+		i, ok := byPID[s.PID]
+		if !ok {
+			i = len(traces)
+			byPID[s.PID] = i
+			traces = append(traces, plotlyTrace{
+				Type:          "bar",
+				Name:          fmt.Sprintf("P%d", s.PID),
+				Orientation:   "h",
+				X:             []int64{},
+				Y:             []string{},
+				HoverTemplate: "P%{customdata[0]} on CPU %{customdata[3]}<br>%{customdata[1]} to %{customdata[2]} (%{x})<extra></extra>",
+			})
+		}
+		t := &traces[i]
+		t.X = append(t.X.([]int64), s.Stop-s.Start)
+		t.Y = append(t.Y.([]string), fmt.Sprintf("P%d", s.PID))
+		t.Base = append(t.Base, s.Start)
+		t.CustomData = append(t.CustomData, []int64{s.PID, s.Start, s.Stop, int64(s.CPU)})
+	}
+
+	return plotlyFigure{
+		Data: traces,
+		Layout: plotlyLayout{
+			Title:   plotlyText{Text: r.Title + " Gantt chart"},
+			BarMode: "overlay",
+			XAxis:   plotlyAxis{Title: plotlyText{Text: unit.Label("Time")}},
+			YAxis:   plotlyAxis{Title: plotlyText{Text: "Process"}, AutoRange: "reversed"},
+		},
+	}
+}
@@ -0,0 +1,102 @@
+package render
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+	"github.com/jh125486/CSCE4600/Project1/workload"
+)
+
+var plotResults = []scheduler.Result{
+	{
+		Algorithm: "fcfs", Input: "workloads/a.csv", Title: "First-come, first-serve",
+		Gantt:   []scheduler.TimeSlice{{PID: 1, Start: 0, Stop: 5}, {PID: 2, Start: 5, Stop: 8}},
+		AveWait: 2, AveTurnaround: 6.5, AveThroughput: 0.25,
+	},
+	{Algorithm: "sjf", Input: "workloads/a.csv", Title: "Shortest-job-first", AveWait: 1.5, AveTurnaround: 6},
+	{Algorithm: "fcfs", Input: "b.csv", Title: "First-come, first-serve"},
+}
+
+func Test_writePlots_gnuplot(t *testing.T) {
+	t.Parallel()
+	dir := filepath.Join(t.TempDir(), "plots")
+	require.NoError(t, WritePlots(dir, []string{"gnuplot"}, plotResults, scheduler.TimeUnitMillis))
+
+	data, err := os.ReadFile(filepath.Join(dir, "a.dat"))
+	require.NoError(t, err)
+	assert.Equal(t, `# algorithm average_wait average_turnaround throughput
+"fcfs" 2 6.5 0.25
+"sjf" 1.5 6 0
+
+
+# fcfs: pid start stop cpu
+1 0 5 0
+2 5 8 0
+`, string(data))
+
+	script, err := os.ReadFile(filepath.Join(dir, "a.gp"))
+	require.NoError(t, err)
+	assert.Contains(t, string(script), "set multiplot layout 2,1")
+	assert.Contains(t, string(script), "set ylabel 'Time (ms)'")
+	assert.Contains(t, string(script), "plot 'a.dat' index 0 using 2:xtic(1)")
+	assert.Contains(t, string(script), "set title 'First-come, first-serve Gantt chart'")
+	assert.Contains(t, string(script), "set yrange [0.5:2.5]")
+	assert.Contains(t, string(script), "plot 'a.dat' index 1 using")
+
+	assert.FileExists(t, filepath.Join(dir, "b.gp"))
+	assert.FileExists(t, filepath.Join(dir, "b.dat"))
+}
+
+func TestParsePlot(t *testing.T) {
+	t.Parallel()
+	got, err := ParsePlot("GnuPlot")
+	require.NoError(t, err)
+	assert.Equal(t, "gnuplot", got)
+
+	_, err = ParsePlot("excel")
+	assert.ErrorIs(t, err, ErrInvalidPlot)
+}
+
+func Test_plotName(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "day", plotName("/tmp/traces/day.sacct"))
+	assert.Equal(t, "stdin", plotName(workload.StdinPath))
+}
+
+func Test_gnuplotString(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "'it''s'", gnuplotString("it's"))
+}
+
+func Test_writePlots_plotly(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	require.NoError(t, WritePlots(dir, []string{"plotly"}, plotResults[:2], scheduler.TimeUnitTicks))
+	assert.NoFileExists(t, filepath.Join(dir, "a-sjf-gantt.plotly.json"), "sjf recorded no slices")
+
+	var metrics, gantt plotlyFigure
+	b, err := os.ReadFile(filepath.Join(dir, "a-metrics.plotly.json"))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(b, &metrics))
+	require.Len(t, metrics.Data, 3)
+	assert.Equal(t, []any{"fcfs", "sjf"}, metrics.Data[0].X)
+	assert.Equal(t, []any{2.0, 1.5}, metrics.Data[0].Y)
+	assert.Equal(t, "y2", metrics.Data[2].YAxis)
+
+	b, err = os.ReadFile(filepath.Join(dir, "a-fcfs-gantt.plotly.json"))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(b, &gantt))
+	require.Len(t, gantt.Data, 2)
+	assert.Equal(t, "P2", gantt.Data[1].Name)
+	assert.Equal(t, "h", gantt.Data[1].Orientation)
+	assert.Equal(t, []any{3.0}, gantt.Data[1].X)
+	assert.Equal(t, []int64{5}, gantt.Data[1].Base)
+	assert.Equal(t, [][]int64{{2, 5, 8, 0}}, gantt.Data[1].CustomData)
+	assert.Equal(t, "reversed", gantt.Layout.YAxis.AutoRange)
+}
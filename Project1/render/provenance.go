@@ -0,0 +1,34 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+// outputProvenance prints the provenance header that precedes text results.
+func outputProvenance(w io.Writer, p scheduler.Provenance) {
+	rev := ""
+	if p.Revision != "" {
+		rev = fmt.Sprintf(" (rev %s)", p.Revision)
+	}
+	_, _ = fmt.Fprintln(w, "Run provenance")
+	_, _ = fmt.Fprintf(w, "  tool:       %s %s%s\n", p.Tool, p.Version, rev)
+	_, _ = fmt.Fprintf(w, "  input:      %s (sha256 %s)\n", p.Input, p.InputSHA256)
+	_, _ = fmt.Fprintf(w, "  parameters: %s\n", joinSorted(p.Parameters, "="))
+	_, _ = fmt.Fprintf(w, "  algorithms: %s\n", joinSorted(p.Algorithms, "@"))
+	_, _ = fmt.Fprintln(w)
+}
+
+func joinSorted(m map[string]string, sep string) string {
+	pairs := make([]string, 0, len(m))
+	for k, v := range m {
+		pairs = append(pairs, k+sep+v)
+	}
+	sort.Strings(pairs)
+
+	return strings.Join(pairs, " ")
+}
@@ -0,0 +1,32 @@
+package render
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+func Test_outputProvenance(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	outputProvenance(&w, scheduler.Provenance{
+		Tool:        "scheduler",
+		Version:     "1.0.0",
+		Revision:    "abc123",
+		Input:       "in.csv",
+		InputSHA256: "ff",
+		Parameters:  map[string]string{"time_unit": "ticks", "quantum": "2"},
+		Algorithms:  map[string]string{"sjf": "1", "fcfs": "1"},
+	})
+	want := `Run provenance
+  tool:       scheduler 1.0.0 (rev abc123)
+  input:      in.csv (sha256 ff)
+  parameters: quantum=2 time_unit=ticks
+  algorithms: fcfs@1 sjf@1
+
+`
+	if got := w.String(); got != want {
+		t.Errorf("outputProvenance() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,174 @@
+package render
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+// WriteJSON renders a single result as an indented JSON object.
+func WriteJSON(w io.Writer, r scheduler.Result) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(r)
+}
+
+// WriteJSONArray renders several results as an indented JSON array.
+func WriteJSONArray(w io.Writer, results []scheduler.Result) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(results)
+}
+
+var csvHeader = []string{"input", "algorithm", "id", "priority", "burst", "arrival", "wait", "turnaround", "exit", "response", "status"}
+
+// writeCSV renders the per-process rows of results as CSV with a single header line.
+func writeCSV(w io.Writer, results []scheduler.Result) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	var (
+		fields  = make([]string, 0, len(csvHeader))
+		scratch []byte
+	)
+	for _, r := range results {
+		for _, p := range r.Processes {
+			fields, scratch = appendCSVRow(append(fields[:0], r.Input, r.Algorithm), scratch, p)
+			if err := cw.Write(fields); err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+
+	return cw.Error()
+}
+
+// appendCSVRow appends the CSV columns of p to fields: the schedule table's, then the response
+// time and the status, empty for a process that completed.
+func appendCSVRow(fields []string, scratch []byte, p scheduler.ProcessResult) ([]string, []byte) {
+	fields, scratch = appendRow(fields, scratch, p)
+
+	return append(fields, strconv.FormatInt(p.Response, 10), string(p.Status)), scratch
+}
+
+var tidyHeader = []string{"run_id", "algorithm", "pid", "metric", "value"}
+
+// writeTidyCSV renders results as long-format CSV, one metric value per row, ready for
+// ggplot or seaborn without reshaping. Each process that completed contributes its wait,
+// turnaround, response and exit; each run adds its averages and throughput with an empty pid, and
+// the number of processes that did not complete when there are any, as those processes are left
+// out. The run_id is the workload input.
+func writeTidyCSV(w io.Writer, results []scheduler.Result) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(tidyHeader); err != nil {
+		return err
+	}
+	var (
+		fields  = make([]string, len(tidyHeader))
+		scratch []byte
+	)
+	for _, r := range results {
+		fields[0], fields[1] = r.Input, r.Algorithm
+		for _, p := range r.Processes {
+			if !p.Completed() {
+				continue
+			}
+			fields[2] = strconv.FormatInt(p.ID, 10)
+			for _, m := range [...]struct {
+				name  string
+				value int64
+			}{{"wait", p.Wait}, {"turnaround", p.Turnaround}, {"response", p.Response}, {"exit", p.Exit}} {
+				scratch = strconv.AppendInt(scratch[:0], m.value, 10)
+				fields[3], fields[4] = m.name, string(scratch)
+				if err := cw.Write(fields); err != nil {
+					return err
+				}
+			}
+		}
+		fields[2] = ""
+		for _, m := range [...]struct {
+			name  string
+			value float64
+		}{{"average_wait", r.AveWait}, {"average_turnaround", r.AveTurnaround}, {"average_response", r.AveResponse}, {"throughput", r.AveThroughput}} {
+			fields[3], fields[4] = m.name, strconv.FormatFloat(m.value, 'g', -1, 64)
+			if err := cw.Write(fields); err != nil {
+				return err
+			}
+		}
+		if r.Incomplete > 0 {
+			fields[3], fields[4] = "incomplete", strconv.Itoa(r.Incomplete)
+			if err := cw.Write(fields); err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+
+	return cw.Error()
+}
+
+type (
+	// NDJSONRecord is one line of NDJSON output: a process row, a Gantt slice or a run summary.
+	NDJSONRecord struct {
+		Type      string `json:"type"`
+		Input     string `json:"input,omitempty"`
+		Algorithm string `json:"algorithm,omitempty"`
+		*scheduler.ProcessResult
+		*scheduler.TimeSlice
+		*NDJSONSummary
+	}
+	// NDJSONSummary is the averages of a run, as reported on its summary line.
+	NDJSONSummary struct {
+		Title         string  `json:"title"`
+		AveWait       float64 `json:"average_wait"`
+		AveTurnaround float64 `json:"average_turnaround"`
+		AveThroughput float64 `json:"throughput"`
+		Incomplete    int     `json:"incomplete,omitempty"`
+	}
+)
+
+// SummaryRecord is the NDJSON summary line of a result.
+func SummaryRecord(r scheduler.Result) NDJSONRecord {
+	return NDJSONRecord{
+		Type:      "summary",
+		Input:     r.Input,
+		Algorithm: r.Algorithm,
+		NDJSONSummary: &NDJSONSummary{
+			Title:         r.Title,
+			AveWait:       r.AveWait,
+			AveTurnaround: r.AveTurnaround,
+			AveThroughput: r.AveThroughput,
+			Incomplete:    r.Incomplete,
+		},
+	}
+}
+
+// writeNDJSON renders results as newline-delimited JSON: each run's Gantt slices, then its
+// process rows, then a summary line. Streamed runs produce the same records incrementally.
+func writeNDJSON(w io.Writer, results []scheduler.Result) error {
+	enc := json.NewEncoder(w)
+	for _, r := range results {
+		for i := range r.Gantt {
+			if err := enc.Encode(NDJSONRecord{Type: "slice", Input: r.Input, Algorithm: r.Algorithm, TimeSlice: &r.Gantt[i]}); err != nil {
+				return err
+			}
+		}
+		for i := range r.Processes {
+			if err := enc.Encode(NDJSONRecord{Type: "process", Input: r.Input, Algorithm: r.Algorithm, ProcessResult: &r.Processes[i]}); err != nil {
+				return err
+			}
+		}
+		if err := enc.Encode(SummaryRecord(r)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package render
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+	"github.com/jh125486/CSCE4600/Project1/schedulerbench"
+)
+
+func TestWriteJSON(t *testing.T) {
+	t.Parallel()
+	want := scheduler.Result{
+		Algorithm:     "fcfs",
+		Title:         "First-come, first-serve",
+		Gantt:         []scheduler.TimeSlice{{PID: 1, Start: 0, Stop: 5}},
+		Processes:     []scheduler.ProcessResult{{ID: 1, Priority: 2, Burst: 5, Turnaround: 5, Exit: 5}},
+		AveTurnaround: 5,
+		AveThroughput: 0.2,
+	}
+	var w bytes.Buffer
+	if err := WriteJSON(&w, want); err != nil {
+		t.Fatal(err)
+	}
+	var got scheduler.Result
+	if err := json.Unmarshal(w.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("round trip = %+v, want %+v", got, want)
+	}
+}
+
+func Test_writeCSV_incomplete(t *testing.T) {
+	t.Parallel()
+	results := []scheduler.Result{{Algorithm: "fcfs", Input: "a.csv", Incomplete: 1, Processes: []scheduler.ProcessResult{
+		{ID: 1, Burst: 2, Arrival: 1, Wait: 3, Turnaround: 5, Response: 3, Exit: 6},
+		{ID: 2, Burst: 4, Arrival: 2, Exit: 2, Status: scheduler.StatusRejected},
+	}}}
+	var w bytes.Buffer
+	if err := writeCSV(&w, results); err != nil {
+		t.Fatal(err)
+	}
+	want := "input,algorithm,id,priority,burst,arrival,wait,turnaround,exit,response,status\n" +
+		"a.csv,fcfs,1,0,2,1,3,5,6,3,\n" +
+		"a.csv,fcfs,2,0,4,2,0,0,2,0,rejected\n"
+	if w.String() != want {
+		t.Errorf("writeCSV() = %q, want %q", w.String(), want)
+	}
+
+	// Tidy CSV leaves the rejected process out, counting it as incomplete.
+	w.Reset()
+	if err := writeTidyCSV(&w, results); err != nil {
+		t.Fatal(err)
+	}
+	want = "run_id,algorithm,pid,metric,value\n" +
+		"a.csv,fcfs,1,wait,3\na.csv,fcfs,1,turnaround,5\na.csv,fcfs,1,response,3\na.csv,fcfs,1,exit,6\n" +
+		"a.csv,fcfs,,average_wait,0\na.csv,fcfs,,average_turnaround,0\na.csv,fcfs,,average_response,0\na.csv,fcfs,,throughput,0\n" +
+		"a.csv,fcfs,,incomplete,1\n"
+	if w.String() != want {
+		t.Errorf("writeTidyCSV() = %q, want %q", w.String(), want)
+	}
+}
+
+func BenchmarkWriteCSV(b *testing.B) {
+	jobs := schedulerbench.Batch(10000, 1).Jobs
+	processes := make([]scheduler.Process, len(jobs))
+	for i, j := range jobs {
+		processes[i] = scheduler.Process{ProcessID: j.ID, ArrivalTime: j.Arrival, BurstDuration: j.Burst, Priority: j.Priority}
+	}
+	r := scheduler.FCFS("bench", processes)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := writeCSV(io.Discard, []scheduler.Result{r}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+// sparklineWidth is the most columns a utilization sparkline spans; shorter schedules get one
+// column per time unit.
+const sparklineWidth = 60
+
+// sparklineLevels are the glyphs for utilization from just above 0 up to 100%. An idle
+// column is a space, so gaps stand out from light load.
+var sparklineLevels = []rune("▁▂▃▄▅▆▇█")
+
+// cpuUtilization is one CPU's busy fraction in each of the columns of a schedule's timeline.
+type cpuUtilization struct {
+	columns []float64
+	overall float64
+}
+
+// utilization divides the timeline from 0 to the last slice's end into at most width equal
+// columns and returns, for every CPU that ran anything, the fraction of each column it was busy.
+func utilization(gantt []scheduler.TimeSlice, width int) []cpuUtilization {
+	slices := scheduler.BusySlices(gantt)
+	var (
+		cpus     int
+		makespan int64
+	)
+	for _, s := range gantt {
+		if s.Stop > makespan {
+			makespan = s.Stop
+		}
+	}
+	for _, s := range slices {
+		if s.CPU+1 > cpus {
+			cpus = s.CPU + 1
+		}
+	}
+	if makespan == 0 || cpus == 0 {
+		return nil
+	}
+	if int64(width) > makespan {
+		width = int(makespan)
+	}
+	column := float64(makespan) / float64(width)
+	cpu := make([]cpuUtilization, cpus)
+	for i := range cpu {
+		cpu[i].columns = make([]float64, width)
+	}
+	for _, s := range slices {
+		start, stop := float64(s.Start), float64(s.Stop)
+		for c := int(start / column); c < width && float64(c)*column < stop; c++ {
+			lo := math.Max(start, float64(c)*column)
+			hi := math.Min(stop, float64(c+1)*column)
+			if hi > lo {
+				cpu[s.CPU].columns[c] += (hi - lo) / column
+			}
+		}
+		cpu[s.CPU].overall += (stop - start) / float64(makespan)
+	}
+
+	return cpu
+}
+
+// sparkline renders busy fractions as a row of block glyphs.
+func sparkline(columns []float64) string {
+	var b strings.Builder
+	for _, u := range columns {
+		if u <= 0 {
+			b.WriteByte(' ')
+			continue
+		}
+		level := int(math.Ceil(u*float64(len(sparklineLevels)))) - 1
+		if level >= len(sparklineLevels) {
+			level = len(sparklineLevels) - 1
+		}
+		b.WriteRune(sparklineLevels[level])
+	}
+
+	return b.String()
+}
+
+// Sparkline renders fractions from 0 to 1 as a row of block glyphs, one per fraction, with a
+// space for 0, as the utilization sparklines are drawn.
+func Sparkline(columns []float64) string {
+	return sparkline(columns)
+}
+
+// outputSparklines prints a utilization sparkline per CPU, with its overall utilization, to show
+// at a glance whether the load was bursty or steady.
+func outputSparklines(w io.Writer, gantt []scheduler.TimeSlice, unit scheduler.TimeUnit) {
+	cpus := utilization(gantt, sparklineWidth)
+	if len(cpus) == 0 {
+		return
+	}
+	var makespan int64
+	for _, s := range gantt {
+		if s.Stop > makespan {
+			makespan = s.Stop
+		}
+	}
+	_, _ = fmt.Fprintln(w, unit.Label(fmt.Sprintf("CPU utilization, 0 to %d", makespan)))
+	for i, c := range cpus {
+		_, _ = fmt.Fprintf(w, "CPU %-3d |%s| %3.0f%%\n", i, sparkline(c.columns), c.overall*100)
+	}
+	_, _ = fmt.Fprintln(w)
+}
@@ -0,0 +1,61 @@
+package render
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+func Test_utilization(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		gantt []scheduler.TimeSlice
+		width int
+		want  []string
+	}{
+		{
+			name:  "one column per tick",
+			gantt: []scheduler.TimeSlice{{PID: 1, Start: 0, Stop: 2}, {Idle: true, Start: 2, Stop: 4}, {PID: 2, Start: 4, Stop: 5}},
+			width: 60,
+			want:  []string{"██  █"},
+		},
+		{
+			name:  "partial columns",
+			gantt: []scheduler.TimeSlice{{PID: 1, Start: 0, Stop: 3}, {PID: 2, Start: 5, Stop: 8}},
+			width: 4,
+			want:  []string{"█▄▄█"},
+		},
+		{
+			name:  "a lane per CPU",
+			gantt: []scheduler.TimeSlice{{PID: 1, Start: 0, Stop: 4}, {PID: 2, CPU: 1, Start: 2, Stop: 4}},
+			width: 4,
+			want:  []string{"████", "  ██"},
+		},
+		{
+			name:  "no slices",
+			width: 4,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var got []string
+			for _, c := range utilization(tt.gantt, tt.width) {
+				got = append(got, sparkline(c.columns))
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_outputSparklines(t *testing.T) {
+	t.Parallel()
+	var b bytes.Buffer
+	outputSparklines(&b, []scheduler.TimeSlice{{PID: 1, Start: 0, Stop: 3}, {PID: 2, Start: 3, Stop: 4}, {Idle: true, Start: 4, Stop: 8}}, scheduler.TimeUnitMillis)
+	assert.Equal(t, "CPU utilization, 0 to 8 (ms)\nCPU 0   |████    |  50%\n\n", b.String())
+}
@@ -0,0 +1,189 @@
+package render
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+var ErrNotStreamable = fmt.Errorf("%w: --stream needs csv or ndjson outputs", scheduler.ErrInvalidArgs)
+
+// StreamOutputs writes rows straight to the output files as schedulers produce them.
+// Files are opened lazily as patterns expand and are shared by concurrent batch workers.
+type StreamOutputs struct {
+	stdout io.Writer
+	outs   []Output
+
+	mu    sync.Mutex
+	files map[string]*streamFile
+	order []string
+}
+
+// NewStreamOutputs streams rows to outs, which must all be csv or ndjson. Files are created as
+// the first run writing to them starts.
+func NewStreamOutputs(stdout io.Writer, outs []Output) (*StreamOutputs, error) {
+	for _, out := range outs {
+		if out.Format != FormatCSV && out.Format != FormatNDJSON {
+			return nil, fmt.Errorf("%w: %s is %s", ErrNotStreamable, out.Pattern, out.Format)
+		}
+	}
+
+	return &StreamOutputs{stdout: stdout, outs: outs, files: make(map[string]*streamFile)}, nil
+}
+
+// Sink returns the sink for one scheduler's run over one workload.
+func (s *StreamOutputs) Sink(input, algorithm string) (*StreamSink, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sink := &StreamSink{input: input, algorithm: algorithm}
+	for _, out := range s.outs {
+		p := out.path(scheduler.Result{Input: input, Algorithm: algorithm})
+		f, ok := s.files[p]
+		if !ok {
+			var err error
+			if f, err = s.open(p, out.Format); err != nil {
+				return nil, err
+			}
+			s.files[p] = f
+			s.order = append(s.order, p)
+		}
+		sink.files = append(sink.files, f)
+	}
+
+	return sink, nil
+}
+
+func (s *StreamOutputs) open(path string, format Format) (*streamFile, error) {
+	sf := &streamFile{format: format}
+	w := s.stdout
+	if path != StdoutPath {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return nil, fmt.Errorf("%w: creating output directory", err)
+		}
+		f, err := os.Create(path)
+		if err != nil {
+			return nil, fmt.Errorf("%w: creating output file", err)
+		}
+		sf.closer = f
+		w = f
+	}
+	sf.buf = bufio.NewWriter(w)
+	sf.csv = csv.NewWriter(sf.buf)
+	sf.json = json.NewEncoder(sf.buf)
+	if format == FormatCSV {
+		sf.err = sf.csv.Write(csvHeader)
+	}
+
+	return sf, nil
+}
+
+// Close flushes and closes every file, returning the first write error.
+func (s *StreamOutputs) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var first error
+	for _, p := range s.order {
+		if err := s.files[p].close(); err != nil && first == nil {
+			first = fmt.Errorf("%s: %w", p, err)
+		}
+	}
+
+	return first
+}
+
+// streamFile is one open streaming destination.
+type streamFile struct {
+	mu     sync.Mutex
+	format Format
+	buf    *bufio.Writer
+	csv    *csv.Writer
+	json   *json.Encoder
+	closer io.Closer
+	err    error
+}
+
+func (f *streamFile) write(fn func() error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err == nil {
+		f.err = fn()
+	}
+}
+
+func (f *streamFile) close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.csv.Flush()
+	if f.err == nil {
+		f.err = f.csv.Error()
+	}
+	if err := f.buf.Flush(); f.err == nil {
+		f.err = err
+	}
+	if f.closer != nil {
+		if err := f.closer.Close(); f.err == nil {
+			f.err = err
+		}
+	}
+
+	return f.err
+}
+
+// StreamSink writes one scheduler's rows to every stream destination.
+// A sink belongs to a single scheduler run, so its scratch buffers need no locking.
+type StreamSink struct {
+	input     string
+	algorithm string
+	files     []*streamFile
+	fields    []string
+	scratch   []byte
+}
+
+// Process writes a completed process row to every destination.
+func (s *StreamSink) Process(p scheduler.ProcessResult) {
+	s.fields, s.scratch = appendCSVRow(append(s.fields[:0], s.input, s.algorithm), s.scratch, p)
+	for _, f := range s.files {
+		f := f
+		f.write(func() error {
+			if f.format == FormatCSV {
+				return f.csv.Write(s.fields)
+			}
+			return f.json.Encode(NDJSONRecord{Type: "process", Input: s.input, Algorithm: s.algorithm, ProcessResult: &p})
+		})
+	}
+}
+
+// Slice writes a Gantt slice to the ndjson destinations; csv has no slices.
+func (s *StreamSink) Slice(ts scheduler.TimeSlice) {
+	for _, f := range s.files {
+		f := f
+		if f.format != FormatNDJSON {
+			continue
+		}
+		f.write(func() error {
+			return f.json.Encode(NDJSONRecord{Type: "slice", Input: s.input, Algorithm: s.algorithm, TimeSlice: &ts})
+		})
+	}
+}
+
+// Summary writes the run's averages once the scheduler has finished.
+func (s *StreamSink) Summary(r scheduler.Result) {
+	for _, f := range s.files {
+		f := f
+		if f.format != FormatNDJSON {
+			continue
+		}
+		f.write(func() error {
+			return f.json.Encode(SummaryRecord(r))
+		})
+	}
+}
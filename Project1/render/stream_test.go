@@ -0,0 +1,88 @@
+package render
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+var exampleProcesses = []scheduler.Process{
+	{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+	{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+	{ProcessID: 3, ArrivalTime: 6, BurstDuration: 6, Priority: 3},
+}
+
+func TestStreamOutputs(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	var stdout bytes.Buffer
+	streams, err := NewStreamOutputs(&stdout, []Output{
+		{Pattern: StdoutPath, Format: FormatNDJSON},
+		{Pattern: filepath.Join(dir, "{algo}.csv"), Format: FormatCSV},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sink, err := streams.Sink("in.csv", "fcfs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := scheduler.FCFS("First-come, first-serve", exampleProcesses, scheduler.WithSink(sink))
+	r.Input, r.Algorithm = "in.csv", "fcfs"
+	sink.Summary(r)
+	if err := streams.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if r.Processes != nil || r.Gantt != nil {
+		t.Errorf("streamed result kept rows: %+v", r)
+	}
+	if r.AveWait != 10.0/3 {
+		t.Errorf("AveWait = %v, want %v", r.AveWait, 10.0/3)
+	}
+
+	var buffered bytes.Buffer
+	want := scheduler.FCFS("First-come, first-serve", exampleProcesses)
+	want.Input, want.Algorithm = "in.csv", "fcfs"
+	if err := writeNDJSON(&buffered, []scheduler.Result{want}); err != nil {
+		t.Fatal(err)
+	}
+	if got, wantLines := sortedLines(stdout.String()), sortedLines(buffered.String()); !reflect.DeepEqual(got, wantLines) {
+		t.Errorf("streamed NDJSON = %v, want %v", got, wantLines)
+	}
+
+	b, err := os.ReadFile(filepath.Join(dir, "fcfs.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantCSV := "input,algorithm,id,priority,burst,arrival,wait,turnaround,exit,response,status\n" +
+		"in.csv,fcfs,1,2,5,0,0,5,5,0,\n" +
+		"in.csv,fcfs,2,1,9,3,2,11,14,2,\n" +
+		"in.csv,fcfs,3,3,6,6,8,14,20,8,\n"
+	if string(b) != wantCSV {
+		t.Errorf("streamed CSV = %q, want %q", b, wantCSV)
+	}
+}
+
+func sortedLines(s string) []string {
+	// Streaming interleaves slices and rows; only the set of records matters.
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	sort.Strings(lines)
+
+	return lines
+}
+
+func TestNewStreamOutputs(t *testing.T) {
+	t.Parallel()
+	_, err := NewStreamOutputs(nil, []Output{{Pattern: StdoutPath, Format: FormatText}})
+	if !errors.Is(err, ErrNotStreamable) {
+		t.Errorf("error = %v, want %v", err, ErrNotStreamable)
+	}
+}
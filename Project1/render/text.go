@@ -0,0 +1,534 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+// FCFSSchedule outputs a schedule of processes in a GANTT chart and a table of timing given:
+// • an output writer
+// • a title for the chart
+// • a slice of processes
+// • optional settings such as progress reporting
+func FCFSSchedule(w io.Writer, title string, processes []scheduler.Process, opts ...scheduler.Option) {
+	writeText(w, scheduler.FCFS(title, processes, opts...), scheduler.TimeUnitOf(opts...))
+}
+
+// SJFPrioritySchedule outputs a schedule of processes run by preemptive priority (ties broken by
+// shortest job), given the same arguments as FCFSSchedule.
+func SJFPrioritySchedule(w io.Writer, title string, processes []scheduler.Process, opts ...scheduler.Option) {
+	writeText(w, scheduler.SJFPriority(title, processes, opts...), scheduler.TimeUnitOf(opts...))
+}
+
+// SJFSchedule outputs a schedule of processes run by preemptive shortest-job-first,
+// given the same arguments as FCFSSchedule.
+func SJFSchedule(w io.Writer, title string, processes []scheduler.Process, opts ...scheduler.Option) {
+	writeText(w, scheduler.SJF(title, processes, opts...), scheduler.TimeUnitOf(opts...))
+}
+
+// SJFNonPreemptiveSchedule outputs a schedule of processes run by non-preemptive
+// shortest-job-first, given the same arguments as FCFSSchedule.
+func SJFNonPreemptiveSchedule(w io.Writer, title string, processes []scheduler.Process, opts ...scheduler.Option) {
+	writeText(w, scheduler.SJFNonPreemptive(title, processes, opts...), scheduler.TimeUnitOf(opts...))
+}
+
+// MLQSchedule outputs a schedule of processes run by multilevel queue scheduling with
+// scheduler.DefaultMLQueues, given the same arguments as FCFSSchedule. Its Gantt chart names the
+// queue that served each slice.
+func MLQSchedule(w io.Writer, title string, processes []scheduler.Process, opts ...scheduler.Option) {
+	writeText(w, scheduler.MLQ(title, processes, opts...), scheduler.TimeUnitOf(opts...))
+}
+
+// PriorityRRSchedule outputs a schedule of processes run round-robin within priority levels,
+// given the same arguments as FCFSSchedule.
+func PriorityRRSchedule(w io.Writer, title string, processes []scheduler.Process, opts ...scheduler.Option) {
+	writeText(w, scheduler.PriorityRR(title, processes, opts...), scheduler.TimeUnitOf(opts...))
+}
+
+// O1Schedule outputs a schedule of processes run by the Linux O(1) scheduler emulation, given
+// the same arguments as FCFSSchedule.
+func O1Schedule(w io.Writer, title string, processes []scheduler.Process, opts ...scheduler.Option) {
+	writeText(w, scheduler.O1(title, processes, opts...), scheduler.TimeUnitOf(opts...))
+}
+
+// SRRSchedule outputs a schedule of processes run by selfish round-robin with DefaultSRR,
+// given the same arguments as FCFSSchedule.
+func SRRSchedule(w io.Writer, title string, processes []scheduler.Process, opts ...scheduler.Option) {
+	writeText(w, scheduler.SRR(title, processes, opts...), scheduler.TimeUnitOf(opts...))
+}
+
+// LotterySchedule outputs a schedule of processes run by lottery scheduling, with each
+// process's entitled and achieved CPU share, given the same arguments as FCFSSchedule plus
+// scheduler.WithSeed to seed the drawings.
+func LotterySchedule(w io.Writer, title string, processes []scheduler.Process, opts ...scheduler.Option) {
+	writeText(w, scheduler.Lottery(title, processes, opts...), scheduler.TimeUnitOf(opts...))
+}
+
+// RandomSchedule outputs a schedule of processes run by random scheduling, with each process's
+// entitled and achieved CPU share, given the same arguments as FCFSSchedule plus
+// scheduler.WithSeed to seed the picks.
+func RandomSchedule(w io.Writer, title string, processes []scheduler.Process, opts ...scheduler.Option) {
+	writeText(w, scheduler.Random(title, processes, opts...), scheduler.TimeUnitOf(opts...))
+}
+
+// StrideSchedule outputs a schedule of processes run by stride scheduling, with each process's
+// entitled and achieved CPU share and final pass, given the same arguments as FCFSSchedule.
+func StrideSchedule(w io.Writer, title string, processes []scheduler.Process, opts ...scheduler.Option) {
+	writeText(w, scheduler.Stride(title, processes, opts...), scheduler.TimeUnitOf(opts...))
+}
+
+// LJFSchedule outputs a schedule of processes run by non-preemptive longest-job-first, given
+// the same arguments as FCFSSchedule.
+func LJFSchedule(w io.Writer, title string, processes []scheduler.Process, opts ...scheduler.Option) {
+	writeText(w, scheduler.LJF(title, processes, opts...), scheduler.TimeUnitOf(opts...))
+}
+
+// CBSSchedule outputs a schedule of processes run by constant bandwidth servers, with each
+// process's reservation, overruns and deadline, given the same arguments as FCFSSchedule.
+func CBSSchedule(w io.Writer, title string, processes []scheduler.Process, opts ...scheduler.Option) {
+	writeText(w, scheduler.CBS(title, processes, opts...), scheduler.TimeUnitOf(opts...))
+}
+
+// SlackSchedule outputs a schedule of hard processes run by EDF as late as their deadlines allow
+// and soft processes run in their slack, with the slack reclaimed, given the same arguments as
+// FCFSSchedule.
+func SlackSchedule(w io.Writer, title string, processes []scheduler.Process, opts ...scheduler.Option) {
+	writeText(w, scheduler.Slack(title, processes, opts...), scheduler.TimeUnitOf(opts...))
+}
+
+// LRTFSchedule outputs a schedule of processes run by preemptive longest-remaining-time-first,
+// whose Gantt chart shows its frequent context switches, given the same arguments as
+// FCFSSchedule.
+func LRTFSchedule(w io.Writer, title string, processes []scheduler.Process, opts ...scheduler.Option) {
+	writeText(w, scheduler.LRTF(title, processes, opts...), scheduler.TimeUnitOf(opts...))
+}
+
+// HPRNSchedule outputs a schedule of processes run by highest-penalty-ratio-next, given the same
+// arguments as FCFSSchedule.
+func HPRNSchedule(w io.Writer, title string, processes []scheduler.Process, opts ...scheduler.Option) {
+	writeText(w, scheduler.HPRN(title, processes, opts...), scheduler.TimeUnitOf(opts...))
+}
+
+// GuaranteedSchedule outputs a schedule of processes run by guaranteed scheduling, with each
+// process's entitled and achieved CPU share, given the same arguments as FCFSSchedule.
+func GuaranteedSchedule(w io.Writer, title string, processes []scheduler.Process, opts ...scheduler.Option) {
+	writeText(w, scheduler.Guaranteed(title, processes, opts...), scheduler.TimeUnitOf(opts...))
+}
+
+// NiceSchedule outputs a schedule of processes run in proportion to their nice weights, with
+// each process's nice value and entitled and achieved CPU share, given the same arguments as
+// FCFSSchedule.
+func NiceSchedule(w io.Writer, title string, processes []scheduler.Process, opts ...scheduler.Option) {
+	writeText(w, scheduler.Nice(title, processes, opts...), scheduler.TimeUnitOf(opts...))
+}
+
+// EEVDFSchedule outputs a schedule of processes run by earliest eligible virtual deadline first,
+// with the range of each process's lag, given the same arguments as FCFSSchedule.
+func EEVDFSchedule(w io.Writer, title string, processes []scheduler.Process, opts ...scheduler.Option) {
+	writeText(w, scheduler.EEVDF(title, processes, opts...), scheduler.TimeUnitOf(opts...))
+}
+
+// EDFSchedule outputs a schedule of processes run by preemptive earliest-deadline-first, with
+// each process's deadline and any miss in the schedule table, given the same arguments as
+// FCFSSchedule.
+func EDFSchedule(w io.Writer, title string, processes []scheduler.Process, opts ...scheduler.Option) {
+	writeText(w, scheduler.EDF(title, processes, opts...), scheduler.TimeUnitOf(opts...))
+}
+
+// RRSchedule outputs a schedule of processes run round-robin,
+// given the same arguments as FCFSSchedule.
+func RRSchedule(w io.Writer, title string, processes []scheduler.Process, opts ...scheduler.Option) {
+	writeText(w, scheduler.RR(title, processes, opts...), scheduler.TimeUnitOf(opts...))
+}
+
+// OutputTitle prints a title underlined and overlined with dashes, as every text section starts.
+func OutputTitle(w io.Writer, title string) {
+	_, _ = fmt.Fprintln(w, strings.Repeat("-", len(title)*2))
+	_, _ = fmt.Fprintln(w, strings.Repeat(" ", len(title)/2), title)
+	_, _ = fmt.Fprintln(w, strings.Repeat("-", len(title)*2))
+}
+
+func outputGantt(w io.Writer, gantt []scheduler.TimeSlice, unit scheduler.TimeUnit) {
+	_, _ = fmt.Fprintln(w, unit.Label("Gantt schedule"))
+	lanes := ganttLanes(gantt)
+	if len(lanes) == 1 {
+		outputGanttLane(w, gantt)
+		return
+	}
+	for cpu, lane := range lanes {
+		if len(lane) > 0 {
+			_, _ = fmt.Fprintf(w, "CPU %d\n", cpu)
+			outputGanttLane(w, lane)
+		}
+	}
+}
+
+// ganttLanes splits a Gantt chart into the busy and reserved slices of each CPU, indexed by CPU,
+// in time order. With more than one CPU, each lane's gaps are filled with idle slices, so every
+// lane reads left to right from 0 like a single-CPU chart.
+func ganttLanes(gantt []scheduler.TimeSlice) [][]scheduler.TimeSlice {
+	cpus := 1
+	for _, s := range gantt {
+		if s.CPU >= cpus {
+			cpus = s.CPU + 1
+		}
+	}
+	if cpus == 1 {
+		return [][]scheduler.TimeSlice{gantt}
+	}
+	busy := chartSlices(gantt)
+	sort.SliceStable(busy, func(i, j int) bool { return busy[i].Start < busy[j].Start })
+	lanes := make([][]scheduler.TimeSlice, cpus)
+	for _, s := range busy {
+		var free int64
+		if lane := lanes[s.CPU]; len(lane) > 0 {
+			free = lane[len(lane)-1].Stop
+		}
+		if free < s.Start {
+			lanes[s.CPU] = append(lanes[s.CPU], scheduler.TimeSlice{CPU: s.CPU, Start: free, Stop: s.Start, Idle: true})
+		}
+		lanes[s.CPU] = append(lanes[s.CPU], s)
+	}
+
+	return lanes
+}
+
+// chartSlices are the slices a Gantt chart draws: those in which a process ran and those in
+// which a CPU was reserved, in chart order.
+func chartSlices(gantt []scheduler.TimeSlice) []scheduler.TimeSlice {
+	slices := make([]scheduler.TimeSlice, 0, len(gantt))
+	for _, s := range gantt {
+		if !s.Idle || s.Reserved {
+			slices = append(slices, s)
+		}
+	}
+
+	return slices
+}
+
+// outputGanttLane writes one row of the classic Gantt chart: the slices in order, then the
+// time each starts. Beneath slices served by a multilevel queue, a second row names the queue.
+func outputGanttLane(w io.Writer, gantt []scheduler.TimeSlice) {
+	var (
+		widths = make([]int, len(gantt))
+		queued bool
+	)
+	_, _ = fmt.Fprint(w, "|")
+	for i := range gantt {
+		pid := fmt.Sprint(gantt[i].PID)
+		switch {
+		case gantt[i].Reserved:
+			pid = "RSVD"
+		case gantt[i].Idle:
+			pid = "IDLE"
+		}
+		padding := strings.Repeat(" ", (8-len(pid))/2)
+		_, _ = fmt.Fprint(w, padding, pid, padding, "|")
+		widths[i] = 2*len(padding) + len(pid)
+		queued = queued || gantt[i].Queue != ""
+	}
+	_, _ = fmt.Fprintln(w)
+	if queued {
+		outputGanttQueues(w, gantt, widths)
+	}
+	for i := range gantt {
+		_, _ = fmt.Fprint(w, fmt.Sprint(gantt[i].Start), "\t")
+		if len(gantt)-1 == i {
+			_, _ = fmt.Fprint(w, fmt.Sprint(gantt[i].Stop))
+		}
+	}
+	_, _ = fmt.Fprintf(w, "\n\n")
+}
+
+// outputGanttQueues writes the queue of each slice, centred in and cut to its cell's width.
+func outputGanttQueues(w io.Writer, gantt []scheduler.TimeSlice, widths []int) {
+	_, _ = fmt.Fprint(w, "|")
+	for i, s := range gantt {
+		name := s.Queue
+		if len(name) > widths[i] {
+			name = name[:widths[i]]
+		}
+		left := (widths[i] - len(name)) / 2
+		_, _ = fmt.Fprint(w, strings.Repeat(" ", left), name, strings.Repeat(" ", widths[i]-len(name)-left), "|")
+	}
+	_, _ = fmt.Fprintln(w)
+}
+
+// outputSchedule writes the schedule table. With missed at 0 or more, the rows carry the deadline
+// columns of appendDeadlines too, and missed deadlines are counted in the footer.
+func outputSchedule(w io.Writer, rows [][]string, wait, turnaround, throughput float64, unit scheduler.TimeUnit, missed int) {
+	_, _ = fmt.Fprintln(w, "Schedule table")
+	table := tablewriter.NewWriter(w)
+	header := []string{"ID", "Priority",
+		unit.Label("Burst"), unit.Label("Arrival"), unit.Label("Wait"), unit.Label("Turnaround"), unit.Label("Exit")}
+	footer := []string{"", "", "", "",
+		fmt.Sprintf("Average\n%.2f", wait),
+		fmt.Sprintf("Average\n%.2f", turnaround),
+		fmt.Sprintf("Throughput\n%s", unit.Throughput(throughput))}
+	if missed >= 0 {
+		header = append(header, unit.Label("Deadline"), unit.Label("Late"))
+		footer = append(footer, "", fmt.Sprintf("Missed\n%d", missed))
+	}
+	table.SetHeader(header)
+	table.AppendBulk(rows)
+	table.SetFooter(footer)
+	table.Render()
+}
+
+// appendDeadlines adds to each row the process's deadline and how late it was, marking
+// processes that left without completing before their deadline as missed, if any process
+// has a deadline. It returns how many missed theirs, or -1 if none had one.
+func appendDeadlines(rows [][]string, processes []scheduler.ProcessResult) int {
+	any := false
+	for _, p := range processes {
+		any = any || p.Deadline > 0
+	}
+	if !any {
+		return -1
+	}
+	missed := 0
+	for i, p := range processes {
+		deadline, late := "", ""
+		if p.Deadline > 0 {
+			deadline = strconv.FormatInt(p.Deadline, 10)
+		}
+		if p.MissedDeadline() {
+			missed++
+			late = "missed"
+			if n := p.Lateness(); n > 0 && p.Completed() {
+				late = strconv.FormatInt(n, 10)
+			}
+		}
+		rows[i] = append(rows[i], deadline, late)
+	}
+
+	return missed
+}
+
+// rowColumns is the number of schedule table columns a ProcessResult formats to.
+const rowColumns = 7
+
+// appendRow appends the schedule table columns of p to fields.
+// The integers are formatted into scratch, which is returned for reuse by the next call,
+// and all columns share a single string allocation instead of one fmt.Sprint each.
+func appendRow(fields []string, scratch []byte, p scheduler.ProcessResult) ([]string, []byte) {
+	var (
+		vals = [rowColumns]int64{p.ID, p.Priority, p.Burst, p.Arrival, p.Wait, p.Turnaround, p.Exit}
+		ends [rowColumns]int
+	)
+	scratch = scratch[:0]
+	for i, v := range vals {
+		scratch = strconv.AppendInt(scratch, v, 10)
+		ends[i] = len(scratch)
+	}
+	s := string(scratch)
+	start := 0
+	for _, end := range ends {
+		fields = append(fields, s[start:end])
+		start = end
+	}
+
+	return fields, scratch
+}
+
+// writeText renders a result as the human-readable title, Gantt chart and schedule table.
+// The Gantt chart is omitted for a result without time slices, and an empty
+// workload prints a note instead of an empty table.
+func writeText(w io.Writer, r scheduler.Result, unit scheduler.TimeUnit) {
+	writeTextWith(w, r, unit, TextOptions{})
+}
+
+// TextOptions are the text output's optional renderings.
+type TextOptions struct {
+	// Gantt is the Gantt chart style; empty is GanttClassic.
+	Gantt GanttStyle
+	// Sparklines adds a per-CPU utilization sparkline beneath the Gantt chart.
+	Sparklines bool
+}
+
+// writeTextWith is writeText with the Gantt chart drawn as opts say.
+func writeTextWith(w io.Writer, r scheduler.Result, unit scheduler.TimeUnit, opts TextOptions) {
+	var (
+		rows    = make([][]string, len(r.Processes))
+		fields  = make([]string, 0, len(r.Processes)*rowColumns)
+		scratch []byte
+	)
+	for i := range r.Processes {
+		start := len(fields)
+		fields, scratch = appendRow(fields, scratch, r.Processes[i])
+		rows[i] = fields[start:len(fields):len(fields)]
+	}
+	OutputTitle(w, r.Title)
+	if len(r.Processes) == 0 {
+		_, _ = fmt.Fprintln(w, "No processes to schedule.")
+		return
+	}
+	if len(r.Gantt) > 0 {
+		if opts.Gantt == GanttBlocks {
+			outputBlockGantt(w, r.Gantt, unit)
+		} else {
+			outputGantt(w, r.Gantt, unit)
+		}
+		if opts.Sparklines {
+			outputSparklines(w, r.Gantt, unit)
+		}
+	}
+	missed := appendDeadlines(rows, r.Processes)
+	outputSchedule(w, rows, r.AveWait, r.AveTurnaround, r.AveThroughput, unit, missed)
+	outputTickets(w, r.Tickets)
+	outputBandwidth(w, r.Bandwidth)
+	outputCycles(w, r.Cycles, unit)
+	outputSporadic(w, r.Sporadic, unit)
+	outputLevels(w, r.Levels)
+	outputPromotions(w, r.Promotions)
+	outputLags(w, r.Lags, unit)
+	if r.Slack != nil {
+		_, _ = fmt.Fprintf(w, "%s: %d\n", unit.Label("Slack reclaimed by soft processes"), r.Slack.Reclaimed)
+	}
+	outputIncomplete(w, r)
+}
+
+// outputTickets writes the CPU share each process of a proportional-share run was entitled to
+// and achieved, for a stride run its final pass, and for a nice run its nice value and weight.
+func outputTickets(w io.Writer, report *scheduler.TicketReport) {
+	if report == nil {
+		return
+	}
+	strided := false
+	for _, s := range report.Shares {
+		strided = strided || s.Pass > 0
+	}
+	_, _ = fmt.Fprintln(w, "CPU shares")
+	table := tablewriter.NewWriter(w)
+	header := []string{"ID", "Tickets", "Entitled", "Achieved"}
+	if report.Nice {
+		header = []string{"ID", "Nice", "Weight", "Entitled", "Achieved"}
+	}
+	if strided {
+		header = append(header, "Pass")
+	}
+	table.SetHeader(header)
+	for _, s := range report.Shares {
+		row := []string{strconv.FormatInt(s.ID, 10), strconv.FormatInt(s.Tickets, 10),
+			fmt.Sprintf("%.1f%%", s.Entitled*100), fmt.Sprintf("%.1f%%", s.Achieved*100)}
+		if report.Nice {
+			row = append(row[:1:1], append([]string{strconv.FormatInt(s.Nice, 10)}, row[1:]...)...)
+		}
+		if strided {
+			row = append(row, strconv.FormatInt(s.Pass, 10))
+		}
+		table.Append(row)
+	}
+	table.Render()
+}
+
+// outputSporadic writes each aperiodic process's response time under a sporadic server against
+// background-only service, and their averages.
+func outputSporadic(w io.Writer, report *scheduler.SporadicReport, unit scheduler.TimeUnit) {
+	if report == nil || len(report.Processes) == 0 {
+		return
+	}
+	_, _ = fmt.Fprintln(w, "Aperiodic response")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"ID", unit.Label("Server"), unit.Label("Background")})
+	for _, a := range report.Processes {
+		table.Append([]string{strconv.FormatInt(a.ID, 10), strconv.FormatInt(a.Response, 10), strconv.FormatInt(a.Background, 10)})
+	}
+	table.SetFooter([]string{"", fmt.Sprintf("Average\n%.2f", report.AveResponse), fmt.Sprintf("Average\n%.2f", report.AveBackgroundResponse)})
+	table.Render()
+}
+
+// outputBandwidth writes each CPU reservation of a CBS run and how often the process overran
+// its budget and was throttled.
+func outputBandwidth(w io.Writer, report *scheduler.BandwidthReport) {
+	if report == nil {
+		return
+	}
+	_, _ = fmt.Fprintln(w, "CPU reservations")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"ID", "Runtime", "Period", "Deadline", "Overruns", "Throttled"})
+	for _, s := range report.Servers {
+		table.Append([]string{strconv.FormatInt(s.ID, 10), strconv.FormatInt(s.Runtime, 10), strconv.FormatInt(s.Period, 10),
+			strconv.FormatInt(s.Deadline, 10), strconv.Itoa(s.Overruns), strconv.FormatInt(s.Throttled, 10)})
+	}
+	table.Render()
+}
+
+// outputCycles writes the quantum of each cycle of a dynamic-quantum round-robin run.
+func outputCycles(w io.Writer, cycles []scheduler.RRCycle, unit scheduler.TimeUnit) {
+	if len(cycles) == 0 {
+		return
+	}
+	_, _ = fmt.Fprintln(w, "Quantum per cycle")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"Cycle", unit.Label("Start"), "Ready", unit.Label("Quantum")})
+	for i, c := range cycles {
+		table.Append([]string{strconv.Itoa(i + 1), strconv.FormatInt(c.Start, 10), strconv.Itoa(c.Ready), strconv.FormatInt(c.Quantum, 10)})
+	}
+	table.Render()
+}
+
+// outputLevels writes the level each process of a multilevel feedback queue run completed in.
+func outputLevels(w io.Writer, levels []scheduler.QueueLevel) {
+	if len(levels) == 0 {
+		return
+	}
+	_, _ = fmt.Fprintln(w, "Final queue level")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"ID", "Level"})
+	for _, l := range levels {
+		table.Append([]string{strconv.FormatInt(l.ID, 10), strconv.Itoa(l.Level)})
+	}
+	table.Render()
+}
+
+// outputPromotions writes how many times the aging of a priority scheduler promoted each process.
+func outputPromotions(w io.Writer, promotions []scheduler.Promotion) {
+	if len(promotions) == 0 {
+		return
+	}
+	_, _ = fmt.Fprintln(w, "Promotions")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"ID", "Promotions"})
+	for _, p := range promotions {
+		table.Append([]string{strconv.FormatInt(p.ID, 10), strconv.Itoa(p.Count)})
+	}
+	table.Render()
+}
+
+// outputLags writes the range of each process's lag over an EEVDF run.
+func outputLags(w io.Writer, lags []scheduler.ProcessLag, unit scheduler.TimeUnit) {
+	if len(lags) == 0 {
+		return
+	}
+	_, _ = fmt.Fprintln(w, "Lag")
+	table := tablewriter.NewWriter(w)
+	table.SetHeader([]string{"ID", "Weight", unit.Label("Min lag"), unit.Label("Max lag")})
+	for _, l := range lags {
+		table.Append([]string{strconv.FormatInt(l.ID, 10), strconv.FormatInt(l.Weight, 10),
+			fmt.Sprintf("%.2f", l.Min), fmt.Sprintf("%.2f", l.Max)})
+	}
+	table.Render()
+}
+
+// outputIncomplete notes which processes never completed and so are left out of the averages.
+func outputIncomplete(w io.Writer, r scheduler.Result) {
+	if r.Incomplete == 0 {
+		return
+	}
+	notes := make([]string, 0, r.Incomplete)
+	for _, p := range r.Processes {
+		if !p.Completed() {
+			notes = append(notes, fmt.Sprintf("P%d %s at %d", p.ID, p.Status, p.Exit))
+		}
+	}
+	_, _ = fmt.Fprintf(w, "%d of %d processes did not complete and are excluded from the averages: %s\n",
+		r.Incomplete, len(r.Processes), strings.Join(notes, ", "))
+}
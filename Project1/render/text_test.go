@@ -0,0 +1,347 @@
+package render
+
+import (
+	"bytes"
+	"os"
+	"path"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+func TestFCFSSchedule(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		processes []scheduler.Process
+		title     string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantOut string
+	}{
+		{
+			name: "default",
+			args: args{
+				processes: []scheduler.Process{
+					{
+						ProcessID:     1,
+						ArrivalTime:   0,
+						BurstDuration: 5,
+						Priority:      2,
+					},
+					{
+						ProcessID:     2,
+						ArrivalTime:   3,
+						BurstDuration: 9,
+						Priority:      1,
+					},
+					{
+						ProcessID:     3,
+						ArrivalTime:   6,
+						BurstDuration: 6,
+						Priority:      3,
+					},
+				},
+				title: "First-come, First-serve",
+			},
+			wantOut: loadFixture(t, "fcfs_test.txt"),
+		},
+		{
+			name: "idle gaps",
+			args: args{
+				processes: []scheduler.Process{
+					{ProcessID: 3, ArrivalTime: 10, BurstDuration: 2, Priority: 1},
+					{ProcessID: 1, ArrivalTime: 2, BurstDuration: 3, Priority: 1},
+					{ProcessID: 2, ArrivalTime: 4, BurstDuration: 2, Priority: 1},
+				},
+				title: "First-come, First-serve",
+			},
+			wantOut: loadFixture(t, "fcfs_idle_test.txt"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			FCFSSchedule(&w, tt.args.title, tt.args.processes)
+			if got := w.String(); got != tt.wantOut {
+				t.Errorf("FCFSSchedule() = %v, want %v", got, tt.wantOut)
+			}
+		})
+	}
+}
+
+func loadFixture(t *testing.T, p ...string) string {
+	b, err := os.ReadFile(path.Join(p...))
+	if err != nil {
+		t.Fail()
+	}
+
+	return string(b)
+}
+
+func Test_writeText(t *testing.T) {
+	t.Parallel()
+	processes := []scheduler.Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 6, BurstDuration: 6, Priority: 3},
+	}
+	var w bytes.Buffer
+	writeText(&w, scheduler.FCFS("First-come, First-serve", processes), scheduler.TimeUnitTicks)
+	if got, want := w.String(), loadFixture(t, "fcfs_test.txt"); got != want {
+		t.Errorf("writeText() = %v, want %v", got, want)
+	}
+}
+
+func Test_writeText_empty(t *testing.T) {
+	t.Parallel()
+	for _, s := range scheduler.Algorithms {
+		s := s
+		t.Run(s.Name, func(t *testing.T) {
+			t.Parallel()
+			r := s.Simulate("Empty", nil)
+			if r.AveWait != 0 || r.AveTurnaround != 0 || r.AveThroughput != 0 {
+				t.Errorf("averages = %v, %v, %v, want 0", r.AveWait, r.AveTurnaround, r.AveThroughput)
+			}
+			var w bytes.Buffer
+			writeText(&w, r, scheduler.TimeUnitTicks)
+			if !strings.HasSuffix(w.String(), "\nNo processes to schedule.\n") {
+				t.Errorf("writeText() = %q, want a no processes note", w.String())
+			}
+			if err := WriteJSON(&w, r); err != nil {
+				t.Errorf("WriteJSON() error = %v", err)
+			}
+		})
+	}
+}
+
+func Test_appendRow(t *testing.T) {
+	p := scheduler.ProcessResult{ID: 12, Priority: 3, Burst: 456, Arrival: 7, Wait: -1, Turnaround: 1000, Exit: 1 << 40}
+	fields, scratch := appendRow([]string{"prefix"}, nil, p)
+	want := []string{"prefix", "12", "3", "456", "7", "-1", "1000", "1099511627776"}
+	if !reflect.DeepEqual(fields, want) {
+		t.Errorf("appendRow() = %q, want %q", fields, want)
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		fields, scratch = appendRow(fields[:0], scratch, p)
+	})
+	if allocs > 1 {
+		t.Errorf("appendRow() with reused buffers allocated %v times, want at most 1", allocs)
+	}
+}
+
+func Test_outputIncomplete(t *testing.T) {
+	t.Parallel()
+	r := scheduler.Result{
+		Processes:  []scheduler.ProcessResult{{ID: 1, Exit: 2}, {ID: 2, Exit: 7, Status: scheduler.StatusKilled}},
+		Incomplete: 1,
+	}
+	var w bytes.Buffer
+	outputIncomplete(&w, r)
+	if got, want := w.String(), "1 of 2 processes did not complete and are excluded from the averages: P2 killed at 7\n"; got != want {
+		t.Errorf("outputIncomplete() = %q, want %q", got, want)
+	}
+}
+
+func Test_outputGantt_lanes(t *testing.T) {
+	t.Parallel()
+	gantt := []scheduler.TimeSlice{
+		{PID: 1, CPU: 0, Start: 0, Stop: 5},
+		{PID: 2, CPU: 1, Start: 3, Stop: 12},
+		{PID: 3, CPU: 0, Start: 6, Stop: 12},
+		{CPU: 1, Start: 12, Stop: 14, Idle: true, Reserved: true},
+	}
+	var w bytes.Buffer
+	outputGantt(&w, gantt, scheduler.TimeUnitTicks)
+	want := "Gantt schedule\nCPU 0\n|   1   |  IDLE  |   3   |\n0\t5\t6\t12\n\nCPU 1\n|  IDLE  |   2   |  RSVD  |\n0\t3\t12\t14\n\n"
+	if got := w.String(); got != want {
+		t.Errorf("outputGantt() = %q, want %q", got, want)
+	}
+}
+
+func Test_outputGantt_queues(t *testing.T) {
+	t.Parallel()
+	gantt := []scheduler.TimeSlice{
+		{PID: 1, Stop: 1, Queue: "batch"},
+		{PID: 2, Start: 1, Stop: 3, Queue: "interactive"},
+		{Start: 3, Stop: 4, Idle: true},
+	}
+	var w bytes.Buffer
+	outputGantt(&w, gantt, scheduler.TimeUnitTicks)
+	want := "Gantt schedule\n|   1   |   2   |  IDLE  |\n| batch |interac|        |\n0\t1\t3\t4\n\n"
+	if got := w.String(); got != want {
+		t.Errorf("outputGantt() = %q, want %q", got, want)
+	}
+}
+
+func Test_outputGantt_priorityLevels(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		r    scheduler.Result
+		want string
+	}{
+		{
+			name: "priority round-robin",
+			r: scheduler.PriorityRR("", []scheduler.Process{
+				{ProcessID: 1, BurstDuration: 1, Priority: 2},
+				{ProcessID: 2, ArrivalTime: 1, BurstDuration: 1, Priority: 10},
+			}),
+			want: "Gantt schedule\n|   1   |   2   |\n|  p2   |  p10  |\n0\t1\t2\n\n",
+		},
+		{
+			name: "O(1)",
+			r: scheduler.O1("", []scheduler.Process{
+				{ProcessID: 1, BurstDuration: 1, Priority: -20},
+				{ProcessID: 2, ArrivalTime: 1, BurstDuration: 1, Priority: 19},
+			}),
+			want: "Gantt schedule\n|   1   |   2   |\n| p100  | p139  |\n0\t1\t2\n\n",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var w bytes.Buffer
+			outputGantt(&w, tt.r.Gantt, scheduler.TimeUnitTicks)
+			if got := w.String(); got != tt.want {
+				t.Errorf("outputGantt() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_outputTickets(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	outputTickets(&w, nil)
+	if w.Len() != 0 {
+		t.Errorf("outputTickets(nil) = %q, want nothing", w.String())
+	}
+	outputTickets(&w, &scheduler.TicketReport{Shares: []scheduler.TicketShare{{ID: 1, Tickets: 300, Entitled: 0.75, Achieved: 0.8}}})
+	if got := w.String(); !strings.HasPrefix(got, "CPU shares\n") || !strings.Contains(got, "|  1 |     300 | 75.0%    | 80.0%    |") {
+		t.Errorf("outputTickets() = %q", got)
+	}
+	w.Reset()
+	outputTickets(&w, &scheduler.TicketReport{Shares: []scheduler.TicketShare{{ID: 1, Tickets: 100, Pass: 400}}})
+	if got := w.String(); !strings.Contains(got, "| PASS |") || !strings.Contains(got, "|  400 |") {
+		t.Errorf("outputTickets() of a stride run = %q, want the passes", got)
+	}
+	w.Reset()
+	outputTickets(&w, &scheduler.TicketReport{Nice: true, Shares: []scheduler.TicketShare{{ID: 1, Nice: -5, Tickets: 3121, Entitled: 0.5, Achieved: 0.5}}})
+	if got := w.String(); !strings.Contains(got, "| NICE | WEIGHT |") || !strings.Contains(got, "|  1 |   -5 |   3121 |") {
+		t.Errorf("outputTickets() of a nice run = %q, want the nice values and weights", got)
+	}
+}
+
+func Test_outputBandwidth(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	outputBandwidth(&w, nil)
+	if w.Len() != 0 {
+		t.Errorf("outputBandwidth(nil) = %q, want nothing", w.String())
+	}
+	outputBandwidth(&w, &scheduler.BandwidthReport{Servers: []scheduler.ServerUsage{{ID: 1, Runtime: 2, Period: 5, Deadline: 5, Overruns: 2, Throttled: 6}}})
+	if got := w.String(); !strings.HasPrefix(got, "CPU reservations\n") || !strings.Contains(got, "|  1 |       2 |      5 |        5 |        2 |         6 |") {
+		t.Errorf("outputBandwidth() = %q", got)
+	}
+}
+
+func Test_outputLevels(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	outputLevels(&w, nil)
+	if w.Len() != 0 {
+		t.Errorf("outputLevels(nil) = %q, want nothing", w.String())
+	}
+	outputLevels(&w, []scheduler.QueueLevel{{ID: 1, Level: 0}, {ID: 2, Level: 2}})
+	if got := w.String(); !strings.HasPrefix(got, "Final queue level\n") || !strings.Contains(got, "|  2 |     2 |") {
+		t.Errorf("outputLevels() = %q", got)
+	}
+}
+
+func Test_outputPromotions(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	outputPromotions(&w, nil)
+	if w.Len() != 0 {
+		t.Errorf("outputPromotions(nil) = %q, want nothing", w.String())
+	}
+	outputPromotions(&w, []scheduler.Promotion{{ID: 1}, {ID: 3, Count: 2}})
+	if got := w.String(); !strings.HasPrefix(got, "Promotions\n") || !strings.Contains(got, "|  3 |          2 |") {
+		t.Errorf("outputPromotions() = %q", got)
+	}
+}
+
+func Test_outputLags(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	outputLags(&w, nil, scheduler.TimeUnitTicks)
+	if w.Len() != 0 {
+		t.Errorf("outputLags(nil) = %q, want nothing", w.String())
+	}
+	outputLags(&w, []scheduler.ProcessLag{{ID: 1, Weight: 1024, Min: -1, Max: 0.5}}, scheduler.TimeUnitTicks)
+	if got := w.String(); !strings.HasPrefix(got, "Lag\n") || !strings.Contains(got, "|  1 |   1024 |   -1.00 |    0.50 |") {
+		t.Errorf("outputLags() = %q", got)
+	}
+}
+
+func Test_outputSporadic(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	outputSporadic(&w, nil, scheduler.TimeUnitTicks)
+	if w.Len() != 0 {
+		t.Errorf("outputSporadic(nil) = %q, want nothing", w.String())
+	}
+	outputSporadic(&w, &scheduler.SporadicReport{Budget: 1, Period: 4, AveResponse: 1, AveBackgroundResponse: 4,
+		Processes: []scheduler.AperiodicResponse{{ID: 2, Response: 1, Background: 4}}}, scheduler.TimeUnitTicks)
+	if got := w.String(); !strings.HasPrefix(got, "Aperiodic response\n") || !strings.Contains(got, "|  2 |       1 |          4 |") || !strings.Contains(got, "4.00") {
+		t.Errorf("outputSporadic() = %q", got)
+	}
+}
+
+func Test_outputCycles(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	outputCycles(&w, nil, scheduler.TimeUnitTicks)
+	if w.Len() != 0 {
+		t.Errorf("outputCycles(nil) = %q, want nothing", w.String())
+	}
+	outputCycles(&w, []scheduler.RRCycle{{Start: 0, Ready: 3, Quantum: 5}, {Start: 10, Ready: 1, Quantum: 7}}, scheduler.TimeUnitTicks)
+	if got := w.String(); !strings.HasPrefix(got, "Quantum per cycle\n") || !strings.Contains(got, "|     2 |    10 |     1 |       7 |") {
+		t.Errorf("outputCycles() = %q", got)
+	}
+}
+
+func Test_writeText_slack(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	writeText(&w, scheduler.Result{Title: "Slack", Processes: []scheduler.ProcessResult{{ID: 1, Burst: 2, Exit: 2}},
+		Slack: &scheduler.SlackReport{Reclaimed: 3}}, scheduler.TimeUnitMillis)
+	if got := w.String(); !strings.Contains(got, "Slack reclaimed by soft processes (ms): 3\n") {
+		t.Errorf("writeText() = %s\nwant the slack reclaimed", got)
+	}
+}
+
+func Test_writeText_deadlines(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	writeText(&w, scheduler.Result{Title: "EDF", Processes: []scheduler.ProcessResult{
+		{ID: 1, Burst: 2, Exit: 2, Deadline: 3},
+		{ID: 2, Burst: 2, Exit: 4, Deadline: 3},
+		{ID: 3, Burst: 2, Exit: 6},
+		{ID: 4, Burst: 2, Exit: 1, Deadline: 5, Status: scheduler.StatusKilled},
+	}}, scheduler.TimeUnitTicks)
+	got := w.String()
+	for _, want := range []string{"| DEADLINE |  LATE  |", "|        3 |      1 |", "|        5 | missed |", "|          |        |", "MISSED"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("writeText() = %s\nwant it to contain %q", got, want)
+		}
+	}
+}
@@ -0,0 +1,372 @@
+package render
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+// This file is a minimal Office Open XML (xlsx) writer: inline-string worksheets, a bold header
+// style and DrawingML bar charts. That is all a results workbook needs, and it keeps the tool free
+// of a spreadsheet dependency while producing files Excel, LibreOffice and Google Sheets open.
+
+// xlsxComparisonSheet is the name of the first sheet, one row of averages per result.
+const xlsxComparisonSheet = "Comparison"
+
+// xlsxEpoch is the modification time of every part, so the same results give byte-identical files.
+var xlsxEpoch = time.Date(1980, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// xlsxCell is one written cell: a number, or an inline string when isStr is set.
+type xlsxCell struct {
+	str    string
+	num    float64
+	isStr  bool
+	header bool
+}
+
+func xlsxString(s string) xlsxCell { return xlsxCell{str: s, isStr: true} }
+
+func xlsxHeader(s string) xlsxCell { return xlsxCell{str: s, isStr: true, header: true} }
+
+func xlsxNumber(v float64) xlsxCell { return xlsxCell{num: v} }
+
+func xlsxInt(v int64) xlsxCell { return xlsxCell{num: float64(v)} }
+
+func xlsxRow(c ...xlsxCell) []xlsxCell { return c }
+
+// xlsxPart is one file of the workbook package.
+type xlsxPart struct {
+	name, body string
+}
+
+// xlsxChart is a clustered column chart of some Comparison columns, by result.
+type xlsxChart struct {
+	title   string
+	columns []int // 0-based Comparison columns plotted as series
+}
+
+// xlsxCharts are the charts embedded in the Comparison sheet. Throughput gets a chart of its own,
+// as its scale is unrelated to the times.
+var xlsxCharts = []xlsxChart{
+	{title: "Average wait and turnaround", columns: []int{2, 3}},
+	{title: "Throughput", columns: []int{4}},
+}
+
+// writeXLSX renders results as a workbook: a Comparison sheet holding each result's averages with
+// bar charts of them, then one sheet per result with its schedule table.
+func writeXLSX(w io.Writer, results []scheduler.Result, unit scheduler.TimeUnit) error {
+	comparison := [][]xlsxCell{xlsxRow(xlsxHeader("Input"), xlsxHeader("Algorithm"),
+		xlsxHeader(unit.Label("Average wait")), xlsxHeader(unit.Label("Average turnaround")),
+		xlsxHeader("Throughput"), xlsxHeader("Incomplete"))}
+	sheets := []string{xlsxComparisonSheet}
+	tables := [][][]xlsxCell{nil}
+	multipleInputs := false
+	for _, r := range results {
+		if r.Input != results[0].Input {
+			multipleInputs = true
+		}
+	}
+	for _, r := range results {
+		comparison = append(comparison, xlsxRow(xlsxString(r.Input), xlsxString(r.Algorithm),
+			xlsxNumber(r.AveWait), xlsxNumber(r.AveTurnaround), xlsxNumber(r.AveThroughput), xlsxInt(int64(r.Incomplete))))
+
+		name := r.Algorithm
+		if name == "" {
+			name = r.Title
+		}
+		if multipleInputs {
+			name = plotName(r.Input) + " " + name
+		}
+		sheets = append(sheets, xlsxSheetName(name, sheets))
+		tables = append(tables, xlsxSchedule(r, unit))
+	}
+	tables[0] = comparison
+
+	zw := zip.NewWriter(w)
+	parts := []xlsxPart{
+		{"[Content_Types].xml", xlsxContentTypes(len(sheets))},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/workbook.xml", xlsxWorkbook(sheets)},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels(len(sheets))},
+		{"xl/styles.xml", xlsxStyles},
+		{"xl/worksheets/_rels/sheet1.xml.rels", xlsxRels([]string{"../drawings/drawing1.xml"}, xlsxRelDrawing)},
+		{"xl/drawings/drawing1.xml", xlsxDrawing(len(xlsxCharts))},
+		{"xl/drawings/_rels/drawing1.xml.rels", xlsxChartRels(len(xlsxCharts))},
+	}
+	for i, chart := range xlsxCharts {
+		parts = append(parts, xlsxPart{fmt.Sprintf("xl/charts/chart%d.xml", i+1), xlsxChartXML(chart, comparison)})
+	}
+	for i, table := range tables {
+		parts = append(parts, xlsxPart{fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), xlsxWorksheet(table, i == 0)})
+	}
+	for _, p := range parts {
+		f, err := zw.CreateHeader(&zip.FileHeader{Name: p.name, Method: zip.Deflate, Modified: xlsxEpoch})
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(f, p.body); err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
+
+// xlsxSchedule is a result's schedule table followed by its averages.
+func xlsxSchedule(r scheduler.Result, unit scheduler.TimeUnit) [][]xlsxCell {
+	rows := [][]xlsxCell{xlsxRow(xlsxHeader("ID"), xlsxHeader("Priority"), xlsxHeader(unit.Label("Burst")),
+		xlsxHeader(unit.Label("Arrival")), xlsxHeader(unit.Label("Wait")), xlsxHeader(unit.Label("Turnaround")),
+		xlsxHeader(unit.Label("Exit")), xlsxHeader("Status"))}
+	for _, p := range r.Processes {
+		status := "completed"
+		if !p.Completed() {
+			status = string(p.Status)
+		}
+		rows = append(rows, xlsxRow(xlsxInt(p.ID), xlsxInt(p.Priority), xlsxInt(p.Burst), xlsxInt(p.Arrival),
+			xlsxInt(p.Wait), xlsxInt(p.Turnaround), xlsxInt(p.Exit), xlsxString(status)))
+	}
+
+	return append(rows, nil,
+		xlsxRow(xlsxHeader("Average wait"), xlsxNumber(r.AveWait)),
+		xlsxRow(xlsxHeader("Average turnaround"), xlsxNumber(r.AveTurnaround)),
+		xlsxRow(xlsxHeader("Throughput"), xlsxNumber(r.AveThroughput)))
+}
+
+// xlsxSheetName makes name a valid sheet name, at most 31 characters without []:*?/\, and
+// distinct from the names already taken.
+func xlsxSheetName(name string, taken []string) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return '_'
+		}
+		return r
+	}, name)
+	base := []rune(name)
+	if len(base) > 31 {
+		base = base[:31]
+	}
+	candidate := string(base)
+	for n := 2; xlsxTaken(candidate, taken); n++ {
+		suffix := " (" + strconv.Itoa(n) + ")"
+		trimmed := base
+		if len(trimmed)+len(suffix) > 31 {
+			trimmed = trimmed[:31-len(suffix)]
+		}
+		candidate = string(trimmed) + suffix
+	}
+
+	return candidate
+}
+
+// xlsxTaken reports whether name is already a sheet name; Excel compares them case-insensitively.
+func xlsxTaken(name string, taken []string) bool {
+	for _, t := range taken {
+		if strings.EqualFold(t, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// xlsxCellRef is the A1-style reference of a 0-based column and 1-based row.
+func xlsxCellRef(col, row int) string {
+	return xlsxColumn(col) + strconv.Itoa(row)
+}
+
+// xlsxColumn is the letters of a 0-based column: A..Z, AA...
+func xlsxColumn(col int) string {
+	var letters []byte
+	for col++; col > 0; col = (col - 1) / 26 {
+		letters = append([]byte{byte('A' + (col-1)%26)}, letters...)
+	}
+
+	return string(letters)
+}
+
+// xlsxEscape escapes text for an XML element or attribute.
+func xlsxEscape(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+
+	return b.String()
+}
+
+func xlsxWorksheet(rows [][]xlsxCell, drawing bool) string {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheetData>`)
+	for i, row := range rows {
+		_, _ = fmt.Fprintf(&b, `<row r="%d">`, i+1)
+		for j, c := range row {
+			ref := xlsxCellRef(j, i+1)
+			style := ""
+			if c.header {
+				style = ` s="1"`
+			}
+			if c.isStr {
+				_, _ = fmt.Fprintf(&b, `<c r="%s" t="inlineStr"%s><is><t>%s</t></is></c>`, ref, style, xlsxEscape(c.str))
+			} else {
+				_, _ = fmt.Fprintf(&b, `<c r="%s"%s><v>%s</v></c>`, ref, style, strconv.FormatFloat(c.num, 'g', -1, 64))
+			}
+		}
+		b.WriteString(`</row>`)
+	}
+	b.WriteString(`</sheetData>`)
+	if drawing {
+		b.WriteString(`<drawing r:id="rId1"/>`)
+	}
+	b.WriteString(`</worksheet>`)
+
+	return b.String()
+}
+
+func xlsxContentTypes(sheets int) string {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">`)
+	b.WriteString(`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>`)
+	b.WriteString(`<Default Extension="xml" ContentType="application/xml"/>`)
+	b.WriteString(`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>`)
+	b.WriteString(`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`)
+	for i := 1; i <= sheets; i++ {
+		_, _ = fmt.Fprintf(&b, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, i)
+	}
+	b.WriteString(`<Override PartName="/xl/drawings/drawing1.xml" ContentType="application/vnd.openxmlformats-officedocument.drawing+xml"/>`)
+	for i := range xlsxCharts {
+		_, _ = fmt.Fprintf(&b, `<Override PartName="/xl/charts/chart%d.xml" ContentType="application/vnd.openxmlformats-officedocument.drawingml.chart+xml"/>`, i+1)
+	}
+	b.WriteString(`</Types>`)
+
+	return b.String()
+}
+
+// Relationship types used between the workbook's parts.
+const (
+	xlsxRelOffice    = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument"
+	xlsxRelWorksheet = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet"
+	xlsxRelStyles    = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles"
+	xlsxRelDrawing   = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/drawing"
+	xlsxRelChart     = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/chart"
+)
+
+var xlsxRootRels = xlsxRels([]string{"xl/workbook.xml"}, xlsxRelOffice)
+
+// xlsxRels is a relationships part pointing at targets, rId1 onwards, all of one type.
+func xlsxRels(targets []string, typ string) string {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	for i, t := range targets {
+		_, _ = fmt.Fprintf(&b, `<Relationship Id="rId%d" Type="%s" Target="%s"/>`, i+1, typ, t)
+	}
+	b.WriteString(`</Relationships>`)
+
+	return b.String()
+}
+
+// xlsxWorkbookRels points rId1..n at the worksheets and rId n+1 at the styles.
+func xlsxWorkbookRels(sheets int) string {
+	targets := make([]string, sheets)
+	for i := range targets {
+		targets[i] = fmt.Sprintf("worksheets/sheet%d.xml", i+1)
+	}
+	rels := xlsxRels(targets, xlsxRelWorksheet)
+
+	return strings.Replace(rels, `</Relationships>`,
+		fmt.Sprintf(`<Relationship Id="rId%d" Type="%s" Target="styles.xml"/></Relationships>`, sheets+1, xlsxRelStyles), 1)
+}
+
+func xlsxChartRels(charts int) string {
+	targets := make([]string, charts)
+	for i := range targets {
+		targets[i] = fmt.Sprintf("../charts/chart%d.xml", i+1)
+	}
+
+	return xlsxRels(targets, xlsxRelChart)
+}
+
+func xlsxWorkbook(sheets []string) string {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`)
+	for i, name := range sheets {
+		_, _ = fmt.Fprintf(&b, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, xlsxEscape(name), i+1, i+1)
+	}
+	b.WriteString(`</sheets></workbook>`)
+
+	return b.String()
+}
+
+// xlsxStyles has the default cell format and, as format 1, bold text for headers.
+const xlsxStyles = xml.Header + `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+	`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+	`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+	`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+	`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+	`<cellXfs count="2"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+	`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/></cellXfs>` +
+	`</styleSheet>`
+
+// xlsxDrawing anchors the charts beside the Comparison table, one below the other.
+func xlsxDrawing(charts int) string {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<xdr:wsDr xmlns:xdr="http://schemas.openxmlformats.org/drawingml/2006/spreadsheetDrawing" xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main">`)
+	for i := 0; i < charts; i++ {
+		top := 1 + 18*i
+		_, _ = fmt.Fprintf(&b, `<xdr:twoCellAnchor><xdr:from><xdr:col>7</xdr:col><xdr:colOff>0</xdr:colOff><xdr:row>%d</xdr:row><xdr:rowOff>0</xdr:rowOff></xdr:from>`+
+			`<xdr:to><xdr:col>15</xdr:col><xdr:colOff>0</xdr:colOff><xdr:row>%d</xdr:row><xdr:rowOff>0</xdr:rowOff></xdr:to>`+
+			`<xdr:graphicFrame macro=""><xdr:nvGraphicFramePr><xdr:cNvPr id="%d" name="Chart %d"/><xdr:cNvGraphicFramePr/></xdr:nvGraphicFramePr>`+
+			`<xdr:xfrm><a:off x="0" y="0"/><a:ext cx="0" cy="0"/></xdr:xfrm>`+
+			`<a:graphic><a:graphicData uri="http://schemas.openxmlformats.org/drawingml/2006/chart">`+
+			`<c:chart xmlns:c="http://schemas.openxmlformats.org/drawingml/2006/chart" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships" r:id="rId%d"/>`+
+			`</a:graphicData></a:graphic></xdr:graphicFrame><xdr:clientData/></xdr:twoCellAnchor>`,
+			top, top+16, i+2, i+1, i+1)
+	}
+	b.WriteString(`</xdr:wsDr>`)
+
+	return b.String()
+}
+
+// xlsxChartXML is a clustered column chart of the chart's Comparison columns, one category per
+// result. Values are cached in the chart so it renders before the workbook is recalculated.
+func xlsxChartXML(chart xlsxChart, comparison [][]xlsxCell) string {
+	var (
+		b    strings.Builder
+		last = len(comparison)
+		sh   = "'" + xlsxComparisonSheet + "'"
+	)
+	b.WriteString(xml.Header)
+	b.WriteString(`<c:chartSpace xmlns:c="http://schemas.openxmlformats.org/drawingml/2006/chart" xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><c:chart>`)
+	_, _ = fmt.Fprintf(&b, `<c:title><c:tx><c:rich><a:bodyPr/><a:p><a:r><a:t>%s</a:t></a:r></a:p></c:rich></c:tx><c:overlay val="0"/></c:title>`, xlsxEscape(chart.title))
+	b.WriteString(`<c:autoTitleDeleted val="0"/><c:plotArea><c:layout/><c:barChart><c:barDir val="col"/><c:grouping val="clustered"/><c:varyColors val="0"/>`)
+	for i, col := range chart.columns {
+		letter := xlsxColumn(col)
+		_, _ = fmt.Fprintf(&b, `<c:ser><c:idx val="%d"/><c:order val="%d"/>`, i, i)
+		_, _ = fmt.Fprintf(&b, `<c:tx><c:strRef><c:f>%s!$%s$1</c:f><c:strCache><c:ptCount val="1"/><c:pt idx="0"><c:v>%s</c:v></c:pt></c:strCache></c:strRef></c:tx>`,
+			sh, letter, xlsxEscape(comparison[0][col].str))
+		_, _ = fmt.Fprintf(&b, `<c:cat><c:strRef><c:f>%s!$B$2:$B$%d</c:f><c:strCache><c:ptCount val="%d"/>`, sh, last, last-1)
+		for j, row := range comparison[1:] {
+			_, _ = fmt.Fprintf(&b, `<c:pt idx="%d"><c:v>%s</c:v></c:pt>`, j, xlsxEscape(row[1].str))
+		}
+		_, _ = fmt.Fprintf(&b, `</c:strCache></c:strRef></c:cat><c:val><c:numRef><c:f>%s!$%s$2:$%s$%d</c:f><c:numCache><c:formatCode>General</c:formatCode><c:ptCount val="%d"/>`,
+			sh, letter, letter, last, last-1)
+		for j, row := range comparison[1:] {
+			_, _ = fmt.Fprintf(&b, `<c:pt idx="%d"><c:v>%s</c:v></c:pt>`, j, strconv.FormatFloat(row[col].num, 'g', -1, 64))
+		}
+		b.WriteString(`</c:numCache></c:numRef></c:val></c:ser>`)
+	}
+	b.WriteString(`<c:axId val="1"/><c:axId val="2"/></c:barChart>`)
+	b.WriteString(`<c:catAx><c:axId val="1"/><c:scaling><c:orientation val="minMax"/></c:scaling><c:delete val="0"/><c:axPos val="b"/><c:crossAx val="2"/></c:catAx>`)
+	b.WriteString(`<c:valAx><c:axId val="2"/><c:scaling><c:orientation val="minMax"/></c:scaling><c:delete val="0"/><c:axPos val="l"/><c:majorGridlines/><c:crossAx val="1"/></c:valAx>`)
+	b.WriteString(`</c:plotArea><c:legend><c:legendPos val="b"/><c:overlay val="0"/></c:legend><c:plotVisOnly val="1"/></c:chart></c:chartSpace>`)
+
+	return b.String()
+}
@@ -0,0 +1,83 @@
+package render
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+func Test_writeXLSX(t *testing.T) {
+	t.Parallel()
+	results := []scheduler.Result{
+		{Algorithm: "fcfs", Input: "a.csv", Title: "FCFS", AveWait: 1.5, AveTurnaround: 4, AveThroughput: 0.25,
+			Processes: []scheduler.ProcessResult{{ID: 1, Burst: 3, Turnaround: 3, Exit: 3}, {ID: 2, Burst: 2, Wait: 3, Turnaround: 5, Exit: 5}}},
+		{Algorithm: "sjf", Input: "a.csv", Title: "SJF & co", Incomplete: 1,
+			Processes: []scheduler.ProcessResult{{ID: 1, Exit: 2, Status: scheduler.StatusKilled}}},
+	}
+	var b bytes.Buffer
+	require.NoError(t, writeXLSX(&b, results, scheduler.TimeUnitMillis))
+
+	zr, err := zip.NewReader(bytes.NewReader(b.Bytes()), int64(b.Len()))
+	require.NoError(t, err)
+	parts := make(map[string]string)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		body, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		parts[f.Name] = string(body)
+
+		// Every part must be well-formed XML.
+		dec := xml.NewDecoder(bytes.NewReader(body))
+		for {
+			if _, err := dec.Token(); errors.Is(err, io.EOF) {
+				break
+			} else if !assert.NoError(t, err, f.Name) {
+				break
+			}
+		}
+	}
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/styles.xml",
+		"xl/worksheets/sheet1.xml", "xl/worksheets/sheet2.xml", "xl/worksheets/sheet3.xml",
+		"xl/drawings/drawing1.xml", "xl/charts/chart1.xml", "xl/charts/chart2.xml"} {
+		assert.Contains(t, parts, name)
+	}
+	assert.Contains(t, parts["xl/workbook.xml"], `<sheet name="Comparison" sheetId="1" r:id="rId1"/><sheet name="fcfs" sheetId="2" r:id="rId2"/><sheet name="sjf" sheetId="3" r:id="rId3"/>`)
+	assert.Contains(t, parts["xl/worksheets/sheet1.xml"], `<c r="C1" t="inlineStr" s="1"><is><t>Average wait (ms)</t></is></c>`)
+	assert.Contains(t, parts["xl/worksheets/sheet1.xml"], `<c r="C2"><v>1.5</v></c>`)
+	assert.Contains(t, parts["xl/worksheets/sheet3.xml"], `<is><t>killed</t></is>`)
+	assert.Contains(t, parts["xl/charts/chart1.xml"], `<c:f>'Comparison'!$D$2:$D$3</c:f>`)
+	assert.Contains(t, parts["xl/charts/chart2.xml"], `<c:pt idx="0"><c:v>0.25</c:v></c:pt>`)
+
+	var again bytes.Buffer
+	require.NoError(t, writeXLSX(&again, results, scheduler.TimeUnitMillis))
+	assert.Equal(t, b.Bytes(), again.Bytes(), "workbooks should be reproducible")
+}
+
+func Test_xlsxColumn(t *testing.T) {
+	t.Parallel()
+	for col, want := range map[int]string{0: "A", 25: "Z", 26: "AA", 701: "ZZ", 702: "AAA"} {
+		assert.Equal(t, want, xlsxColumn(col))
+	}
+}
+
+func Test_xlsxSheetName(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "a_b_c", xlsxSheetName("a/b:c", nil))
+	assert.Equal(t, "FCFS (2)", xlsxSheetName("FCFS", []string{"fcfs"}))
+	long := strings.Repeat("x", 40)
+	assert.Equal(t, strings.Repeat("x", 27)+" (2)", xlsxSheetName(long, []string{strings.Repeat("x", 31)}))
+	// Names are cut by character, never through a multi-byte one.
+	wide := strings.Repeat("é", 40)
+	assert.Equal(t, strings.Repeat("é", 31), xlsxSheetName(wide, nil))
+	assert.Equal(t, strings.Repeat("é", 27)+" (2)", xlsxSheetName(wide, []string{strings.Repeat("é", 31)}))
+}
@@ -0,0 +1,198 @@
+package scheduler
+
+import "sort"
+
+// Algorithm is a scheduling algorithm the simulator can run.
+type Algorithm struct {
+	Name     string
+	Version  string
+	Title    string
+	Simulate SimulateFunc
+}
+
+// Algorithms are run in order on every workload.
+// The name is used for {algo} in output patterns. The version is recorded in run
+// provenance and must be bumped whenever a change alters the scheduler's results.
+var Algorithms = []Algorithm{
+	{"fcfs", "3", "First-come, first-serve", FCFS},
+	{"sjf", "7", "Shortest-job-first", SJF},
+	{"sjf-np", "2", "Non-preemptive shortest-job-first", SJFNonPreemptive},
+	{"priority", "7", "Priority", SJFPriority},
+	{"mlq", "1", "Multilevel queue", MLQ},
+	{"priority-rr", "1", "Round-robin within priority levels", PriorityRR},
+	{"o1", "1", "Linux O(1)", O1},
+	{"srr", "1", "Selfish round-robin", SRR},
+	{"lottery", "1", "Lottery", Lottery},
+	{"random", "1", "Random", Random},
+	{"stride", "1", "Stride", Stride},
+	{"guaranteed", "1", "Guaranteed", Guaranteed},
+	{"nice", "1", "Nice", Nice},
+	{"eevdf", "1", "Earliest eligible virtual deadline first", EEVDF},
+	{"edf", "2", "Earliest deadline first", EDF},
+	{"cbs", "1", "Constant bandwidth server", CBS},
+	{"slack", "1", "Slack stealing", Slack},
+	{"ljf", "2", "Longest-job-first", LJF},
+	{"lrtf", "2", "Longest-remaining-time-first", LRTF},
+	{"hprn", "1", "Highest penalty ratio next", HPRN},
+	{"rr", "4", "Round-robin", RR},
+}
+
+// FCFS simulates first-come, first-serve scheduling of processes.
+func FCFS(title string, processes []Process, opts ...Option) Result {
+	return fcfs(title, processes, newOptions(opts))
+}
+
+// SJF simulates preemptive shortest-job-first scheduling of processes.
+func SJF(title string, processes []Process, opts ...Option) Result {
+	return sjf(title, processes, newOptions(opts))
+}
+
+// SJFNonPreemptive simulates non-preemptive shortest-job-first scheduling of processes: the
+// shortest ready process runs to completion, and the ready queue is only re-evaluated then.
+func SJFNonPreemptive(title string, processes []Process, opts ...Option) Result {
+	return sjfNonPreemptive(title, processes, newOptions(opts))
+}
+
+// LJF simulates non-preemptive longest-job-first scheduling of processes: the longest ready
+// process runs to completion. It is the opposite of SJF, for showing how much worse waits get
+// when short processes queue behind long ones.
+func LJF(title string, processes []Process, opts ...Option) Result {
+	o := newOptions(opts)
+	o.preemption.toCompletion = true
+	return preemptive(title, processes, o, byLongest)
+}
+
+// EDF simulates preemptive earliest-deadline-first scheduling of processes: the ready process
+// with the earliest AbsoluteDeadline runs, preempting any with a later one, and processes
+// without a deadline only run when no process with one is ready.
+func EDF(title string, processes []Process, opts ...Option) Result {
+	return preemptive(title, processes, newOptions(opts), byDeadline)
+}
+
+// SJFPriority simulates preemptive priority scheduling of processes.
+func SJFPriority(title string, processes []Process, opts ...Option) Result {
+	return sjfPriority(title, processes, newOptions(opts))
+}
+
+// RR simulates round-robin scheduling of processes with a fixed quantum of DefaultQuantum.
+func RR(title string, processes []Process, opts ...Option) Result {
+	return rr(title, processes, newOptions(opts))
+}
+
+// fcfs simulates first-come, first-serve scheduling.
+func fcfs(title string, processes []Process, o options) Result {
+	title = o.tieBreak.title(title)
+	var (
+		progress = newProgressReporter(o.progress, title, len(processes))
+		clock    int64
+		rec      = newRecorder(o, len(processes))
+		order    = make([]int, len(processes))
+	)
+	// Serve in order of arrival; processes arriving together keep their input order, unless
+	// another tie-break is set.
+	for i := range order {
+		order[i] = i
+	}
+	var t *processTable
+	if o.tieBreak != TieBreakDefault {
+		t = newProcessTable(processes)
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		if x, y := processes[order[a]].ArrivalTime, processes[order[b]].ArrivalTime; x != y || t == nil {
+			return x < y
+		}
+		return o.tieBreak.less(t, order[a], order[b])
+	})
+	arrivals := arrivalAnnouncer{hooks: o.hooks, processes: processes, order: order}
+	for n, i := range order {
+		p := processes[i]
+		if p.ArrivalTime > clock {
+			// The CPU idles until the next process arrives.
+			rec.slice(TimeSlice{Idle: true, Start: clock, Stop: p.ArrivalTime})
+			o.hooks.idle(clock, p.ArrivalTime)
+			clock = p.ArrivalTime
+		}
+		start := clock
+		clock += p.BurstDuration
+
+		arrivals.before(start + 1)
+		if p.BurstDuration > 0 {
+			o.hooks.dispatch(start, p, 0)
+		}
+		arrivals.before(clock)
+		row := ProcessResult{
+			ID:         p.ProcessID,
+			Priority:   p.Priority,
+			Burst:      p.BurstDuration,
+			Arrival:    p.ArrivalTime,
+			Wait:       start - p.ArrivalTime,
+			Turnaround: clock - p.ArrivalTime,
+			Response:   start - p.ArrivalTime,
+			Exit:       clock,
+			Deadline:   p.AbsoluteDeadline(),
+		}
+		rec.process(row)
+		o.hooks.complete(clock, row)
+		if p.BurstDuration > 0 {
+			// A zero-burst process completes as it is dispatched, without occupying the CPU.
+			rec.slice(TimeSlice{
+				PID:   p.ProcessID,
+				Start: start,
+				Stop:  clock,
+			})
+		}
+		progress.update(clock, n+1)
+	}
+	rec.flush()
+	progress.finish(clock, len(processes))
+
+	r := Result{
+		Title:     title,
+		Gantt:     rec.gantt,
+		Processes: rec.processes,
+	}
+	rec.totals.apply(&r)
+
+	return r
+}
+
+// sjfPriority simulates preemptive priority scheduling.
+func sjfPriority(title string, processes []Process, o options) Result {
+	return preemptive(title, processes, o, byPriority)
+}
+
+// sjf simulates preemptive shortest-job-first (shortest remaining time) scheduling.
+func sjf(title string, processes []Process, o options) Result {
+	return preemptive(o.tieBreak.title(title), processes, o, byRemainingThen(o.tieBreak))
+}
+
+// sjfNonPreemptive simulates non-preemptive shortest-job-first scheduling. It is the preemptive
+// engine with every process held on the CPU until it completes, so arrivals while one runs only
+// join the ready queue.
+func sjfNonPreemptive(title string, processes []Process, o options) Result {
+	o.preemption.toCompletion = true
+	return preemptive(o.tieBreak.title(title), processes, o, byRemainingThen(o.tieBreak))
+}
+
+// rr simulates round-robin scheduling with a fixed quantum. A process that uses up its quantum
+// goes to the back of the ready queue, behind those that were waiting, but ahead of any that
+// arrived during the quantum, which join the queue after it.
+func rr(title string, processes []Process, o options) Result {
+	var (
+		e     = newQuantumEngine(title, processes, o)
+		t     = e.t
+		ready []int
+		used  = make([]int64, t.len()) // of the current quantum
+	)
+
+	return e.run(quantumPolicy{
+		admit: func(p int) { ready = append(ready, p) },
+		pick:  func() (int, int64, string) { return ready[0], DefaultQuantum - used[ready[0]], "" },
+		ran: func(p int, run int64) {
+			if used[p] += run; t.remaining[p] > 0 && used[p] >= DefaultQuantum {
+				used[p], ready = 0, rotate(ready, p)
+			}
+		},
+		done: func(p int) { ready, _ = without(ready, p) },
+	})
+}
@@ -0,0 +1,206 @@
+package scheduler
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func Test_rr_lateFirstArrival(t *testing.T) {
+	t.Parallel()
+	got := rr("test", []Process{
+		{ProcessID: 2, ArrivalTime: 4, BurstDuration: 1},
+		{ProcessID: 1, ArrivalTime: 3, BurstDuration: 2},
+	}, options{})
+	want := []TimeSlice{
+		{Idle: true, Start: 0, Stop: 3},
+		{PID: 1, Start: 3, Stop: 5},
+		{PID: 2, Start: 5, Stop: 6},
+	}
+	if !reflect.DeepEqual(got.Gantt, want) {
+		t.Errorf("rr() gantt = %+v, want %+v", got.Gantt, want)
+	}
+}
+
+func Test_fcfs_zeroBurst(t *testing.T) {
+	t.Parallel()
+	got := fcfs("test", []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 0},
+		{ProcessID: 3, ArrivalTime: 1, BurstDuration: 1},
+	}, options{})
+	if want := []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 3, Start: 2, Stop: 3}}; !reflect.DeepEqual(got.Gantt, want) {
+		t.Errorf("fcfs() gantt = %+v, want %+v", got.Gantt, want)
+	}
+	if want := (ProcessResult{ID: 2, Arrival: 1, Wait: 1, Turnaround: 1, Response: 1, Exit: 2}); got.Processes[1] != want {
+		t.Errorf("fcfs() zero-burst row = %+v, want %+v", got.Processes[1], want)
+	}
+	if only := fcfs("test", []Process{{ProcessID: 1}}, options{}); only.AveThroughput != 0 {
+		t.Errorf("fcfs() throughput with nothing but zero bursts at 0 = %v, want 0", only.AveThroughput)
+	}
+}
+
+func Test_rr_arbitraryPIDs(t *testing.T) {
+	t.Parallel()
+	got := rr("test", []Process{
+		{ProcessID: 4000, ArrivalTime: 0, BurstDuration: 3},
+		{ProcessID: 17, ArrivalTime: 1, BurstDuration: 1},
+	}, options{})
+	want := []ProcessResult{
+		{ID: 4000, Burst: 3, Arrival: 0, Wait: 0, Turnaround: 3, Exit: 3},
+		{ID: 17, Burst: 1, Arrival: 1, Wait: 2, Turnaround: 3, Response: 2, Exit: 4},
+	}
+	if !reflect.DeepEqual(got.Processes, want) {
+		t.Errorf("rr() processes = %+v, want %+v", got.Processes, want)
+	}
+}
+
+func Test_rr_rows(t *testing.T) {
+	t.Parallel()
+	// P1 runs 0-2 and, requeued ahead of P2, which arrived during its quantum, 2-4; P2 runs 4-6,
+	// P1 completes at 7 and P2 at 8, for an average turnaround of 7.
+	got := rr("test", []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 3},
+	}, options{})
+	want := []ProcessResult{
+		{ID: 1, Burst: 5, Arrival: 0, Wait: 2, Turnaround: 7, Response: 0, Exit: 7},
+		{ID: 2, Burst: 3, Arrival: 1, Wait: 4, Turnaround: 7, Response: 3, Exit: 8},
+	}
+	if !reflect.DeepEqual(got.Processes, want) {
+		t.Errorf("rr() processes = %+v, want %+v", got.Processes, want)
+	}
+	if got.AveTurnaround != 7 || got.AveWait != 3 || got.AveResponse != 1.5 {
+		t.Errorf("rr() averages = turnaround %v, wait %v, response %v, want 7, 3, 1.5", got.AveTurnaround, got.AveWait, got.AveResponse)
+	}
+}
+
+func Test_sjfNonPreemptive(t *testing.T) {
+	t.Parallel()
+	// P1 runs to completion at 10 although shorter processes arrive from 1; the shortest of
+	// those then runs first, and P2 and P3 tie on burst and go in arrival order.
+	got := exits(sjfNonPreemptive("test", preemptionProcesses, options{}))
+	if want := map[int64]int64{1: 10, 2: 13, 3: 15, 4: 11}; !reflect.DeepEqual(got, want) {
+		t.Errorf("sjfNonPreemptive() exits = %v, want %v", got, want)
+	}
+	if r := Chain(SJFNonPreemptive, PreemptionEffect())("test", preemptionProcesses, WithMinGranularity(1)); r.Preemption.With.Preemptions != 0 || r.Preemption.Without.Preemptions != 0 {
+		t.Errorf("SJFNonPreemptive() preempted: %+v", r.Preemption)
+	}
+}
+
+func TestEDF(t *testing.T) {
+	t.Parallel()
+	r := EDF("edf", []Process{
+		{ProcessID: 1, BurstDuration: 4, Deadline: 10},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2, Deadline: 3},
+		{ProcessID: 3, ArrivalTime: 2, BurstDuration: 3},
+		{ProcessID: 4, ArrivalTime: 2, BurstDuration: 2, Deadline: 2},
+	})
+	// P2 preempts P1, P4 ties with it on deadline 4 and runs next, and P3, without a deadline,
+	// runs last.
+	if want := map[int64]int64{1: 8, 2: 3, 3: 11, 4: 5}; !reflect.DeepEqual(exits(r), want) {
+		t.Errorf("EDF() exits = %v, want %v", exits(r), want)
+	}
+	for _, p := range r.Processes {
+		wantMissed, wantLate := p.ID == 4, map[int64]int64{4: 1}[p.ID]
+		if p.MissedDeadline() != wantMissed || p.Lateness() != wantLate {
+			t.Errorf("P%d deadline %d: missed %v by %d, want %v by %d", p.ID, p.Deadline, p.MissedDeadline(), p.Lateness(), wantMissed, wantLate)
+		}
+	}
+	if r.Processes[0].Deadline != 4 {
+		t.Errorf("P2 absolute deadline = %d, want 4", r.Processes[0].Deadline)
+	}
+}
+
+func TestProcess_AbsoluteDeadline(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		p    Process
+		want int64
+	}{
+		{Process{ArrivalTime: 3}, 0},
+		{Process{ArrivalTime: 3, Deadline: 4}, 7},
+		{Process{ArrivalTime: 3, Deadline: math.MaxInt64 - 3}, math.MaxInt64},
+		// Past the largest time, the deadline saturates rather than wrapping negative.
+		{Process{ArrivalTime: 3, Deadline: math.MaxInt64}, math.MaxInt64},
+	} {
+		if got := tt.p.AbsoluteDeadline(); got != tt.want {
+			t.Errorf("%+v.AbsoluteDeadline() = %d, want %d", tt.p, got, tt.want)
+		}
+	}
+}
+
+func TestLJF(t *testing.T) {
+	t.Parallel()
+	// The longest ready process runs to completion each time, so P4, the shortest, waits longest.
+	r := LJF("ljf", preemptionProcesses)
+	if want := map[int64]int64{1: 10, 2: 12, 3: 14, 4: 15}; !reflect.DeepEqual(exits(r), want) {
+		t.Errorf("LJF() exits = %v, want %v", exits(r), want)
+	}
+	if sjf := SJFNonPreemptive("sjf-np", preemptionProcesses); r.AveWait <= sjf.AveWait {
+		t.Errorf("LJF() average wait %.2f, want worse than non-preemptive SJF's %.2f", r.AveWait, sjf.AveWait)
+	}
+}
+
+func TestLRTF(t *testing.T) {
+	t.Parallel()
+	// P1 runs until it is behind P2, since it wins ties, and the two then alternate every time unit.
+	r := LRTF("lrtf", []Process{
+		{ProcessID: 1, BurstDuration: 4},
+		{ProcessID: 2, BurstDuration: 3},
+	})
+	want := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 2},
+		{PID: 2, Start: 2, Stop: 3},
+		{PID: 1, Start: 3, Stop: 4},
+		{PID: 2, Start: 4, Stop: 5},
+		{PID: 1, Start: 5, Stop: 6},
+		{PID: 2, Start: 6, Stop: 7},
+	}
+	if !reflect.DeepEqual(r.Gantt, want) {
+		t.Errorf("LRTF() Gantt = %+v, want %+v", r.Gantt, want)
+	}
+	if want := map[int64]int64{1: 6, 2: 7}; !reflect.DeepEqual(exits(r), want) {
+		t.Errorf("LRTF() exits = %v, want %v", exits(r), want)
+	}
+}
+
+func TestHPRN(t *testing.T) {
+	t.Parallel()
+	r := HPRN("hprn", []Process{
+		{ProcessID: 1, BurstDuration: 5},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9},
+		{ProcessID: 3, ArrivalTime: 6, BurstDuration: 6},
+	})
+	// Each arrival runs first; at 8 P2's ratio, 5/2, beats P1's 8/4 and P3's 2/2, and at 10
+	// P1's 10/4 beats P3's 4/2 and P2's 7/4.
+	want := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 4},
+		{PID: 2, Start: 4, Stop: 6},
+		{PID: 3, Start: 6, Stop: 8},
+		{PID: 2, Start: 8, Stop: 10},
+		{PID: 1, Start: 10, Stop: 11},
+	}
+	if !reflect.DeepEqual(r.Gantt[:len(want)], want) {
+		t.Errorf("HPRN() Gantt = %+v, want it to start %+v", r.Gantt, want)
+	}
+	if want := map[int64]int64{1: 11, 2: 20, 3: 17}; !reflect.DeepEqual(exits(r), want) {
+		t.Errorf("HPRN() exits = %v, want %v", exits(r), want)
+	}
+
+	// The cross products of times this long pass the largest int64, but P1 and P2 still take
+	// turns: at 6e12 P1's ratio of 2 beats P2's 5.9/3, and at 9e12 P2's 8.9/3 beats P1's 1.5.
+	r = HPRN("hprn", []Process{
+		{ProcessID: 1, BurstDuration: 1e13},
+		{ProcessID: 2, ArrivalTime: 1e11, BurstDuration: 1e13},
+	}, WithMinGranularity(3e12))
+	want = []TimeSlice{
+		{PID: 1, Start: 0, Stop: 3e12},
+		{PID: 2, Start: 3e12, Stop: 6e12},
+		{PID: 1, Start: 6e12, Stop: 9e12},
+		{PID: 2, Start: 9e12, Stop: 12e12},
+	}
+	if !reflect.DeepEqual(r.Gantt[:len(want)], want) {
+		t.Errorf("HPRN() Gantt = %+v, want it to start %+v", r.Gantt, want)
+	}
+}
@@ -0,0 +1,73 @@
+package scheduler
+
+import "sort"
+
+// BatchEASY is EASY backfilling on a pool of resources: processes start in arrival order like
+// BatchFCFS, but when the process at the head of the queue does not fit, it gets a reservation
+// at the earliest time enough of the pool will be free for it, and later processes that fit now
+// are started out of order (backfilled) as long as they cannot delay that reservation: they
+// either complete by then or use only what the head process will leave spare. Run times are
+// the bursts, so reservations are exact rather than estimated from users' requested times.
+func BatchEASY(pool Resources) Algorithm {
+	return Algorithm{
+		Name:    "batch-easy",
+		Version: "1",
+		Title:   "Batch EASY backfilling (" + pool.String() + ")",
+		Simulate: func(title string, processes []Process, opts ...Option) Result {
+			return batch(title, processes, newOptions(opts), pool, easyPick)
+		},
+	}
+}
+
+// easyPick starts the head of the queue if it fits, and otherwise the first later process that
+// fits without delaying the head's reservation.
+func easyPick(s *batchState) int {
+	if len(s.queue) == 0 {
+		return -1
+	}
+	head := s.queue[0]
+	if s.fits(head) {
+		return head
+	}
+	shadow := s.shadowTime(head)
+	for _, p := range s.queue[1:] {
+		if s.fits(p) && (s.now+s.processes[p].BurstDuration <= shadow || !s.delays(p, head, shadow)) {
+			s.backfilled++
+			return p
+		}
+	}
+
+	return -1
+}
+
+// shadowTime is the earliest time process p could start and run to completion, as running
+// processes complete and reservations end.
+func (s *batchState) shadowTime(p int) int64 {
+	times := make([]int64, 0, len(s.running)+len(s.reservations))
+	for _, j := range s.running {
+		times = append(times, j.end)
+	}
+	for _, r := range s.reservations {
+		if r.End() > s.now {
+			times = append(times, r.End())
+		}
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i] < times[j] })
+	for _, t := range times {
+		if s.fitsFrom(p, t) {
+			return t
+		}
+	}
+
+	return s.now // unreachable: p fits the empty pool, or it was rejected
+}
+
+// delays reports whether starting process p now would keep head from starting at shadow, using
+// what head would leave spare then.
+func (s *batchState) delays(p, head int, shadow int64) bool {
+	s.running = append(s.running, batchJob{p: p, end: s.now + s.processes[p].BurstDuration})
+	ok := s.fitsFrom(head, shadow)
+	s.running = s.running[:len(s.running)-1]
+
+	return !ok
+}
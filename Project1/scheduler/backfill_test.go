@@ -0,0 +1,61 @@
+package scheduler
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBatchEASY(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 5, Resources: Resources{2, 0}},
+		{ProcessID: 2, BurstDuration: 2, Resources: Resources{4, 0}},
+		{ProcessID: 3, BurstDuration: 10},
+		{ProcessID: 4, BurstDuration: 3},
+	}
+	a := BatchEASY(Resources{5, 0})
+	got := a.Simulate(a.Title, processes)
+
+	// P2 is reserved the pool at 5, when P1 completes, leaving one CPU spare: P3 runs past 5 on
+	// that CPU and P4 completes before 5, so both start at once without delaying P2.
+	want := []ProcessResult{
+		{ID: 4, Burst: 3, Turnaround: 3, Exit: 3},
+		{ID: 1, Burst: 5, Turnaround: 5, Exit: 5},
+		{ID: 2, Burst: 2, Wait: 5, Turnaround: 7, Response: 5, Exit: 7},
+		{ID: 3, Burst: 10, Turnaround: 10, Exit: 10},
+	}
+	if !reflect.DeepEqual(got.Processes, want) {
+		t.Errorf("processes = %+v, want %+v", got.Processes, want)
+	}
+	if got.Resources.Backfilled != 2 {
+		t.Errorf("Backfilled = %d, want 2", got.Resources.Backfilled)
+	}
+
+	// FCFS holds P3 and P4 behind P2.
+	fcfs := BatchFCFS(Resources{5, 0}).Simulate("", processes)
+	if got.AveWait >= fcfs.AveWait || got.Resources.Usage[0].Utilization <= fcfs.Resources.Usage[0].Utilization {
+		t.Errorf("EASY wait %.2f, utilization %.2f; FCFS wait %.2f, utilization %.2f", got.AveWait,
+			got.Resources.Usage[0].Utilization, fcfs.AveWait, fcfs.Resources.Usage[0].Utilization)
+	}
+	if fcfs.Resources.Backfilled != 0 {
+		t.Errorf("FCFS Backfilled = %d", fcfs.Resources.Backfilled)
+	}
+}
+
+func TestBatchEASYNoDelay(t *testing.T) {
+	t.Parallel()
+	// P3 would fit now but hold a CPU P2 needs at its reservation, so it waits for P2.
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 5, Resources: Resources{2, 0}},
+		{ProcessID: 2, BurstDuration: 2, Resources: Resources{4, 0}},
+		{ProcessID: 3, BurstDuration: 10},
+	}
+	a := BatchEASY(Resources{4, 0})
+	got := a.Simulate(a.Title, processes)
+	if p := got.Processes[len(got.Processes)-1]; p.ID != 3 || p.Exit != 17 {
+		t.Errorf("last process = %+v, want P3 exiting at 17", p)
+	}
+	if got.Resources.Backfilled != 0 {
+		t.Errorf("Backfilled = %d, want 0", got.Resources.Backfilled)
+	}
+}
@@ -0,0 +1,32 @@
+package scheduler
+
+import (
+	"testing"
+
+	"github.com/jh125486/CSCE4600/Project1/schedulerbench"
+)
+
+func toProcesses(jobs []schedulerbench.Job) []Process {
+	processes := make([]Process, len(jobs))
+	for i, j := range jobs {
+		processes[i] = Process{
+			ProcessID:     j.ID,
+			ArrivalTime:   j.Arrival,
+			BurstDuration: j.Burst,
+			Priority:      j.Priority,
+		}
+	}
+
+	return processes
+}
+
+func BenchmarkSchedulers(b *testing.B) {
+	for _, s := range Algorithms {
+		s := s
+		b.Run(s.Name, func(b *testing.B) {
+			schedulerbench.RunAll(b, schedulerbench.Standard(10000), toProcesses, func(processes []Process) {
+				s.Simulate(s.Title, processes)
+			})
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package scheduler
+
+type (
+	// BandwidthReport is how each process with a CPU reservation used it in a CBS run.
+	BandwidthReport struct {
+		Servers []ServerUsage `json:"servers"`
+	}
+	// ServerUsage is one process's part of a BandwidthReport: its reservation of Runtime every
+	// Period, due Deadline into each period, how many times it overran the budget with work left,
+	// and how long it was throttled for it.
+	ServerUsage struct {
+		ID        int64 `json:"id"`
+		Runtime   int64 `json:"runtime"`
+		Period    int64 `json:"period"`
+		Deadline  int64 `json:"deadline"`
+		Overruns  int   `json:"overruns"`
+		Throttled int64 `json:"throttled"`
+	}
+)
+
+// Reserved reports whether the process has a CPU reservation for CBS: a Runtime and Period. Its
+// relative deadline in each period is its Deadline, if that is within the period, or the Period.
+func (p Process) Reserved() bool {
+	return p.Runtime > 0 && p.Period > 0
+}
+
+func (p Process) serverDeadline() int64 {
+	if p.Deadline > 0 && p.Deadline <= p.Period {
+		return p.Deadline
+	}
+
+	return p.Period
+}
+
+// CBS simulates SCHED_DEADLINE, earliest-deadline-first over constant bandwidth servers: each
+// process with a reservation (see Reserved) may run Runtime in every Period from its arrival, and
+// the ready one whose current period is due first runs. A process that uses up its budget with
+// work left overruns and is throttled until its next period, when the budget is replenished.
+// Processes without a reservation run in the background, by arrival, whenever no reserved process
+// is ready. Its Result reports each reservation's overruns and throttled time, and the schedule
+// table reports whether each reserved process completed by its first period's deadline.
+func CBS(title string, processes []Process, opts ...Option) Result {
+	return cbs(title, processes, newOptions(opts))
+}
+
+func cbs(title string, processes []Process, o options) Result {
+	var (
+		e         = newQuantumEngine(title, processes, o)
+		t         = e.t
+		ready     []int
+		throttled []int
+		budget    = make([]int64, t.len())
+		start     = make([]int64, t.len()) // start of the current period
+		wake      = make([]int64, t.len()) // when a throttled process's budget is replenished
+		usage     = make([]ServerUsage, t.len())
+	)
+	reserved := func(p int) bool { return processes[p].Reserved() }
+	due := func(p int) int64 { return start[p] + usage[p].Deadline }
+	for i, p := range processes {
+		usage[i].ID = p.ProcessID
+		if p.Reserved() {
+			usage[i].Runtime, usage[i].Period, usage[i].Deadline = p.Runtime, p.Period, p.serverDeadline()
+		}
+	}
+	// before orders reserved processes by their current deadline ahead of background ones, then
+	// by arrival.
+	before := func(a, b int) bool {
+		if reserved(a) != reserved(b) {
+			return reserved(a)
+		}
+		if reserved(a) && due(a) != due(b) {
+			return due(a) < due(b)
+		}
+		if t.arrival[a] != t.arrival[b] {
+			return t.arrival[a] < t.arrival[b]
+		}
+		return t.pid[a] < t.pid[b]
+	}
+
+	r := e.run(quantumPolicy{
+		admit: func(p int) {
+			budget[p], start[p] = usage[p].Runtime, t.arrival[p]
+			ready = append(ready, p)
+		},
+		pick: func() (int, int64, string) {
+			waiting := throttled[:0]
+			for _, p := range throttled {
+				if wake[p] > e.now {
+					waiting = append(waiting, p)
+					continue
+				}
+				budget[p], start[p] = usage[p].Runtime, wake[p]
+				ready = append(ready, p)
+			}
+			throttled = waiting
+			if len(ready) == 0 {
+				// Idle until the next arrival or replenishment.
+				return -1, 0, ""
+			}
+			i := 0
+			for j := range ready {
+				if before(ready[j], ready[i]) {
+					i = j
+				}
+			}
+			p := ready[i]
+			if reserved(p) {
+				return p, budget[p], ""
+			}
+			return p, t.remaining[p], ""
+		},
+		// A replenished budget may be due before the next arrival.
+		wake: func(int) (int64, bool) {
+			var (
+				next int64
+				ok   bool
+			)
+			for _, p := range throttled {
+				if !ok || wake[p] < next {
+					next, ok = wake[p], true
+				}
+			}
+			return next, ok
+		},
+		ran: func(p int, run int64) {
+			if budget[p] -= run; !reserved(p) || budget[p] > 0 || t.remaining[p] == 0 {
+				return
+			}
+			// The budget is used up with work left: throttled until the next period.
+			ready, _ = without(ready, p)
+			e.deschedule()
+			wake[p] = start[p] + usage[p].Period
+			if wake[p] < e.now {
+				wake[p] = e.now
+			}
+			usage[p].Overruns++
+			usage[p].Throttled += wake[p] - e.now
+			throttled = append(throttled, p)
+		},
+		done: func(p int) { ready, _ = without(ready, p) },
+		completed: func(p int, row *ProcessResult) {
+			if reserved(p) {
+				row.Deadline = t.arrival[p] + usage[p].Deadline
+			}
+		},
+		preemptOnArrival: true,
+	})
+	for i, p := range processes {
+		if p.Reserved() {
+			if r.Bandwidth == nil {
+				r.Bandwidth = &BandwidthReport{}
+			}
+			r.Bandwidth.Servers = append(r.Bandwidth.Servers, usage[i])
+		}
+	}
+
+	return r
+}
@@ -0,0 +1,54 @@
+package scheduler
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCBS(t *testing.T) {
+	t.Parallel()
+	r := CBS("cbs", []Process{
+		{ProcessID: 1, BurstDuration: 5, Runtime: 2, Period: 5},
+		{ProcessID: 2, BurstDuration: 4},
+	})
+	// P1 overruns its budget of 2 in each of its first two periods and is throttled until the
+	// next; P2, without a reservation, only runs in the meantime.
+	want := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 2},
+		{PID: 2, Start: 2, Stop: 5},
+		{PID: 1, Start: 5, Stop: 7},
+		{PID: 2, Start: 7, Stop: 8},
+		{Idle: true, Start: 8, Stop: 10},
+		{PID: 1, Start: 10, Stop: 11},
+	}
+	if !reflect.DeepEqual(r.Gantt, want) {
+		t.Errorf("CBS() gantt = %+v, want %+v", r.Gantt, want)
+	}
+	if want := []ServerUsage{{ID: 1, Runtime: 2, Period: 5, Deadline: 5, Overruns: 2, Throttled: 6}}; !reflect.DeepEqual(r.Bandwidth.Servers, want) {
+		t.Errorf("CBS() servers = %+v, want %+v", r.Bandwidth.Servers, want)
+	}
+	for _, p := range r.Processes {
+		if missed := p.ID == 1; p.MissedDeadline() != missed {
+			t.Errorf("P%d missed deadline %d: %v, want %v", p.ID, p.Deadline, p.MissedDeadline(), missed)
+		}
+	}
+
+	// A throttled process's slice ends when it is throttled, rather than spanning the idle
+	// time until its budget is replenished, and it is preempted then.
+	processes := []Process{{ProcessID: 1, BurstDuration: 4, Runtime: 1, Period: 5}}
+	var preempted []int64
+	r = CBS("cbs", processes, WithHooks(Hooks{OnPreempt: func(now int64, _ Process, _ int, _ int64) { preempted = append(preempted, now) }}))
+	checkGantt(t, r, processes)
+	if want := []int64{1, 6, 11}; !reflect.DeepEqual(preempted, want) {
+		t.Errorf("CBS() preempted at %v, want %v", preempted, want)
+	}
+
+	// Between reservations the earlier deadline runs first: P2's 3 beats P1's period of 10.
+	r = CBS("cbs", []Process{
+		{ProcessID: 1, BurstDuration: 2, Runtime: 2, Period: 10},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 1, Runtime: 1, Period: 10, Deadline: 3},
+	})
+	if want := map[int64]int64{1: 3, 2: 2}; !reflect.DeepEqual(exits(r), want) {
+		t.Errorf("CBS() exits = %v, want %v", exits(r), want)
+	}
+}
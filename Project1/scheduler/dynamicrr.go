@@ -0,0 +1,111 @@
+package scheduler
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// RRCycle is one cycle of a dynamic-quantum round-robin run: from Start, each of the Ready
+// processes queued then got a turn of up to Quantum.
+type RRCycle struct {
+	Start   int64 `json:"start"`
+	Ready   int   `json:"ready"`
+	Quantum int64 `json:"quantum"`
+}
+
+// QuantumStrategies compute the quantum of a dynamic-quantum round-robin cycle from the
+// remaining bursts of the processes queued at its start, rounded and at least 1.
+var QuantumStrategies = map[string]func(remaining []int64) float64{
+	"mean": func(remaining []int64) float64 {
+		var sum int64
+		for _, r := range remaining {
+			sum += r
+		}
+		return float64(sum) / float64(len(remaining))
+	},
+	"median": func(remaining []int64) float64 {
+		sorted := append([]int64(nil), remaining...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+		mid := len(sorted) / 2
+		if len(sorted)%2 == 0 {
+			return float64(sorted[mid-1]+sorted[mid]) / 2
+		}
+		return float64(sorted[mid])
+	},
+}
+
+var ErrUnknownQuantumStrategy = fmt.Errorf("%w: unknown quantum strategy", ErrInvalidArgs)
+
+// QuantumStrategyNames lists the names in QuantumStrategies, sorted and comma separated.
+func QuantumStrategyNames() string {
+	names := make([]string, 0, len(QuantumStrategies))
+	for name := range QuantumStrategies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return strings.Join(names, ", ")
+}
+
+// DynamicRR is round-robin whose quantum is recomputed every cycle by the named strategy from
+// QuantumStrategies, such as the median remaining burst of the processes queued when the cycle
+// starts. A cycle gives each of those processes one turn; processes arriving during it queue
+// behind them for the next. Its Result lists every cycle's quantum. Its name is rr-<strategy>.
+func DynamicRR(strategy string) (Algorithm, error) {
+	quantum, ok := QuantumStrategies[strategy]
+	if !ok {
+		return Algorithm{}, fmt.Errorf("%w: %q (known: %s)", ErrUnknownQuantumStrategy, strategy, QuantumStrategyNames())
+	}
+
+	return Algorithm{
+		Name:    "rr-" + strategy,
+		Version: "1",
+		Title:   "Round-robin with a dynamic quantum (" + strategy + ")",
+		Simulate: func(title string, processes []Process, opts ...Option) Result {
+			return dynamicRR(title, processes, newOptions(opts), quantum)
+		},
+	}, nil
+}
+
+func dynamicRR(title string, processes []Process, o options, strategy func([]int64) float64) Result {
+	var (
+		e      = newQuantumEngine(title, processes, o)
+		t      = e.t
+		queue  []int
+		cycles []RRCycle
+		turns  int // turns left in the current cycle
+	)
+
+	r := e.run(quantumPolicy{
+		admit: func(p int) { queue = append(queue, p) },
+		pick: func() (int, int64, string) {
+			if turns == 0 {
+				remaining := make([]int64, len(queue))
+				for i, p := range queue {
+					remaining[i] = t.remaining[p]
+				}
+				quantum := int64(math.Round(strategy(remaining)))
+				if quantum < 1 {
+					quantum = 1
+				}
+				cycles = append(cycles, RRCycle{Start: e.now, Ready: len(queue), Quantum: quantum})
+				turns = len(queue)
+			}
+			return queue[0], cycles[len(cycles)-1].Quantum, ""
+		},
+		ran: func(p int, _ int64) {
+			turns--
+			queue, _ = without(queue, p)
+			// Arrivals during the turn queue ahead of the process it preempts.
+			e.admitArrivals()
+			if t.remaining[p] > 0 {
+				queue = append(queue, p)
+			}
+		},
+	})
+	r.Cycles = cycles
+
+	return r
+}
@@ -0,0 +1,48 @@
+package scheduler
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestDynamicRR(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 10},
+		{ProcessID: 2, BurstDuration: 2},
+		{ProcessID: 3, BurstDuration: 3},
+	}
+	tests := []struct {
+		strategy string
+		gantt    []TimeSlice
+		cycles   []RRCycle
+	}{
+		{
+			strategy: "mean", // of 10, 2 and 3, then of P1's 5 left
+			gantt:    []TimeSlice{{PID: 1, Stop: 5}, {PID: 2, Start: 5, Stop: 7}, {PID: 3, Start: 7, Stop: 10}, {PID: 1, Start: 10, Stop: 15}},
+			cycles:   []RRCycle{{Start: 0, Ready: 3, Quantum: 5}, {Start: 10, Ready: 1, Quantum: 5}},
+		},
+		{
+			strategy: "median",
+			gantt:    []TimeSlice{{PID: 1, Stop: 3}, {PID: 2, Start: 3, Stop: 5}, {PID: 3, Start: 5, Stop: 8}, {PID: 1, Start: 8, Stop: 15}},
+			cycles:   []RRCycle{{Start: 0, Ready: 3, Quantum: 3}, {Start: 8, Ready: 1, Quantum: 7}},
+		},
+	}
+	for _, tt := range tests {
+		a, err := DynamicRR(tt.strategy)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r := a.Simulate(a.Name, processes)
+		if !reflect.DeepEqual(r.Gantt, tt.gantt) {
+			t.Errorf("%s gantt = %+v, want %+v", a.Name, r.Gantt, tt.gantt)
+		}
+		if !reflect.DeepEqual(r.Cycles, tt.cycles) {
+			t.Errorf("%s cycles = %+v, want %+v", a.Name, r.Cycles, tt.cycles)
+		}
+	}
+	if _, err := DynamicRR("mode"); !errors.Is(err, ErrUnknownQuantumStrategy) {
+		t.Errorf("DynamicRR(mode) error = %v", err)
+	}
+}
@@ -0,0 +1,97 @@
+package scheduler
+
+import "math"
+
+// ProcessLag is the range of one process's lag over an EEVDF run: the CPU time it was owed,
+// positive, or had run ahead of its share, negative, compared with an ideal fair scheduler
+// giving every ready process a share in proportion to its weight.
+type ProcessLag struct {
+	ID     int64   `json:"id"`
+	Weight int64   `json:"weight"`
+	Min    float64 `json:"min"`
+	Max    float64 `json:"max"`
+}
+
+// EEVDF simulates Earliest Eligible Virtual Deadline First, the Linux scheduler since 6.6, with
+// each process weighted by its NiceWeight. Every process has a virtual runtime, advanced by the
+// CPU time it gets scaled down by its weight, and the virtual time of the run is the
+// weight-averaged virtual runtime of the ready processes; a process's lag is how far its
+// virtual runtime is behind that, times its weight. A process is eligible while its lag is not
+// negative, and each request of DefaultQuantum gets a virtual deadline a quantum scaled by its
+// weight past its virtual runtime: the eligible process with the earliest virtual deadline (the
+// earliest arrival on a tie) runs until the request is done, re-evaluated on every arrival.
+// An arrival starts with no lag. Its Result reports the range of every process's lag.
+func EEVDF(title string, processes []Process, opts ...Option) Result {
+	return eevdf(title, processes, newOptions(opts))
+}
+
+func eevdf(title string, processes []Process, o options) Result {
+	var (
+		e        = newQuantumEngine(title, processes, o)
+		t        = e.t
+		ready    []int
+		weight   = make([]float64, t.len()) // relative to nice 0
+		vruntime = make([]float64, t.len())
+		deadline = make([]float64, t.len())
+		used     = make([]int64, t.len()) // time used of the current request
+		lags     = make([]ProcessLag, t.len())
+		vtime    float64 // the virtual time when the ready queue last emptied
+	)
+	for i, p := range processes {
+		weight[i] = float64(p.NiceWeight()) / float64(niceToWeight[20])
+		lags[i] = ProcessLag{ID: p.ProcessID, Weight: p.NiceWeight()}
+	}
+	// virtualTime is the weight-averaged virtual runtime of the ready processes.
+	virtualTime := func() float64 {
+		if len(ready) == 0 {
+			return vtime
+		}
+		var sum, total float64
+		for _, p := range ready {
+			sum += weight[p] * vruntime[p]
+			total += weight[p]
+		}
+		vtime = sum / total
+		return vtime
+	}
+
+	r := e.run(quantumPolicy{
+		admit: func(p int) {
+			vruntime[p] = virtualTime()
+			deadline[p] = vruntime[p] + DefaultQuantum/weight[p]
+			ready = append(ready, p)
+		},
+		pick: func() (int, int64, string) {
+			v := virtualTime()
+			i := -1
+			for j, p := range ready {
+				lag := weight[p] * (v - vruntime[p])
+				lags[p].Min, lags[p].Max = math.Min(lags[p].Min, lag), math.Max(lags[p].Max, lag)
+				// The process with the least virtual runtime is always eligible; the tolerance
+				// keeps rounding from making the others lose eligibility.
+				if lag < -1e-9 {
+					continue
+				}
+				if i < 0 || deadline[p] < deadline[ready[i]] {
+					i = j
+				}
+			}
+			p := ready[i]
+			return p, DefaultQuantum - used[p], ""
+		},
+		ran: func(p int, run int64) {
+			used[p] += run
+			vruntime[p] += float64(run) / weight[p]
+			if t.remaining[p] > 0 && used[p] >= DefaultQuantum {
+				// The request is done: the next one is due a quantum, scaled by weight, later.
+				used[p] = 0
+				deadline[p] = vruntime[p] + DefaultQuantum/weight[p]
+			}
+		},
+		done:             func(p int) { ready, _ = without(ready, p) },
+		preemptOnArrival: true,
+	})
+	r.Lags = lags
+
+	return r
+}
@@ -0,0 +1,39 @@
+package scheduler
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestEEVDF(t *testing.T) {
+	t.Parallel()
+	// Equal weights take turns a request at a time, the one run ahead owing the other a unit.
+	r := EEVDF("eevdf", []Process{
+		{ProcessID: 1, BurstDuration: 4},
+		{ProcessID: 2, BurstDuration: 4},
+	})
+	want := []TimeSlice{{PID: 1, Stop: 2}, {PID: 2, Start: 2, Stop: 4}, {PID: 1, Start: 4, Stop: 6}, {PID: 2, Start: 6, Stop: 8}}
+	if !reflect.DeepEqual(r.Gantt, want) {
+		t.Errorf("EEVDF() gantt = %+v, want %+v", r.Gantt, want)
+	}
+	if want := []ProcessLag{{ID: 1, Weight: 1024, Min: -1}, {ID: 2, Weight: 1024, Max: 1}}; !reflect.DeepEqual(r.Lags, want) {
+		t.Errorf("EEVDF() lags = %+v, want %+v", r.Lags, want)
+	}
+
+	// Nice -5 weighs about three times nice 0, so P1's virtual deadlines come three times as
+	// often: once P2 has had its first request, P1 runs three in a row.
+	r = EEVDF("eevdf", []Process{
+		{ProcessID: 1, BurstDuration: 10, Nice: -5},
+		{ProcessID: 2, BurstDuration: 10},
+	})
+	want = []TimeSlice{{PID: 1, Stop: 2}, {PID: 2, Start: 2, Stop: 4}, {PID: 1, Start: 4, Stop: 10}, {PID: 2, Start: 10, Stop: 12}}
+	if !reflect.DeepEqual(r.Gantt[:len(want)], want) {
+		t.Errorf("EEVDF() gantt = %+v, want it to start %+v", r.Gantt, want)
+	}
+	for _, l := range r.Lags {
+		if math.Abs(l.Min) > DefaultQuantum || math.Abs(l.Max) > DefaultQuantum {
+			t.Errorf("P%d lag ranged %.2f to %.2f, want it within a quantum", l.ID, l.Min, l.Max)
+		}
+	}
+}
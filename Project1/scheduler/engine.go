@@ -0,0 +1,454 @@
+package scheduler
+
+import (
+	"container/heap"
+	"sort"
+)
+
+// processTable is column-oriented process storage for the simulation engine.
+// Keeping each attribute in its own slice keeps the hot dispatch loop cache friendly
+// and avoids copying whole Process values around the ready queue on large traces.
+type processTable struct {
+	pid       []int64
+	arrival   []int64
+	burst     []int64
+	priority  []int64
+	remaining []int64
+	// firstRun is when each process was first dispatched, or -1 before then.
+	firstRun []int64
+	// deadline is each process's absolute deadline, 0 for none, or nil when no process has one.
+	deadline []int64
+}
+
+func newProcessTable(processes []Process) *processTable {
+	n := len(processes)
+	t := &processTable{
+		pid:       make([]int64, n),
+		arrival:   make([]int64, n),
+		burst:     make([]int64, n),
+		priority:  make([]int64, n),
+		remaining: make([]int64, n),
+		firstRun:  make([]int64, n),
+	}
+	for i := range processes {
+		t.pid[i] = processes[i].ProcessID
+		t.arrival[i] = processes[i].ArrivalTime
+		t.burst[i] = processes[i].BurstDuration
+		t.priority[i] = processes[i].Priority
+		t.remaining[i] = processes[i].BurstDuration
+		t.firstRun[i] = -1
+		if processes[i].Deadline > 0 {
+			if t.deadline == nil {
+				t.deadline = make([]int64, n)
+			}
+			t.deadline[i] = processes[i].AbsoluteDeadline()
+		}
+	}
+
+	return t
+}
+
+func (t *processTable) len() int {
+	return len(t.pid)
+}
+
+// less orders two processes in the table by index. Every ordering ends with the process ID, so
+// equal keys never leave the order to the sort or heap implementation and two runs of the same
+// input produce identical schedules.
+type less func(t *processTable, a, b int) bool
+
+// byArrival orders by arrival time, then shortest burst, then lowest PID.
+func byArrival(t *processTable, a, b int) bool {
+	if t.arrival[a] != t.arrival[b] {
+		return t.arrival[a] < t.arrival[b]
+	}
+	if t.burst[a] != t.burst[b] {
+		return t.burst[a] < t.burst[b]
+	}
+
+	return t.pid[a] < t.pid[b]
+}
+
+// byRemaining orders by shortest remaining time, then earliest arrival, then lowest PID.
+func byRemaining(t *processTable, a, b int) bool {
+	if t.remaining[a] != t.remaining[b] {
+		return t.remaining[a] < t.remaining[b]
+	}
+	if t.arrival[a] != t.arrival[b] {
+		return t.arrival[a] < t.arrival[b]
+	}
+
+	return t.pid[a] < t.pid[b]
+}
+
+// byPriority orders by highest priority (lowest value), then shortest burst, then earliest arrival,
+// then lowest PID.
+func byPriority(t *processTable, a, b int) bool {
+	if t.priority[a] != t.priority[b] {
+		return t.priority[a] < t.priority[b]
+	}
+	if t.burst[a] != t.burst[b] {
+		return t.burst[a] < t.burst[b]
+	}
+	if t.arrival[a] != t.arrival[b] {
+		return t.arrival[a] < t.arrival[b]
+	}
+
+	return t.pid[a] < t.pid[b]
+}
+
+// byLongest orders by longest remaining time, then earliest arrival, then lowest PID.
+func byLongest(t *processTable, a, b int) bool {
+	if t.remaining[a] != t.remaining[b] {
+		return t.remaining[a] > t.remaining[b]
+	}
+	if t.arrival[a] != t.arrival[b] {
+		return t.arrival[a] < t.arrival[b]
+	}
+
+	return t.pid[a] < t.pid[b]
+}
+
+// byDeadline orders by earliest absolute deadline, with processes without one last, then
+// earliest arrival, then lowest PID.
+func byDeadline(t *processTable, a, b int) bool {
+	if t.deadline != nil && t.deadline[a] != t.deadline[b] {
+		switch {
+		case t.deadline[a] == 0:
+			return false
+		case t.deadline[b] == 0:
+			return true
+		}
+		return t.deadline[a] < t.deadline[b]
+	}
+	if t.arrival[a] != t.arrival[b] {
+		return t.arrival[a] < t.arrival[b]
+	}
+
+	return t.pid[a] < t.pid[b]
+}
+
+// sort orders idx stably, so processes equal under by keep their order in idx.
+func (t *processTable) sort(idx []int, by less) {
+	sort.SliceStable(idx, func(i, j int) bool {
+		return by(t, idx[i], idx[j])
+	})
+}
+
+// result builds the schedule table row for a process completed at exit. A process that was
+// never dispatched (a zero burst) responds as it completes.
+func (t *processTable) result(i int, exit int64) ProcessResult {
+	turnaround := exit - t.arrival[i]
+	firstRun := t.firstRun[i]
+	if firstRun < 0 {
+		firstRun = exit
+	}
+	row := ProcessResult{
+		ID:         t.pid[i],
+		Priority:   t.priority[i],
+		Burst:      t.burst[i],
+		Arrival:    t.arrival[i],
+		Wait:       turnaround - t.burst[i],
+		Turnaround: turnaround,
+		Response:   firstRun - t.arrival[i],
+		Exit:       exit,
+	}
+	if t.deadline != nil {
+		row.Deadline = t.deadline[i]
+	}
+
+	return row
+}
+
+// arrivalIndex hands out processes in arrival order. Processes are sorted once up front,
+// so finding the next arrival and admitting everything that has arrived by a given time
+// costs O(1) per process rather than a scan of every pending process at each event.
+type arrivalIndex struct {
+	t     *processTable
+	order []int
+	next  int
+}
+
+func newArrivalIndex(t *processTable) *arrivalIndex {
+	return newArrivalIndexBy(t, byArrival)
+}
+
+// newArrivalIndexBy hands out processes in the order by sorts them, which must be by arrival
+// first; processes equal under by keep their input order.
+func newArrivalIndexBy(t *processTable, by less) *arrivalIndex {
+	order := make([]int, t.len())
+	for i := range order {
+		order[i] = i
+	}
+	t.sort(order, by)
+
+	return &arrivalIndex{t: t, order: order}
+}
+
+// pending reports whether any process has yet to arrive.
+func (a *arrivalIndex) pending() bool {
+	return a.next < len(a.order)
+}
+
+// peek returns the arrival time of the next process to arrive.
+func (a *arrivalIndex) peek() (int64, bool) {
+	if !a.pending() {
+		return 0, false
+	}
+
+	return a.t.arrival[a.order[a.next]], true
+}
+
+// pop removes and returns the next process to arrive.
+func (a *arrivalIndex) pop() int {
+	p := a.order[a.next]
+	a.next++
+
+	return p
+}
+
+// admit passes every process that has arrived by now to push.
+func (a *arrivalIndex) admit(now int64, push func(p int)) {
+	for a.pending() && a.t.arrival[a.order[a.next]] <= now {
+		push(a.pop())
+	}
+}
+
+// readyQueue is a heap of ready processes ordered by a less function, so admitting a
+// process or rescheduling the running one costs O(log n) instead of a full re-sort.
+type readyQueue struct {
+	t   *processTable
+	by  less
+	idx []int
+}
+
+func (q *readyQueue) Len() int { return len(q.idx) }
+
+func (q *readyQueue) Less(i, j int) bool { return q.by(q.t, q.idx[i], q.idx[j]) }
+
+func (q *readyQueue) Swap(i, j int) { q.idx[i], q.idx[j] = q.idx[j], q.idx[i] }
+
+func (q *readyQueue) Push(x any) { q.idx = append(q.idx, x.(int)) }
+
+func (q *readyQueue) Pop() any {
+	last := q.idx[len(q.idx)-1]
+	q.idx = q.idx[:len(q.idx)-1]
+
+	return last
+}
+
+// push adds a process to the queue. Unlike heap.Push it does not box the index.
+func (q *readyQueue) push(p int) {
+	q.idx = append(q.idx, p)
+	heap.Fix(q, len(q.idx)-1)
+}
+
+// pop removes the process at the head of the queue.
+func (q *readyQueue) pop() int {
+	head, n := q.idx[0], len(q.idx)-1
+	q.idx[0] = q.idx[n]
+	q.idx = q.idx[:n]
+	if n > 0 {
+		heap.Fix(q, 0)
+	}
+
+	return head
+}
+
+// remove removes the process at position i of the queue, as pop does the head.
+func (q *readyQueue) remove(i int) int {
+	p, n := q.idx[i], len(q.idx)-1
+	q.idx[i] = q.idx[n]
+	q.idx = q.idx[:n]
+	if i < n {
+		heap.Fix(q, i)
+	}
+
+	return p
+}
+
+// index returns the position of process p in the queue.
+func (q *readyQueue) index(p int) int {
+	for i, x := range q.idx {
+		if x == p {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// peek returns the process at the head of the queue.
+func (q *readyQueue) peek() int {
+	return q.idx[0]
+}
+
+// runnerUp returns the process that would head the queue without its head, or -1.
+func (q *readyQueue) runnerUp() int {
+	switch len(q.idx) {
+	case 0, 1:
+		return -1
+	case 2:
+		return q.idx[1]
+	}
+	// The runner-up is the better of the head's children in the heap.
+	if q.Less(2, 1) {
+		return q.idx[2]
+	}
+
+	return q.idx[1]
+}
+
+// overtakenAfter returns how long running can run, up to run, before second orders ahead of it
+// under by, which must only ever move running back as its remaining time falls; run if second
+// never does.
+func overtakenAfter(t *processTable, by less, running, second int, run int64) int64 {
+	remaining := t.remaining[running]
+	defer func() { t.remaining[running] = remaining }()
+	behind := func(d int64) bool {
+		t.remaining[running] = remaining - d
+		return by(t, second, running)
+	}
+	if !behind(run) {
+		return run
+	}
+	lo, hi := int64(1), run // behind(hi) holds
+	for lo < hi {
+		if mid := lo + (hi-lo)/2; behind(mid) {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+
+	return lo
+}
+
+// preemptive simulates a preemptive scheduler that always runs the first ready process
+// as ordered by the given less function, re-evaluating the ready queue on each arrival.
+// Rather than ticking, the clock jumps straight to the next arrival or completion event,
+// so the run time scales with the number of processes instead of total burst time.
+// The clock also stops when the running process, as it runs, would fall behind the runner-up.
+// The minimum granularity and preemption budget options keep the running process on the CPU
+// past a re-evaluation that would preempt it, until the time they allow, and a switch cost
+// keeps it there unless the preempting process has more than that much less remaining time.
+func preemptive(title string, processes []Process, o options, by less) Result {
+	var (
+		t           = newProcessTable(processes)
+		progress    = newProgressReporter(o.progress, title, t.len())
+		rec         = newRecorder(o, t.len())
+		currentTime int64
+		count       int
+		arrivals    = newArrivalIndex(t)
+		onCPU       = -1      // the process last dispatched, until it completes
+		current     TimeSlice // the Gantt slice being extended, if onCPU >= 0
+		dispatched  int64
+		preempted   [][]int64 // each process's latest preemption times, with a budget
+	)
+	if o.preemption.budget > 0 {
+		preempted = make([][]int64, t.len())
+	}
+	ready := &readyQueue{t: t, by: by}
+	complete := func(p int) {
+		row := t.result(p, currentTime)
+		rec.process(row)
+		o.hooks.complete(currentTime, row)
+		count++
+		progress.update(currentTime, count)
+	}
+	admit := func(p int) {
+		o.hooks.arrival(t.arrival[p], processes[p])
+		if t.remaining[p] == 0 {
+			// A zero-burst process needs no CPU, so it completes the moment it arrives.
+			complete(p)
+			return
+		}
+		ready.push(p)
+	}
+	endSlice := func() {
+		if onCPU >= 0 && current.Stop > current.Start {
+			rec.slice(current)
+		}
+	}
+
+	for ready.Len() > 0 || arrivals.pending() {
+		// Admit everything that has arrived by now before choosing what to run, so processes
+		// arriving together, or while the CPU was idle, all compete for the next dispatch.
+		arrivals.admit(currentTime, admit)
+		if ready.Len() == 0 {
+			// Idle until the next arrival.
+			if next, ok := arrivals.peek(); ok {
+				rec.slice(TimeSlice{Idle: true, Start: currentTime, Stop: next})
+				o.hooks.idle(currentTime, next)
+				currentTime = next
+			}
+			continue
+		}
+		running, pos, hold := ready.peek(), 0, int64(-1)
+		if running != onCPU && onCPU >= 0 && o.preemption.set() {
+			var times []int64
+			if preempted != nil {
+				times = preempted[onCPU]
+			}
+			if until := o.preemption.until(dispatched, times); until > currentTime {
+				running, pos, hold = onCPU, ready.index(onCPU), until
+			} else if c := o.preemption.switchCost; c > 0 && t.remaining[onCPU]-t.remaining[running] <= c {
+				// Switching would not save the others more waiting than it costs.
+				running, pos = onCPU, ready.index(onCPU)
+			}
+		}
+		if running != onCPU {
+			endSlice()
+			if onCPU >= 0 {
+				o.hooks.preempt(currentTime, processes[onCPU], 0, t.remaining[onCPU])
+				if preempted != nil {
+					times := append(preempted[onCPU], currentTime)
+					if len(times) > o.preemption.budget {
+						times = times[1:]
+					}
+					preempted[onCPU] = times
+				}
+			}
+			o.hooks.dispatch(currentTime, processes[running], 0)
+			onCPU, dispatched = running, currentTime
+			current = TimeSlice{PID: t.pid[running], Start: currentTime, Stop: currentTime}
+		}
+		if t.firstRun[running] < 0 {
+			t.firstRun[running] = currentTime
+		}
+		run := t.remaining[running]
+		if next, ok := arrivals.peek(); ok && next-currentTime < run {
+			run = next - currentTime
+		}
+		if hold >= 0 && hold-currentTime < run {
+			run = hold - currentTime // re-evaluate once the process may be preempted
+		}
+		if pos == 0 && !o.preemption.toCompletion {
+			// Running can lower the process's own place in the queue, as with the longest
+			// remaining time first, so also re-evaluate once it falls behind the runner-up.
+			if second := ready.runnerUp(); second >= 0 {
+				run = overtakenAfter(t, by, running, second, run)
+			}
+		}
+		t.remaining[running] -= run
+		currentTime += run
+		current.Stop = currentTime
+		if t.remaining[running] == 0 {
+			complete(running)
+			ready.remove(pos)
+			endSlice()
+			onCPU = -1
+		} else {
+			// The running process's key may have changed (e.g. less remaining time).
+			heap.Fix(ready, pos)
+		}
+	}
+	endSlice()
+	rec.flush()
+	progress.finish(currentTime, count)
+
+	r := Result{Title: title, Gantt: rec.gantt, Processes: rec.processes}
+	rec.totals.apply(&r)
+
+	return r
+}
@@ -0,0 +1,259 @@
+package scheduler
+
+import (
+	"container/heap"
+	"reflect"
+	"testing"
+)
+
+var exampleProcesses = []Process{
+	{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+	{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+	{ProcessID: 3, ArrivalTime: 6, BurstDuration: 6, Priority: 3},
+}
+
+func Test_newProcessTable(t *testing.T) {
+	t.Parallel()
+	got := newProcessTable(exampleProcesses)
+	want := &processTable{
+		pid:       []int64{1, 2, 3},
+		arrival:   []int64{0, 3, 6},
+		burst:     []int64{5, 9, 6},
+		priority:  []int64{2, 1, 3},
+		remaining: []int64{5, 9, 6},
+		firstRun:  []int64{-1, -1, -1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("newProcessTable() = %+v, want %+v", got, want)
+	}
+}
+
+func Test_preemptive(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name           string
+		processes      []Process
+		by             less
+		want           []ProcessResult
+		wantThroughput float64
+		wantGantt      []TimeSlice
+	}{
+		{
+			name:           "shortest remaining",
+			processes:      exampleProcesses,
+			by:             byRemaining,
+			wantThroughput: 3.0 / 20,
+			want: []ProcessResult{
+				{ID: 1, Priority: 2, Burst: 5, Arrival: 0, Wait: 0, Turnaround: 5, Exit: 5},
+				{ID: 3, Priority: 3, Burst: 6, Arrival: 6, Wait: 0, Turnaround: 6, Exit: 12},
+				{ID: 2, Priority: 1, Burst: 9, Arrival: 3, Wait: 8, Turnaround: 17, Response: 2, Exit: 20},
+			},
+			wantGantt: []TimeSlice{{PID: 1, Stop: 5}, {PID: 2, Start: 5, Stop: 6}, {PID: 3, Start: 6, Stop: 12}, {PID: 2, Start: 12, Stop: 20}},
+		},
+		{
+			name:           "priority",
+			processes:      exampleProcesses,
+			by:             byPriority,
+			wantThroughput: 3.0 / 20,
+			want: []ProcessResult{
+				{ID: 2, Priority: 1, Burst: 9, Arrival: 3, Wait: 0, Turnaround: 9, Exit: 12},
+				{ID: 1, Priority: 2, Burst: 5, Arrival: 0, Wait: 9, Turnaround: 14, Exit: 14},
+				{ID: 3, Priority: 3, Burst: 6, Arrival: 6, Wait: 8, Turnaround: 14, Response: 8, Exit: 20},
+			},
+			wantGantt: []TimeSlice{{PID: 1, Stop: 3}, {PID: 2, Start: 3, Stop: 12}, {PID: 1, Start: 12, Stop: 14}, {PID: 3, Start: 14, Stop: 20}},
+		},
+		{
+			name: "huge bursts jump straight to events",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 1 << 40},
+				{ProcessID: 2, ArrivalTime: 1 << 39, BurstDuration: 1},
+			},
+			by: byRemaining,
+			want: []ProcessResult{
+				{ID: 2, Burst: 1, Arrival: 1 << 39, Wait: 0, Turnaround: 1, Exit: 1<<39 + 1},
+				{ID: 1, Burst: 1 << 40, Arrival: 0, Wait: 1, Turnaround: 1<<40 + 1, Exit: 1<<40 + 1},
+			},
+			wantThroughput: 2.0 / (1<<40 + 1),
+		},
+		{
+			name: "idle gap and simultaneous arrivals",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2},
+				{ProcessID: 2, ArrivalTime: 5, BurstDuration: 3},
+				{ProcessID: 3, ArrivalTime: 5, BurstDuration: 1},
+			},
+			by: byRemaining,
+			want: []ProcessResult{
+				{ID: 1, Burst: 2, Arrival: 0, Wait: 0, Turnaround: 2, Exit: 2},
+				{ID: 3, Burst: 1, Arrival: 5, Wait: 0, Turnaround: 1, Exit: 6},
+				{ID: 2, Burst: 3, Arrival: 5, Wait: 1, Turnaround: 4, Response: 1, Exit: 9},
+			},
+			wantThroughput: 3.0 / 9,
+			wantGantt:      []TimeSlice{{PID: 1, Stop: 2}, {Idle: true, Start: 2, Stop: 5}, {PID: 3, Start: 5, Stop: 6}, {PID: 2, Start: 6, Stop: 9}},
+		},
+		{
+			name: "simultaneous arrivals compete for the first dispatch",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 1, Priority: 3},
+				{ProcessID: 2, ArrivalTime: 0, BurstDuration: 4, Priority: 1},
+				{ProcessID: 3, ArrivalTime: 0, BurstDuration: 2, Priority: 2},
+			},
+			by: byPriority,
+			want: []ProcessResult{
+				{ID: 2, Priority: 1, Burst: 4, Arrival: 0, Wait: 0, Turnaround: 4, Exit: 4},
+				{ID: 3, Priority: 2, Burst: 2, Arrival: 0, Wait: 4, Turnaround: 6, Response: 4, Exit: 6},
+				{ID: 1, Priority: 3, Burst: 1, Arrival: 0, Wait: 6, Turnaround: 7, Response: 6, Exit: 7},
+			},
+			wantThroughput: 3.0 / 7,
+		},
+		{
+			name: "arrivals while idle are admitted before running",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 3, BurstDuration: 2, Priority: 2},
+				{ProcessID: 2, ArrivalTime: 3, BurstDuration: 2, Priority: 1},
+				{ProcessID: 3, ArrivalTime: 5, BurstDuration: 1, Priority: 3},
+			},
+			by: byPriority,
+			want: []ProcessResult{
+				{ID: 2, Priority: 1, Burst: 2, Arrival: 3, Wait: 0, Turnaround: 2, Exit: 5},
+				{ID: 1, Priority: 2, Burst: 2, Arrival: 3, Wait: 2, Turnaround: 4, Response: 2, Exit: 7},
+				{ID: 3, Priority: 3, Burst: 1, Arrival: 5, Wait: 2, Turnaround: 3, Response: 2, Exit: 8},
+			},
+			wantThroughput: 3.0 / 8,
+		},
+		{
+			name: "zero bursts complete on arrival",
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4, Priority: 1},
+				{ProcessID: 2, ArrivalTime: 1, BurstDuration: 0, Priority: 9},
+				{ProcessID: 3, ArrivalTime: 6, BurstDuration: 0, Priority: 9},
+			},
+			by: byPriority,
+			want: []ProcessResult{
+				{ID: 2, Priority: 9, Arrival: 1, Exit: 1},
+				{ID: 1, Priority: 1, Burst: 4, Arrival: 0, Wait: 0, Turnaround: 4, Exit: 4},
+				{ID: 3, Priority: 9, Arrival: 6, Exit: 6},
+			},
+			wantThroughput: 3.0 / 6,
+		},
+		{
+			name: "equal keys fall back to the lowest PID",
+			processes: []Process{
+				{ProcessID: 9, ArrivalTime: 0, BurstDuration: 2, Priority: 1},
+				{ProcessID: 4, ArrivalTime: 0, BurstDuration: 2, Priority: 1},
+				{ProcessID: 6, ArrivalTime: 0, BurstDuration: 2, Priority: 1},
+			},
+			by: byPriority,
+			want: []ProcessResult{
+				{ID: 4, Priority: 1, Burst: 2, Arrival: 0, Wait: 0, Turnaround: 2, Exit: 2},
+				{ID: 6, Priority: 1, Burst: 2, Arrival: 0, Wait: 2, Turnaround: 4, Response: 2, Exit: 4},
+				{ID: 9, Priority: 1, Burst: 2, Arrival: 0, Wait: 4, Turnaround: 6, Response: 4, Exit: 6},
+			},
+			wantThroughput: 3.0 / 6,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := preemptive("test", tt.processes, options{}, tt.by)
+			if !reflect.DeepEqual(got.Processes, tt.want) {
+				t.Errorf("preemptive() = %+v, want %+v", got.Processes, tt.want)
+			}
+			if got.AveThroughput != tt.wantThroughput {
+				t.Errorf("throughput = %v, want %v", got.AveThroughput, tt.wantThroughput)
+			}
+			if tt.wantGantt != nil && !reflect.DeepEqual(got.Gantt, tt.wantGantt) {
+				t.Errorf("preemptive() Gantt = %+v, want %+v", got.Gantt, tt.wantGantt)
+			}
+			checkGantt(t, got, tt.processes)
+		})
+	}
+}
+
+func Test_preemptive_inputOrder(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 3, Priority: 2},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 3, Priority: 2},
+		{ProcessID: 3, ArrivalTime: 1, BurstDuration: 2, Priority: 2},
+		{ProcessID: 4, ArrivalTime: 1, BurstDuration: 2, Priority: 2},
+		{ProcessID: 5, ArrivalTime: 2, BurstDuration: 1, Priority: 1},
+	}
+	reversed := make([]Process, len(processes))
+	for i, p := range processes {
+		reversed[len(processes)-1-i] = p
+	}
+	for _, by := range []less{byRemaining, byPriority} {
+		want := preemptive("test", processes, options{}, by).Processes
+		if got := preemptive("test", reversed, options{}, by).Processes; !reflect.DeepEqual(got, want) {
+			t.Errorf("preemptive() on reversed input = %+v, want %+v", got, want)
+		}
+	}
+}
+
+func Test_arrivalIndex(t *testing.T) {
+	t.Parallel()
+	a := newArrivalIndex(newProcessTable([]Process{
+		{ProcessID: 1, ArrivalTime: 4, BurstDuration: 1},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 1},
+		{ProcessID: 3, ArrivalTime: 4, BurstDuration: 1},
+		{ProcessID: 4, ArrivalTime: 2, BurstDuration: 1},
+	}))
+	if next, ok := a.peek(); !ok || next != 0 {
+		t.Fatalf("peek() = %d, %v, want 0, true", next, ok)
+	}
+	var got []int
+	push := func(p int) { got = append(got, p) }
+	a.admit(3, push)
+	if !reflect.DeepEqual(got, []int{1, 3}) {
+		t.Errorf("admit(3) = %v, want [1 3]", got)
+	}
+	got = nil
+	a.admit(3, push)
+	if got != nil {
+		t.Errorf("second admit(3) = %v, want nothing", got)
+	}
+	got = []int{9}
+	a.admit(10, push)
+	if !reflect.DeepEqual(got, []int{9, 0, 2}) {
+		t.Errorf("admit(10) = %v, want [9 0 2]", got)
+	}
+	if a.pending() {
+		t.Error("pending() after admitting everything")
+	}
+}
+
+func Test_readyQueue(t *testing.T) {
+	t.Parallel()
+	pt := newProcessTable([]Process{
+		{ProcessID: 1, BurstDuration: 5},
+		{ProcessID: 2, BurstDuration: 2},
+		{ProcessID: 3, BurstDuration: 8},
+		{ProcessID: 4, BurstDuration: 1},
+	})
+	q := &readyQueue{t: pt, by: byRemaining}
+	for i := 0; i < pt.len(); i++ {
+		q.push(i)
+	}
+	if got := q.peek(); got != 3 {
+		t.Fatalf("peek() = %d, want 3", got)
+	}
+	// The head runs down but stays shortest; then process 3 (index 2) is fixed in place
+	// after its remaining time drops below everyone but the head's.
+	pt.remaining[3] = 0
+	heap.Fix(q, 0)
+	for i, p := range q.idx {
+		if p == 2 {
+			pt.remaining[2] = 1
+			heap.Fix(q, i)
+		}
+	}
+	var order []int
+	for q.Len() > 0 {
+		order = append(order, heap.Pop(q).(int))
+	}
+	if want := []int{3, 2, 1, 0}; !reflect.DeepEqual(order, want) {
+		t.Errorf("pop order = %v, want %v", order, want)
+	}
+}
@@ -0,0 +1,85 @@
+package scheduler
+
+type (
+	// Clock is the simulated time a run advances. A run moves its clock from event to event,
+	// just before reporting each one to the hooks, so hooks and middleware that read Now see the
+	// time of the event being reported. The schedulertest package has a fake for tests.
+	Clock interface {
+		Now() int64
+		AdvanceTo(t int64)
+	}
+	// EventKind is the kind of an Event.
+	EventKind string
+	// Event is one scheduling event, as reported to Hooks. Which fields are set depends on Kind:
+	// Process is set for arrivals, dispatches and preemptions, CPU for dispatches and
+	// preemptions, Remaining for preemptions, Result for completions and Until for idle periods.
+	Event struct {
+		Time      int64
+		Kind      EventKind
+		Process   Process
+		CPU       int
+		Remaining int64
+		Result    ProcessResult
+		Until     int64
+	}
+	// EventQueue holds events in the order they are to be replayed. The schedulertest package
+	// has an in-memory fake.
+	EventQueue interface {
+		Push(e Event)
+		// Pop removes and returns the next event; ok is false when the queue is empty.
+		Pop() (e Event, ok bool)
+		Len() int
+	}
+)
+
+// The kinds of Event, one per callback in Hooks.
+const (
+	EventArrival  EventKind = "arrival"
+	EventDispatch EventKind = "dispatch"
+	EventPreempt  EventKind = "preempt"
+	EventComplete EventKind = "complete"
+	EventIdle     EventKind = "idle"
+)
+
+// Record returns hooks that push every event they are called with onto q, so a run's events
+// can be inspected, or replayed later with Replay.
+func Record(q EventQueue) Hooks {
+	return Hooks{
+		OnArrival: func(now int64, p Process) {
+			q.Push(Event{Time: now, Kind: EventArrival, Process: p})
+		},
+		OnDispatch: func(now int64, p Process, cpu int) {
+			q.Push(Event{Time: now, Kind: EventDispatch, Process: p, CPU: cpu})
+		},
+		OnPreempt: func(now int64, p Process, cpu int, remaining int64) {
+			q.Push(Event{Time: now, Kind: EventPreempt, Process: p, CPU: cpu, Remaining: remaining})
+		},
+		OnComplete: func(now int64, r ProcessResult) {
+			q.Push(Event{Time: now, Kind: EventComplete, Result: r})
+		},
+		OnIdle: func(now, until int64) {
+			q.Push(Event{Time: now, Kind: EventIdle, Until: until})
+		},
+	}
+}
+
+// Replay drains q, reporting each event to the hooks and clock set by opts exactly as a run
+// would, without simulating anything. It lets hooks and middleware be tested against a
+// scripted sequence of events.
+func Replay(q EventQueue, opts ...Option) {
+	hooks := newOptions(opts).hooks
+	for e, ok := q.Pop(); ok; e, ok = q.Pop() {
+		switch e.Kind {
+		case EventArrival:
+			hooks.arrival(e.Time, e.Process)
+		case EventDispatch:
+			hooks.dispatch(e.Time, e.Process, e.CPU)
+		case EventPreempt:
+			hooks.preempt(e.Time, e.Process, e.CPU, e.Remaining)
+		case EventComplete:
+			hooks.complete(e.Time, e.Result)
+		case EventIdle:
+			hooks.idle(e.Time, e.Until)
+		}
+	}
+}
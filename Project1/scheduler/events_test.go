@@ -0,0 +1,80 @@
+package scheduler
+
+import (
+	"reflect"
+	"testing"
+)
+
+// sliceQueue is a FIFO EventQueue.
+type sliceQueue []Event
+
+func (q *sliceQueue) Push(e Event) { *q = append(*q, e) }
+func (q *sliceQueue) Len() int     { return len(*q) }
+func (q *sliceQueue) Pop() (Event, bool) {
+	if len(*q) == 0 {
+		return Event{}, false
+	}
+	e := (*q)[0]
+	*q = (*q)[1:]
+	return e, true
+}
+
+// stepClock is a Clock that remembers the times it was advanced to.
+type stepClock struct {
+	now   int64
+	times []int64
+}
+
+func (c *stepClock) Now() int64 { return c.now }
+func (c *stepClock) AdvanceTo(t int64) {
+	c.now = t
+	c.times = append(c.times, t)
+}
+
+func TestRecordReplay(t *testing.T) {
+	t.Parallel()
+	var (
+		q      sliceQueue
+		direct []string
+	)
+	SJFPriority("Priority", exampleProcesses, WithHooks(Record(&q)), WithHooks(eventLog(&direct)))
+	if q.Len() != len(direct) {
+		t.Fatalf("recorded %d events, hooks saw %d", q.Len(), len(direct))
+	}
+
+	var replayed []string
+	Replay(&q, WithHooks(eventLog(&replayed)))
+	if !reflect.DeepEqual(replayed, direct) {
+		t.Errorf("Replay() = %q, want %q", replayed, direct)
+	}
+	if q.Len() != 0 {
+		t.Errorf("Replay() left %d events queued", q.Len())
+	}
+}
+
+func TestWithClock(t *testing.T) {
+	t.Parallel()
+	var (
+		clock stepClock
+		seen  []int64
+	)
+	// The hooks are given first, yet see the clock already at each event's time.
+	FCFS("FCFS", []Process{
+		{ProcessID: 1, ArrivalTime: 1, BurstDuration: 2},
+		{ProcessID: 2, ArrivalTime: 2, BurstDuration: 1},
+	}, WithHooks(Hooks{
+		OnDispatch: func(now int64, _ Process, _ int) {
+			if clock.Now() != now {
+				t.Errorf("clock at %d during dispatch at %d", clock.Now(), now)
+			}
+			seen = append(seen, now)
+		},
+	}), WithClock(&clock))
+
+	if want := []int64{0, 1, 1, 2, 3, 3, 4}; !reflect.DeepEqual(clock.times, want) {
+		t.Errorf("clock advanced to %v, want %v", clock.times, want)
+	}
+	if want := []int64{1, 3}; !reflect.DeepEqual(seen, want) {
+		t.Errorf("dispatches at %v, want %v", seen, want)
+	}
+}
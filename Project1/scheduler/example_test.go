@@ -0,0 +1,102 @@
+package scheduler_test
+
+import (
+	"fmt"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+var processes = []scheduler.Process{
+	{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, Priority: 2},
+	{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9, Priority: 1},
+	{ProcessID: 3, ArrivalTime: 6, BurstDuration: 6, Priority: 3},
+}
+
+func ExampleFCFS() {
+	r := scheduler.FCFS("First-come, first-serve", processes)
+	for _, p := range r.Processes {
+		fmt.Printf("P%d waited %d and finished at %d\n", p.ID, p.Wait, p.Exit)
+	}
+	fmt.Printf("average wait %.2f, turnaround %.2f\n", r.AveWait, r.AveTurnaround)
+	// Output:
+	// P1 waited 0 and finished at 5
+	// P2 waited 2 and finished at 14
+	// P3 waited 8 and finished at 20
+	// average wait 3.33, turnaround 10.00
+}
+
+func ExampleAlgorithms() {
+	for _, a := range scheduler.Algorithms {
+		r := a.Simulate(a.Title, processes)
+		fmt.Printf("%-8s average turnaround %.2f\n", a.Name, r.AveTurnaround)
+	}
+	// Output:
+	// fcfs     average turnaround 10.00
+	// sjf      average turnaround 9.33
+	// sjf-np   average turnaround 10.00
+	// priority average turnaround 12.33
+	// mlq      average turnaround 10.00
+	// priority-rr average turnaround 12.33
+	// o1       average turnaround 13.00
+	// srr      average turnaround 10.67
+	// lottery  average turnaround 11.33
+	// random   average turnaround 15.33
+	// stride   average turnaround 11.33
+	// guaranteed average turnaround 12.33
+	// nice     average turnaround 12.33
+	// eevdf    average turnaround 11.67
+	// edf      average turnaround 10.00
+	// cbs      average turnaround 10.00
+	// slack    average turnaround 10.00
+	// ljf      average turnaround 10.00
+	// lrtf     average turnaround 16.00
+	// hprn     average turnaround 13.00
+	// rr       average turnaround 11.67
+}
+
+// printSink prints rows and slices as the scheduler produces them.
+type printSink struct{}
+
+func (printSink) Process(p scheduler.ProcessResult) { fmt.Printf("P%d done at %d\n", p.ID, p.Exit) }
+func (printSink) Slice(s scheduler.TimeSlice)       { fmt.Printf("P%d ran %d-%d\n", s.PID, s.Start, s.Stop) }
+
+func ExampleWithSink() {
+	r := scheduler.FCFS("First-come, first-serve", processes, scheduler.WithSink(printSink{}))
+	fmt.Println(len(r.Processes), "rows kept")
+	// Output:
+	// P1 done at 5
+	// P1 ran 0-5
+	// P2 done at 14
+	// P2 ran 5-14
+	// P3 done at 20
+	// P3 ran 14-20
+	// 0 rows kept
+}
+
+func ExampleWithHooks() {
+	var preemptions int
+	scheduler.SJFPriority("Priority", processes, scheduler.WithHooks(scheduler.Hooks{
+		OnPreempt: func(now int64, p scheduler.Process, _ int, remaining int64) {
+			fmt.Printf("P%d preempted at %d with %d left\n", p.ProcessID, now, remaining)
+			preemptions++
+		},
+	}))
+	fmt.Println(preemptions, "preemptions")
+	// Output:
+	// P1 preempted at 3 with 2 left
+	// 1 preemptions
+}
+
+func ExampleAlgorithm_With() {
+	fcfs := scheduler.Algorithms[0].With(scheduler.Budget(6))
+	r := fcfs.Simulate(fcfs.Title, processes)
+	for _, p := range r.Processes {
+		fmt.Printf("P%d left at %d, completed: %t\n", p.ID, p.Exit, p.Completed())
+	}
+	fmt.Println(r.Incomplete, "incomplete")
+	// Output:
+	// P1 left at 5, completed: true
+	// P2 left at 11, completed: false
+	// P3 left at 17, completed: true
+	// 1 incomplete
+}
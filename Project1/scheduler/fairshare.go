@@ -0,0 +1,161 @@
+package scheduler
+
+import "sort"
+
+type (
+	// UserShare is one user's dominant share of a batch scheduler's pool over a run: the largest
+	// fraction of any one resource that the user's running processes hold.
+	UserShare struct {
+		User string `json:"user"`
+		// Steps are the user's dominant share from each time on, in time order.
+		Steps []ShareStep `json:"steps"`
+		// Mean is the time-weighted mean share, from time 0 to the last completion.
+		Mean float64 `json:"mean"`
+		Peak float64 `json:"peak"`
+	}
+	// ShareStep is a user's dominant share from Time until the next step.
+	ShareStep struct {
+		Time  int64   `json:"time"`
+		Share float64 `json:"share"`
+	}
+)
+
+// BatchDRF is Dominant Resource Fairness on a pool of resources: whenever work can start, the
+// user with the lowest dominant share (the largest fraction of any one resource its running
+// processes hold) starts its earliest queued process that fits, so users needing mostly CPUs and
+// users needing mostly GPUs each get an equal share of what they need most. Ties go to the
+// user whose process arrived first. Processes hold their demand until they complete, as in
+// BatchFCFS. A process's user is its ProcessClass: its Name, or its priority for unnamed ones.
+func BatchDRF(pool Resources) Algorithm {
+	return Algorithm{
+		Name:    "batch-drf",
+		Version: "1",
+		Title:   "Batch dominant resource fairness (" + pool.String() + ")",
+		Simulate: func(title string, processes []Process, opts ...Option) Result {
+			return batch(title, processes, newOptions(opts), pool, fairPick(dominantShare))
+		},
+	}
+}
+
+// BatchFairShare is fair sharing of the pool's CPUs alone, for comparison with BatchDRF: the
+// user holding the fewest CPUs starts its earliest queued process that fits, however many GPUs
+// it takes, as single-resource fair-share schedulers do.
+func BatchFairShare(pool Resources) Algorithm {
+	return Algorithm{
+		Name:    "batch-fair",
+		Version: "1",
+		Title:   "Batch fair share of CPUs (" + pool.String() + ")",
+		Simulate: func(title string, processes []Process, opts ...Option) Result {
+			return batch(title, processes, newOptions(opts), pool, fairPick(cpuShare))
+		},
+	}
+}
+
+// dominantShare is the largest fraction of any resource of pool that held is.
+func dominantShare(held, pool Resources) float64 {
+	var share float64
+	for r := range held {
+		if pool[r] > 0 {
+			if f := float64(held[r]) / float64(pool[r]); f > share {
+				share = f
+			}
+		}
+	}
+
+	return share
+}
+
+// cpuShare is the fraction of pool's CPUs that held is.
+func cpuShare(held, pool Resources) float64 {
+	if pool[ResourceCPU] == 0 {
+		return 0
+	}
+
+	return float64(held[ResourceCPU]) / float64(pool[ResourceCPU])
+}
+
+// fairPick starts, for the user with the lowest share of what it holds, its earliest queued
+// process that fits.
+func fairPick(share func(held, pool Resources) float64) batchPolicy {
+	return func(s *batchState) int {
+		held := s.held()
+		best, bestShare := -1, 0.0
+		for _, p := range s.queue { // in arrival order, so the first user found wins ties
+			if !s.fits(p) {
+				continue
+			}
+			if f := share(held[s.users[p]], s.pool); best < 0 || f < bestShare {
+				best, bestShare = p, f
+			}
+		}
+
+		return best
+	}
+}
+
+// held is what each user's running processes hold.
+func (s *batchState) held() map[string]Resources {
+	held := make(map[string]Resources)
+	for _, j := range s.running {
+		held[s.users[j.p]] = held[s.users[j.p]].plus(s.demand[j.p])
+	}
+
+	return held
+}
+
+// shareTracker records every user's dominant share each time it changes.
+type shareTracker struct {
+	shares map[string]*UserShare
+}
+
+func newShareTracker(users []string) *shareTracker {
+	t := &shareTracker{shares: make(map[string]*UserShare)}
+	for _, u := range users {
+		if t.shares[u] == nil {
+			t.shares[u] = &UserShare{User: u, Steps: []ShareStep{{}}}
+		}
+	}
+
+	return t
+}
+
+// record notes each user's dominant share from now on.
+func (t *shareTracker) record(s *batchState) {
+	held := s.held()
+	for u, share := range t.shares {
+		f, n := dominantShare(held[u], s.pool), len(share.Steps)
+		switch last := &share.Steps[n-1]; {
+		case last.Time == s.now && n > 1 && share.Steps[n-2].Share == f:
+			share.Steps = share.Steps[:n-1] // back where it was before now
+		case last.Time == s.now:
+			last.Share = f
+		case last.Share != f:
+			share.Steps = append(share.Steps, ShareStep{Time: s.now, Share: f})
+		}
+	}
+}
+
+// finish returns the users' shares, by user, with their means up to end.
+func (t *shareTracker) finish(end int64) []UserShare {
+	out := make([]UserShare, 0, len(t.shares))
+	for _, share := range t.shares {
+		var area float64
+		for i, step := range share.Steps {
+			stop := end
+			if i+1 < len(share.Steps) {
+				stop = share.Steps[i+1].Time
+			}
+			area += step.Share * float64(stop-step.Time)
+			if step.Share > share.Peak {
+				share.Peak = step.Share
+			}
+		}
+		if end > 0 {
+			share.Mean = area / float64(end)
+		}
+		out = append(out, *share)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].User < out[j].User })
+
+	return out
+}
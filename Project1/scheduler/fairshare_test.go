@@ -0,0 +1,61 @@
+package scheduler
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+// drfProcesses is the example from the DRF paper: on 9 CPUs and 18 GPUs, user A's jobs each need
+// 1 CPU and 4 GPUs and user B's 3 CPUs and 1 GPU.
+func drfProcesses() []Process {
+	var processes []Process
+	for i := int64(1); i <= 10; i++ {
+		p := Process{ProcessID: i, BurstDuration: 10, Name: "A", Resources: Resources{1, 4}}
+		if i > 5 {
+			p.Name, p.Resources = "B", Resources{3, 1}
+		}
+		processes = append(processes, p)
+	}
+
+	return processes
+}
+
+func TestBatchDRF(t *testing.T) {
+	t.Parallel()
+	a := BatchDRF(Resources{9, 18})
+	got := a.Simulate(a.Title, drfProcesses())
+
+	// A runs three jobs and B two, which equalizes their dominant shares at 2/3: A's of the GPUs
+	// and B's of the CPUs.
+	want := []UserShare{
+		{User: "A", Steps: []ShareStep{{0, 2.0 / 3}, {10, 4.0 / 9}, {20, 0}}, Peak: 2.0 / 3},
+		{User: "B", Steps: []ShareStep{{0, 2.0 / 3}, {20, 1.0 / 3}, {30, 0}}, Peak: 2.0 / 3},
+	}
+	shares := got.Resources.Shares
+	if len(shares) > 0 && math.Abs(shares[0].Mean-(2.0/3*10+4.0/9*10)/30) > 1e-9 {
+		t.Errorf("A's mean share = %v", shares[0].Mean)
+	}
+	for i := range shares {
+		shares[i].Mean = 0
+	}
+	if !reflect.DeepEqual(shares, want) {
+		t.Errorf("Shares = %+v, want %+v", shares, want)
+	}
+}
+
+func TestBatchFairShare(t *testing.T) {
+	t.Parallel()
+	a := BatchFairShare(Resources{9, 18})
+	got := a.Simulate(a.Title, drfProcesses())
+
+	// Sharing CPUs alone, A's light CPU demand lets it start a fourth job, taking 16 of the 18
+	// GPUs while B waits with only 1/3 of the CPUs.
+	shares := got.Resources.Shares
+	if len(shares) != 2 || shares[0].Steps[0] != (ShareStep{0, 8.0 / 9}) || shares[1].Steps[0] != (ShareStep{0, 1.0 / 3}) {
+		t.Errorf("Shares = %+v", shares)
+	}
+	if shares[0].Peak != 8.0/9 {
+		t.Errorf("A's peak share = %v, want 8/9", shares[0].Peak)
+	}
+}
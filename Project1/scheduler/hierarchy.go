@@ -0,0 +1,371 @@
+package scheduler
+
+import (
+	"bytes"
+	"container/heap"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrInvalidHierarchy is wrapped by every error in a scheduler hierarchy.
+var ErrInvalidHierarchy = fmt.Errorf("%w: invalid hierarchy", ErrInvalidArgs)
+
+// Leaf policies a hierarchy can schedule a group's processes with.
+var hierarchyPolicies = map[string]less{
+	"fcfs":     byArrival,
+	"sjf":      byRemaining,
+	"priority": byPriority,
+	"rr":       nil, // a FIFO queue rotated every quantum
+}
+
+type (
+	// Hierarchy composes schedulers into a tree. Each inner node shares the CPU between its
+	// children in proportion to their weights (weighted fair share, re-decided every quantum),
+	// and each leaf schedules the processes matched to it with one of the fcfs, sjf, priority or
+	// rr policies. A process belongs to the first leaf, depth first, that it and the leaf's
+	// ancestors all match; a process matching no leaf is rejected.
+	Hierarchy struct {
+		Name    string
+		Quantum int64
+		Root    HierarchyNode
+		nodes   []hierarchyNode
+	}
+	// HierarchyNode is one node of a Hierarchy: a leaf with a Policy, or an inner node with
+	// Children. Weight is the node's share relative to its siblings; 0 means 1.
+	HierarchyNode struct {
+		Name     string          `yaml:"name"`
+		Weight   int64           `yaml:"weight"`
+		Match    HierarchyMatch  `yaml:"match"`
+		Policy   string          `yaml:"policy"`
+		Children []HierarchyNode `yaml:"children"`
+	}
+	// HierarchyMatch selects the processes a node accepts; an empty match accepts every process.
+	// PIDs and Priorities are comma-separated values and ranges such as "1-3,7,10-", and Names
+	// are glob patterns matched against process names; every field given must match.
+	HierarchyMatch struct {
+		PIDs       string   `yaml:"pids"`
+		Priorities string   `yaml:"priorities"`
+		Names      []string `yaml:"names"`
+	}
+
+	// hierarchyNode is a node of the flattened tree; the root is node 0.
+	hierarchyNode struct {
+		parent   int
+		children []int
+		weight   float64
+		policy   string
+		match    func(Process) bool
+	}
+)
+
+// ParseHierarchy parses a YAML hierarchy: the root node's fields, plus an optional quantum
+// (default DefaultQuantum), e.g.
+//
+//	quantum: 2
+//	children:
+//	  - name: interactive
+//	    weight: 3
+//	    match: {priorities: "0-1"}
+//	    policy: rr
+//	  - name: batch
+//	    policy: sjf
+func ParseHierarchy(name string, r io.Reader) (*Hierarchy, error) {
+	var file struct {
+		Quantum       int64 `yaml:"quantum"`
+		HierarchyNode `yaml:",inline"`
+	}
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+	if err := dec.Decode(&file); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = errors.New("empty document")
+		}
+		return nil, fmt.Errorf("%w: %v", ErrInvalidHierarchy, err)
+	}
+
+	return NewHierarchy(name, file.Quantum, file.HierarchyNode)
+}
+
+// NewHierarchy checks a hierarchy rooted at root. A quantum of 0 means DefaultQuantum.
+func NewHierarchy(name string, quantum int64, root HierarchyNode) (*Hierarchy, error) {
+	if quantum == 0 {
+		quantum = DefaultQuantum
+	}
+	if quantum < 0 {
+		return nil, fmt.Errorf("%w: quantum must be positive, got %d", ErrInvalidHierarchy, quantum)
+	}
+	h := &Hierarchy{Name: name, Quantum: quantum, Root: root}
+	if err := h.add(root, -1, "root"); err != nil {
+		return nil, err
+	}
+
+	return h, nil
+}
+
+// add flattens node and its subtree into h.nodes; at is the node's path in errors, such as
+// root/batch or root/0 for an unnamed first child.
+func (h *Hierarchy) add(node HierarchyNode, parent int, at string) error {
+	fail := func(format string, args ...any) error {
+		return fmt.Errorf("%w: %s: %s", ErrInvalidHierarchy, at, fmt.Sprintf(format, args...))
+	}
+	switch {
+	case node.Weight < 0:
+		return fail("weight must not be negative, got %d", node.Weight)
+	case node.Policy != "" && len(node.Children) > 0:
+		return fail("a node has either a policy or children, not both")
+	case node.Policy == "" && len(node.Children) == 0:
+		return fail("a node needs a policy (fcfs, sjf, priority or rr) or children")
+	}
+	if _, ok := hierarchyPolicies[node.Policy]; node.Policy != "" && !ok {
+		return fail("unknown policy %q (known: fcfs, sjf, priority, rr)", node.Policy)
+	}
+	match, err := node.Match.compile()
+	if err != nil {
+		return fail("%v", err)
+	}
+	weight := float64(node.Weight)
+	if weight == 0 {
+		weight = 1
+	}
+	i := len(h.nodes)
+	h.nodes = append(h.nodes, hierarchyNode{parent: parent, weight: weight, policy: node.Policy, match: match})
+	if parent >= 0 {
+		h.nodes[parent].children = append(h.nodes[parent].children, i)
+	}
+	for n, child := range node.Children {
+		name := child.Name
+		if name == "" {
+			name = strconv.Itoa(n)
+		}
+		if err := h.add(child, i, at+"/"+name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// compile turns the match into a predicate.
+func (m HierarchyMatch) compile() (func(Process) bool, error) {
+	pids, err := parseRanges(m.PIDs)
+	if err != nil {
+		return nil, fmt.Errorf("pids: %w", err)
+	}
+	priorities, err := parseRanges(m.Priorities)
+	if err != nil {
+		return nil, fmt.Errorf("priorities: %w", err)
+	}
+	for _, pattern := range m.Names {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("names: bad pattern %q", pattern)
+		}
+	}
+
+	return func(p Process) bool {
+		if !pids.contains(p.ProcessID) || !priorities.contains(p.Priority) {
+			return false
+		}
+		if len(m.Names) == 0 {
+			return true
+		}
+		for _, pattern := range m.Names {
+			if ok, _ := path.Match(pattern, p.Name); ok {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
+// ranges are inclusive [lo, hi] pairs; no ranges contain every value.
+type ranges [][2]int64
+
+// parseRanges parses comma-separated values and ranges such as "1-3,7,10-".
+func parseRanges(s string) (ranges, error) {
+	var rs ranges
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		lo, hi, isRange := strings.Cut(part, "-")
+		from, err := strconv.ParseInt(strings.TrimSpace(lo), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("bad range %q", part)
+		}
+		to := from
+		if isRange {
+			to = int64(^uint64(0) >> 1)
+			if hi = strings.TrimSpace(hi); hi != "" {
+				if to, err = strconv.ParseInt(hi, 10, 64); err != nil || to < from {
+					return nil, fmt.Errorf("bad range %q", part)
+				}
+			}
+		}
+		rs = append(rs, [2]int64{from, to})
+	}
+
+	return rs, nil
+}
+
+func (rs ranges) contains(v int64) bool {
+	if len(rs) == 0 {
+		return true
+	}
+	for _, r := range rs {
+		if v >= r[0] && v <= r[1] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Algorithm runs the hierarchy. Its version is a hash of the tree, so provenance changes
+// whenever the hierarchy does.
+func (h *Hierarchy) Algorithm() Algorithm {
+	var tree bytes.Buffer
+	_, _ = fmt.Fprintf(&tree, "%d %+v", h.Quantum, h.Root)
+	sum := sha256.Sum256(tree.Bytes())
+
+	return Algorithm{
+		Name:    h.Name,
+		Version: hex.EncodeToString(sum[:6]),
+		Title:   "Hierarchy " + h.Name,
+		Simulate: func(title string, processes []Process, opts ...Option) Result {
+			return h.simulate(title, processes, newOptions(opts))
+		},
+	}
+}
+
+// leafOf is the leaf process p belongs to, or -1.
+func (h *Hierarchy) leafOf(p Process, node int) int {
+	n := &h.nodes[node]
+	if !n.match(p) {
+		return -1
+	}
+	if n.policy != "" {
+		return node
+	}
+	for _, child := range n.children {
+		if leaf := h.leafOf(p, child); leaf >= 0 {
+			return leaf
+		}
+	}
+
+	return -1
+}
+
+// simulate runs the hierarchy on the quantum engine: at every arrival, completion and quantum
+// boundary the tree is walked from the root to the leaf whose process runs next.
+func (h *Hierarchy) simulate(title string, processes []Process, o options) Result {
+	var (
+		e         = newQuantumEngine(title, processes, o)
+		t         = e.t
+		leaf      = make([]int, t.len())
+		vtime     = make([]float64, len(h.nodes)) // CPU time used by each node over its weight
+		backlog   = make([]int, len(h.nodes))     // ready processes in each node's subtree
+		queues    = make([]*readyQueue, len(h.nodes))
+		fifos     = make([][]int, len(h.nodes))
+		sliceUsed = make([]int64, t.len()) // rr time used of the current quantum
+	)
+	for i := range processes {
+		leaf[i] = h.leafOf(processes[i], 0)
+	}
+	for i, n := range h.nodes {
+		if by := hierarchyPolicies[n.policy]; by != nil {
+			queues[i] = &readyQueue{t: t, by: by}
+		}
+	}
+	// activate updates the backlog of every node above leaf, bringing the virtual time of a
+	// node becoming busy up to its busy siblings' so it cannot claim CPU time it was not owed.
+	activate := func(node, delta int) {
+		for n := node; n >= 0; n = h.nodes[n].parent {
+			if backlog[n] == 0 && delta > 0 && n > 0 {
+				floor, busy := 0.0, false
+				for _, sibling := range h.nodes[h.nodes[n].parent].children {
+					if backlog[sibling] > 0 && (!busy || vtime[sibling] < floor) {
+						floor, busy = vtime[sibling], true
+					}
+				}
+				if busy && floor > vtime[n] {
+					vtime[n] = floor
+				}
+			}
+			backlog[n] += delta
+		}
+	}
+	// position is where p is in its leaf's ready queue, usually the head.
+	position := func(q *readyQueue, p int) int {
+		if q.peek() == p {
+			return 0
+		}
+		return q.index(p)
+	}
+
+	return e.run(quantumPolicy{
+		order:   byArrival,
+		rejects: func(p int) bool { return leaf[p] < 0 },
+		admit: func(p int) {
+			if q := queues[leaf[p]]; q != nil {
+				q.push(p)
+			} else {
+				fifos[leaf[p]] = append(fifos[leaf[p]], p)
+			}
+			activate(leaf[p], 1)
+		},
+		pick: func() (int, int64, string) {
+			// Walk down to a leaf, taking the busy child that has had the least of its share.
+			node := 0
+			for h.nodes[node].policy == "" {
+				best := -1
+				for _, child := range h.nodes[node].children {
+					if backlog[child] > 0 && (best < 0 || vtime[child] < vtime[best]) {
+						best = child
+					}
+				}
+				node = best
+			}
+			if q := queues[node]; q != nil {
+				return q.peek(), h.Quantum, ""
+			}
+			p := fifos[node][0]
+			return p, h.Quantum - sliceUsed[p], ""
+		},
+		ran: func(p int, run int64) {
+			node := leaf[p]
+			for n := node; n > 0; n = h.nodes[n].parent {
+				vtime[n] += float64(run) / h.nodes[n].weight
+			}
+			switch q := queues[node]; {
+			case t.remaining[p] == 0:
+			case q != nil:
+				// The running process's key may have changed (e.g. less remaining time).
+				heap.Fix(q, position(q, p))
+			default:
+				// Round-robin within the leaf: rotate once the process has used its quantum.
+				if sliceUsed[p] += run; sliceUsed[p] >= h.Quantum {
+					sliceUsed[p] = 0
+					fifos[node] = rotate(fifos[node], p)
+				}
+			}
+		},
+		done: func(p int) {
+			if q := queues[leaf[p]]; q != nil {
+				q.remove(position(q, p))
+			} else {
+				fifos[leaf[p]], _ = without(fifos[leaf[p]], p)
+			}
+			activate(leaf[p], -1)
+		},
+		preemptOnArrival: true,
+	})
+}
@@ -0,0 +1,179 @@
+package scheduler
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestHierarchy_Algorithm(t *testing.T) {
+	t.Parallel()
+	groups := func(a, b HierarchyNode) HierarchyNode {
+		a.Match.PIDs, b.Match.PIDs = "1", "2-"
+		return HierarchyNode{Children: []HierarchyNode{a, b}}
+	}
+	tests := []struct {
+		name      string
+		root      HierarchyNode
+		processes []Process
+		want      []ProcessResult
+		wantGantt []TimeSlice
+	}{
+		{
+			name: "equal shares",
+			root: groups(HierarchyNode{Policy: "fcfs"}, HierarchyNode{Policy: "fcfs"}),
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 4},
+				{ProcessID: 2, BurstDuration: 4},
+			},
+			want: []ProcessResult{
+				{ID: 1, Burst: 4, Wait: 2, Turnaround: 6, Exit: 6},
+				{ID: 2, Burst: 4, Wait: 4, Turnaround: 8, Response: 2, Exit: 8},
+			},
+			wantGantt: []TimeSlice{{PID: 1, Stop: 2}, {PID: 2, Start: 2, Stop: 4}, {PID: 1, Start: 4, Stop: 6}, {PID: 2, Start: 6, Stop: 8}},
+		},
+		{
+			name: "weighted shares",
+			root: groups(HierarchyNode{Weight: 3, Policy: "sjf"}, HierarchyNode{Policy: "sjf"}),
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 6},
+				{ProcessID: 2, BurstDuration: 4},
+			},
+			want: []ProcessResult{
+				{ID: 1, Burst: 6, Wait: 2, Turnaround: 8, Exit: 8},
+				{ID: 2, Burst: 4, Wait: 6, Turnaround: 10, Response: 2, Exit: 10},
+			},
+			wantGantt: []TimeSlice{{PID: 1, Stop: 2}, {PID: 2, Start: 2, Stop: 4}, {PID: 1, Start: 4, Stop: 8}, {PID: 2, Start: 8, Stop: 10}},
+		},
+		{
+			name: "late group gets no catch-up",
+			root: groups(HierarchyNode{Policy: "priority"}, HierarchyNode{Policy: "priority"}),
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 10},
+				{ProcessID: 2, ArrivalTime: 6, BurstDuration: 4},
+			},
+			want: []ProcessResult{
+				{ID: 1, Burst: 10, Wait: 2, Turnaround: 12, Exit: 12},
+				{ID: 2, Burst: 4, Arrival: 6, Wait: 4, Turnaround: 8, Response: 2, Exit: 14},
+			},
+			wantGantt: []TimeSlice{{PID: 1, Stop: 8}, {PID: 2, Start: 8, Stop: 10}, {PID: 1, Start: 10, Stop: 12}, {PID: 2, Start: 12, Stop: 14}},
+		},
+		{
+			name: "round-robin leaf and idle",
+			root: HierarchyNode{Policy: "rr"},
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 1, BurstDuration: 3},
+				{ProcessID: 2, ArrivalTime: 1, BurstDuration: 3},
+			},
+			want: []ProcessResult{
+				{ID: 1, Burst: 3, Arrival: 1, Wait: 2, Turnaround: 5, Exit: 6},
+				{ID: 2, Burst: 3, Arrival: 1, Wait: 3, Turnaround: 6, Response: 2, Exit: 7},
+			},
+			wantGantt: []TimeSlice{
+				{Idle: true, Stop: 1},
+				{PID: 1, Start: 1, Stop: 3}, {PID: 2, Start: 3, Stop: 5}, {PID: 1, Start: 5, Stop: 6}, {PID: 2, Start: 6, Stop: 7},
+			},
+		},
+		{
+			name: "unmatched and zero burst",
+			root: HierarchyNode{Children: []HierarchyNode{{Match: HierarchyMatch{Names: []string{"web-*"}}, Policy: "sjf"}}},
+			processes: []Process{
+				{ProcessID: 1, Name: "web-1", BurstDuration: 2},
+				{ProcessID: 2, Name: "batch", ArrivalTime: 1, BurstDuration: 2},
+				{ProcessID: 3, Name: "web-2", ArrivalTime: 1},
+			},
+			want: []ProcessResult{
+				{ID: 3, Arrival: 1, Exit: 1},
+				{ID: 2, Burst: 2, Arrival: 1, Exit: 1, Status: StatusRejected},
+				{ID: 1, Burst: 2, Turnaround: 2, Exit: 2},
+			},
+			wantGantt: []TimeSlice{{PID: 1, Stop: 2}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			h, err := NewHierarchy("test", 0, tt.root)
+			if err != nil {
+				t.Fatalf("NewHierarchy() error = %v", err)
+			}
+			got := h.Algorithm().Simulate("test", tt.processes)
+			if !reflect.DeepEqual(got.Processes, tt.want) {
+				t.Errorf("processes = %+v, want %+v", got.Processes, tt.want)
+			}
+			if !reflect.DeepEqual(got.Gantt, tt.wantGantt) {
+				t.Errorf("gantt = %+v, want %+v", got.Gantt, tt.wantGantt)
+			}
+		})
+	}
+}
+
+func TestHierarchy_singleLeaf(t *testing.T) {
+	t.Parallel()
+	for policy, want := range map[string]func(string, []Process, ...Option) Result{
+		"fcfs": FCFS, "sjf": SJF, "priority": SJFPriority,
+	} {
+		h, err := NewHierarchy(policy, 0, HierarchyNode{Policy: policy})
+		if err != nil {
+			t.Fatalf("NewHierarchy() error = %v", err)
+		}
+		got, want := h.Algorithm().Simulate("test", exampleProcesses), want("test", exampleProcesses)
+		if !reflect.DeepEqual(got.Processes, want.Processes) || got.AveWait != want.AveWait {
+			t.Errorf("%s leaf = %+v, want %+v", policy, got.Processes, want.Processes)
+		}
+	}
+}
+
+func TestParseHierarchy(t *testing.T) {
+	t.Parallel()
+	h, err := ParseHierarchy("fair", strings.NewReader(`
+quantum: 4
+children:
+  - name: interactive
+    weight: 3
+    match: {priorities: "0-1, 5"}
+    policy: rr
+  - name: batch
+    children:
+      - {match: {pids: "10-"}, policy: sjf}
+      - policy: fcfs
+`))
+	if err != nil {
+		t.Fatalf("ParseHierarchy() error = %v", err)
+	}
+	if h.Quantum != 4 || len(h.nodes) != 5 || h.nodes[1].weight != 3 || h.nodes[3].parent != 2 {
+		t.Errorf("ParseHierarchy() = %+v", h)
+	}
+	for _, tt := range []struct {
+		p    Process
+		want int
+	}{
+		{Process{ProcessID: 1, Priority: 1}, 1},
+		{Process{ProcessID: 1, Priority: 5}, 1},
+		{Process{ProcessID: 12, Priority: 2}, 3},
+		{Process{ProcessID: 2, Priority: 2}, 4},
+	} {
+		if got := h.leafOf(tt.p, 0); got != tt.want {
+			t.Errorf("leafOf(%+v) = %d, want %d", tt.p, got, tt.want)
+		}
+	}
+
+	for _, tt := range []struct{ name, src, want string }{
+		{"empty", "", "empty document"},
+		{"unknown field", "policy: sjf\nweighting: 2\n", "weighting"},
+		{"no policy", "name: top\n", "root: a node needs a policy"},
+		{"both", "policy: sjf\nchildren: [{policy: rr}]\n", "not both"},
+		{"unknown policy", "children: [{name: a, policy: cfs}]\n", `root/a: unknown policy "cfs"`},
+		{"negative weight", "children: [{policy: rr}, {weight: -1, policy: rr}]\n", "root/1: weight must not be negative"},
+		{"bad range", "policy: rr\nmatch: {pids: 3-1}\n", `pids: bad range "3-1"`},
+		{"bad pattern", "policy: rr\nmatch: {names: ['[']}\n", "bad pattern"},
+		{"bad quantum", "quantum: -1\npolicy: rr\n", "quantum must be positive"},
+	} {
+		_, err := ParseHierarchy("test", strings.NewReader(tt.src))
+		if !errors.Is(err, ErrInvalidHierarchy) || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: ParseHierarchy() error = %v, want %q", tt.name, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package scheduler
+
+// Hooks are callbacks a scheduler invokes as scheduling events happen, so embedding programs
+// can collect their own metrics or drive their own visualizations. Any hook may be nil.
+//
+// Events are reported in simulated-time order. At the same instant a completion is reported
+// before arrivals, and arrivals before the preemption and dispatch they cause. A zero-burst
+// process completes without ever being dispatched.
+type Hooks struct {
+	// OnArrival is called when a process arrives and becomes ready to run.
+	OnArrival func(now int64, p Process)
+	// OnDispatch is called when a process starts, or resumes, running on a CPU.
+	OnDispatch func(now int64, p Process, cpu int)
+	// OnPreempt is called when a running process leaves its CPU with burst time remaining.
+	OnPreempt func(now int64, p Process, cpu int, remaining int64)
+	// OnComplete is called with a process's schedule row when it completes.
+	OnComplete func(now int64, r ProcessResult)
+	// OnIdle is called when the CPU goes idle from now until the next arrival.
+	OnIdle func(now, until int64)
+}
+
+// hookList runs each registered Hooks in turn, skipping nil callbacks.
+type hookList []Hooks
+
+func (l hookList) arrival(now int64, p Process) {
+	for _, h := range l {
+		if h.OnArrival != nil {
+			h.OnArrival(now, p)
+		}
+	}
+}
+
+func (l hookList) dispatch(now int64, p Process, cpu int) {
+	for _, h := range l {
+		if h.OnDispatch != nil {
+			h.OnDispatch(now, p, cpu)
+		}
+	}
+}
+
+func (l hookList) preempt(now int64, p Process, cpu int, remaining int64) {
+	for _, h := range l {
+		if h.OnPreempt != nil {
+			h.OnPreempt(now, p, cpu, remaining)
+		}
+	}
+}
+
+func (l hookList) complete(now int64, r ProcessResult) {
+	for _, h := range l {
+		if h.OnComplete != nil {
+			h.OnComplete(now, r)
+		}
+	}
+}
+
+func (l hookList) idle(now, until int64) {
+	for _, h := range l {
+		if h.OnIdle != nil {
+			h.OnIdle(now, until)
+		}
+	}
+}
+
+// arrivalAnnouncer reports arrivals to hooks in arrival order as the clock passes them.
+type arrivalAnnouncer struct {
+	hooks     hookList
+	processes []Process
+	order     []int // indexes into processes, in arrival order
+	next      int
+}
+
+// before reports every process arriving before t that has not been reported yet.
+func (a *arrivalAnnouncer) before(t int64) {
+	for a.next < len(a.order) && a.processes[a.order[a.next]].ArrivalTime < t {
+		p := a.processes[a.order[a.next]]
+		a.hooks.arrival(p.ArrivalTime, p)
+		a.next++
+	}
+}
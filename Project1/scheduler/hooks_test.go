@@ -0,0 +1,134 @@
+package scheduler
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// eventLog returns hooks that log every event to events.
+func eventLog(events *[]string) Hooks {
+	logf := func(format string, args ...any) {
+		*events = append(*events, fmt.Sprintf(format, args...))
+	}
+	return Hooks{
+		OnArrival:  func(now int64, p Process) { logf("%d arrive P%d", now, p.ProcessID) },
+		OnDispatch: func(now int64, p Process, cpu int) { logf("%d dispatch P%d on %d", now, p.ProcessID, cpu) },
+		OnPreempt: func(now int64, p Process, cpu int, remaining int64) {
+			logf("%d preempt P%d on %d with %d left", now, p.ProcessID, cpu, remaining)
+		},
+		OnComplete: func(now int64, r ProcessResult) { logf("%d complete P%d", now, r.ID) },
+		OnIdle:     func(now, until int64) { logf("%d idle until %d", now, until) },
+	}
+}
+
+func TestWithHooks(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		simulate  func(string, []Process, options) Result
+		processes []Process
+		want      []string
+	}{
+		{
+			name:     "fcfs idle and zero burst",
+			simulate: fcfs,
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 2, BurstDuration: 2},
+				{ProcessID: 2, ArrivalTime: 4, BurstDuration: 0},
+				{ProcessID: 3, ArrivalTime: 4, BurstDuration: 1},
+			},
+			want: []string{
+				"0 idle until 2",
+				"2 arrive P1",
+				"2 dispatch P1 on 0",
+				"4 complete P1",
+				"4 arrive P2",
+				"4 arrive P3",
+				"4 complete P2",
+				"4 dispatch P3 on 0",
+				"5 complete P3",
+			},
+		},
+		{
+			name:      "sjf",
+			simulate:  sjf,
+			processes: exampleProcesses,
+			want: []string{
+				"0 arrive P1",
+				"0 dispatch P1 on 0",
+				"3 arrive P2",
+				"5 complete P1",
+				"5 dispatch P2 on 0",
+				"6 arrive P3",
+				"6 preempt P2 on 0 with 8 left",
+				"6 dispatch P3 on 0",
+				"12 complete P3",
+				"12 dispatch P2 on 0",
+				"20 complete P2",
+			},
+		},
+		{
+			name:      "priority",
+			simulate:  sjfPriority,
+			processes: exampleProcesses,
+			want: []string{
+				"0 arrive P1",
+				"0 dispatch P1 on 0",
+				"3 arrive P2",
+				"3 preempt P1 on 0 with 2 left",
+				"3 dispatch P2 on 0",
+				"6 arrive P3",
+				"12 complete P2",
+				"12 dispatch P1 on 0",
+				"14 complete P1",
+				"14 dispatch P3 on 0",
+				"20 complete P3",
+			},
+		},
+		{
+			name:     "rr",
+			simulate: rr,
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 1, BurstDuration: 3},
+				{ProcessID: 2, ArrivalTime: 2, BurstDuration: 1},
+			},
+			want: []string{
+				"0 idle until 1",
+				"1 arrive P1",
+				"1 dispatch P1 on 0",
+				"2 arrive P2",
+				"4 complete P1",
+				"4 dispatch P2 on 0",
+				"5 complete P2",
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var events []string
+			tt.simulate("test", tt.processes, newOptions([]Option{WithHooks(eventLog(&events))}))
+			if !reflect.DeepEqual(events, tt.want) {
+				t.Errorf("events = %q, want %q", events, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithHooks_partial(t *testing.T) {
+	t.Parallel()
+	var completed []int64
+	without := FCFS("test", exampleProcesses)
+	with := FCFS("test", exampleProcesses,
+		WithHooks(Hooks{}),
+		WithHooks(Hooks{OnComplete: func(_ int64, r ProcessResult) { completed = append(completed, r.ID) }}),
+	)
+	if !reflect.DeepEqual(with, without) {
+		t.Errorf("FCFS() with hooks = %+v, want %+v", with, without)
+	}
+	if want := []int64{1, 2, 3}; !reflect.DeepEqual(completed, want) {
+		t.Errorf("completed = %v, want %v", completed, want)
+	}
+}
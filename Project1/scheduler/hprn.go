@@ -0,0 +1,43 @@
+package scheduler
+
+import "math/bits"
+
+// HPRN simulates highest-penalty-ratio-next scheduling of processes: every DefaultQuantum the
+// ready process with the highest penalty ratio, the time since it arrived over the CPU time it
+// has received, runs for the quantum, or until it completes. A process that has not run yet has
+// an unbounded ratio, so arrivals run first, the earliest first. The ratio of a waiting process
+// grows while that of the running one falls, so short processes finish quickly, as under SJF,
+// but no process starves. Ties go to the earliest arrival.
+func HPRN(title string, processes []Process, opts ...Option) Result {
+	// higher reports whether a's penalty ratio is above b's, comparing the cross products
+	// (now-arrival[a])*used[b] and (now-arrival[b])*used[a] to stay in integers. The products
+	// are 128-bit, as times that fit in an int64 need not multiply to one.
+	higher := func(s *shares, a, b int) bool {
+		ha, la := bits.Mul64(uint64(s.now-processes[a].ArrivalTime), uint64(s.used[b]))
+		hb, lb := bits.Mul64(uint64(s.now-processes[b].ArrivalTime), uint64(s.used[a]))
+		switch {
+		case s.used[a] == 0 || s.used[b] == 0:
+			if (s.used[a] == 0) != (s.used[b] == 0) {
+				return s.used[a] == 0
+			}
+		case ha != hb:
+			return ha > hb
+		case la != lb:
+			return la > lb
+		}
+		return processes[a].ArrivalTime < processes[b].ArrivalTime
+	}
+	r := proportionalShare(title, processes, newOptions(opts), func(Process) int64 { return 1 }, func(ready []int, s *shares) int {
+		i := 0
+		for j, p := range ready {
+			if higher(s, p, ready[i]) {
+				i = j
+			}
+		}
+		return i
+	}, nil)
+	// Every process holds one ticket only to share the engine; HPRN has no shares to report.
+	r.Tickets = nil
+
+	return r
+}
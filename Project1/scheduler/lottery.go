@@ -0,0 +1,220 @@
+package scheduler
+
+import "math/rand"
+
+type (
+	// TicketReport is the CPU share each process of a proportional-share run was entitled to by
+	// its tickets, and the share it achieved.
+	TicketReport struct {
+		// Seed seeds the drawings of a lottery run.
+		Seed int64 `json:"seed,omitempty"`
+		// Nice marks a Nice run, whose tickets are the processes' nice weights.
+		Nice   bool          `json:"nice,omitempty"`
+		Shares []TicketShare `json:"shares"`
+	}
+	// TicketShare is one process's part of a TicketReport. Achieved is the share of its time in
+	// the system, from arrival to completion, that it had the CPU, and Entitled the share its
+	// tickets were of all the ready processes' tickets at each drawing, over the same time. Pass
+	// is a stride run's final pass value, and Nice a Nice run's nice value.
+	TicketShare struct {
+		ID       int64   `json:"id"`
+		Nice     int64   `json:"nice,omitempty"`
+		Tickets  int64   `json:"tickets"`
+		Entitled float64 `json:"entitled"`
+		Achieved float64 `json:"achieved"`
+		Pass     int64   `json:"pass,omitempty"`
+	}
+)
+
+// WithSeed seeds the random drawings of the randomized schedulers, such as Lottery, so runs
+// with the same seed are identical. The default seed is 0.
+func WithSeed(seed int64) Option {
+	return func(o *options) {
+		o.seed = seed
+	}
+}
+
+// TicketCount is the process's lottery tickets: its Tickets or, without any, 100/(Priority+1)
+// and at least 1, so a higher priority (a lower number) holds more tickets.
+func (p Process) TicketCount() int64 {
+	switch {
+	case p.Tickets > 0:
+		return p.Tickets
+	case p.Priority < 0 || 100/(p.Priority+1) < 1:
+		return 1
+	default:
+		return 100 / (p.Priority + 1)
+	}
+}
+
+// Lottery simulates lottery scheduling of processes: every DefaultQuantum a ticket is drawn at
+// random, seeded by WithSeed, from all the ready processes' TicketCount, and its holder runs
+// for the quantum, or until it completes. Processes arriving mid-quantum join the next drawing.
+// Its Result reports each process's entitled and achieved CPU share.
+func Lottery(title string, processes []Process, opts ...Option) Result {
+	o := newOptions(opts)
+	draw := rand.New(rand.NewSource(o.seed))
+	r := proportionalShare(title, processes, o, Process.TicketCount, func(ready []int, s *shares) int {
+		n := draw.Int63n(s.total)
+		for i, p := range ready {
+			if n -= s.tickets[p]; n < 0 {
+				return i
+			}
+		}
+		return len(ready) - 1
+	}, nil)
+	r.Tickets.Seed = o.seed
+
+	return r
+}
+
+// Random simulates random scheduling of processes, a baseline for the real algorithms: every
+// DefaultQuantum one of the ready processes is picked at random, seeded by WithSeed, and runs
+// for the quantum, or until it completes. It is Lottery with one ticket each, so its Result
+// reports the equal share each process was entitled to against the share it achieved.
+func Random(title string, processes []Process, opts ...Option) Result {
+	o := newOptions(opts)
+	draw := rand.New(rand.NewSource(o.seed))
+	r := proportionalShare(title, processes, o, func(Process) int64 { return 1 }, func(ready []int, _ *shares) int {
+		return draw.Intn(len(ready))
+	}, nil)
+	r.Tickets.Seed = o.seed
+
+	return r
+}
+
+// shares is the state a proportional-share scheduler picks from: every process's tickets, the
+// ready processes' total, the CPU time each process has been entitled to and has used, and the
+// time of the pick.
+type shares struct {
+	tickets  []int64
+	total    int64
+	entitled []float64
+	used     []int64
+	now      int64
+}
+
+// proportionalShare runs a quantum-based proportional-share scheduler: each process holds
+// tickets(p), and pick chooses, at the start of each quantum, the index in ready (the ready
+// processes in arrival order) of the one to run. charge, if set, is told of every stretch a
+// process runs.
+func proportionalShare(title string, processes []Process, o options, tickets func(Process) int64, pick func(ready []int, s *shares) int, charge func(p int, run int64)) Result {
+	var (
+		e     = newQuantumEngine(title, processes, o)
+		t     = e.t
+		ready []int
+		s     = shares{tickets: make([]int64, t.len()), entitled: make([]float64, t.len()), used: make([]int64, t.len())}
+		exit  = make([]int64, t.len())
+	)
+	for i, p := range processes {
+		s.tickets[i] = tickets(p)
+	}
+	total := func() int64 {
+		var n int64
+		for _, p := range ready {
+			n += s.tickets[p]
+		}
+		return n
+	}
+
+	r := e.run(quantumPolicy{
+		admit: func(p int) { ready = append(ready, p) },
+		pick: func() (int, int64, string) {
+			s.total, s.now = total(), e.now
+			return ready[pick(ready, &s)], DefaultQuantum, ""
+		},
+		ran: func(p int, run int64) {
+			s.total = total()
+			for _, q := range ready {
+				s.entitled[q] += float64(run) * float64(s.tickets[q]) / float64(s.total)
+			}
+			s.used[p] += run
+			if charge != nil {
+				charge(p, run)
+			}
+		},
+		done:      func(p int) { ready, _ = without(ready, p) },
+		completed: func(p int, _ *ProcessResult) { exit[p] = e.now },
+	})
+	r.Tickets = &TicketReport{}
+	for i := range processes {
+		share := TicketShare{ID: t.pid[i], Tickets: s.tickets[i]}
+		if turnaround := exit[i] - t.arrival[i]; turnaround > 0 {
+			share.Entitled = s.entitled[i] / float64(turnaround)
+			share.Achieved = float64(t.burst[i]) / float64(turnaround)
+		}
+		r.Tickets.Shares = append(r.Tickets.Shares, share)
+	}
+
+	return r
+}
+
+// strideConstant is divided by a process's tickets to give its stride, as in OSTEP.
+const strideConstant = 10000
+
+// Stride simulates stride scheduling of processes, the deterministic counterpart of Lottery:
+// each process's stride is 10000 divided by its TicketCount, every DefaultQuantum the ready
+// process with the lowest pass runs (the earliest arrival on a tie), and its pass then grows by
+// its stride. A process arriving starts at the lowest pass of those already ready, so it neither
+// monopolizes the CPU nor waits for the others' head start. Its Result reports each process's
+// entitled and achieved CPU share and final pass.
+func Stride(title string, processes []Process, opts ...Option) Result {
+	var (
+		pass   = make([]int64, len(processes))
+		joined = make([]bool, len(processes))
+	)
+	r := proportionalShare(title, processes, newOptions(opts), Process.TicketCount, func(ready []int, s *shares) int {
+		var lowest int64 = -1
+		for _, p := range ready {
+			if joined[p] && (lowest < 0 || pass[p] < lowest) {
+				lowest = pass[p]
+			}
+		}
+		if lowest < 0 {
+			lowest = 0
+		}
+		best := -1
+		for i, p := range ready {
+			if !joined[p] {
+				pass[p], joined[p] = lowest, true
+			}
+			if best < 0 || pass[p] < pass[ready[best]] {
+				best = i
+			}
+		}
+		return best
+	}, func(p int, run int64) {
+		// A pass grows by a stride for every quantum, or part of one, the process runs.
+		pass[p] = grow(pass[p], strideConstant/processes[p].TicketCount(), (run+DefaultQuantum-1)/DefaultQuantum)
+	})
+	for i := range r.Tickets.Shares {
+		r.Tickets.Shares[i].Pass = pass[i]
+	}
+
+	return r
+}
+
+// Guaranteed simulates guaranteed scheduling of processes: each of n ready processes is
+// entitled to 1/n of the CPU for as long as it is ready, and every DefaultQuantum the one that
+// has used the least CPU for its entitlement runs, a process just arrived first and the earliest
+// arrival on a tie. Every process holds one ticket, so its Result reports the equal share each
+// was entitled to against the share each achieved, as Lottery and Stride do theirs.
+func Guaranteed(title string, processes []Process, opts ...Option) Result {
+	return proportionalShare(title, processes, newOptions(opts), func(Process) int64 { return 1 }, leastServed, nil)
+}
+
+// leastServed picks the ready process that has used the least CPU for its entitlement, one not
+// yet entitled to any first and the earliest arrival on a tie.
+func leastServed(ready []int, s *shares) int {
+	best, bestRatio := 0, 0.0
+	for i, p := range ready {
+		ratio := 0.0
+		if s.entitled[p] > 0 {
+			ratio = float64(s.used[p]) / s.entitled[p]
+		}
+		if i == 0 || ratio < bestRatio {
+			best, bestRatio = i, ratio
+		}
+	}
+	return best
+}
@@ -0,0 +1,177 @@
+package scheduler
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestLottery(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 400, Tickets: 300},
+		{ProcessID: 2, BurstDuration: 400, Tickets: 100},
+	}
+	r := Lottery("lottery", processes, WithSeed(7))
+	if !reflect.DeepEqual(r, Lottery("lottery", processes, WithSeed(7))) {
+		t.Error("Lottery() differs between runs with the same seed")
+	}
+	if reflect.DeepEqual(r.Gantt, Lottery("lottery", processes, WithSeed(8)).Gantt) {
+		t.Error("Lottery() is the same with another seed")
+	}
+	if r.Tickets == nil || r.Tickets.Seed != 7 || len(r.Tickets.Shares) != 2 {
+		t.Fatalf("Tickets = %+v", r.Tickets)
+	}
+	// Both compete for the whole of P1's life, so it is entitled to 3/4 of the CPU and, over
+	// hundreds of drawings, gets about that.
+	p1 := r.Tickets.Shares[0]
+	if p1.ID != 1 || p1.Tickets != 300 || math.Abs(p1.Entitled-0.75) > 1e-9 || math.Abs(p1.Achieved-0.75) > 0.1 {
+		t.Errorf("P1 share = %+v, want about 75%% entitled and achieved", p1)
+	}
+	if p2 := r.Tickets.Shares[1]; p2.Achieved != 0.5 || p2.Entitled <= 0.25 {
+		t.Errorf("P2 share = %+v, want 50%% achieved, completing last, and more than 25%% entitled once alone", p2)
+	}
+	var ran int64
+	for _, s := range r.Gantt {
+		ran += s.Stop - s.Start
+	}
+	if ran != 800 || r.Processes[0].Exit > 800 || r.Processes[1].Exit != 800 {
+		t.Errorf("ran %d with exits %d and %d, want 800 with P2 last", ran, r.Processes[0].Exit, r.Processes[1].Exit)
+	}
+}
+
+func TestProcess_TicketCount(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		p    Process
+		want int64
+	}{
+		{Process{Tickets: 7, Priority: 1}, 7},
+		{Process{}, 100},
+		{Process{Priority: 3}, 25},
+		{Process{Priority: 500}, 1},
+		{Process{Priority: -2}, 1},
+	} {
+		if got := tt.p.TicketCount(); got != tt.want {
+			t.Errorf("%+v.TicketCount() = %d, want %d", tt.p, got, tt.want)
+		}
+	}
+}
+
+func TestStride(t *testing.T) {
+	t.Parallel()
+	// OSTEP's example: strides of 100, 200 and 40.
+	r := Stride("stride", []Process{
+		{ProcessID: 1, BurstDuration: 4, Tickets: 100},
+		{ProcessID: 2, BurstDuration: 2, Tickets: 50},
+		{ProcessID: 3, BurstDuration: 10, Tickets: 250},
+	})
+	want := []TimeSlice{
+		{PID: 1, Stop: 2}, {PID: 2, Start: 2, Stop: 4}, {PID: 3, Start: 4, Stop: 10},
+		{PID: 1, Start: 10, Stop: 12}, {PID: 3, Start: 12, Stop: 16},
+	}
+	if !reflect.DeepEqual(r.Gantt, want) {
+		t.Errorf("Stride() gantt = %+v, want %+v", r.Gantt, want)
+	}
+	for _, s := range r.Tickets.Shares {
+		if s.Pass != 200 {
+			t.Errorf("P%d pass = %d, want 200", s.ID, s.Pass)
+		}
+	}
+
+	// P2 joins at P1's pass rather than 0, so they alternate from then on.
+	r = Stride("stride", []Process{
+		{ProcessID: 1, BurstDuration: 8, Tickets: 100},
+		{ProcessID: 2, ArrivalTime: 4, BurstDuration: 4, Tickets: 100},
+	})
+	want = []TimeSlice{{PID: 1, Stop: 6}, {PID: 2, Start: 6, Stop: 8}, {PID: 1, Start: 8, Stop: 10}, {PID: 2, Start: 10, Stop: 12}}
+	if !reflect.DeepEqual(r.Gantt[:4], want) {
+		t.Errorf("Stride() gantt = %+v, want it to start %+v", r.Gantt, want)
+	}
+
+	// A pass that would pass the largest int64 stops there rather than wrapping negative.
+	r = Stride("stride", []Process{{ProcessID: 1, BurstDuration: math.MaxInt64 / 2, Tickets: 1}}, WithMinGranularity(math.MaxInt64/2))
+	if got := r.Tickets.Shares[0].Pass; got != math.MaxInt64 {
+		t.Errorf("Stride() pass = %d, want %d", got, int64(math.MaxInt64))
+	}
+}
+
+func TestRandom(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 400, Tickets: 300},
+		{ProcessID: 2, BurstDuration: 400, Priority: 9},
+	}
+	r := Random("random", processes, WithSeed(7))
+	if !reflect.DeepEqual(r, Random("random", processes, WithSeed(7))) {
+		t.Error("Random() differs between runs with the same seed")
+	}
+	if reflect.DeepEqual(r.Gantt, Random("random", processes, WithSeed(8)).Gantt) {
+		t.Error("Random() is the same with another seed")
+	}
+	// Tickets and priorities count for nothing: both are entitled to half the CPU while both
+	// are ready, and get about that.
+	for _, s := range r.Tickets.Shares {
+		if s.Tickets != 1 {
+			t.Errorf("P%d holds %d tickets, want 1", s.ID, s.Tickets)
+		}
+	}
+	if p1 := r.Tickets.Shares[0]; math.Abs(p1.Achieved-p1.Entitled) > 0.1 {
+		t.Errorf("P1 share = %+v, want about what it was entitled to", p1)
+	}
+}
+
+func TestGuaranteed(t *testing.T) {
+	t.Parallel()
+	r := Guaranteed("guaranteed", []Process{
+		{ProcessID: 1, BurstDuration: 6},
+		{ProcessID: 2, ArrivalTime: 2, BurstDuration: 4},
+	})
+	// P2 has used none of its share when it arrives, so it runs first; once both have used all
+	// they were entitled to, P1, the earlier arrival, runs on the tie.
+	want := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 2},
+		{PID: 2, Start: 2, Stop: 4},
+		{PID: 1, Start: 4, Stop: 8},
+		{PID: 2, Start: 8, Stop: 10},
+	}
+	if !reflect.DeepEqual(r.Gantt, want) {
+		t.Errorf("Guaranteed() gantt = %+v, want %+v", r.Gantt, want)
+	}
+	for _, s := range r.Tickets.Shares {
+		if s.Tickets != 1 || math.Abs(s.Entitled-5.0/8) > 1e-9 {
+			t.Errorf("P%d: %d tickets entitled to %.3f, want 1 entitled to %.3f", s.ID, s.Tickets, s.Entitled, 5.0/8)
+		}
+	}
+}
+
+func TestNice(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 20, Nice: -5},
+		{ProcessID: 2, BurstDuration: 20},
+		{ProcessID: 3, BurstDuration: 20, Nice: 20}, // clamped to 19
+	}
+	r := Nice("nice", processes)
+	if !r.Tickets.Nice {
+		t.Errorf("Nice() report = %+v, want it marked nice", r.Tickets)
+	}
+	weights := []int64{3121, 1024, 15}
+	for i, s := range r.Tickets.Shares {
+		if s.Nice != processes[i].Nice || s.Tickets != weights[i] {
+			t.Errorf("P%d: nice %d weight %d, want nice %d weight %d", s.ID, s.Nice, s.Tickets, processes[i].Nice, weights[i])
+		}
+	}
+	// While all three are ready P1 is entitled to 3121/4160 of the CPU, so it completes first
+	// and the lowest-weighted P3 last.
+	exit := map[int64]int64{}
+	for _, p := range r.Processes {
+		exit[p.ID] = p.Exit
+	}
+	if !(exit[1] < exit[2] && exit[2] < exit[3]) {
+		t.Errorf("Nice() exits = %v, want P1, P2 and P3 to complete in that order", exit)
+	}
+	if p1 := r.Tickets.Shares[0]; math.Abs(p1.Achieved-p1.Entitled) > 0.1 {
+		t.Errorf("P1 achieved %.3f of the CPU, want about its entitled %.3f", p1.Achieved, p1.Entitled)
+	}
+}
@@ -0,0 +1,10 @@
+package scheduler
+
+// LRTF simulates longest-remaining-time-first scheduling of processes, the preemptive form of
+// LJF: the ready process with the most work left always runs, so the running process is
+// preempted as soon as it has run down to the remaining time of the next longest. Processes
+// with equal work left then take turns every time unit (the earliest arrival first), and the
+// Gantt chart shows every one of those context switches.
+func LRTF(title string, processes []Process, opts ...Option) Result {
+	return preemptive(title, processes, newOptions(opts), byLongest)
+}
@@ -0,0 +1,209 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidMemory is wrapped by every error in a memory admission configuration.
+var ErrInvalidMemory = fmt.Errorf("%w: invalid memory admission", ErrInvalidArgs)
+
+type (
+	// MemoryConfig configures two-level scheduling with memory admission: Size is the memory of
+	// the machine, and SwapAfter, if positive, is how long the process at the head of the job
+	// queue waits for memory before the swapper suspends ready processes to make room for it.
+	MemoryConfig struct {
+		Size      int64
+		SwapAfter int64
+	}
+	// MemoryReport is how long a memory admission run kept each process out of the ready queue.
+	MemoryReport struct {
+		Size  int64 `json:"size"`
+		Swaps int   `json:"swaps"`
+		// AveAdmissionDelay and AveCPUWait split the average wait of the admitted processes into
+		// the time spent waiting for memory and the time spent ready, waiting for the CPU.
+		AveAdmissionDelay float64       `json:"average_admission_delay"`
+		AveCPUWait        float64       `json:"average_cpu_wait"`
+		Processes         []MemoryUsage `json:"processes"`
+	}
+	// MemoryUsage is one process's part of a MemoryReport: its memory, the time it spent in the
+	// job queue waiting for it, the time it spent ready waiting for the CPU, and how many times
+	// the swapper suspended it.
+	MemoryUsage struct {
+		ID             int64 `json:"id"`
+		Memory         int64 `json:"memory"`
+		AdmissionDelay int64 `json:"admission_delay"`
+		CPUWait        int64 `json:"cpu_wait"`
+		Swapped        int   `json:"swapped,omitempty"`
+	}
+)
+
+// ParseMemory parses a memory admission configuration given as the memory size and, optionally,
+// a colon and the swap delay, as MemoryConfig.String formats it: e.g. 1024:20.
+func ParseMemory(s string) (MemoryConfig, error) {
+	var c MemoryConfig
+	size, swap, hasSwap := strings.Cut(s, ":")
+	n, err := strconv.ParseInt(strings.TrimSpace(size), 10, 64)
+	if err != nil {
+		return c, fmt.Errorf("%w: the memory size must be an integer, got %q", ErrInvalidMemory, size)
+	}
+	c.Size = n
+	if hasSwap {
+		if n, err = strconv.ParseInt(strings.TrimSpace(swap), 10, 64); err != nil {
+			return c, fmt.Errorf("%w: the swap delay must be an integer, got %q", ErrInvalidMemory, swap)
+		}
+		c.SwapAfter = n
+	}
+
+	return c, c.check()
+}
+
+func (c MemoryConfig) check() error {
+	if c.Size <= 0 {
+		return fmt.Errorf("%w: the memory size must be positive, got %d", ErrInvalidMemory, c.Size)
+	}
+	if c.SwapAfter < 0 {
+		return fmt.Errorf("%w: the swap delay must not be negative, got %d", ErrInvalidMemory, c.SwapAfter)
+	}
+
+	return nil
+}
+
+// String formats c as ParseMemory reads it, e.g. 1024:20.
+func (c MemoryConfig) String() string {
+	s := strconv.FormatInt(c.Size, 10)
+	if c.SwapAfter > 0 {
+		s += ":" + strconv.FormatInt(c.SwapAfter, 10)
+	}
+
+	return s
+}
+
+// MemoryAdmission is two-level scheduling on a machine with c.Size memory: the long-term
+// scheduler admits arrivals in order from the job queue while the one at its head fits in the
+// free memory, and only admitted processes enter the ready queue, which runs round-robin with a
+// quantum of DefaultQuantum. A process holds its Memory from admission to completion, and one
+// needing more than the machine has is rejected. With c.SwapAfter set, once the head of the job
+// queue has waited that long the swapper suspends ready processes, the last in line first, to
+// the back of the job queue until it fits, if suspending all but the next to run would make it
+// fit. Its Result reports each process's admission delay apart from its wait for the CPU. Its
+// name is mem-<config>, such as mem-1024:20.
+func MemoryAdmission(c MemoryConfig) (Algorithm, error) {
+	if err := c.check(); err != nil {
+		return Algorithm{}, err
+	}
+
+	return Algorithm{
+		Name:    "mem-" + c.String(),
+		Version: "1",
+		Title:   "Memory admission (" + c.String() + ")",
+		Simulate: func(title string, processes []Process, opts ...Option) Result {
+			return memoryAdmission(title, processes, newOptions(opts), c)
+		},
+	}, nil
+}
+
+func memoryAdmission(title string, processes []Process, o options, c MemoryConfig) Result {
+	var (
+		e      = newQuantumEngine(title, processes, o)
+		t      = e.t
+		jobs   []int // the job queue, waiting for memory
+		ready  []int
+		free   = c.Size
+		queued = make([]int64, t.len()) // when each process last joined the job queue
+		usage  = make([]MemoryUsage, t.len())
+		used   = make([]int64, t.len()) // time used of the current quantum
+		swaps  int
+	)
+	for i, p := range processes {
+		usage[i] = MemoryUsage{ID: p.ProcessID, Memory: p.Memory}
+	}
+	admit := func() {
+		for len(jobs) > 0 && processes[jobs[0]].Memory <= free {
+			p := jobs[0]
+			jobs = jobs[1:]
+			free -= processes[p].Memory
+			usage[p].AdmissionDelay += e.now - queued[p]
+			ready = append(ready, p)
+		}
+	}
+	swap := func() {
+		if c.SwapAfter == 0 || len(jobs) == 0 || len(ready) == 0 || e.now-queued[jobs[0]] < c.SwapAfter {
+			return
+		}
+		need, spare := processes[jobs[0]].Memory, free
+		for _, p := range ready[1:] {
+			spare += processes[p].Memory
+		}
+		if spare < need {
+			return
+		}
+		for free < need {
+			p := ready[len(ready)-1]
+			ready = ready[:len(ready)-1]
+			free += processes[p].Memory
+			queued[p], used[p] = e.now, 0
+			usage[p].Swapped++
+			swaps++
+			jobs = append(jobs, p)
+		}
+		admit()
+	}
+
+	r := e.run(quantumPolicy{
+		rejects: func(p int) bool { return processes[p].Memory > c.Size },
+		admit: func(p int) {
+			queued[p] = t.arrival[p]
+			jobs = append(jobs, p)
+		},
+		pick: func() (int, int64, string) {
+			admit()
+			swap()
+			if len(ready) == 0 {
+				// Idle until the next arrival: with nothing ready, all the memory is free.
+				return -1, 0, ""
+			}
+			return ready[0], DefaultQuantum - used[ready[0]], ""
+		},
+		// The clock stops when the head of the job queue has waited long enough to swap for.
+		wake: func(int) (int64, bool) {
+			if c.SwapAfter == 0 || len(jobs) == 0 || e.now-queued[jobs[0]] >= c.SwapAfter {
+				return 0, false
+			}
+			return queued[jobs[0]] + c.SwapAfter, true
+		},
+		ran: func(p int, run int64) {
+			used[p] += run
+			if t.remaining[p] > 0 && used[p] >= DefaultQuantum {
+				used[p] = 0
+				ready = rotate(ready, p)
+			}
+		},
+		done: func(p int) {
+			ready, _ = without(ready, p)
+			free += processes[p].Memory
+		},
+		completed: func(p int, row *ProcessResult) {
+			usage[p].CPUWait = row.Wait - usage[p].AdmissionDelay
+		},
+		preemptOnArrival: true,
+	})
+	r.Memory = &MemoryReport{Size: c.Size, Swaps: swaps}
+	var admitted int
+	for i := range processes {
+		if processes[i].Memory > c.Size {
+			continue
+		}
+		r.Memory.Processes = append(r.Memory.Processes, usage[i])
+		r.Memory.AveAdmissionDelay += float64(usage[i].AdmissionDelay)
+		r.Memory.AveCPUWait += float64(usage[i].CPUWait)
+		admitted++
+	}
+	if admitted > 0 {
+		r.Memory.AveAdmissionDelay /= float64(admitted)
+		r.Memory.AveCPUWait /= float64(admitted)
+	}
+
+	return r
+}
@@ -0,0 +1,80 @@
+package scheduler
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseMemory(t *testing.T) {
+	t.Parallel()
+	c, err := ParseMemory("1024:20")
+	if err != nil || c != (MemoryConfig{Size: 1024, SwapAfter: 20}) || c.String() != "1024:20" {
+		t.Errorf("ParseMemory(1024:20) = %+v, %v", c, err)
+	}
+	for _, bad := range []string{"", "x", "0", "64:y", "64:-1"} {
+		if _, err := ParseMemory(bad); !errors.Is(err, ErrInvalidMemory) {
+			t.Errorf("ParseMemory(%q) error = %v, want ErrInvalidMemory", bad, err)
+		}
+	}
+}
+
+func TestMemoryAdmission(t *testing.T) {
+	t.Parallel()
+	a, err := MemoryAdmission(MemoryConfig{Size: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := a.Simulate(a.Name, []Process{
+		{ProcessID: 1, BurstDuration: 4, Memory: 6},
+		{ProcessID: 2, BurstDuration: 2, Memory: 6},
+		{ProcessID: 3, ArrivalTime: 1, BurstDuration: 2, Memory: 4},
+		{ProcessID: 4, ArrivalTime: 1, BurstDuration: 1, Memory: 20},
+	})
+	// P2 does not fit beside P1, and P3 queues behind it although it would; P4 never fits.
+	want := []MemoryUsage{
+		{ID: 1, Memory: 6},
+		{ID: 2, Memory: 6, AdmissionDelay: 4},
+		{ID: 3, Memory: 4, AdmissionDelay: 3, CPUWait: 2},
+	}
+	if !reflect.DeepEqual(r.Memory.Processes, want) {
+		t.Errorf("memory usage = %+v, want %+v", r.Memory.Processes, want)
+	}
+	if r.AveWait != 3 || r.Memory.AveAdmissionDelay != 7.0/3 || r.Memory.AveCPUWait != 2.0/3 {
+		t.Errorf("average wait %.2f = admission %.2f + CPU %.2f, want 3 = 2.33 + 0.67", r.AveWait, r.Memory.AveAdmissionDelay, r.Memory.AveCPUWait)
+	}
+	for _, p := range r.Processes {
+		if p.ID == 4 && p.Status != StatusRejected {
+			t.Errorf("P4 status = %q, want rejected", p.Status)
+		}
+	}
+}
+
+func TestMemoryAdmission_swap(t *testing.T) {
+	t.Parallel()
+	a, err := MemoryAdmission(MemoryConfig{Size: 10, SwapAfter: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := a.Simulate(a.Name, []Process{
+		{ProcessID: 1, BurstDuration: 6, Memory: 4},
+		{ProcessID: 2, BurstDuration: 6, Memory: 4},
+		{ProcessID: 3, BurstDuration: 1, Memory: 4},
+	})
+	// After waiting 2, P3 is swapped in for P1, the last in line, and 2 later P1 for P2.
+	want := []TimeSlice{
+		{PID: 1, Stop: 2},
+		{PID: 2, Start: 2, Stop: 4},
+		{PID: 3, Start: 4, Stop: 5},
+		{PID: 1, Start: 5, Stop: 7},
+		{PID: 2, Start: 7, Stop: 9},
+		{PID: 1, Start: 9, Stop: 11},
+		{PID: 2, Start: 11, Stop: 13},
+	}
+	if !reflect.DeepEqual(r.Gantt, want) {
+		t.Errorf("gantt = %+v, want %+v", r.Gantt, want)
+	}
+	if r.Memory.Swaps != 2 {
+		t.Errorf("swaps = %d, want 2", r.Memory.Swaps)
+	}
+}
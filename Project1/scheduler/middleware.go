@@ -0,0 +1,190 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+)
+
+// SimulateFunc runs a scheduling algorithm over processes.
+type SimulateFunc func(title string, processes []Process, opts ...Option) Result
+
+// Middleware wraps a scheduler with behavior that applies to any algorithm, such as logging,
+// admission control or overhead, so it is not re-implemented inside each one.
+type Middleware func(next SimulateFunc) SimulateFunc
+
+// Chain wraps simulate in middleware. The first middleware is the outermost, so it sees
+// the processes first and the result last.
+func Chain(simulate SimulateFunc, middleware ...Middleware) SimulateFunc {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		simulate = middleware[i](simulate)
+	}
+
+	return simulate
+}
+
+// With returns a copy of the algorithm whose Simulate is wrapped in middleware, as by Chain.
+func (a Algorithm) With(middleware ...Middleware) Algorithm {
+	a.Simulate = Chain(a.Simulate, middleware...)
+
+	return a
+}
+
+// Logging writes a line to w for every scheduling event of a run.
+func Logging(w io.Writer) Middleware {
+	return func(next SimulateFunc) SimulateFunc {
+		return func(title string, processes []Process, opts ...Option) Result {
+			logf := func(now int64, format string, args ...any) {
+				_, _ = fmt.Fprintf(w, "%s @%d: %s\n", title, now, fmt.Sprintf(format, args...))
+			}
+			return next(title, processes, append(opts[:len(opts):len(opts)], WithHooks(Hooks{
+				OnArrival: func(now int64, p Process) { logf(now, "P%d arrived", p.ProcessID) },
+				OnDispatch: func(now int64, p Process, cpu int) {
+					logf(now, "P%d dispatched on CPU %d", p.ProcessID, cpu)
+				},
+				OnPreempt: func(now int64, p Process, cpu int, remaining int64) {
+					logf(now, "P%d preempted on CPU %d with %d left", p.ProcessID, cpu, remaining)
+				},
+				OnComplete: func(now int64, r ProcessResult) { logf(now, "P%d completed", r.ID) },
+				OnIdle:     func(now, until int64) { logf(now, "idle until %d", until) },
+			}))...)
+		}
+	}
+}
+
+// Admission rejects every process admit returns false for before the wrapped scheduler
+// sees it. A rejected process leaves the system as it arrives, with a StatusRejected row
+// after the scheduled ones, and is left out of the averages.
+func Admission(admit func(Process) bool) Middleware {
+	return func(next SimulateFunc) SimulateFunc {
+		return func(title string, processes []Process, opts ...Option) Result {
+			var admitted, rejected []Process
+			for _, p := range processes {
+				if admit(p) {
+					admitted = append(admitted, p)
+				} else {
+					rejected = append(rejected, p)
+				}
+			}
+
+			return rewriteRows(next, title, admitted, opts, nil, func(emit func(ProcessResult)) {
+				for _, p := range rejected {
+					emit(ProcessResult{
+						ID:       p.ProcessID,
+						Priority: p.Priority,
+						Burst:    p.BurstDuration,
+						Arrival:  p.ArrivalTime,
+						Exit:     p.ArrivalTime,
+						Status:   StatusRejected,
+					})
+				}
+			})
+		}
+	}
+}
+
+// Budget kills any process once it has run for limit time units. A killed process keeps its
+// full burst in its row, with StatusKilled and the time it was killed as its exit.
+func Budget(limit int64) Middleware {
+	return func(next SimulateFunc) SimulateFunc {
+		return func(title string, processes []Process, opts ...Option) Result {
+			capped, bursts := withBursts(processes, func(p Process) int64 {
+				return minimum(p.BurstDuration, limit)
+			})
+
+			return rewriteRows(next, title, capped, opts, func(r ProcessResult) ProcessResult {
+				if burst := bursts[r.ID]; burst > r.Burst {
+					r.Burst = burst
+					r.Status = StatusKilled
+				}
+				return r
+			}, nil)
+		}
+	}
+}
+
+// ContextSwitch charges cost time units of overhead each time a process is dispatched. The
+// overhead is added to a process's burst for every dispatch it gets when scheduled without
+// overhead, then the processes are scheduled again; the reported burst excludes it, so it
+// shows up as waiting time.
+func ContextSwitch(cost int64) Middleware {
+	return func(next SimulateFunc) SimulateFunc {
+		return func(title string, processes []Process, opts ...Option) Result {
+			dispatches := make(map[int64]int64, len(processes))
+			next(title, processes, append(opts[:len(opts):len(opts)], withoutOutput(), WithHooks(Hooks{
+				OnDispatch: func(_ int64, p Process, _ int) { dispatches[p.ProcessID]++ },
+			}))...)
+			inflated, bursts := withBursts(processes, func(p Process) int64 {
+				return p.BurstDuration + cost*dispatches[p.ProcessID]
+			})
+
+			return rewriteRows(next, title, inflated, opts, func(r ProcessResult) ProcessResult {
+				r.Burst = bursts[r.ID]
+				r.Wait = r.Turnaround - r.Burst
+				return r
+			}, nil)
+		}
+	}
+}
+
+// withBursts copies processes with each burst replaced by burst(p), returning the original
+// burst of each process by PID.
+func withBursts(processes []Process, burst func(Process) int64) ([]Process, map[int64]int64) {
+	out := make([]Process, len(processes))
+	original := make(map[int64]int64, len(processes))
+	for i, p := range processes {
+		original[p.ProcessID] = p.BurstDuration
+		p.BurstDuration = burst(p)
+		out[i] = p
+	}
+
+	return out, original
+}
+
+// rewriteRows runs next with every process row passed through fix (when not nil), then emits
+// any extra rows, keeping the result's averages in step with the rows whether they are
+// buffered in the result or streamed to a sink.
+func rewriteRows(next SimulateFunc, title string, processes []Process, opts []Option,
+	fix func(ProcessResult) ProcessResult, extra func(emit func(ProcessResult))) Result {
+	if fix == nil {
+		fix = func(r ProcessResult) ProcessResult { return r }
+	}
+	rows := &rowSink{sink: newOptions(opts).sink, fix: fix}
+	if rows.sink != nil {
+		opts = append(opts[:len(opts):len(opts)], WithSink(rows))
+	}
+	r := next(title, processes, opts...)
+	if rows.sink == nil {
+		for i := range r.Processes {
+			r.Processes[i] = fix(r.Processes[i])
+			rows.totals.add(r.Processes[i])
+		}
+	}
+	if extra != nil {
+		extra(func(p ProcessResult) {
+			rows.totals.add(p)
+			if rows.sink != nil {
+				rows.sink.Process(p)
+				return
+			}
+			r.Processes = append(r.Processes, p)
+		})
+	}
+	rows.totals.apply(&r)
+
+	return r
+}
+
+// rowSink rewrites the rows streamed to sink, totalling them as they pass.
+type rowSink struct {
+	sink   Sink
+	fix    func(ProcessResult) ProcessResult
+	totals runTotals
+}
+
+func (s *rowSink) Process(p ProcessResult) {
+	p = s.fix(p)
+	s.totals.add(p)
+	s.sink.Process(p)
+}
+
+func (s *rowSink) Slice(t TimeSlice) { s.sink.Slice(t) }
@@ -0,0 +1,125 @@
+package scheduler
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestChain(t *testing.T) {
+	t.Parallel()
+	var calls []string
+	named := func(name string) Middleware {
+		return func(next SimulateFunc) SimulateFunc {
+			return func(title string, processes []Process, opts ...Option) Result {
+				calls = append(calls, name+" in")
+				r := next(title, processes, opts...)
+				calls = append(calls, name+" out")
+				return r
+			}
+		}
+	}
+	a := Algorithms[0].With(named("outer"), named("inner"))
+	if got, want := a.Simulate("test", exampleProcesses), FCFS("test", exampleProcesses); !reflect.DeepEqual(got, want) {
+		t.Errorf("Simulate() = %+v, want %+v", got, want)
+	}
+	if want := []string{"outer in", "inner in", "inner out", "outer out"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %q, want %q", calls, want)
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		middleware Middleware
+		want       []ProcessResult
+		wantWait   float64
+		wantGantt  []TimeSlice
+	}{
+		{
+			name:       "admission",
+			middleware: Admission(func(p Process) bool { return p.BurstDuration < 8 }),
+			want: []ProcessResult{
+				{ID: 1, Priority: 2, Burst: 5, Arrival: 0, Turnaround: 5, Exit: 5},
+				{ID: 3, Priority: 3, Burst: 6, Arrival: 6, Turnaround: 6, Exit: 12},
+				{ID: 2, Priority: 1, Burst: 9, Arrival: 3, Exit: 3, Status: StatusRejected},
+			},
+			wantGantt: []TimeSlice{{PID: 1, Start: 0, Stop: 5}, {Idle: true, Start: 5, Stop: 6}, {PID: 3, Start: 6, Stop: 12}},
+		},
+		{
+			name:       "budget",
+			middleware: Budget(6),
+			want: []ProcessResult{
+				{ID: 1, Priority: 2, Burst: 5, Arrival: 0, Turnaround: 5, Exit: 5},
+				{ID: 2, Priority: 1, Burst: 9, Arrival: 3, Wait: 2, Turnaround: 8, Response: 2, Exit: 11, Status: StatusKilled},
+				{ID: 3, Priority: 3, Burst: 6, Arrival: 6, Wait: 5, Turnaround: 11, Response: 5, Exit: 17},
+			},
+			wantWait:  2.5,
+			wantGantt: []TimeSlice{{PID: 1, Start: 0, Stop: 5}, {PID: 2, Start: 5, Stop: 11}, {PID: 3, Start: 11, Stop: 17}},
+		},
+		{
+			name:       "context switch",
+			middleware: ContextSwitch(1),
+			want: []ProcessResult{
+				{ID: 1, Priority: 2, Burst: 5, Arrival: 0, Wait: 1, Turnaround: 6, Exit: 6},
+				{ID: 2, Priority: 1, Burst: 9, Arrival: 3, Wait: 4, Turnaround: 13, Response: 3, Exit: 16},
+				{ID: 3, Priority: 3, Burst: 6, Arrival: 6, Wait: 11, Turnaround: 17, Response: 10, Exit: 23},
+			},
+			wantWait:  16.0 / 3,
+			wantGantt: []TimeSlice{{PID: 1, Start: 0, Stop: 6}, {PID: 2, Start: 6, Stop: 16}, {PID: 3, Start: 16, Stop: 23}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			simulate := Chain(FCFS, tt.middleware)
+			got := simulate("test", exampleProcesses)
+			if !reflect.DeepEqual(got.Processes, tt.want) {
+				t.Errorf("processes = %+v, want %+v", got.Processes, tt.want)
+			}
+			if !reflect.DeepEqual(got.Gantt, tt.wantGantt) {
+				t.Errorf("gantt = %+v, want %+v", got.Gantt, tt.wantGantt)
+			}
+			if got.AveWait != tt.wantWait {
+				t.Errorf("average wait = %v, want %v", got.AveWait, tt.wantWait)
+			}
+
+			// Streaming the rows rewrites them the same way and keeps the averages.
+			sink := &fakeSink{}
+			streamed := simulate("test", exampleProcesses, WithSink(sink))
+			if !reflect.DeepEqual(sink.processes, tt.want) {
+				t.Errorf("streamed processes = %+v, want %+v", sink.processes, tt.want)
+			}
+			if !reflect.DeepEqual(sink.slices, tt.wantGantt) {
+				t.Errorf("streamed gantt = %+v, want %+v", sink.slices, tt.wantGantt)
+			}
+			if streamed.AveWait != got.AveWait || streamed.Incomplete != got.Incomplete {
+				t.Errorf("streamed averages = %+v, want %+v", streamed, got)
+			}
+		})
+	}
+}
+
+func TestLogging(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	Chain(SJF, Logging(&w))("sjf", []Process{
+		{ProcessID: 1, ArrivalTime: 1, BurstDuration: 4},
+		{ProcessID: 2, ArrivalTime: 2, BurstDuration: 1},
+	})
+	want := `sjf @0: idle until 1
+sjf @1: P1 arrived
+sjf @1: P1 dispatched on CPU 0
+sjf @2: P2 arrived
+sjf @2: P1 preempted on CPU 0 with 3 left
+sjf @2: P2 dispatched on CPU 0
+sjf @3: P2 completed
+sjf @3: P1 dispatched on CPU 0
+sjf @6: P1 completed
+`
+	if got := w.String(); got != want {
+		t.Errorf("Logging() wrote %q, want %q", got, want)
+	}
+}
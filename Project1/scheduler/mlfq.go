@@ -0,0 +1,192 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidMLFQ is wrapped by every error in a multilevel feedback queue's configuration.
+var ErrInvalidMLFQ = fmt.Errorf("%w: invalid multilevel feedback queue", ErrInvalidArgs)
+
+// MLFQConfig configures a multilevel feedback queue scheduler: Quanta are the quantum of each
+// level, highest first, so their number is the number of levels, and Boost is how often every
+// process is moved back to the top level; 0 never boosts.
+type MLFQConfig struct {
+	Quanta []int64
+	Boost  int64
+}
+
+// ParseMLFQ parses a multilevel feedback queue configuration given as the quanta separated by
+// commas and, optionally, a colon and the boost period, as MLFQConfig.String formats it: e.g.
+// 10,10,10:50 for three levels with a quantum of 10 each, boosted every 50 time units.
+func ParseMLFQ(s string) (MLFQConfig, error) {
+	var c MLFQConfig
+	quanta, boost, hasBoost := strings.Cut(s, ":")
+	for _, q := range strings.Split(quanta, ",") {
+		n, err := strconv.ParseInt(strings.TrimSpace(q), 10, 64)
+		if err != nil {
+			return c, fmt.Errorf("%w: quanta must be integers separated by commas, got %q", ErrInvalidMLFQ, quanta)
+		}
+		c.Quanta = append(c.Quanta, n)
+	}
+	if hasBoost {
+		n, err := strconv.ParseInt(strings.TrimSpace(boost), 10, 64)
+		if err != nil {
+			return c, fmt.Errorf("%w: the boost period must be an integer, got %q", ErrInvalidMLFQ, boost)
+		}
+		c.Boost = n
+	}
+
+	return c, c.check()
+}
+
+func (c MLFQConfig) check() error {
+	if len(c.Quanta) == 0 {
+		return fmt.Errorf("%w: no levels", ErrInvalidMLFQ)
+	}
+	for i, q := range c.Quanta {
+		if q <= 0 {
+			return fmt.Errorf("%w: level %d: quantum must be positive, got %d", ErrInvalidMLFQ, i, q)
+		}
+	}
+	if c.Boost < 0 {
+		return fmt.Errorf("%w: the boost period must not be negative, got %d", ErrInvalidMLFQ, c.Boost)
+	}
+
+	return nil
+}
+
+// String formats c as ParseMLFQ reads it, e.g. 10,10,10:50.
+func (c MLFQConfig) String() string {
+	quanta := make([]string, len(c.Quanta))
+	for i, q := range c.Quanta {
+		quanta[i] = strconv.FormatInt(q, 10)
+	}
+	s := strings.Join(quanta, ",")
+	if c.Boost > 0 {
+		s += ":" + strconv.FormatInt(c.Boost, 10)
+	}
+
+	return s
+}
+
+// QueueLevel is the level a process of a multilevel feedback queue run completed in, 0 at the
+// top: a process demoted for using up its quanta ends low, one that completes quickly or keeps
+// yielding for I/O stays high.
+type QueueLevel struct {
+	ID    int64 `json:"id"`
+	Level int   `json:"level"`
+}
+
+// MLFQ is multilevel feedback queue scheduling, following the rules of the classic OSTEP
+// chapter: a process arrives at the top level; the highest non-empty level runs, round-robin
+// within it, preempted as soon as a process arrives above it; a process that uses up its level's
+// quantum, across however many dispatches, moves down a level, or to the back of the bottom
+// level; a process that blocks for I/O (see Process.IOInterval) before using up its quantum moves
+// up a level, rejoining it once the I/O completes, so interactive processes stay ahead of CPU
+// hogs; and every Boost time units every process moves back to the top with a fresh quantum.
+// Each Gantt slice's Queue is its level, "level 0" at the top, and its Result reports the level
+// each process completed in. Its name is mlfq-<config>, such as mlfq-10,10,10:50.
+func MLFQ(c MLFQConfig) (Algorithm, error) {
+	if err := c.check(); err != nil {
+		return Algorithm{}, err
+	}
+	c.Quanta = append([]int64(nil), c.Quanta...)
+
+	return Algorithm{
+		Name:    "mlfq-" + c.String(),
+		Version: "1",
+		Title:   "Multilevel feedback queue (" + c.String() + ")",
+		Simulate: func(title string, processes []Process, opts ...Option) Result {
+			return mlfq(title, processes, newOptions(opts), c)
+		},
+	}, nil
+}
+
+// mlfq simulates multilevel feedback queue scheduling like mlq, with boosts as events too.
+func mlfq(title string, processes []Process, o options, c MLFQConfig) Result {
+	var (
+		e         = newQuantumEngine(title, processes, o)
+		t         = e.t
+		levels    = make([][]int, len(c.Quanta))
+		level     = make([]int, t.len())   // the level each process is in
+		used      = make([]int64, t.len()) // time used of the current level's quantum
+		nextBoost = c.Boost
+		names     = make([]string, len(c.Quanta))
+		final     = make([]QueueLevel, t.len())
+	)
+	for i, p := range processes {
+		final[i].ID = p.ProcessID
+	}
+	for i := range names {
+		names[i] = "level " + strconv.Itoa(i)
+	}
+	policy := quantumPolicy{
+		admit: func(p int) {
+			levels[0] = append(levels[0], p)
+		},
+		pick: func() (int, int64, string) {
+			l := 0
+			for len(levels[l]) == 0 {
+				l++
+			}
+			p := levels[l][0]
+			return p, c.Quanta[l] - used[p], names[l]
+		},
+		ran: func(p int, run int64) {
+			l := level[p]
+			if used[p] += run; used[p] < c.Quanta[l] || t.remaining[p] == 0 {
+				return
+			}
+			// The quantum is used up: move down a level, or to the back of the bottom one.
+			levels[l], _ = without(levels[l], p)
+			if l+1 < len(levels) {
+				l++
+			}
+			level[p], used[p] = l, 0
+			levels[l] = append(levels[l], p)
+		},
+		done: func(p int) {
+			levels[level[p]], _ = without(levels[level[p]], p)
+			final[p].Level = level[p]
+		},
+		yield: func(p int) {
+			levels[level[p]], _ = without(levels[level[p]], p)
+			// A process demoted by ran has just used up its quantum, so only one with some of it
+			// left gave up the CPU of its own accord.
+			if used[p] > 0 && level[p] > 0 {
+				level[p]--
+			}
+			used[p] = 0
+		},
+		ready: func(p int) { levels[level[p]] = append(levels[level[p]], p) },
+		// An arrival at the top preempts a lower level.
+		preemptOnArrival: true,
+	}
+	if c.Boost > 0 {
+		policy.tick = func() {
+			if e.now < nextBoost {
+				return
+			}
+			top := levels[0]
+			for l := 1; l < len(levels); l++ {
+				top = append(top, levels[l]...)
+				levels[l] = nil
+			}
+			levels[0] = top
+			// Processes blocked for I/O move to the top too, rejoining it once the I/O completes.
+			for p := range level {
+				level[p], used[p] = 0, 0
+			}
+			nextBoost = (e.now/c.Boost + 1) * c.Boost
+		}
+		// A boost only matters to a running process: an idle CPU waits for the next arrival.
+		policy.wake = func(p int) (int64, bool) { return nextBoost, p >= 0 }
+	}
+
+	r := e.run(policy)
+	r.Levels = final
+
+	return r
+}
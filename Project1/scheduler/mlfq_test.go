@@ -0,0 +1,141 @@
+package scheduler
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestMLFQ(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		config    MLFQConfig
+		processes []Process
+		want      []TimeSlice
+		levels    []QueueLevel
+	}{
+		{
+			// OSTEP's "along came a short job": the long job sinks to the bottom, and the short
+			// one arriving at 100 runs above it until it completes.
+			name:   "short job",
+			config: MLFQConfig{Quanta: []int64{10, 10, 10}},
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 200},
+				{ProcessID: 2, ArrivalTime: 100, BurstDuration: 20},
+			},
+			want: []TimeSlice{
+				{PID: 1, Stop: 10, Queue: "level 0"},
+				{PID: 1, Start: 10, Stop: 20, Queue: "level 1"},
+				{PID: 1, Start: 20, Stop: 100, Queue: "level 2"},
+				{PID: 2, Start: 100, Stop: 110, Queue: "level 0"},
+				{PID: 2, Start: 110, Stop: 120, Queue: "level 1"},
+				{PID: 1, Start: 120, Stop: 220, Queue: "level 2"},
+			},
+			levels: []QueueLevel{{ID: 1, Level: 2}, {ID: 2, Level: 1}},
+		},
+		{
+			// The boost at 25 brings the job back to the top, with a fresh quantum.
+			name:      "boost",
+			config:    MLFQConfig{Quanta: []int64{10, 10}, Boost: 25},
+			processes: []Process{{ProcessID: 1, BurstDuration: 40}},
+			want: []TimeSlice{
+				{PID: 1, Stop: 10, Queue: "level 0"},
+				{PID: 1, Start: 10, Stop: 25, Queue: "level 1"},
+				{PID: 1, Start: 25, Stop: 35, Queue: "level 0"},
+				{PID: 1, Start: 35, Stop: 40, Queue: "level 1"},
+			},
+		},
+		{
+			// P2 blocks for I/O every 2 and stays at the top, while P1, a CPU hog, sinks and is
+			// preempted whenever P2's I/O completes. P3 uses up its quantum, but then blocks at
+			// 19 with some of its next one left, so it moves back up and completes ahead of P1.
+			name:   "I/O",
+			config: MLFQConfig{Quanta: []int64{4, 4, 4}},
+			processes: []Process{
+				{ProcessID: 1, BurstDuration: 20},
+				{ProcessID: 2, BurstDuration: 6, IOInterval: 2, IODuration: 3},
+				{ProcessID: 3, ArrivalTime: 1, BurstDuration: 8, IOInterval: 5, IODuration: 1},
+			},
+			want: []TimeSlice{
+				{PID: 1, Stop: 4, Queue: "level 0"},
+				{PID: 2, Start: 4, Stop: 6, Queue: "level 0"},
+				{PID: 3, Start: 6, Stop: 10, Queue: "level 0"},
+				{PID: 2, Start: 10, Stop: 12, Queue: "level 0"},
+				{PID: 1, Start: 12, Stop: 15, Queue: "level 1"},
+				{PID: 2, Start: 15, Stop: 17, Queue: "level 0"},
+				{PID: 1, Start: 17, Stop: 18, Queue: "level 1"},
+				{PID: 3, Start: 18, Stop: 19, Queue: "level 1"},
+				{PID: 1, Start: 19, Stop: 20, Queue: "level 2"},
+				{PID: 3, Start: 20, Stop: 23, Queue: "level 0"},
+				{PID: 1, Start: 23, Stop: 34, Queue: "level 2"},
+			},
+			levels: []QueueLevel{{ID: 1, Level: 2}, {ID: 2, Level: 0}, {ID: 3, Level: 0}},
+		},
+		{
+			name:   "round-robin at the top and idle",
+			config: MLFQConfig{Quanta: []int64{2, 4}},
+			processes: []Process{
+				{ProcessID: 1, ArrivalTime: 1, BurstDuration: 3},
+				{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2},
+			},
+			want: []TimeSlice{
+				{Idle: true, Stop: 1},
+				{PID: 1, Start: 1, Stop: 3, Queue: "level 0"},
+				{PID: 2, Start: 3, Stop: 5, Queue: "level 0"},
+				{PID: 1, Start: 5, Stop: 6, Queue: "level 1"},
+			},
+			levels: []QueueLevel{{ID: 1, Level: 1}, {ID: 2, Level: 0}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			a, err := MLFQ(tt.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r := a.Simulate(a.Title, tt.processes)
+			if !reflect.DeepEqual(r.Gantt, tt.want) {
+				t.Errorf("gantt = %+v, want %+v", r.Gantt, tt.want)
+			}
+			if tt.levels != nil && !reflect.DeepEqual(r.Levels, tt.levels) {
+				t.Errorf("levels = %+v, want %+v", r.Levels, tt.levels)
+			}
+		})
+	}
+}
+
+func TestMLFQ_ioWait(t *testing.T) {
+	t.Parallel()
+	// The CPU idles while the only process is blocked, and the time blocked is not wait.
+	a, _ := MLFQ(MLFQConfig{Quanta: []int64{4}})
+	r := a.Simulate(a.Title, []Process{{ProcessID: 1, BurstDuration: 4, IOInterval: 2, IODuration: 3}})
+	want := []TimeSlice{{PID: 1, Stop: 2, Queue: "level 0"}, {Idle: true, Start: 2, Stop: 5}, {PID: 1, Start: 5, Stop: 7, Queue: "level 0"}}
+	if !reflect.DeepEqual(r.Gantt, want) {
+		t.Errorf("gantt = %+v, want %+v", r.Gantt, want)
+	}
+	if row := r.Processes[0]; row.Turnaround != 7 || row.Wait != 0 {
+		t.Errorf("turnaround %d, wait %d, want 7 and 0", row.Turnaround, row.Wait)
+	}
+}
+
+func TestParseMLFQ(t *testing.T) {
+	t.Parallel()
+	got, err := ParseMLFQ("10, 20,40:100")
+	if want := (MLFQConfig{Quanta: []int64{10, 20, 40}, Boost: 100}); err != nil || !reflect.DeepEqual(got, want) {
+		t.Fatalf("ParseMLFQ() = %+v, %v, want %+v", got, err, want)
+	}
+	if s := got.String(); s != "10,20,40:100" {
+		t.Errorf("String() = %q", s)
+	}
+	if a, _ := MLFQ(MLFQConfig{Quanta: []int64{8}}); a.Name != "mlfq-8" {
+		t.Errorf("Name = %q, want mlfq-8", a.Name)
+	}
+	for _, bad := range []string{"", "10,x", "10:x", "0,10", "10:-1"} {
+		if _, err := ParseMLFQ(bad); !errors.Is(err, ErrInvalidMLFQ) {
+			t.Errorf("ParseMLFQ(%q) error = %v, want ErrInvalidMLFQ", bad, err)
+		}
+	}
+}
@@ -0,0 +1,280 @@
+package scheduler
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidMLQ is wrapped by every error in a multilevel queue's configuration.
+var ErrInvalidMLQ = fmt.Errorf("%w: invalid multilevel queue", ErrInvalidArgs)
+
+// MLQueue is one queue of a multilevel queue scheduler. Priorities are the comma-separated
+// values and ranges, such as "0-9" or "30-", of the Priority column the queue takes; empty takes
+// every priority. Policy is rr or fcfs. Weight is the queue's round-robin quantum in multiples of
+// DefaultQuantum, so a heavier queue keeps each process longer before rotating; 0 means 1, and
+// fcfs queues ignore it.
+type MLQueue struct {
+	Name       string
+	Priorities string
+	Policy     string
+	Weight     int64
+}
+
+// DefaultMLQueues partition the Priority column into system, interactive and batch queues.
+var DefaultMLQueues = []MLQueue{
+	{Name: "system", Priorities: "0-9", Policy: "fcfs"},
+	{Name: "interactive", Priorities: "10-29", Policy: "rr", Weight: 1},
+	{Name: "batch", Priorities: "30-", Policy: "fcfs"},
+}
+
+// mlqQueue is a checked MLQueue.
+type mlqQueue struct {
+	name       string
+	priorities ranges
+	quantum    int64 // 0 for fcfs
+}
+
+// MLQ simulates multilevel queue scheduling of processes with DefaultMLQueues.
+func MLQ(title string, processes []Process, opts ...Option) Result {
+	queues, _ := newMLQueues(DefaultMLQueues) // valid unless changed, when processes no queue takes are rejected
+
+	return mlq(title, processes, newOptions(opts), queues, 0)
+}
+
+// PriorityRR simulates round-robin within priority levels, the priority scheduling of real
+// kernels: every distinct Priority is a level with its own round-robin queue of DefaultQuantum,
+// the highest non-empty level (the lowest number) always runs, and an arrival at a higher level
+// preempts a lower one at once. Each Gantt slice's Queue is its level, such as "p2",
+// short enough for the text chart's cells.
+func PriorityRR(title string, processes []Process, opts ...Option) Result {
+	queues, byPriority := priorityLevels(processes)
+
+	return mlq(title, byPriority, newOptions(opts), queues, 0)
+}
+
+// ErrInvalidAging is wrapped by every error in an aging priority scheduler's configuration.
+var ErrInvalidAging = fmt.Errorf("%w: invalid aging", ErrInvalidArgs)
+
+// Promotion is how many times the aging of a priority scheduler moved a process up a level.
+type Promotion struct {
+	ID    int64 `json:"id"`
+	Count int   `json:"count"`
+}
+
+// AgingPriorityRR is PriorityRR without starvation: a process that has waited after ticks at a
+// level, since it joined the level or last ran, is promoted to the back of the level above, where
+// it stays until it completes. No process so waits more than after ticks at any level below the
+// top. Its Result counts each process's promotions. Its name is aging-<after>, such as aging-20.
+func AgingPriorityRR(after int64) (Algorithm, error) {
+	if after <= 0 {
+		return Algorithm{}, fmt.Errorf("%w: the promotion timer must be positive, got %d", ErrInvalidAging, after)
+	}
+	name := strconv.FormatInt(after, 10)
+
+	return Algorithm{
+		Name:    "aging-" + name,
+		Version: "1",
+		Title:   "Round-robin within priority levels with aging (" + name + ")",
+		Simulate: func(title string, processes []Process, opts ...Option) Result {
+			queues, byPriority := priorityLevels(processes)
+			return mlq(title, byPriority, newOptions(opts), queues, after)
+		},
+	}, nil
+}
+
+// priorityLevels are the round-robin queues of PriorityRR, one for every distinct Priority of
+// processes, highest first, and processes with their Queue cleared to place them by priority.
+func priorityLevels(processes []Process) ([]mlqQueue, []Process) {
+	var levels []int64
+	seen := map[int64]bool{}
+	for _, p := range processes {
+		if !seen[p.Priority] {
+			seen[p.Priority] = true
+			levels = append(levels, p.Priority)
+		}
+	}
+	sort.Slice(levels, func(i, j int) bool { return levels[i] < levels[j] })
+	queues := make([]mlqQueue, len(levels))
+	for i, l := range levels {
+		queues[i] = mlqQueue{name: "p" + strconv.FormatInt(l, 10), priorities: ranges{{l, l}}, quantum: DefaultQuantum}
+	}
+	// Levels are by priority alone, so a process's Queue must not place it elsewhere.
+	byPriority := make([]Process, len(processes))
+	for i, p := range processes {
+		byPriority[i] = p
+		byPriority[i].Queue = ""
+	}
+
+	return queues, byPriority
+}
+
+// MultilevelQueue is multilevel queue scheduling with the given queues, highest priority first.
+// Each process belongs to the queue named by its Queue or, without one, to the first queue taking
+// its priority; a process no queue takes is rejected. Queues are served by strict priority: a
+// queue runs only while every queue above it is empty, and an arrival in a higher queue preempts
+// it at once. Within a queue, processes run in arrival order, rotated every quantum by rr.
+func MultilevelQueue(name string, queues []MLQueue) (Algorithm, error) {
+	checked, err := newMLQueues(queues)
+	if err != nil {
+		return Algorithm{}, err
+	}
+	names := make([]string, len(queues))
+	for i, q := range queues {
+		names[i] = q.Name
+	}
+
+	return Algorithm{
+		Name:    name,
+		Version: "1",
+		Title:   "Multilevel queue (" + strings.Join(names, ", ") + ")",
+		Simulate: func(title string, processes []Process, opts ...Option) Result {
+			return mlq(title, processes, newOptions(opts), checked, 0)
+		},
+	}, nil
+}
+
+func newMLQueues(queues []MLQueue) ([]mlqQueue, error) {
+	if len(queues) == 0 {
+		return nil, fmt.Errorf("%w: no queues", ErrInvalidMLQ)
+	}
+	checked := make([]mlqQueue, len(queues))
+	for i, q := range queues {
+		fail := func(format string, args ...any) error {
+			return fmt.Errorf("%w: queue %q: %s", ErrInvalidMLQ, q.Name, fmt.Sprintf(format, args...))
+		}
+		priorities, err := parseRanges(q.Priorities)
+		if err != nil {
+			return nil, fail("priorities: %v", err)
+		}
+		checked[i] = mlqQueue{name: q.Name, priorities: priorities}
+		switch {
+		case q.Name == "":
+			return nil, fmt.Errorf("%w: queue %d has no name", ErrInvalidMLQ, i)
+		case q.Weight < 0:
+			return nil, fail("weight must not be negative, got %d", q.Weight)
+		case q.Policy == "rr":
+			checked[i].quantum = DefaultQuantum
+			if q.Weight > 0 {
+				checked[i].quantum *= q.Weight
+			}
+		case q.Policy != "fcfs":
+			return nil, fail("unknown policy %q (known: fcfs, rr)", q.Policy)
+		}
+		for _, prev := range queues[:i] {
+			if prev.Name == q.Name {
+				return nil, fail("named twice")
+			}
+		}
+	}
+
+	return checked, nil
+}
+
+// queueOf is the queue process p belongs to, or -1.
+func queueOf(queues []mlqQueue, p Process) int {
+	for i, q := range queues {
+		if p.Queue != "" && q.name == p.Queue || p.Queue == "" && q.priorities.contains(p.Priority) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// mlq simulates multilevel queue scheduling like the hierarchy: the clock jumps between
+// arrivals, completions and quantum boundaries, at each of which the first non-empty queue's
+// head runs. Every Gantt slice names the queue that served it. With promoteAfter set, a process
+// waiting that long in a queue moves up to the one above, and the clock stops for that too.
+func mlq(title string, processes []Process, o options, queues []mlqQueue, promoteAfter int64) Result {
+	var (
+		e          = newQuantumEngine(title, processes, o)
+		t          = e.t
+		queue      = make([]int, t.len())
+		fifos      = make([][]int, len(queues))
+		sliceUsed  = make([]int64, t.len()) // rr time used of the current quantum
+		waiting    = make([]int64, t.len()) // when each process joined its queue or last ran
+		promotions []Promotion
+	)
+	for i := range processes {
+		queue[i] = queueOf(queues, processes[i])
+	}
+	if promoteAfter > 0 {
+		promotions = make([]Promotion, t.len())
+		for i, p := range processes {
+			promotions[i].ID = p.ProcessID
+		}
+	}
+	// promote moves every process that has waited promoteAfter in a queue to the one above.
+	promote := func() {
+		for q := 1; q < len(fifos); q++ {
+			kept := fifos[q][:0]
+			for _, p := range fifos[q] {
+				if e.now-waiting[p] < promoteAfter {
+					kept = append(kept, p)
+					continue
+				}
+				queue[p], waiting[p], sliceUsed[p] = q-1, e.now, 0
+				fifos[q-1] = append(fifos[q-1], p)
+				promotions[p].Count++
+			}
+			fifos[q] = kept
+		}
+	}
+	policy := quantumPolicy{
+		rejects: func(p int) bool { return queue[p] < 0 },
+		admit: func(p int) {
+			fifos[queue[p]] = append(fifos[queue[p]], p)
+			waiting[p] = t.arrival[p]
+		},
+		pick: func() (int, int64, string) {
+			if promoteAfter > 0 {
+				promote()
+			}
+			q := 0
+			for len(fifos[q]) == 0 {
+				q++
+			}
+			p := fifos[q][0]
+			if quantum := queues[q].quantum; quantum > 0 {
+				return p, quantum - sliceUsed[p], queues[q].name
+			}
+			return p, t.remaining[p], queues[q].name
+		},
+		ran: func(p int, run int64) {
+			sliceUsed[p] += run
+			waiting[p] = e.now
+			if q := queue[p]; t.remaining[p] > 0 && queues[q].quantum > 0 && sliceUsed[p] >= queues[q].quantum {
+				sliceUsed[p] = 0
+				fifos[q] = rotate(fifos[q], p)
+			}
+		},
+		done: func(p int) { fifos[queue[p]], _ = without(fifos[queue[p]], p) },
+		// An arrival in a higher queue preempts.
+		preemptOnArrival: true,
+	}
+	if promoteAfter > 0 {
+		// So may a promotion: the clock stops when the next waiting process other than the
+		// running one is due one.
+		policy.wake = func(running int) (int64, bool) {
+			var (
+				due int64
+				ok  bool
+			)
+			for q := 1; q < len(fifos); q++ {
+				for _, w := range fifos[q] {
+					if w != running && (!ok || waiting[w]+promoteAfter < due) {
+						due, ok = waiting[w]+promoteAfter, true
+					}
+				}
+			}
+			return due, ok
+		}
+	}
+
+	r := e.run(policy)
+	r.Promotions = promotions
+
+	return r
+}
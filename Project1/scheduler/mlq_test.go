@@ -0,0 +1,128 @@
+package scheduler
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// mlqProcesses has one process in each of the batch and system queues of DefaultMLQueues, and
+// two interactive ones.
+var mlqProcesses = []Process{
+	{ProcessID: 1, BurstDuration: 6, Priority: 40},
+	{ProcessID: 2, ArrivalTime: 1, BurstDuration: 3, Priority: 15},
+	{ProcessID: 3, ArrivalTime: 1, BurstDuration: 2, Priority: 20},
+	{ProcessID: 4, ArrivalTime: 3, BurstDuration: 1, Priority: 5},
+}
+
+func TestMLQ(t *testing.T) {
+	t.Parallel()
+	got := MLQ("mlq", mlqProcesses)
+	// The interactive processes preempt P1 and take turns each quantum, P4 takes the CPU the
+	// moment it arrives, and P1 only runs again once the higher queues are empty.
+	want := []TimeSlice{
+		{PID: 1, Stop: 1, Queue: "batch"},
+		{PID: 2, Start: 1, Stop: 3, Queue: "interactive"},
+		{PID: 4, Start: 3, Stop: 4, Queue: "system"},
+		{PID: 3, Start: 4, Stop: 6, Queue: "interactive"},
+		{PID: 2, Start: 6, Stop: 7, Queue: "interactive"},
+		{PID: 1, Start: 7, Stop: 12, Queue: "batch"},
+	}
+	if !reflect.DeepEqual(got.Gantt, want) {
+		t.Errorf("MLQ() gantt = %+v, want %+v", got.Gantt, want)
+	}
+	if want := map[int64]int64{1: 12, 2: 7, 3: 6, 4: 4}; !reflect.DeepEqual(exits(got), want) {
+		t.Errorf("MLQ() exits = %v, want %v", exits(got), want)
+	}
+}
+
+func TestPriorityRR(t *testing.T) {
+	t.Parallel()
+	got := PriorityRR("priority-rr", []Process{
+		{ProcessID: 1, BurstDuration: 3, Priority: 1, Queue: "batch"}, // the level is by priority alone
+		{ProcessID: 2, BurstDuration: 3, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 1, BurstDuration: 1},
+		{ProcessID: 4, BurstDuration: 2, Priority: 2},
+	})
+	// P3 preempts P1 on arrival, P1 and P2 then take turns each quantum, and P4 runs last.
+	want := []TimeSlice{
+		{PID: 1, Stop: 1, Queue: "p1"},
+		{PID: 3, Start: 1, Stop: 2, Queue: "p0"},
+		{PID: 1, Start: 2, Stop: 3, Queue: "p1"},
+		{PID: 2, Start: 3, Stop: 5, Queue: "p1"},
+		{PID: 1, Start: 5, Stop: 6, Queue: "p1"},
+		{PID: 2, Start: 6, Stop: 7, Queue: "p1"},
+		{PID: 4, Start: 7, Stop: 9, Queue: "p2"},
+	}
+	if !reflect.DeepEqual(got.Gantt, want) {
+		t.Errorf("PriorityRR() gantt = %+v, want %+v", got.Gantt, want)
+	}
+}
+
+func TestAgingPriorityRR(t *testing.T) {
+	t.Parallel()
+	a, err := AgingPriorityRR(5)
+	if err != nil || a.Name != "aging-5" {
+		t.Fatalf("AgingPriorityRR(5) = %q, %v", a.Name, err)
+	}
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 10},
+		{ProcessID: 2, BurstDuration: 10},
+		{ProcessID: 3, BurstDuration: 2, Priority: 1},
+	}
+	// Under PriorityRR P3 waits for both others; here it is promoted behind them at 5 and runs
+	// after a turn each.
+	if got := exits(PriorityRR("priority-rr", processes)); got[3] != 22 {
+		t.Errorf("PriorityRR() exits = %v, want P3 last at 22", got)
+	}
+	r := a.Simulate(a.Title, processes)
+	if want := map[int64]int64{1: 20, 2: 22, 3: 10}; !reflect.DeepEqual(exits(r), want) {
+		t.Errorf("AgingPriorityRR() exits = %v, want %v", exits(r), want)
+	}
+	if want := []Promotion{{ID: 1}, {ID: 2}, {ID: 3, Count: 1}}; !reflect.DeepEqual(r.Promotions, want) {
+		t.Errorf("AgingPriorityRR() promotions = %+v, want %+v", r.Promotions, want)
+	}
+	if _, err := AgingPriorityRR(0); !errors.Is(err, ErrInvalidAging) {
+		t.Errorf("AgingPriorityRR(0) error = %v, want %v", err, ErrInvalidAging)
+	}
+}
+
+func TestMultilevelQueue(t *testing.T) {
+	t.Parallel()
+	a, err := MultilevelQueue("two", []MLQueue{
+		{Name: "fast", Priorities: "0-19", Policy: "rr", Weight: 2},
+		{Name: "slow", Policy: "fcfs"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Title != "Multilevel queue (fast, slow)" {
+		t.Errorf("Title = %q", a.Title)
+	}
+	processes := append([]Process(nil), mlqProcesses...)
+	processes[3].Queue = "slow" // P4 would be fast by its priority
+	// With a quantum of 4 P2 runs to completion, and P4 waits behind P1 and P3.
+	if got, want := exits(a.Simulate(a.Title, processes)), map[int64]int64{1: 9, 2: 4, 3: 11, 4: 12}; !reflect.DeepEqual(got, want) {
+		t.Errorf("exits = %v, want %v", got, want)
+	}
+
+	processes[3].Queue = "missing"
+	for _, p := range a.Simulate(a.Title, processes).Processes {
+		if p.ID == 4 && p.Status != StatusRejected {
+			t.Errorf("process in an unknown queue = %+v, want rejected", p)
+		}
+	}
+
+	for name, queues := range map[string][]MLQueue{
+		"none":        nil,
+		"unnamed":     {{Policy: "rr"}},
+		"bad policy":  {{Name: "a", Policy: "sjf"}},
+		"bad range":   {{Name: "a", Priorities: "9-1", Policy: "rr"}},
+		"negative":    {{Name: "a", Policy: "rr", Weight: -1}},
+		"named twice": {{Name: "a", Policy: "rr"}, {Name: "a", Policy: "fcfs"}},
+	} {
+		if _, err := MultilevelQueue("bad", queues); !errors.Is(err, ErrInvalidMLQ) {
+			t.Errorf("%s: error = %v, want ErrInvalidMLQ", name, err)
+		}
+	}
+}
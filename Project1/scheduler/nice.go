@@ -0,0 +1,43 @@
+package scheduler
+
+// niceToWeight is the Linux kernel's sched_prio_to_weight table: the load weight of each nice
+// value from -20 to 19, 1024 at nice 0 and about 1.25 times more per step down.
+var niceToWeight = [40]int64{
+	/* -20 */ 88761, 71755, 56483, 46273, 36291,
+	/* -15 */ 29154, 23254, 18705, 14949, 11916,
+	/* -10 */ 9548, 7620, 6100, 4904, 3906,
+	/*  -5 */ 3121, 2501, 1991, 1586, 1277,
+	/*   0 */ 1024, 820, 655, 526, 423,
+	/*   5 */ 335, 272, 215, 172, 137,
+	/*  10 */ 110, 87, 70, 56, 45,
+	/*  15 */ 36, 29, 23, 18, 15,
+}
+
+// NiceWeight is the process's load weight for Nice: its Nice value, clamped to -20 to 19, looked
+// up in the kernel's prio_to_weight table.
+func (p Process) NiceWeight() int64 {
+	nice := p.Nice
+	switch {
+	case nice < -20:
+		nice = -20
+	case nice > 19:
+		nice = 19
+	}
+
+	return niceToWeight[nice+20]
+}
+
+// Nice simulates proportional-share scheduling of processes by their Linux nice values: each
+// ready process is entitled to the share of the CPU its NiceWeight is of all the ready processes'
+// weights, and every DefaultQuantum the one that has used the least CPU for its entitlement runs,
+// a process just arrived first and the earliest arrival on a tie. Its Result reports each
+// process's nice value and weight with the share it was entitled to and the share it achieved.
+func Nice(title string, processes []Process, opts ...Option) Result {
+	r := proportionalShare(title, processes, newOptions(opts), Process.NiceWeight, leastServed, nil)
+	r.Tickets.Nice = true
+	for i := range r.Tickets.Shares {
+		r.Tickets.Shares[i].Nice = processes[i].Nice
+	}
+
+	return r
+}
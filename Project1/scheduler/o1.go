@@ -0,0 +1,113 @@
+package scheduler
+
+import "strconv"
+
+// o1Levels is the number of static priorities of the O(1) scheduler's user range, nice -20 to 19.
+const o1Levels = 40
+
+// o1Nice is a process's nice value for O1: its Priority, clamped to -20 to 19.
+func o1Nice(p Process) int64 {
+	switch {
+	case p.Priority < -20:
+		return -20
+	case p.Priority > 19:
+		return 19
+	default:
+		return p.Priority
+	}
+}
+
+// o1Timeslice is the timeslice of the O(1) scheduler for a nice value, scaled so nice 0's 100ms
+// is DefaultQuantum: (140-static)·20ms below static priority 120 and (140-static)·5ms from it,
+// at least 1.
+func o1Timeslice(nice int64) int64 {
+	static := 120 + nice
+	ms := (140 - static) * 5
+	if static < 120 {
+		ms = (140 - static) * 20
+	}
+	if ts := (ms*DefaultQuantum + 50) / 100; ts > 1 {
+		return ts
+	}
+
+	return 1
+}
+
+// o1Array is one of the O(1) scheduler's two priority arrays: a FIFO per static priority.
+type o1Array [o1Levels][]int
+
+// head is the highest non-empty level of the array, or -1.
+func (a *o1Array) head() int {
+	for l := range a {
+		if len(a[l]) > 0 {
+			return l
+		}
+	}
+
+	return -1
+}
+
+// O1 emulates the Linux 2.6 O(1) scheduler: every process's nice value is its Priority, clamped
+// to -20 to 19, and its static priority 120 plus that. The active array runs its highest level's
+// head, round-robin within a level, and an arrival at a higher level preempts it. A process that
+// uses up its timeslice (longer the lower its nice value, see o1Timeslice) moves to the expired
+// array with a fresh one, and when the active array empties the two arrays swap, so no process
+// starves behind higher priorities for longer than one pass. Processes in the simulator never
+// sleep, so the interactivity bonus of the real scheduler, earned by sleeping, is always 0 and
+// the dynamic priority is the static one. Each Gantt slice's Queue is its static priority, such
+// as "p120".
+func O1(title string, processes []Process, opts ...Option) Result {
+	return o1(title, processes, newOptions(opts))
+}
+
+func o1(title string, processes []Process, o options) Result {
+	var (
+		e         = newQuantumEngine(title, processes, o)
+		t         = e.t
+		level     = make([]int, t.len())
+		timeslice = make([]int64, t.len()) // time left of the current timeslice
+		active    = new(o1Array)
+		expired   = new(o1Array)
+	)
+	for i, p := range processes {
+		level[i] = int(o1Nice(p) + 20)
+	}
+
+	return e.run(quantumPolicy{
+		admit: func(p int) {
+			timeslice[p] = o1Timeslice(int64(level[p]) - 20)
+			active[level[p]] = append(active[level[p]], p)
+		},
+		pick: func() (int, int64, string) {
+			l := active.head()
+			if l < 0 {
+				// Every ready process has used its timeslice: the expired array becomes the active one.
+				active, expired = expired, active
+				l = active.head()
+			}
+			p := active[l][0]
+			return p, timeslice[p], "p" + strconv.Itoa(100+l)
+		},
+		ran: func(p int, run int64) {
+			if timeslice[p] -= run; timeslice[p] > 0 || t.remaining[p] == 0 {
+				return
+			}
+			// The timeslice is used up: wait in the expired array with a fresh one.
+			l := level[p]
+			timeslice[p] = o1Timeslice(int64(l) - 20)
+			if queue, ok := without(active[l], p); ok {
+				active[l], expired[l] = queue, append(expired[l], p)
+			}
+		},
+		done: func(p int) {
+			l := level[p]
+			if queue, ok := without(active[l], p); ok {
+				active[l] = queue
+				return
+			}
+			expired[l], _ = without(expired[l], p)
+		},
+		// An arrival at a higher level preempts.
+		preemptOnArrival: true,
+	})
+}
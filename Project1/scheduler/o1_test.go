@@ -0,0 +1,44 @@
+package scheduler
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_o1Timeslice(t *testing.T) {
+	t.Parallel()
+	for nice, want := range map[int64]int64{-20: 16, -5: 10, 0: DefaultQuantum, 10: 1, 19: 1} {
+		if got := o1Timeslice(nice); got != want {
+			t.Errorf("o1Timeslice(%d) = %d, want %d", nice, got, want)
+		}
+	}
+}
+
+func TestO1(t *testing.T) {
+	t.Parallel()
+	r := O1("o1", []Process{
+		{ProcessID: 1, BurstDuration: 40, Priority: -20},
+		{ProcessID: 2, BurstDuration: 2, Priority: 19},
+	})
+	// P1 outranks P2 but, after each timeslice of 16, waits in the expired array until P2 has had
+	// its timeslice of 1 too.
+	want := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 16, Queue: "p100"},
+		{PID: 2, Start: 16, Stop: 17, Queue: "p139"},
+		{PID: 1, Start: 17, Stop: 33, Queue: "p100"},
+		{PID: 2, Start: 33, Stop: 34, Queue: "p139"},
+		{PID: 1, Start: 34, Stop: 42, Queue: "p100"},
+	}
+	if !reflect.DeepEqual(r.Gantt, want) {
+		t.Errorf("O1() gantt = %+v, want %+v", r.Gantt, want)
+	}
+
+	// An arrival at a higher level preempts at once.
+	r = O1("o1", []Process{
+		{ProcessID: 1, BurstDuration: 5},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 1, Priority: -5},
+	})
+	if want := map[int64]int64{1: 6, 2: 2}; !reflect.DeepEqual(exits(r), want) {
+		t.Errorf("O1() exits = %v, want %v", exits(r), want)
+	}
+}
@@ -0,0 +1,86 @@
+package scheduler
+
+import "io"
+
+// Option configures a scheduler run.
+type Option func(*options)
+
+type options struct {
+	progress     io.Writer
+	timeUnit     TimeUnit
+	sink         Sink
+	compactGantt bool
+	hooks        hookList
+	reservations []Reservation
+	preemption   preemptionControls
+	seed         int64
+	tieBreak     TieBreak
+}
+
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// WithProgress writes periodic progress lines (simulated time, completed processes, ETA) to w.
+func WithProgress(w io.Writer) Option {
+	return func(o *options) {
+		o.progress = w
+	}
+}
+
+// WithSink streams completed-process rows and Gantt slices to sink as they are produced
+// instead of buffering them in the Result.
+func WithSink(sink Sink) Option {
+	return func(o *options) {
+		o.sink = sink
+	}
+}
+
+// WithCompactGantt merges consecutive Gantt slices of the same process on the same CPU.
+func WithCompactGantt() Option {
+	return func(o *options) {
+		o.compactGantt = true
+	}
+}
+
+// WithTimeUnit labels output times and throughput with the given unit.
+func WithTimeUnit(u TimeUnit) Option {
+	return func(o *options) {
+		o.timeUnit = u
+	}
+}
+
+// WithHooks calls the hooks in h as scheduling events happen. It may be given more than once;
+// the hooks are then called in the order they were added.
+func WithHooks(h Hooks) Option {
+	return func(o *options) {
+		o.hooks = append(o.hooks, h)
+	}
+}
+
+// WithClock advances c to the time of every event in the run. The clock moves before any hooks
+// are called, whatever order the options are given in.
+func WithClock(c Clock) Option {
+	return func(o *options) {
+		o.hooks = append(hookList{{
+			OnArrival:  func(now int64, _ Process) { c.AdvanceTo(now) },
+			OnDispatch: func(now int64, _ Process, _ int) { c.AdvanceTo(now) },
+			OnPreempt:  func(now int64, _ Process, _ int, _ int64) { c.AdvanceTo(now) },
+			OnComplete: func(now int64, _ ProcessResult) { c.AdvanceTo(now) },
+			OnIdle:     func(now, _ int64) { c.AdvanceTo(now) },
+		}}, o.hooks...)
+	}
+}
+
+// withoutOutput drops the progress, sink and hooks set by earlier options, for a run whose
+// result is only used internally.
+func withoutOutput() Option {
+	return func(o *options) {
+		o.progress, o.sink, o.hooks = nil, nil, nil
+	}
+}
@@ -0,0 +1,288 @@
+package scheduler
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// OverloadPolicy is what Overload does with work that arrives while the system is overloaded.
+type OverloadPolicy string
+
+const (
+	// OverloadReject turns away each process that would push the load over the threshold.
+	OverloadReject OverloadPolicy = "reject"
+	// OverloadDefer holds each such process back until the load has drained enough to take it,
+	// in arrival order, so it runs late rather than not at all.
+	OverloadDefer OverloadPolicy = "defer"
+	// OverloadShed makes room by dropping the lowest-priority work that has not started,
+	// which may be the arriving process itself.
+	OverloadShed OverloadPolicy = "shed"
+)
+
+// OverloadPolicies are the overload policies, in the order they are documented.
+var OverloadPolicies = []OverloadPolicy{OverloadReject, OverloadDefer, OverloadShed}
+
+var ErrUnknownOverloadPolicy = fmt.Errorf("%w: unknown overload policy", ErrInvalidArgs)
+
+// ParseOverloadPolicy parses an overload policy name.
+func ParseOverloadPolicy(s string) (OverloadPolicy, error) {
+	for _, p := range OverloadPolicies {
+		if string(p) == s {
+			return p, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: %q (known: reject, defer, shed)", ErrUnknownOverloadPolicy, s)
+}
+
+// OverloadReport is what Overload did to a run's workload: the processes it rejected, deferred
+// and shed, by PID in the order it acted on them, and how long the deferred ones were held back.
+type OverloadReport struct {
+	Policy    OverloadPolicy `json:"policy"`
+	Threshold int64          `json:"threshold"`
+	Rejected  []int64        `json:"rejected,omitempty"`
+	Deferred  []int64        `json:"deferred,omitempty"`
+	Shed      []int64        `json:"shed,omitempty"`
+	// DeferredDelay is the total time deferred processes were held back.
+	DeferredDelay int64 `json:"deferred_delay,omitempty"`
+	// PeakLoad is the highest load the admitted work reached.
+	PeakLoad int64 `json:"peak_load"`
+}
+
+// Overload applies policy whenever the offered load would exceed threshold. The load is the
+// work still queued in the system: the bursts admitted so far, less what a single CPU that is
+// never idle while work waits would have served by then. It is measured as each process
+// arrives, before the wrapped scheduler sees it, so every scheduler faces the same admitted
+// workload. Rejected and shed processes leave the system with StatusRejected and StatusShed rows
+// after the scheduled ones and are left out of the averages; deferred ones keep their arrival
+// time, so the time they were held back counts as waiting. The Result's Overload reports each.
+func Overload(policy OverloadPolicy, threshold int64) Middleware {
+	return func(next SimulateFunc) SimulateFunc {
+		return func(title string, processes []Process, opts ...Option) Result {
+			a := newOverloadAdmission(policy, threshold)
+			admitted := a.admit(processes)
+
+			r := rewriteRows(next, title, admitted, opts, func(r ProcessResult) ProcessResult {
+				if d := a.delay[r.ID]; d > 0 {
+					r.Arrival -= d
+					r.Wait += d
+					r.Turnaround += d
+					r.Response += d
+				}
+				return r
+			}, func(emit func(ProcessResult)) {
+				for _, left := range a.left {
+					emit(left)
+				}
+			})
+			r.Overload = &a.report
+
+			return r
+		}
+	}
+}
+
+// overloadAdmission decides which processes to admit, and when, by tracking the queued work
+// of a single work-conserving CPU serving admitted processes in admission order.
+type overloadAdmission struct {
+	policy    OverloadPolicy
+	threshold int64
+	now       int64
+	queue     []queuedWork // admitted work not yet served, the head first
+	held      []Process    // deferred processes waiting to be admitted, in arrival order
+	delay     map[int64]int64
+	left      []ProcessResult // rows of the rejected and shed processes
+	report    OverloadReport
+}
+
+type queuedWork struct {
+	process   Process
+	remaining int64
+}
+
+func newOverloadAdmission(policy OverloadPolicy, threshold int64) *overloadAdmission {
+	return &overloadAdmission{
+		policy:    policy,
+		threshold: threshold,
+		delay:     make(map[int64]int64),
+		report:    OverloadReport{Policy: policy, Threshold: threshold},
+	}
+}
+
+// admit returns the processes to schedule, deferred ones with their admission time as arrival.
+func (a *overloadAdmission) admit(processes []Process) []Process {
+	ordered := append([]Process(nil), processes...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if ordered[i].ArrivalTime != ordered[j].ArrivalTime {
+			return ordered[i].ArrivalTime < ordered[j].ArrivalTime
+		}
+		return ordered[i].ProcessID < ordered[j].ProcessID
+	})
+
+	var admitted []Process
+	for _, p := range ordered {
+		admitted = append(admitted, a.release(p.ArrivalTime)...)
+		a.advance(p.ArrivalTime)
+		switch {
+		case a.policy == OverloadDefer && (len(a.held) > 0 || a.overloaded(p)):
+			// Later arrivals queue behind those already held back.
+			a.held = append(a.held, p)
+			a.report.Deferred = append(a.report.Deferred, p.ProcessID)
+		case !a.overloaded(p):
+			a.enqueue(p)
+			admitted = append(admitted, p)
+		case a.policy == OverloadShed:
+			if a.shed(p) {
+				a.enqueue(p)
+				admitted = append(admitted, p)
+			}
+		default:
+			a.turnAway(p, StatusRejected)
+		}
+	}
+	admitted = append(admitted, a.release(math.MaxInt64)...)
+
+	// Shed processes were admitted before they were dropped.
+	kept := admitted[:0]
+	for _, p := range admitted {
+		if !a.isShed(p.ProcessID) {
+			kept = append(kept, p)
+		}
+	}
+
+	return kept
+}
+
+// load is the work queued at the current time.
+func (a *overloadAdmission) load() int64 {
+	var load int64
+	for _, w := range a.queue {
+		load += w.remaining
+	}
+
+	return load
+}
+
+func (a *overloadAdmission) overloaded(p Process) bool {
+	return a.load()+p.BurstDuration > a.threshold
+}
+
+func (a *overloadAdmission) enqueue(p Process) {
+	a.queue = append(a.queue, queuedWork{process: p, remaining: p.BurstDuration})
+	if load := a.load(); load > a.report.PeakLoad {
+		a.report.PeakLoad = load
+	}
+}
+
+// advance serves the queued work up to time t.
+func (a *overloadAdmission) advance(t int64) {
+	for len(a.queue) > 0 && a.now < t {
+		served := minimum(a.queue[0].remaining, t-a.now)
+		a.now += served
+		if a.queue[0].remaining -= served; a.queue[0].remaining == 0 {
+			a.queue = a.queue[1:]
+		}
+	}
+	if a.now < t {
+		a.now = t
+	}
+}
+
+// release admits the held processes that fit before time until, each as soon as the load has
+// drained enough to take it (or completely, for one too large to ever fit).
+func (a *overloadAdmission) release(until int64) []Process {
+	var released []Process
+	for len(a.held) > 0 {
+		p := a.held[0]
+		room := a.threshold - p.BurstDuration
+		if room < 0 {
+			room = 0
+		}
+		at := a.now
+		if load := a.load(); load > room {
+			at += load - room
+		}
+		if at > until {
+			break
+		}
+		a.advance(at)
+		a.held = a.held[1:]
+		a.delay[p.ProcessID] = at - p.ArrivalTime
+		a.report.DeferredDelay += at - p.ArrivalTime
+		p.ArrivalTime = at
+		a.enqueue(p)
+		released = append(released, p)
+	}
+
+	return released
+}
+
+// shed drops the lowest-priority work that has not started until p fits, reporting whether p
+// was admitted. Work is lowest priority by the largest priority value, then latest arrival, then
+// highest PID; the process at the head of the queue has started and is never shed. When
+// dropping everything of lower priority than p would not make room, p itself is shed instead.
+func (a *overloadAdmission) shed(p Process) bool {
+	var lower int64
+	for i := 1; i < len(a.queue); i++ {
+		if lowerPriority(a.queue[i].process, p) {
+			lower += a.queue[i].remaining
+		}
+	}
+	if a.load()-lower+p.BurstDuration > a.threshold {
+		a.turnAway(p, StatusShed)
+		return false
+	}
+	for a.overloaded(p) {
+		victim := -1
+		for i := 1; i < len(a.queue); i++ {
+			if w := a.queue[i].process; lowerPriority(w, p) && (victim < 0 || lowerPriority(w, a.queue[victim].process)) {
+				victim = i
+			}
+		}
+		a.turnAway(a.queue[victim].process, StatusShed)
+		a.queue = append(a.queue[:victim:victim], a.queue[victim+1:]...)
+	}
+
+	return true
+}
+
+// lowerPriority reports whether a is to be shed before b.
+func lowerPriority(a, b Process) bool {
+	if a.Priority != b.Priority {
+		return a.Priority > b.Priority
+	}
+	if a.ArrivalTime != b.ArrivalTime {
+		return a.ArrivalTime > b.ArrivalTime
+	}
+
+	return a.ProcessID > b.ProcessID
+}
+
+// turnAway records p as leaving the system now with status.
+func (a *overloadAdmission) turnAway(p Process, status Status) {
+	switch status {
+	case StatusRejected:
+		a.report.Rejected = append(a.report.Rejected, p.ProcessID)
+	case StatusShed:
+		a.report.Shed = append(a.report.Shed, p.ProcessID)
+	}
+	a.left = append(a.left, ProcessResult{
+		ID:       p.ProcessID,
+		Priority: p.Priority,
+		Burst:    p.BurstDuration,
+		Arrival:  p.ArrivalTime,
+		Exit:     a.now,
+		Status:   status,
+	})
+}
+
+func (a *overloadAdmission) isShed(pid int64) bool {
+	for _, id := range a.report.Shed {
+		if id == pid {
+			return true
+		}
+	}
+
+	return false
+}
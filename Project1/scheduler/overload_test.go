@@ -0,0 +1,108 @@
+package scheduler
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestOverload(t *testing.T) {
+	t.Parallel()
+	shedProcesses := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4, Priority: 1},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 4, Priority: 3},
+		{ProcessID: 3, ArrivalTime: 1, BurstDuration: 4, Priority: 2},
+	}
+	tests := []struct {
+		name       string
+		policy     OverloadPolicy
+		threshold  int64
+		processes  []Process
+		want       []ProcessResult
+		wantReport OverloadReport
+	}{
+		{
+			name:   "under threshold",
+			policy: OverloadReject, threshold: 20, processes: exampleProcesses,
+			want:       FCFS("test", exampleProcesses).Processes,
+			wantReport: OverloadReport{Policy: OverloadReject, Threshold: 20, PeakLoad: 14},
+		},
+		{
+			// At 3, P1's 2 left plus P2's 9 exceed 10.
+			name:   "reject",
+			policy: OverloadReject, threshold: 10, processes: exampleProcesses,
+			want: []ProcessResult{
+				{ID: 1, Priority: 2, Burst: 5, Arrival: 0, Turnaround: 5, Exit: 5},
+				{ID: 3, Priority: 3, Burst: 6, Arrival: 6, Turnaround: 6, Exit: 12},
+				{ID: 2, Priority: 1, Burst: 9, Arrival: 3, Exit: 3, Status: StatusRejected},
+			},
+			wantReport: OverloadReport{Policy: OverloadReject, Threshold: 10, Rejected: []int64{2}, PeakLoad: 6},
+		},
+		{
+			// P2 is admitted at 4, once P1 has 1 left; P3 at 10, once P2 has 4 left.
+			name:   "defer",
+			policy: OverloadDefer, threshold: 10, processes: exampleProcesses,
+			want: []ProcessResult{
+				{ID: 1, Priority: 2, Burst: 5, Arrival: 0, Turnaround: 5, Exit: 5},
+				{ID: 2, Priority: 1, Burst: 9, Arrival: 3, Wait: 2, Turnaround: 11, Response: 2, Exit: 14},
+				{ID: 3, Priority: 3, Burst: 6, Arrival: 6, Wait: 8, Turnaround: 14, Response: 8, Exit: 20},
+			},
+			wantReport: OverloadReport{
+				Policy: OverloadDefer, Threshold: 10, Deferred: []int64{2, 3}, DeferredDelay: 5, PeakLoad: 10,
+			},
+		},
+		{
+			// At 1, P3 does not fit, but shedding the lower-priority P2 makes room.
+			name:   "shed",
+			policy: OverloadShed, threshold: 8, processes: shedProcesses,
+			want: []ProcessResult{
+				{ID: 1, Priority: 1, Burst: 4, Arrival: 0, Turnaround: 4, Exit: 4},
+				{ID: 3, Priority: 2, Burst: 4, Arrival: 1, Wait: 3, Turnaround: 7, Response: 3, Exit: 8},
+				{ID: 2, Priority: 3, Burst: 4, Arrival: 0, Exit: 1, Status: StatusShed},
+			},
+			wantReport: OverloadReport{Policy: OverloadShed, Threshold: 8, Shed: []int64{2}, PeakLoad: 8},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := Chain(FCFS, Overload(tt.policy, tt.threshold))("test", tt.processes)
+			if !reflect.DeepEqual(got.Processes, tt.want) {
+				t.Errorf("processes = %+v, want %+v", got.Processes, tt.want)
+			}
+			if got.Overload == nil || !reflect.DeepEqual(*got.Overload, tt.wantReport) {
+				t.Errorf("Overload = %+v, want %+v", got.Overload, tt.wantReport)
+			}
+		})
+	}
+}
+
+func TestOverloadShedsArriving(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 6, Priority: 3},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 2, Priority: 1},
+		{ProcessID: 3, ArrivalTime: 1, BurstDuration: 4, Priority: 2},
+	}
+	// At 1, P1 has started and P2 outranks P3, so P3 is shed rather than P2.
+	got := Chain(FCFS, Overload(OverloadShed, 8))("test", processes)
+	if want := []int64{3}; !reflect.DeepEqual(got.Overload.Shed, want) {
+		t.Errorf("Shed = %v, want %v", got.Overload.Shed, want)
+	}
+	if got.Incomplete != 1 || len(got.Processes) != 3 {
+		t.Errorf("Incomplete = %d with %d rows, want 1 of 3", got.Incomplete, len(got.Processes))
+	}
+}
+
+func TestParseOverloadPolicy(t *testing.T) {
+	t.Parallel()
+	for _, p := range OverloadPolicies {
+		if got, err := ParseOverloadPolicy(string(p)); err != nil || got != p {
+			t.Errorf("ParseOverloadPolicy(%q) = %q, %v", p, got, err)
+		}
+	}
+	if _, err := ParseOverloadPolicy("drop"); !errors.Is(err, ErrUnknownOverloadPolicy) {
+		t.Errorf("ParseOverloadPolicy(drop) error = %v", err)
+	}
+}
@@ -0,0 +1,504 @@
+package scheduler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidPolicy is wrapped by every error parsing a policy script.
+var ErrInvalidPolicy = fmt.Errorf("%w: invalid policy", ErrInvalidArgs)
+
+// Policy is a preemptive scheduling policy defined by a script rather than in Go. The script
+// assigns a key to each ready process and the process with the lowest key runs, re-evaluated
+// at every arrival and completion like SJF. Ties are broken by earliest arrival, then lowest PID.
+//
+// A script is a small key-expression language, not a general one: comments start with #,
+// and a single assignment
+//
+//	key = (priority, remaining)  # highest priority first, then shortest remaining time
+//
+// sets key to an integer expression, or a parenthesized tuple of them compared in order. An
+// expression may use the process's pid, arrival, burst, priority, remaining and ran (burst
+// run so far); integers; + - * / // % (integer division, with x/0 and x%0 taken as 0);
+// comparisons, and, or and not (true is 1 and false 0); min, max and abs; and the conditional
+// a if cond else b. Keys must be fixed while a process waits, so there is no clock variable.
+type Policy struct {
+	Name   string
+	source string
+	keys   []policyExpr
+}
+
+// policyExpr evaluates an expression for process i of the table.
+type policyExpr func(t *processTable, i int) int64
+
+// ParsePolicy parses the policy script src, naming the policy name.
+func ParsePolicy(name, src string) (*Policy, error) {
+	var (
+		stmt  strings.Builder
+		start int // line the statement starts on
+		keys  []policyExpr
+	)
+	lines := strings.Split(src, "\n")
+	for n, line := range lines {
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		if strings.TrimSpace(line) == "" && stmt.Len() == 0 {
+			continue
+		}
+		if stmt.Len() == 0 {
+			start = n + 1
+		}
+		stmt.WriteString(line)
+		stmt.WriteByte(' ')
+		// A statement continues onto the next line while its parentheses are open.
+		if depth := strings.Count(stmt.String(), "(") - strings.Count(stmt.String(), ")"); depth > 0 && n < len(lines)-1 {
+			continue
+		}
+		if keys != nil {
+			return nil, fmt.Errorf("%w: line %d: only one statement, key = ..., is allowed", ErrInvalidPolicy, start)
+		}
+		var err error
+		if keys, err = parsePolicyStatement(stmt.String()); err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidPolicy, start, err)
+		}
+		stmt.Reset()
+	}
+	if keys == nil {
+		return nil, fmt.Errorf("%w: no key = ... statement", ErrInvalidPolicy)
+	}
+
+	return &Policy{Name: name, source: src, keys: keys}, nil
+}
+
+// Algorithm runs the policy through the preemptive engine. Its version is a hash of the
+// script, so provenance changes whenever the script does.
+func (p *Policy) Algorithm() Algorithm {
+	sum := sha256.Sum256([]byte(p.source))
+	return Algorithm{
+		Name:    p.Name,
+		Version: hex.EncodeToString(sum[:6]),
+		Title:   "Policy " + p.Name,
+		Simulate: func(title string, processes []Process, opts ...Option) Result {
+			return preemptive(title, processes, newOptions(opts), p.less)
+		},
+	}
+}
+
+func (p *Policy) less(t *processTable, a, b int) bool {
+	for _, key := range p.keys {
+		if ka, kb := key(t, a), key(t, b); ka != kb {
+			return ka < kb
+		}
+	}
+	if t.arrival[a] != t.arrival[b] {
+		return t.arrival[a] < t.arrival[b]
+	}
+
+	return t.pid[a] < t.pid[b]
+}
+
+func parsePolicyStatement(stmt string) ([]policyExpr, error) {
+	toks, err := policyTokens(stmt)
+	if err != nil {
+		return nil, err
+	}
+	if len(toks) < 2 || toks[0] != "key" || toks[1] != "=" {
+		return nil, fmt.Errorf("want key = <expression>, got %q", strings.TrimSpace(stmt))
+	}
+	p := &policyParser{toks: toks[2:]}
+	var keys []policyExpr
+	if p.peek() == "(" && p.isTuple() {
+		p.next()
+		for {
+			e, err := p.conditional()
+			if err != nil {
+				return nil, err
+			}
+			keys = append(keys, e)
+			if p.peek() == ")" {
+				p.next()
+				break
+			}
+			if err := p.expect(","); err != nil {
+				return nil, err
+			}
+			if p.peek() == ")" { // a trailing comma
+				p.next()
+				break
+			}
+		}
+	} else {
+		e, err := p.conditional()
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, e)
+	}
+	if tok := p.peek(); tok != "" {
+		return nil, fmt.Errorf("unexpected %q", tok)
+	}
+
+	return keys, nil
+}
+
+// policyTokens splits an expression into identifiers, integers and operators.
+func policyTokens(s string) ([]string, error) {
+	var toks []string
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+		case c >= '0' && c <= '9', c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z':
+			j := i + 1
+			for j < len(s) && (s[j] == '_' || s[j] >= '0' && s[j] <= '9' || s[j] >= 'a' && s[j] <= 'z' || s[j] >= 'A' && s[j] <= 'Z') {
+				j++
+			}
+			toks = append(toks, s[i:j])
+			i = j
+		case strings.HasPrefix(s[i:], "//"), strings.HasPrefix(s[i:], "<="), strings.HasPrefix(s[i:], ">="),
+			strings.HasPrefix(s[i:], "=="), strings.HasPrefix(s[i:], "!="):
+			toks = append(toks, s[i:i+2])
+			i += 2
+		case strings.IndexByte("+-*/%()<>=,", c) >= 0:
+			toks = append(toks, s[i:i+1])
+			i++
+		default:
+			return nil, fmt.Errorf("unexpected character %q", c)
+		}
+	}
+
+	return toks, nil
+}
+
+// policyParser is a recursive-descent parser over tokens, with precedence from the conditional
+// expression (lowest) through or, and, not, comparisons, + -, * / // % down to unary minus.
+type policyParser struct {
+	toks []string
+	pos  int
+}
+
+func (p *policyParser) peek() string {
+	if p.pos < len(p.toks) {
+		return p.toks[p.pos]
+	}
+
+	return ""
+}
+
+func (p *policyParser) next() string {
+	tok := p.peek()
+	p.pos++
+
+	return tok
+}
+
+func (p *policyParser) expect(tok string) error {
+	if got := p.next(); got != tok {
+		if got == "" {
+			return fmt.Errorf("want %q, got end of expression", tok)
+		}
+		return fmt.Errorf("want %q, got %q", tok, got)
+	}
+
+	return nil
+}
+
+// isTuple reports whether the parenthesis at the current token holds a top-level comma.
+func (p *policyParser) isTuple() bool {
+	depth := 0
+	for _, tok := range p.toks[p.pos:] {
+		switch tok {
+		case "(":
+			depth++
+		case ")":
+			if depth--; depth == 0 {
+				return false
+			}
+		case ",":
+			if depth == 1 {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// conditional parses a if cond else b.
+func (p *policyParser) conditional() (policyExpr, error) {
+	then, err := p.or()
+	if err != nil || p.peek() != "if" {
+		return then, err
+	}
+	p.next()
+	cond, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect("else"); err != nil {
+		return nil, err
+	}
+	otherwise, err := p.conditional()
+	if err != nil {
+		return nil, err
+	}
+
+	return func(t *processTable, i int) int64 {
+		if cond(t, i) != 0 {
+			return then(t, i)
+		}
+		return otherwise(t, i)
+	}, nil
+}
+
+func (p *policyParser) or() (policyExpr, error) {
+	x, err := p.and()
+	for err == nil && p.peek() == "or" {
+		p.next()
+		var y policyExpr
+		if y, err = p.and(); err == nil {
+			l := x
+			x = func(t *processTable, i int) int64 { return truth(l(t, i) != 0 || y(t, i) != 0) }
+		}
+	}
+
+	return x, err
+}
+
+func (p *policyParser) and() (policyExpr, error) {
+	x, err := p.not()
+	for err == nil && p.peek() == "and" {
+		p.next()
+		var y policyExpr
+		if y, err = p.not(); err == nil {
+			l := x
+			x = func(t *processTable, i int) int64 { return truth(l(t, i) != 0 && y(t, i) != 0) }
+		}
+	}
+
+	return x, err
+}
+
+func (p *policyParser) not() (policyExpr, error) {
+	if p.peek() != "not" {
+		return p.comparison()
+	}
+	p.next()
+	x, err := p.not()
+	if err != nil {
+		return nil, err
+	}
+
+	return func(t *processTable, i int) int64 { return truth(x(t, i) == 0) }, nil
+}
+
+func (p *policyParser) comparison() (policyExpr, error) {
+	x, err := p.sum()
+	if err != nil {
+		return nil, err
+	}
+	var cmp func(a, b int64) bool
+	switch p.peek() {
+	case "<":
+		cmp = func(a, b int64) bool { return a < b }
+	case "<=":
+		cmp = func(a, b int64) bool { return a <= b }
+	case ">":
+		cmp = func(a, b int64) bool { return a > b }
+	case ">=":
+		cmp = func(a, b int64) bool { return a >= b }
+	case "==":
+		cmp = func(a, b int64) bool { return a == b }
+	case "!=":
+		cmp = func(a, b int64) bool { return a != b }
+	default:
+		return x, nil
+	}
+	p.next()
+	y, err := p.sum()
+	if err != nil {
+		return nil, err
+	}
+
+	return func(t *processTable, i int) int64 { return truth(cmp(x(t, i), y(t, i))) }, nil
+}
+
+func (p *policyParser) sum() (policyExpr, error) {
+	x, err := p.product()
+	for err == nil && (p.peek() == "+" || p.peek() == "-") {
+		op := p.next()
+		var y policyExpr
+		if y, err = p.product(); err == nil {
+			l := x
+			if op == "+" {
+				x = func(t *processTable, i int) int64 { return l(t, i) + y(t, i) }
+			} else {
+				x = func(t *processTable, i int) int64 { return l(t, i) - y(t, i) }
+			}
+		}
+	}
+
+	return x, err
+}
+
+func (p *policyParser) product() (policyExpr, error) {
+	x, err := p.unary()
+	for err == nil && (p.peek() == "*" || p.peek() == "/" || p.peek() == "//" || p.peek() == "%") {
+		op := p.next()
+		var y policyExpr
+		if y, err = p.unary(); err == nil {
+			l := x
+			switch op {
+			case "*":
+				x = func(t *processTable, i int) int64 { return l(t, i) * y(t, i) }
+			case "%":
+				x = func(t *processTable, i int) int64 { return floorMod(l(t, i), y(t, i)) }
+			default:
+				x = func(t *processTable, i int) int64 { return floorDiv(l(t, i), y(t, i)) }
+			}
+		}
+	}
+
+	return x, err
+}
+
+func (p *policyParser) unary() (policyExpr, error) {
+	switch p.peek() {
+	case "-":
+		p.next()
+		x, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return func(t *processTable, i int) int64 { return -x(t, i) }, nil
+	case "+":
+		p.next()
+		return p.unary()
+	}
+
+	return p.primary()
+}
+
+func (p *policyParser) primary() (policyExpr, error) {
+	tok := p.next()
+	switch {
+	case tok == "":
+		return nil, fmt.Errorf("unexpected end of expression")
+	case tok == "(":
+		x, err := p.conditional()
+		if err != nil {
+			return nil, err
+		}
+		return x, p.expect(")")
+	case tok[0] >= '0' && tok[0] <= '9':
+		v, err := strconv.ParseInt(tok, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("bad integer %q", tok)
+		}
+		return func(*processTable, int) int64 { return v }, nil
+	case tok == "True" || tok == "False":
+		v := truth(tok == "True")
+		return func(*processTable, int) int64 { return v }, nil
+	case tok == "min" || tok == "max" || tok == "abs":
+		return p.call(tok)
+	}
+	if v, ok := policyVariables[tok]; ok {
+		return v, nil
+	}
+
+	return nil, fmt.Errorf("unknown name %q (known: pid, arrival, burst, priority, remaining, ran)", tok)
+}
+
+// call parses the arguments of a builtin function.
+func (p *policyParser) call(name string) (policyExpr, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	var args []policyExpr
+	for p.peek() != ")" {
+		x, err := p.conditional()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, x)
+		if p.peek() != ")" {
+			if err := p.expect(","); err != nil {
+				return nil, err
+			}
+		}
+	}
+	p.next()
+
+	if name == "abs" {
+		if len(args) != 1 {
+			return nil, fmt.Errorf("abs takes 1 argument, got %d", len(args))
+		}
+		x := args[0]
+		return func(t *processTable, i int) int64 {
+			v := x(t, i)
+			if v < 0 {
+				return -v
+			}
+			return v
+		}, nil
+	}
+	if len(args) < 2 {
+		return nil, fmt.Errorf("%s takes at least 2 arguments, got %d", name, len(args))
+	}
+	largest := name == "max"
+
+	return func(t *processTable, i int) int64 {
+		v := args[0](t, i)
+		for _, arg := range args[1:] {
+			if w := arg(t, i); largest && w > v || !largest && w < v {
+				v = w
+			}
+		}
+		return v
+	}, nil
+}
+
+// policyVariables are the process attributes a policy can use.
+var policyVariables = map[string]policyExpr{
+	"pid":       func(t *processTable, i int) int64 { return t.pid[i] },
+	"arrival":   func(t *processTable, i int) int64 { return t.arrival[i] },
+	"burst":     func(t *processTable, i int) int64 { return t.burst[i] },
+	"priority":  func(t *processTable, i int) int64 { return t.priority[i] },
+	"remaining": func(t *processTable, i int) int64 { return t.remaining[i] },
+	"ran":       func(t *processTable, i int) int64 { return t.burst[i] - t.remaining[i] },
+}
+
+func truth(b bool) int64 {
+	if b {
+		return 1
+	}
+
+	return 0
+}
+
+// floorDiv is the script's // integer division, rounding toward negative infinity.
+func floorDiv(a, b int64) int64 {
+	if b == 0 {
+		return 0
+	}
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+
+	return q
+}
+
+// floorMod is the remainder of floorDiv, taking the sign of b.
+func floorMod(a, b int64) int64 {
+	if b == 0 {
+		return 0
+	}
+
+	return a - floorDiv(a, b)*b
+}
@@ -0,0 +1,124 @@
+package scheduler
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestPolicy_Algorithm(t *testing.T) {
+	t.Parallel()
+	workload := append(append([]Process(nil), exampleProcesses...),
+		Process{ProcessID: 4, ArrivalTime: 6, BurstDuration: 1, Priority: 3},
+		Process{ProcessID: 5, ArrivalTime: 6, BurstDuration: 6, Priority: 1},
+		Process{ProcessID: 6, ArrivalTime: 20, BurstDuration: 2, Priority: 2},
+	)
+	tests := []struct {
+		name   string
+		script string
+		want   func(string, []Process, ...Option) Result
+	}{
+		{name: "shortest remaining", script: "key = remaining", want: SJF},
+		{
+			name:   "priority",
+			script: "# highest priority, then shortest job\nkey = (\n\tpriority,  # lowest value first\n\tburst,\n)\n",
+			want:   SJFPriority,
+		},
+		{name: "non-preemptive first come", script: "key = (0 if ran > 0 else 1, arrival)", want: FCFS},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			p, err := ParsePolicy("custom", tt.script)
+			if err != nil {
+				t.Fatalf("ParsePolicy() error = %v", err)
+			}
+			for _, processes := range [][]Process{exampleProcesses, workload} {
+				got := p.Algorithm().Simulate("test", processes)
+				want := tt.want("test", processes)
+				if !reflect.DeepEqual(got.Processes, want.Processes) || got.AveWait != want.AveWait {
+					t.Errorf("Simulate() = %+v, want %+v", got.Processes, want.Processes)
+				}
+			}
+		})
+	}
+}
+
+func TestParsePolicy_expressions(t *testing.T) {
+	t.Parallel()
+	table := newProcessTable([]Process{{ProcessID: 7, ArrivalTime: 3, BurstDuration: 10, Priority: 2}})
+	table.remaining[0] = 4
+	tests := []struct {
+		expr string
+		want int64
+	}{
+		{"2 + 3 * 4", 14},
+		{"(2 + 3) * 4", 20},
+		{"-7 // 2", -4},
+		{"-7 / 2", -4},
+		{"-7 % 2", 1},
+		{"7 % -2", -1},
+		{"1 / 0", 0},
+		{"1 % 0", 0},
+		{"pid + arrival + burst + priority", 22},
+		{"remaining - ran", -2},
+		{"min(3, remaining, 9)", 3},
+		{"max(3, remaining, -9)", 4},
+		{"abs(-priority)", 2},
+		{"-priority", -2},
+		{"+priority", 2},
+		{"1 if burst > 3 else 2", 1},
+		{"1 if burst < 3 else 2 if pid == 7 else 3", 2},
+		{"burst >= 10", 1},
+		{"burst <= 9", 0},
+		{"burst != 10", 0},
+		{"not 0", 1},
+		{"not arrival", 0},
+		{"1 and 0 or 1", 1},
+		{"1 and 0", 0},
+		{"True + True", 2},
+		{"False", 0},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.expr, func(t *testing.T) {
+			t.Parallel()
+			p, err := ParsePolicy("test", "key = "+tt.expr)
+			if err != nil {
+				t.Fatalf("ParsePolicy() error = %v", err)
+			}
+			if got := p.keys[0](table, 0); got != tt.want {
+				t.Errorf("%s = %d, want %d", tt.expr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePolicy_errors(t *testing.T) {
+	t.Parallel()
+	for _, script := range []string{
+		"",
+		"# only a comment\n",
+		"key =",
+		"weight = 1",
+		"key = wait",
+		"key = 1 +",
+		"key = (1, 2",
+		"key = 1\nkey = 2",
+		"key = 1 $ 2",
+		"key = min(1)",
+		"key = abs(1, 2)",
+		"key = 1 if 2",
+		"key = 1 2",
+		"key = 99999999999999999999",
+	} {
+		script := script
+		t.Run(script, func(t *testing.T) {
+			t.Parallel()
+			if _, err := ParsePolicy("test", script); !errors.Is(err, ErrInvalidPolicy) || !errors.Is(err, ErrInvalidArgs) {
+				t.Errorf("ParsePolicy() error = %v, want %v", err, ErrInvalidPolicy)
+			}
+		})
+	}
+}
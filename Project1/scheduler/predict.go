@@ -0,0 +1,216 @@
+package scheduler
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type (
+	// Predictor estimates burst lengths from the bursts of the processes that completed before,
+	// as a real scheduler must, having no oracle for how long a job will run. A run calls
+	// Predict as each process arrives and Observe as it completes, in simulated-time order.
+	Predictor interface {
+		Predict(p Process) int64
+		Observe(p Process, burst int64)
+	}
+	// PredictionAccuracy is how close a run's burst predictions were to the actual bursts, over
+	// the processes that completed. Errors are predicted minus actual, so a positive MeanError
+	// means the predictor overestimated.
+	PredictionAccuracy struct {
+		Predictions  int     `json:"predictions"`
+		MeanError    float64 `json:"mean_error"`
+		MeanAbsError float64 `json:"mean_abs_error"`
+		RMSError     float64 `json:"rms_error"`
+	}
+
+	// LastValue predicts that the next burst is as long as the last one observed.
+	LastValue struct {
+		last int64
+	}
+	// MovingAverage predicts the mean of the last Window bursts observed (default 3).
+	MovingAverage struct {
+		Window int
+		recent []int64
+	}
+	// ExponentialAverage predicts τ(n+1) = α·t(n) + (1-α)·τ(n), the textbook estimate, where t(n)
+	// is the last burst observed and Alpha is α (default 0.5).
+	ExponentialAverage struct {
+		Alpha float64
+		tau   float64
+	}
+	// PerClass keeps a separate predictor for each class of process, as Class names it.
+	PerClass struct {
+		Class   func(p Process) string
+		New     func() Predictor
+		classes map[string]Predictor
+	}
+)
+
+// Predict returns 0 before any burst has been observed; the same holds for every predictor here,
+// so processes arriving before any history are ordered by arrival.
+func (l *LastValue) Predict(Process) int64 { return l.last }
+
+func (l *LastValue) Observe(_ Process, burst int64) { l.last = burst }
+
+func (m *MovingAverage) Predict(Process) int64 {
+	if len(m.recent) == 0 {
+		return 0
+	}
+	var sum int64
+	for _, b := range m.recent {
+		sum += b
+	}
+
+	return int64(math.Round(float64(sum) / float64(len(m.recent))))
+}
+
+func (m *MovingAverage) Observe(_ Process, burst int64) {
+	window := m.Window
+	if window <= 0 {
+		window = 3
+	}
+	if m.recent = append(m.recent, burst); len(m.recent) > window {
+		m.recent = m.recent[len(m.recent)-window:]
+	}
+}
+
+func (e *ExponentialAverage) Predict(Process) int64 { return int64(math.Round(e.tau)) }
+
+func (e *ExponentialAverage) Observe(_ Process, burst int64) {
+	alpha := e.Alpha
+	if alpha <= 0 || alpha > 1 {
+		alpha = 0.5
+	}
+	e.tau = alpha*float64(burst) + (1-alpha)*e.tau
+}
+
+func (c *PerClass) predictor(p Process) Predictor {
+	if c.classes == nil {
+		c.classes = make(map[string]Predictor)
+	}
+	class := c.Class(p)
+	pr, ok := c.classes[class]
+	if !ok {
+		pr = c.New()
+		c.classes[class] = pr
+	}
+
+	return pr
+}
+
+func (c *PerClass) Predict(p Process) int64 { return c.predictor(p).Predict(p) }
+
+func (c *PerClass) Observe(p Process, burst int64) { c.predictor(p).Observe(p, burst) }
+
+// ProcessClass is a process's Name, or its priority as "priority N" when it has no name,
+// grouping jobs of the same kind for PerClass.
+func ProcessClass(p Process) string {
+	if p.Name != "" {
+		return p.Name
+	}
+
+	return "priority " + strconv.FormatInt(p.Priority, 10)
+}
+
+// Predictors are the named predictors, each a constructor for a fresh predictor per run.
+var Predictors = map[string]func() Predictor{
+	"last": func() Predictor { return &LastValue{} },
+	"mean": func() Predictor { return &MovingAverage{} },
+	"ema":  func() Predictor { return &ExponentialAverage{} },
+	"class": func() Predictor {
+		return &PerClass{Class: ProcessClass, New: func() Predictor { return &ExponentialAverage{} }}
+	},
+}
+
+var ErrUnknownPredictor = fmt.Errorf("%w: unknown predictor", ErrInvalidArgs)
+
+// PredictorNames lists the names in Predictors, sorted and comma separated.
+func PredictorNames() string {
+	names := make([]string, 0, len(Predictors))
+	for name := range Predictors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return strings.Join(names, ", ")
+}
+
+// PredictedSJF is preemptive shortest-job-first on predicted rather than actual bursts, with
+// the named predictor from Predictors, or ema:<α> for an exponential average with that α, such
+// as ema:0.25, to study how α trades responsiveness for stability. It runs the ready process
+// with the least predicted time left (its prediction less the time it has run, floored at 0),
+// and its Result reports the predictor's accuracy. Its name is sjf-<predictor>.
+func PredictedSJF(predictor string) (Algorithm, error) {
+	newPredictor, ok := Predictors[predictor]
+	if name, arg, hasArg := strings.Cut(predictor, ":"); hasArg && name == "ema" {
+		alpha, err := strconv.ParseFloat(arg, 64)
+		if err != nil || alpha <= 0 || alpha > 1 {
+			return Algorithm{}, fmt.Errorf("%w: ema alpha must be in (0, 1], got %q", ErrInvalidArgs, arg)
+		}
+		newPredictor, ok = func() Predictor { return &ExponentialAverage{Alpha: alpha} }, true
+	}
+	if !ok {
+		return Algorithm{}, fmt.Errorf("%w: %q (known: %s, ema:<alpha>)", ErrUnknownPredictor, predictor, PredictorNames())
+	}
+
+	return Algorithm{
+		Name:    "sjf-" + predictor,
+		Version: "2",
+		Title:   "Predicted shortest-job-first (" + predictor + ")",
+		Simulate: func(title string, processes []Process, opts ...Option) Result {
+			return predictedSJF(title, processes, newOptions(opts), newPredictor())
+		},
+	}, nil
+}
+
+func predictedSJF(title string, processes []Process, o options, pr Predictor) Result {
+	var (
+		predicted = make(map[int64]int64, len(processes))
+		byPID     = make(map[int64]Process, len(processes))
+		sum, abs  float64
+		squares   float64
+		count     int
+	)
+	// The predictor sees each arrival before the process joins the ready queue, and each
+	// completion as it happens, ahead of any hooks of the caller.
+	o.hooks = append(hookList{{
+		OnArrival: func(_ int64, p Process) {
+			byPID[p.ProcessID] = p
+			if predicted[p.ProcessID] = pr.Predict(p); predicted[p.ProcessID] < 0 {
+				predicted[p.ProcessID] = 0
+			}
+		},
+		OnComplete: func(_ int64, r ProcessResult) {
+			pr.Observe(byPID[r.ID], r.Burst)
+			e := float64(predicted[r.ID] - r.Burst)
+			sum, abs, squares = sum+e, abs+math.Abs(e), squares+e*e
+			count++
+		},
+	}}, o.hooks...)
+	left := func(t *processTable, i int) int64 {
+		if l := predicted[t.pid[i]] - (t.burst[i] - t.remaining[i]); l > 0 {
+			return l
+		}
+		return 0
+	}
+
+	r := preemptive(title, processes, o, func(t *processTable, a, b int) bool {
+		if la, lb := left(t, a), left(t, b); la != lb {
+			return la < lb
+		}
+		if t.arrival[a] != t.arrival[b] {
+			return t.arrival[a] < t.arrival[b]
+		}
+		return t.pid[a] < t.pid[b]
+	})
+	r.Prediction = &PredictionAccuracy{Predictions: count}
+	if count > 0 {
+		n := float64(count)
+		r.Prediction.MeanError, r.Prediction.MeanAbsError, r.Prediction.RMSError = sum/n, abs/n, math.Sqrt(squares/n)
+	}
+
+	return r
+}
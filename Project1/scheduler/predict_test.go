@@ -0,0 +1,117 @@
+package scheduler
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestPredictors(t *testing.T) {
+	t.Parallel()
+	bursts := []int64{4, 8, 6, 2}
+	tests := []struct {
+		name string
+		p    Predictor
+		want []int64 // the prediction before each burst is observed
+	}{
+		{name: "last", p: &LastValue{}, want: []int64{0, 4, 8, 6}},
+		{name: "mean of 2", p: &MovingAverage{Window: 2}, want: []int64{0, 4, 6, 7}},
+		{name: "mean default window", p: &MovingAverage{}, want: []int64{0, 4, 6, 6}},
+		{name: "ema", p: &ExponentialAverage{Alpha: 0.5}, want: []int64{0, 2, 5, 6}},
+		{name: "ema alpha 1", p: &ExponentialAverage{Alpha: 1}, want: []int64{0, 4, 8, 6}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var got []int64
+			for _, b := range bursts {
+				got = append(got, tt.p.Predict(Process{}))
+				tt.p.Observe(Process{}, b)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("predictions = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPerClass(t *testing.T) {
+	t.Parallel()
+	p := &PerClass{Class: ProcessClass, New: func() Predictor { return &LastValue{} }}
+	batch, shell := Process{Name: "batch"}, Process{Priority: 1}
+	p.Observe(batch, 50)
+	p.Observe(shell, 2)
+	if got := p.Predict(batch); got != 50 {
+		t.Errorf("Predict(batch) = %d, want 50", got)
+	}
+	if got := p.Predict(shell); got != 2 {
+		t.Errorf("Predict(shell) = %d, want 2", got)
+	}
+	if got := p.Predict(Process{Priority: 2}); got != 0 {
+		t.Errorf("Predict(unseen class) = %d, want 0", got)
+	}
+	if got := ProcessClass(shell); got != "priority 1" {
+		t.Errorf("ProcessClass() = %q", got)
+	}
+}
+
+func TestPredictedSJF(t *testing.T) {
+	t.Parallel()
+	if _, err := PredictedSJF("oracle"); !errors.Is(err, ErrUnknownPredictor) {
+		t.Fatalf("PredictedSJF(oracle) error = %v", err)
+	}
+	a, err := PredictedSJF("last")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Name != "sjf-last" {
+		t.Errorf("Name = %q", a.Name)
+	}
+
+	// P1 runs alone and teaches the predictor that bursts are 2 long. P2 (actually 9) and P3
+	// (actually 1) then both arrive predicted at 2, so they run in arrival order, unlike SJF.
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 2},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9},
+		{ProcessID: 3, ArrivalTime: 4, BurstDuration: 1},
+	}
+	r := a.Simulate(a.Title, processes)
+	var exits []int64
+	for _, p := range r.Processes {
+		exits = append(exits, p.Exit)
+	}
+	if want := []int64{2, 12, 13}; !reflect.DeepEqual(exits, want) {
+		t.Errorf("exits = %v, want %v", exits, want)
+	}
+	// Predictions were 0, 2 and 2 for bursts of 2, 9 and 1: errors -2, -7 and 1.
+	want := PredictionAccuracy{Predictions: 3, MeanError: -8.0 / 3, MeanAbsError: 10.0 / 3, RMSError: math.Sqrt(54.0 / 3)}
+	if got := *r.Prediction; math.Abs(got.MeanError-want.MeanError) > 1e-9 || math.Abs(got.MeanAbsError-want.MeanAbsError) > 1e-9 ||
+		math.Abs(got.RMSError-want.RMSError) > 1e-9 || got.Predictions != want.Predictions {
+		t.Errorf("Prediction = %+v, want %+v", got, want)
+	}
+
+	// A fresh predictor per run: a second run predicts exactly as the first.
+	if again := a.Simulate(a.Title, processes); !reflect.DeepEqual(again, r) {
+		t.Errorf("second run = %+v, want %+v", again, r)
+	}
+
+	// With α = 0.25 the estimate after P1 is only τ = 0.25·2 = 0.5, so P2 and P3 are both
+	// predicted 1 and it underestimates more than last: errors -2, -8 and 0.
+	ema, err := PredictedSJF("ema:0.25")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ema.Name != "sjf-ema:0.25" {
+		t.Errorf("Name = %q", ema.Name)
+	}
+	if got := ema.Simulate(ema.Title, processes).Prediction; math.Abs(got.MeanError+10.0/3) > 1e-9 {
+		t.Errorf("ema:0.25 mean error = %.2f, want %.2f", got.MeanError, -10.0/3)
+	}
+	for _, bad := range []string{"ema:0", "ema:1.5", "ema:x", "last:0.5"} {
+		if _, err := PredictedSJF(bad); !errors.Is(err, ErrInvalidArgs) {
+			t.Errorf("PredictedSJF(%s) error = %v", bad, err)
+		}
+	}
+}
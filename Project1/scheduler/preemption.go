@@ -0,0 +1,143 @@
+package scheduler
+
+import (
+	"fmt"
+	"math"
+)
+
+// preemptionControls limit how often the preemptive and quantum engines take the CPU from a
+// running process.
+type preemptionControls struct {
+	granularity int64 // the least a process runs once dispatched, unless it completes
+	budget      int   // preemptions a process may suffer per interval; 0 is unlimited
+	interval    int64
+	// toCompletion keeps every dispatched process on the CPU until it completes, for the
+	// non-preemptive schedulers built on the engine.
+	toCompletion bool
+	// switchCost keeps the running process on the CPU unless the process that would preempt it
+	// has more than this much less remaining time, for SwitchAwareSJF.
+	switchCost int64
+}
+
+func (c preemptionControls) set() bool {
+	return c.granularity > 0 || c.budget > 0 || c.toCompletion || c.switchCost > 0
+}
+
+// until is when a process dispatched at dispatched, and last preempted at the times in
+// preempted (the latest last), may next be preempted.
+func (c preemptionControls) until(dispatched int64, preempted []int64) int64 {
+	if c.toCompletion {
+		return math.MaxInt64
+	}
+	until := dispatched + c.granularity
+	if c.budget > 0 && len(preempted) >= c.budget {
+		// The budget is spent until the oldest of the last budget preemptions leaves the interval.
+		if free := preempted[len(preempted)-c.budget] + c.interval; free > until {
+			until = free
+		}
+	}
+
+	return until
+}
+
+// WithMinGranularity lets a process dispatched by a preemptive scheduler run for at least g time
+// units (or until it completes) before another can take the CPU, trading responsiveness for
+// fewer context switches. A preemption held back happens as soon as g has passed; under a
+// quantum scheduler the process also runs on past the end of a shorter quantum.
+func WithMinGranularity(g int64) Option {
+	return func(o *options) {
+		o.preemption.granularity = g
+	}
+}
+
+// WithPreemptionBudget lets each process be preempted by a preemptive scheduler at most n times
+// in any interval time units; once its budget is spent it keeps the CPU until the oldest of those
+// preemptions is interval old. n of 0 (or less) or a non-positive interval is no budget.
+func WithPreemptionBudget(n int, interval int64) Option {
+	return func(o *options) {
+		if n <= 0 || interval <= 0 {
+			n, interval = 0, 0
+		}
+		o.preemption.budget, o.preemption.interval = n, interval
+	}
+}
+
+// withoutPreemptionControls drops the minimum granularity and preemption budget set by earlier
+// options, for the baseline run of PreemptionEffect.
+func withoutPreemptionControls() Option {
+	return func(o *options) {
+		o.preemption = preemptionControls{}
+	}
+}
+
+type (
+	// PreemptionReport is the effect of the minimum granularity and preemption budget on a run:
+	// the same processes scheduled with and without them.
+	PreemptionReport struct {
+		MinGranularity int64 `json:"min_granularity,omitempty"`
+		// Budget is the preemptions allowed per process in each Interval.
+		Budget   int           `json:"budget,omitempty"`
+		Interval int64         `json:"interval,omitempty"`
+		Without  PreemptionRun `json:"without"`
+		With     PreemptionRun `json:"with"`
+	}
+	// PreemptionRun is how often processes were switched and how long they waited in one run.
+	PreemptionRun struct {
+		// ContextSwitches counts dispatches, one per time a process is put on a CPU.
+		ContextSwitches int     `json:"context_switches"`
+		Preemptions     int     `json:"preemptions"`
+		AveResponse     float64 `json:"average_response"`
+		AveWait         float64 `json:"average_wait"`
+	}
+)
+
+// String describes the controls, e.g. "min granularity 4, 2 preemptions per 10".
+func (r PreemptionReport) String() string {
+	var s string
+	if r.MinGranularity > 0 {
+		s = fmt.Sprintf("min granularity %d", r.MinGranularity)
+	}
+	if r.Budget > 0 {
+		if s != "" {
+			s += ", "
+		}
+		s += fmt.Sprintf("%d preemptions per %d", r.Budget, r.Interval)
+	}
+
+	return s
+}
+
+// PreemptionEffect reports, in the result's Preemption, what the minimum granularity and
+// preemption budget options did to a run: the processes are also scheduled without them, and
+// context switches, preemptions and average response and wait are compared. A run without
+// those options is passed straight through.
+func PreemptionEffect() Middleware {
+	return func(next SimulateFunc) SimulateFunc {
+		return func(title string, processes []Process, opts ...Option) Result {
+			controls := newOptions(opts).preemption
+			if !controls.set() {
+				return next(title, processes, opts...)
+			}
+			count := func(run *PreemptionRun) Option {
+				return WithHooks(Hooks{
+					OnDispatch: func(int64, Process, int) { run.ContextSwitches++ },
+					OnPreempt:  func(int64, Process, int, int64) { run.Preemptions++ },
+				})
+			}
+			report := PreemptionReport{
+				MinGranularity: controls.granularity,
+				Budget:         controls.budget,
+				Interval:       controls.interval,
+			}
+			base := next(title, processes,
+				append(opts[:len(opts):len(opts)], withoutOutput(), withoutPreemptionControls(), count(&report.Without))...)
+			report.Without.AveResponse, report.Without.AveWait = base.AveResponse, base.AveWait
+
+			r := next(title, processes, append(opts[:len(opts):len(opts)], count(&report.With))...)
+			report.With.AveResponse, report.With.AveWait = r.AveResponse, r.AveWait
+			r.Preemption = &report
+
+			return r
+		}
+	}
+}
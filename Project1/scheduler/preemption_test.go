@@ -0,0 +1,119 @@
+package scheduler
+
+import (
+	"reflect"
+	"testing"
+)
+
+// preemptionProcesses has P1 preempted on every arrival by shortest-job-first.
+var preemptionProcesses = []Process{
+	{ProcessID: 1, BurstDuration: 10},
+	{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2},
+	{ProcessID: 3, ArrivalTime: 4, BurstDuration: 2},
+	{ProcessID: 4, ArrivalTime: 6, BurstDuration: 1},
+}
+
+// exits returns each process's exit by PID.
+func exits(r Result) map[int64]int64 {
+	out := make(map[int64]int64, len(r.Processes))
+	for _, p := range r.Processes {
+		out[p.ID] = p.Exit
+	}
+
+	return out
+}
+
+// checkGantt fails t unless r's Gantt slices are in order without overlapping and the slices of
+// each process add up to its burst.
+func checkGantt(t *testing.T, r Result, processes []Process) {
+	t.Helper()
+	ran := make(map[int64]int64, len(processes))
+	for i, s := range r.Gantt {
+		if i > 0 && s.Start < r.Gantt[i-1].Stop {
+			t.Errorf("slice %+v overlaps %+v", s, r.Gantt[i-1])
+		}
+		if !s.Idle {
+			ran[s.PID] += s.Stop - s.Start
+		}
+	}
+	for _, p := range processes {
+		if ran[p.ProcessID] != p.BurstDuration {
+			t.Errorf("P%d ran %d in the Gantt chart, want its burst of %d", p.ProcessID, ran[p.ProcessID], p.BurstDuration)
+		}
+	}
+}
+
+func TestWithMinGranularity(t *testing.T) {
+	t.Parallel()
+	// P1 keeps the CPU until 5 although P2 arrives at 1; P2 then runs to completion, and the
+	// shortest of the rest follow.
+	got := exits(SJF("sjf", preemptionProcesses, WithMinGranularity(5)))
+	if want := map[int64]int64{1: 15, 2: 7, 3: 10, 4: 8}; !reflect.DeepEqual(got, want) {
+		t.Errorf("exits = %v, want %v", got, want)
+	}
+}
+
+func TestWithPreemptionBudget(t *testing.T) {
+	t.Parallel()
+	// P1 is preempted by P2 at 1, which spends its budget until 11, by when it has 1 left and
+	// finishes ahead of P4 on arrival order.
+	got := exits(SJF("sjf", preemptionProcesses, WithPreemptionBudget(1, 10)))
+	if want := map[int64]int64{1: 12, 2: 3, 3: 15, 4: 13}; !reflect.DeepEqual(got, want) {
+		t.Errorf("exits = %v, want %v", got, want)
+	}
+	if got := exits(SJF("sjf", preemptionProcesses, WithPreemptionBudget(0, 10))); !reflect.DeepEqual(got, exits(SJF("sjf", preemptionProcesses))) {
+		t.Errorf("a budget of 0 changed the schedule: %v", got)
+	}
+}
+
+func TestPreemptionControls_quantum(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		opt  Option
+		want map[int64]int64
+	}{
+		// Left alone, round-robin takes turns every 2.
+		{name: "none", want: map[int64]int64{1: 15, 2: 4, 3: 8, 4: 11}},
+		// P1, and P1 again once P2 has had its turn, keeps the CPU for 5 whatever its quantum.
+		{name: "granularity", opt: WithMinGranularity(5), want: map[int64]int64{1: 12, 2: 7, 3: 14, 4: 15}},
+		// P1, preempted at 2, keeps the CPU from 4 until it completes, before its budget frees at 12.
+		{name: "budget", opt: WithPreemptionBudget(1, 10), want: map[int64]int64{1: 12, 2: 4, 3: 14, 4: 15}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var opts []Option
+			if tt.opt != nil {
+				opts = append(opts, tt.opt)
+			}
+			r := PriorityRR("priority-rr", preemptionProcesses, opts...)
+			if got := exits(r); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("exits = %v, want %v", got, tt.want)
+			}
+			checkGantt(t, r, preemptionProcesses)
+		})
+	}
+}
+
+func TestPreemptionEffect(t *testing.T) {
+	t.Parallel()
+	simulate := Chain(SJF, PreemptionEffect())
+	got := simulate("sjf", preemptionProcesses, WithMinGranularity(5)).Preemption
+	want := &PreemptionReport{
+		MinGranularity: 5,
+		Without:        PreemptionRun{ContextSwitches: 6, Preemptions: 2, AveResponse: 0, AveWait: 1.25},
+		With:           PreemptionRun{ContextSwitches: 5, Preemptions: 1, AveResponse: 2.25, AveWait: 3.5},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Preemption = %+v, want %+v", got, want)
+	}
+	if got.String() != "min granularity 5" {
+		t.Errorf("String() = %q", got.String())
+	}
+
+	if r := simulate("sjf", preemptionProcesses); r.Preemption != nil {
+		t.Errorf("Preemption = %+v without controls, want nil", r.Preemption)
+	}
+}
@@ -0,0 +1,75 @@
+package scheduler
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// defaultProgressInterval is how often (in wall-clock time) progress lines are written.
+const defaultProgressInterval = time.Second
+
+// progressReporter periodically writes a progress line for a running simulation:
+// the simulated time, how many processes have completed, and an estimated time remaining.
+// A nil *progressReporter is valid and reports nothing, so schedulers can call it unconditionally.
+type progressReporter struct {
+	w        io.Writer
+	title    string
+	total    int
+	interval time.Duration
+	now      func() time.Time
+	started  time.Time
+	last     time.Time
+}
+
+func newProgressReporter(w io.Writer, title string, total int) *progressReporter {
+	if w == nil {
+		return nil
+	}
+	p := &progressReporter{
+		w:        w,
+		title:    title,
+		total:    total,
+		interval: defaultProgressInterval,
+		now:      time.Now,
+	}
+	p.started = p.now()
+	p.last = p.started
+
+	return p
+}
+
+// update reports progress if the reporting interval has elapsed since the last line.
+func (p *progressReporter) update(simTime int64, done int) {
+	if p == nil {
+		return
+	}
+	if t := p.now(); t.Sub(p.last) >= p.interval {
+		p.last = t
+		p.write(simTime, done)
+	}
+}
+
+// finish always writes a final progress line.
+func (p *progressReporter) finish(simTime int64, done int) {
+	if p == nil {
+		return
+	}
+	p.last = p.now()
+	p.write(simTime, done)
+}
+
+func (p *progressReporter) write(simTime int64, done int) {
+	percent := 100.0
+	if p.total > 0 {
+		percent = float64(done) / float64(p.total) * 100
+	}
+	eta := "?"
+	if done > 0 {
+		elapsed := p.last.Sub(p.started)
+		remaining := time.Duration(float64(elapsed) / float64(done) * float64(p.total-done))
+		eta = remaining.Round(time.Second).String()
+	}
+	_, _ = fmt.Fprintf(p.w, "%s: t=%d %d/%d processes (%.1f%%) ETA %s\n",
+		p.title, simTime, done, p.total, percent, eta)
+}
@@ -0,0 +1,78 @@
+package scheduler
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func Test_progressReporter(t *testing.T) {
+	t.Parallel()
+	type call struct {
+		simTime int64
+		done    int
+		elapsed time.Duration
+		finish  bool
+	}
+	tests := []struct {
+		name    string
+		total   int
+		calls   []call
+		wantOut string
+	}{
+		{
+			name:  "throttled",
+			total: 4,
+			calls: []call{
+				{simTime: 5, done: 1, elapsed: 500 * time.Millisecond},
+				{simTime: 9, done: 2, elapsed: 2 * time.Second},
+				{simTime: 12, done: 3, elapsed: 2500 * time.Millisecond},
+				{simTime: 20, done: 4, elapsed: 4 * time.Second, finish: true},
+			},
+			wantOut: "test: t=9 2/4 processes (50.0%) ETA 2s\n" +
+				"test: t=20 4/4 processes (100.0%) ETA 0s\n",
+		},
+		{
+			name:    "nothing completed",
+			total:   2,
+			calls:   []call{{simTime: 3, elapsed: time.Second, finish: true}},
+			wantOut: "test: t=3 0/2 processes (0.0%) ETA ?\n",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var (
+				w     bytes.Buffer
+				start = time.Unix(0, 0)
+				clock = start
+			)
+			p := newProgressReporter(&w, "test", tt.total)
+			p.now = func() time.Time { return clock }
+			p.started, p.last = start, start
+			for _, c := range tt.calls {
+				clock = start.Add(c.elapsed)
+				if c.finish {
+					p.finish(c.simTime, c.done)
+				} else {
+					p.update(c.simTime, c.done)
+				}
+			}
+			if got := w.String(); got != tt.wantOut {
+				t.Errorf("progress = %q, want %q", got, tt.wantOut)
+			}
+		})
+	}
+}
+
+func Test_progressReporterNil(t *testing.T) {
+	t.Parallel()
+	p := newProgressReporter(nil, "test", 1)
+	if p != nil {
+		t.Fatalf("newProgressReporter(nil) = %v, want nil", p)
+	}
+	// Must not panic.
+	p.update(1, 1)
+	p.finish(1, 1)
+}
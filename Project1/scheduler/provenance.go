@@ -0,0 +1,15 @@
+package scheduler
+
+// Version is the scheduler tool version recorded in run provenance.
+const Version = "1.0.0"
+
+// Provenance records everything needed to regenerate a result bit-for-bit.
+type Provenance struct {
+	Tool        string            `json:"tool"`
+	Version     string            `json:"version"`
+	Revision    string            `json:"revision,omitempty"`
+	Input       string            `json:"input"`
+	InputSHA256 string            `json:"input_sha256"`
+	Parameters  map[string]string `json:"parameters"`
+	Algorithms  map[string]string `json:"algorithms"`
+}
@@ -0,0 +1,352 @@
+package scheduler
+
+// quantumPolicy is what a scheduler built on the quantum engine decides: which ready process runs
+// next and for how long, and where it goes once it has run. admit, pick and ran are required.
+type quantumPolicy struct {
+	// order is the order arrivals due together are admitted in, byArrivalOnly if nil.
+	order less
+	// rejects, if set, reports whether an arriving process is turned away rather than admitted.
+	rejects func(p int) bool
+	// admit queues a process that has arrived with work left.
+	admit func(p int)
+	// tick, if set, runs the policy's own timed events due by now, before the arrivals due then
+	// are admitted.
+	tick func()
+	// pick returns the process to run, or -1 while none of those admitted can, the longest it may
+	// run before the policy picks again, and the Queue of its Gantt slice.
+	pick func() (p int, quantum int64, queue string)
+	// wake, if set, is when the policy must pick again whatever happens, besides arrivals and
+	// quanta, while p runs, or while the CPU idles when p is -1.
+	wake func(p int) (int64, bool)
+	// ran charges p, which has just run for d up to now, moving it within the policy's queues.
+	ran func(p int, d int64)
+	// done, if set, removes p from the policy's queues once it completes.
+	done func(p int)
+	// completed, if set, amends the schedule table row of p before it is recorded.
+	completed func(p int, row *ProcessResult)
+	// yield, if set, models I/O: once a process with an IOInterval has run that long since it
+	// last blocked, it leaves the CPU and yield takes it out of the policy's queues, and once it
+	// has been blocked for its IODuration, ready puts it back. Without yield every process is
+	// CPU-bound.
+	yield func(p int)
+	ready func(p int)
+	// preemptOnArrival stops the running process on every arrival, so an arrival the policy
+	// prefers takes the CPU at once rather than at the end of the quantum.
+	preemptOnArrival bool
+}
+
+// quantumEngine runs the schedulers that pick a process, let it run for up to a quantum and pick
+// again. Like preemptive, the clock jumps from event to event: arrivals, completions, the ends of
+// quanta and the policy's own wake-ups. It keeps the Gantt chart, fires the hooks and records the
+// rows, rejections included, so a policy only orders its own queues. The minimum granularity and
+// preemption budget hold the running process on the CPU past the end of its quantum, or an event
+// that would preempt it, without asking the policy; ran then charges it the whole stretch, so a
+// policy must find the process it charges wherever in its queues it is. For a policy that models
+// I/O, the engine also blocks each process for its I/O and wakes it once that completes.
+type quantumEngine struct {
+	title     string
+	processes []Process
+	o         options
+	t         *processTable
+	rec       *recorder
+	progress  *progressReporter
+	arrivals  *arrivalIndex
+	q         quantumPolicy
+	now       int64
+	count     int
+	backlog   int       // admitted processes that have yet to complete
+	onCPU     int       // the process last dispatched, until it completes or is descheduled
+	current   TimeSlice // the Gantt slice being extended, if onCPU >= 0
+	// dispatched is when onCPU was dispatched, and preempted each process's latest preemption
+	// times, with a preemption budget, for the preemption controls.
+	dispatched int64
+	preempted  [][]int64
+	// sinceIO is the CPU time each process has run since it last blocked for I/O, and ioTime the
+	// time it has spent blocked, if the policy models I/O; blocked are the processes blocked now.
+	sinceIO []int64
+	ioTime  []int64
+	blocked []blockedProcess
+}
+
+// blockedProcess is a process blocked for I/O until a time.
+type blockedProcess struct {
+	p     int
+	until int64
+}
+
+func newQuantumEngine(title string, processes []Process, o options) *quantumEngine {
+	t := newProcessTable(processes)
+	e := &quantumEngine{
+		title:     title,
+		processes: processes,
+		o:         o,
+		t:         t,
+		rec:       newRecorder(o, t.len()),
+		progress:  newProgressReporter(o.progress, title, t.len()),
+		onCPU:     -1,
+	}
+	if o.preemption.budget > 0 {
+		e.preempted = make([][]int64, t.len())
+	}
+
+	return e
+}
+
+// finish records the row of a process that has left the system.
+func (e *quantumEngine) finish(row ProcessResult) {
+	e.rec.process(row)
+	e.count++
+	e.progress.update(e.now, e.count)
+}
+
+func (e *quantumEngine) complete(p int) {
+	row := e.t.result(p, e.now)
+	if e.ioTime != nil {
+		// Time blocked for I/O is not time waiting for the CPU.
+		row.Wait -= e.ioTime[p]
+	}
+	if e.q.completed != nil {
+		e.q.completed(p, &row)
+	}
+	e.finish(row)
+	e.o.hooks.complete(e.now, row)
+}
+
+func (e *quantumEngine) admit(p int) {
+	t := e.t
+	e.o.hooks.arrival(t.arrival[p], e.processes[p])
+	switch {
+	case e.q.rejects != nil && e.q.rejects(p):
+		e.finish(ProcessResult{
+			ID:       t.pid[p],
+			Priority: t.priority[p],
+			Burst:    t.burst[p],
+			Arrival:  t.arrival[p],
+			Exit:     t.arrival[p],
+			Status:   StatusRejected,
+		})
+	case t.remaining[p] == 0:
+		// A zero-burst process needs no CPU, so it completes the moment it arrives.
+		e.complete(p)
+	default:
+		e.q.admit(p)
+		e.backlog++
+	}
+}
+
+// admitArrivals admits every process that has arrived by now, for policies that queue arrivals
+// during a quantum ahead of the process it ends.
+func (e *quantumEngine) admitArrivals() {
+	e.arrivals.admit(e.now, e.admit)
+}
+
+func (e *quantumEngine) endSlice() {
+	if e.onCPU >= 0 && e.current.Stop > e.current.Start {
+		e.rec.slice(e.current)
+	}
+}
+
+// deschedule takes the CPU from the process last dispatched, if it has work left, as when a policy
+// throttles it or one it prefers is dispatched.
+func (e *quantumEngine) deschedule() {
+	if e.onCPU < 0 {
+		return
+	}
+	e.endSlice()
+	if p := e.onCPU; e.t.remaining[p] > 0 {
+		e.o.hooks.preempt(e.now, e.processes[p], 0, e.t.remaining[p])
+		if e.preempted != nil {
+			times := append(e.preempted[p], e.now)
+			if len(times) > e.o.preemption.budget {
+				times = times[1:]
+			}
+			e.preempted[p] = times
+		}
+	}
+	e.onCPU = -1
+}
+
+// block takes p, which has just run its IOInterval, off the CPU until its I/O completes.
+func (e *quantumEngine) block(p int) {
+	e.endSlice()
+	e.onCPU = -1
+	e.sinceIO[p] = 0
+	d := e.processes[p].IODuration
+	if d < 0 {
+		d = 0
+	}
+	e.ioTime[p] += d
+	e.blocked = append(e.blocked, blockedProcess{p: p, until: e.now + d})
+	e.q.yield(p)
+}
+
+// nextUnblock returns the index in blocked of the process whose I/O completes first, the one that
+// blocked first on a tie, or -1 if none is blocked.
+func (e *quantumEngine) nextUnblock() int {
+	next := -1
+	for i, b := range e.blocked {
+		if next < 0 || b.until < e.blocked[next].until {
+			next = i
+		}
+	}
+
+	return next
+}
+
+// unblock hands every process whose I/O has completed by now back to the policy, in the order
+// their I/O completed.
+func (e *quantumEngine) unblock() {
+	for {
+		i := e.nextUnblock()
+		if i < 0 || e.blocked[i].until > e.now {
+			return
+		}
+		p := e.blocked[i].p
+		e.blocked = append(e.blocked[:i], e.blocked[i+1:]...)
+		e.q.ready(p)
+	}
+}
+
+// run simulates the processes under q.
+func (e *quantumEngine) run(q quantumPolicy) Result {
+	t := e.t
+	e.q = q
+	if q.order == nil {
+		q.order = byArrivalOnly
+	}
+	e.arrivals = newArrivalIndexBy(t, q.order)
+	if q.yield != nil {
+		e.sinceIO, e.ioTime = make([]int64, t.len()), make([]int64, t.len())
+	}
+
+	for e.backlog > 0 || e.arrivals.pending() {
+		if q.tick != nil {
+			q.tick()
+		}
+		e.admitArrivals()
+		e.unblock()
+		running, quantum, queue := -1, int64(0), ""
+		held := e.onCPU >= 0 && e.o.preemption.set()
+		if held {
+			var times []int64
+			if e.preempted != nil {
+				times = e.preempted[e.onCPU]
+			}
+			// The minimum granularity and preemption budget keep the process last dispatched on
+			// the CPU, whatever the policy would pick, until they let it be preempted.
+			until := e.o.preemption.until(e.dispatched, times)
+			if held = until > e.now; held {
+				running, quantum, queue = e.onCPU, until-e.now, e.current.Queue
+			}
+		}
+		if !held && e.backlog > len(e.blocked) {
+			running, quantum, queue = q.pick()
+		}
+		if running < 0 {
+			// Idle until the next arrival or I/O completion, or until the policy has a process to
+			// run again.
+			e.deschedule()
+			next, ok := e.arrivals.peek()
+			if i := e.nextUnblock(); i >= 0 && (!ok || e.blocked[i].until < next) {
+				next, ok = e.blocked[i].until, true
+			}
+			if q.wake != nil && e.backlog > 0 {
+				if at, due := q.wake(-1); due && (!ok || at < next) {
+					next, ok = at, true
+				}
+			}
+			if ok {
+				e.rec.slice(TimeSlice{Idle: true, Start: e.now, Stop: next})
+				e.o.hooks.idle(e.now, next)
+				e.now = next
+			}
+			continue
+		}
+
+		if running != e.onCPU {
+			e.deschedule()
+			e.o.hooks.dispatch(e.now, e.processes[running], 0)
+			e.onCPU, e.dispatched = running, e.now
+			e.current = TimeSlice{PID: t.pid[running], Start: e.now, Stop: e.now, Queue: queue}
+		} else if queue != e.current.Queue {
+			// Moved to another queue while keeping the CPU: the chart starts a slice there.
+			e.endSlice()
+			e.current = TimeSlice{PID: t.pid[running], Start: e.now, Stop: e.now, Queue: queue}
+		}
+		if t.firstRun[running] < 0 {
+			t.firstRun[running] = e.now
+		}
+
+		run := minimum(t.remaining[running], quantum)
+		if q.preemptOnArrival {
+			// A process whose I/O completes arrives back, as far as the policy is concerned.
+			next, ok := e.arrivals.peek()
+			if i := e.nextUnblock(); i >= 0 && (!ok || e.blocked[i].until < next) {
+				next, ok = e.blocked[i].until, true
+			}
+			if ok && next-e.now < run {
+				run = next - e.now
+			}
+		}
+		if q.wake != nil && !held {
+			if at, ok := q.wake(running); ok && at-e.now < run {
+				run = at - e.now
+			}
+		}
+		interval := int64(0)
+		if e.sinceIO != nil {
+			interval = e.processes[running].IOInterval
+		}
+		if interval > 0 && interval-e.sinceIO[running] < run {
+			run = interval - e.sinceIO[running]
+		}
+		t.remaining[running] -= run
+		e.now += run
+		e.current.Stop = e.now
+		q.ran(running, run)
+		switch {
+		case t.remaining[running] == 0:
+			if q.done != nil {
+				q.done(running)
+			}
+			e.backlog--
+			e.complete(running)
+			e.endSlice()
+			e.onCPU = -1
+		case interval > 0:
+			if e.sinceIO[running] += run; e.sinceIO[running] >= interval {
+				e.block(running)
+			}
+		}
+	}
+	e.endSlice()
+	e.rec.flush()
+	e.progress.finish(e.now, e.count)
+
+	r := Result{Title: e.title, Gantt: e.rec.gantt, Processes: e.rec.processes}
+	e.rec.totals.apply(&r)
+
+	return r
+}
+
+// without returns queue without process p, and whether p was in it. The head is checked first,
+// where the process that just ran usually is.
+func without(queue []int, p int) ([]int, bool) {
+	if len(queue) > 0 && queue[0] == p {
+		return queue[1:], true
+	}
+	for i, x := range queue {
+		if x == p {
+			return append(queue[:i:i], queue[i+1:]...), true
+		}
+	}
+
+	return queue, false
+}
+
+// rotate moves process p to the back of queue, as round-robin does once its quantum is used up.
+func rotate(queue []int, p int) []int {
+	queue, _ = without(queue, p)
+
+	return append(queue, p)
+}
@@ -0,0 +1,80 @@
+package scheduler
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_quantumEngine(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 3},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2},
+		{ProcessID: 3, ArrivalTime: 1},
+		{ProcessID: 9, ArrivalTime: 2, BurstDuration: 4},
+		{ProcessID: 4, ArrivalTime: 9, BurstDuration: 1},
+	}
+	var preempted int
+	e := newQuantumEngine("rr", processes, newOptions([]Option{WithHooks(Hooks{
+		OnPreempt: func(int64, Process, int, int64) { preempted++ },
+	})}))
+	var (
+		ready []int
+		used  = make([]int64, len(processes))
+	)
+	// Round-robin with a quantum of 2 that turns P9 away: P1 keeps the CPU past its quantum, as
+	// it is alone in the queue when the quantum ends, and arrivals wait for the quantum to end.
+	r := e.run(quantumPolicy{
+		rejects: func(p int) bool { return processes[p].ProcessID == 9 },
+		admit:   func(p int) { ready = append(ready, p) },
+		pick:    func() (int, int64, string) { return ready[0], 2 - used[ready[0]], "" },
+		ran: func(p int, run int64) {
+			if used[p] += run; used[p] == 2 {
+				used[p], ready = 0, rotate(ready, p)
+			}
+		},
+		done: func(p int) { ready, _ = without(ready, p) },
+	})
+
+	want := []ProcessResult{
+		{ID: 3, Arrival: 1, Wait: 1, Turnaround: 1, Response: 1, Exit: 2},
+		{ID: 9, Burst: 4, Arrival: 2, Exit: 2, Status: StatusRejected},
+		{ID: 1, Burst: 3, Arrival: 0, Turnaround: 3, Exit: 3},
+		{ID: 2, Burst: 2, Arrival: 1, Wait: 2, Turnaround: 4, Response: 2, Exit: 5},
+		{ID: 4, Burst: 1, Arrival: 9, Turnaround: 1, Exit: 10},
+	}
+	if !reflect.DeepEqual(r.Processes, want) {
+		t.Errorf("run() rows = %+v, want %+v", r.Processes, want)
+	}
+	wantGantt := []TimeSlice{{PID: 1, Stop: 3}, {PID: 2, Start: 3, Stop: 5}, {Idle: true, Start: 5, Stop: 9}, {PID: 4, Start: 9, Stop: 10}}
+	if !reflect.DeepEqual(r.Gantt, wantGantt) {
+		t.Errorf("run() Gantt = %+v, want %+v", r.Gantt, wantGantt)
+	}
+	if preempted != 0 {
+		t.Errorf("run() preempted %d times, want 0", preempted)
+	}
+}
+
+func Test_without(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		queue  []int
+		p      int
+		want   []int
+		wantOK bool
+	}{
+		{queue: []int{4, 5, 6}, p: 4, want: []int{5, 6}, wantOK: true},
+		{queue: []int{4, 5, 6}, p: 5, want: []int{4, 6}, wantOK: true},
+		{queue: []int{4, 5, 6}, p: 7, want: []int{4, 5, 6}},
+		{queue: nil, p: 1, want: nil},
+	}
+	for _, tt := range tests {
+		got, ok := without(append([]int(nil), tt.queue...), tt.p)
+		if !reflect.DeepEqual(got, tt.want) || ok != tt.wantOK {
+			t.Errorf("without(%v, %d) = %v, %v, want %v, %v", tt.queue, tt.p, got, ok, tt.want, tt.wantOK)
+		}
+	}
+	if got, want := rotate([]int{4, 5, 6}, 5), []int{4, 6, 5}; !reflect.DeepEqual(got, want) {
+		t.Errorf("rotate() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,121 @@
+package scheduler
+
+import "sort"
+
+// recorder collects the rows and Gantt slices a scheduler produces, forwarding them
+// to a sink when streaming and buffering them for the Result otherwise.
+//
+// With compaction enabled, a slice that continues the previous slice on the same CPU for
+// the same PID is merged into it. Streamed slices are held back until they can no longer
+// grow, so flush must be called once the scheduler finishes.
+type recorder struct {
+	sink      Sink
+	compact   bool
+	processes []ProcessResult
+	totals    runTotals
+	gantt     []TimeSlice
+	lastByCPU map[int]int       // index into gantt of each CPU's latest slice
+	pending   map[int]TimeSlice // streamed slices not yet emitted, by CPU
+}
+
+// newRecorder returns a recorder sized for n processes, so buffering a run grows its
+// row and Gantt storage at most once.
+func newRecorder(o options, n int) *recorder {
+	r := &recorder{sink: o.sink, compact: o.compactGantt}
+	if r.sink == nil {
+		r.processes = make([]ProcessResult, 0, n)
+		r.gantt = make([]TimeSlice, 0, n)
+	}
+	if r.compact {
+		r.lastByCPU = make(map[int]int)
+		r.pending = make(map[int]TimeSlice)
+	}
+
+	return r
+}
+
+func (r *recorder) process(p ProcessResult) {
+	r.totals.add(p)
+	if r.sink != nil {
+		r.sink.Process(p)
+		return
+	}
+	r.processes = append(r.processes, p)
+}
+
+func (r *recorder) slice(s TimeSlice) {
+	switch {
+	case r.compact && r.sink != nil:
+		if prev, ok := r.pending[s.CPU]; ok {
+			if continues(prev, s) {
+				prev.Stop = s.Stop
+				r.pending[s.CPU] = prev
+				return
+			}
+			r.sink.Slice(prev)
+		}
+		r.pending[s.CPU] = s
+	case r.sink != nil:
+		r.sink.Slice(s)
+	case r.compact:
+		if i, ok := r.lastByCPU[s.CPU]; ok && continues(r.gantt[i], s) {
+			r.gantt[i].Stop = s.Stop
+			return
+		}
+		r.lastByCPU[s.CPU] = len(r.gantt)
+		r.gantt = append(r.gantt, s)
+	default:
+		r.gantt = append(r.gantt, s)
+	}
+}
+
+// flush emits any streamed slices still held back for compaction, in CPU order.
+func (r *recorder) flush() {
+	if r.sink == nil || len(r.pending) == 0 {
+		return
+	}
+	cpus := make([]int, 0, len(r.pending))
+	for cpu := range r.pending {
+		cpus = append(cpus, cpu)
+	}
+	sort.Ints(cpus)
+	for _, cpu := range cpus {
+		r.sink.Slice(r.pending[cpu])
+		delete(r.pending, cpu)
+	}
+}
+
+// continues reports whether next picks up exactly where prev left off for the same process
+// (or idle or reserved period).
+func continues(prev, next TimeSlice) bool {
+	return prev.PID == next.PID && prev.Idle == next.Idle && prev.Reserved == next.Reserved && prev.CPU == next.CPU &&
+		prev.Queue == next.Queue && prev.Stop == next.Start
+}
+
+// BusySlices returns the slices in which a process ran, leaving out idle periods.
+// A chart without idle slices is returned as is.
+func BusySlices(gantt []TimeSlice) []TimeSlice {
+	var busy []TimeSlice
+	for i, s := range gantt {
+		if s.Idle {
+			if busy == nil {
+				busy = append(make([]TimeSlice, 0, len(gantt)), gantt[:i]...)
+			}
+			continue
+		}
+		if busy != nil {
+			busy = append(busy, s)
+		}
+	}
+	if busy == nil {
+		return gantt
+	}
+
+	return busy
+}
+
+// Sink receives a scheduler's output as it is produced.
+type Sink interface {
+	Process(p ProcessResult)
+	Slice(s TimeSlice)
+}
@@ -0,0 +1,90 @@
+package scheduler
+
+import (
+	"reflect"
+	"testing"
+)
+
+// fakeSink records everything streamed to it.
+type fakeSink struct {
+	processes []ProcessResult
+	slices    []TimeSlice
+}
+
+func (f *fakeSink) Process(p ProcessResult) { f.processes = append(f.processes, p) }
+
+func (f *fakeSink) Slice(s TimeSlice) { f.slices = append(f.slices, s) }
+
+func Test_recorder_slice(t *testing.T) {
+	t.Parallel()
+	in := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 2},
+		{PID: 1, Start: 2, Stop: 4},
+		{PID: 2, CPU: 1, Start: 2, Stop: 3},
+		{PID: 1, Start: 4, Stop: 5},
+		{PID: 2, Start: 5, Stop: 7},
+		{PID: 2, CPU: 1, Start: 3, Stop: 6},
+		{PID: 2, Start: 8, Stop: 9},
+	}
+	compacted := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 5},
+		{PID: 2, CPU: 1, Start: 2, Stop: 6},
+		{PID: 2, Start: 5, Stop: 7},
+		{PID: 2, Start: 8, Stop: 9},
+	}
+	tests := []struct {
+		name   string
+		opts   options
+		stream bool
+		want   []TimeSlice
+	}{
+		{name: "buffered", want: in},
+		{name: "buffered compact", opts: options{compactGantt: true}, want: compacted},
+		{name: "streamed", stream: true, want: in},
+		{
+			name:   "streamed compact",
+			opts:   options{compactGantt: true},
+			stream: true,
+			want: []TimeSlice{
+				{PID: 1, Start: 0, Stop: 5},
+				{PID: 2, Start: 5, Stop: 7},
+				{PID: 2, Start: 8, Stop: 9},
+				{PID: 2, CPU: 1, Start: 2, Stop: 6},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			sink := &fakeSink{}
+			if tt.stream {
+				tt.opts.sink = sink
+			}
+			r := newRecorder(tt.opts, len(in))
+			for _, s := range in {
+				r.slice(s)
+			}
+			r.flush()
+			got := r.gantt
+			if tt.stream {
+				got = sink.slices
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("slices = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBusySlices(t *testing.T) {
+	t.Parallel()
+	busy := []TimeSlice{{PID: 1, Start: 0, Stop: 2}, {PID: 2, Start: 4, Stop: 5}}
+	if got := BusySlices(busy); !reflect.DeepEqual(got, busy) {
+		t.Errorf("BusySlices() = %+v, want %+v", got, busy)
+	}
+	withIdle := []TimeSlice{{Idle: true, Start: 0, Stop: 1}, busy[0], {Idle: true, Start: 2, Stop: 4}, busy[1]}
+	if got := BusySlices(withIdle); !reflect.DeepEqual(got, busy) {
+		t.Errorf("BusySlices() = %+v, want %+v", got, busy)
+	}
+}
@@ -0,0 +1,96 @@
+package scheduler
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Reservation blocks out CPUs of a batch scheduler's pool in advance, from Start for Duration,
+// such as a maintenance window or a slot guaranteed to work outside the workload.
+type Reservation struct {
+	Start    int64 `json:"start"`
+	Duration int64 `json:"duration"`
+	CPUs     int64 `json:"cpus"`
+}
+
+// End is when the reservation's CPUs are free again.
+func (r Reservation) End() int64 {
+	return r.Start + r.Duration
+}
+
+func (r Reservation) String() string {
+	cpus := "CPUs"
+	if r.CPUs == 1 {
+		cpus = "CPU"
+	}
+
+	return fmt.Sprintf("%d %s from %d to %d", r.CPUs, cpus, r.Start, r.End())
+}
+
+// WithReservations blocks out the reservations' CPUs. The batch schedulers start a process only
+// if it can run to completion without needing reserved CPUs at any time, and draw each reserved
+// window as Reserved slices in the Gantt chart. The single-CPU schedulers ignore reservations.
+func WithReservations(rs ...Reservation) Option {
+	return func(o *options) {
+		o.reservations = append(o.reservations, rs...)
+	}
+}
+
+// poolReservations are the reservations that block out any of pool, in start order, each
+// reserving no more than the pool's CPUs.
+func poolReservations(rs []Reservation, pool Resources) []Reservation {
+	var out []Reservation
+	for _, r := range rs {
+		if r.Duration <= 0 || r.CPUs <= 0 {
+			continue
+		}
+		if r.CPUs > pool[ResourceCPU] {
+			r.CPUs = pool[ResourceCPU]
+		}
+		out = append(out, r)
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Start < out[j].Start })
+
+	return out
+}
+
+// heldReservation is a reservation holding CPUs of the pool.
+type heldReservation struct {
+	end   int64
+	cores []int
+}
+
+// heldAt is what the running processes and the reservations will hold at time t, if nothing
+// more starts.
+func (s *batchState) heldAt(t int64) Resources {
+	var held Resources
+	for _, j := range s.running {
+		if j.end > t {
+			held = held.plus(s.demand[j.p])
+		}
+	}
+	for _, r := range s.reservations {
+		if r.Start <= t && t < r.End() {
+			held[ResourceCPU] += r.CPUs
+		}
+	}
+
+	return held
+}
+
+// fitsFrom reports whether process p could run from t to completion alongside the running
+// processes and the reservations. What they hold only grows as a reservation starts, so it is
+// enough to check t and the reservation starts while p would run.
+func (s *batchState) fitsFrom(p int, t int64) bool {
+	end := t + s.processes[p].BurstDuration
+	if !s.demand[p].plus(s.heldAt(t)).fits(s.pool) {
+		return false
+	}
+	for _, r := range s.reservations {
+		if r.Start > t && r.Start < end && !s.demand[p].plus(s.heldAt(r.Start)).fits(s.pool) {
+			return false
+		}
+	}
+
+	return true
+}
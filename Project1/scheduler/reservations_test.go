@@ -0,0 +1,66 @@
+package scheduler
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestBatchReservations(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 2},
+		{ProcessID: 2, BurstDuration: 3},
+		{ProcessID: 3, ArrivalTime: 1, BurstDuration: 2},
+		{ProcessID: 4, ArrivalTime: 1, BurstDuration: 1},
+	}
+	// The whole pool is down for maintenance from 3 to 7, and a reservation of no CPUs is ignored.
+	opts := []Option{WithReservations(Reservation{Start: 3, Duration: 4, CPUs: 5}, Reservation{Start: 1, Duration: 9})}
+
+	// P2 completes just as the window opens, but P3 would run into it and so waits it out,
+	// holding back P4 behind it.
+	a := BatchFCFS(Resources{2, 0})
+	got := a.Simulate(a.Title, processes, opts...)
+	wantGantt := []TimeSlice{
+		{PID: 1, CPU: 0, Start: 0, Stop: 2},
+		{PID: 2, CPU: 1, Start: 0, Stop: 3},
+		{CPU: 1, Start: 3, Stop: 7, Idle: true, Reserved: true},
+		{CPU: 0, Start: 3, Stop: 7, Idle: true, Reserved: true},
+		{PID: 3, CPU: 0, Start: 7, Stop: 9},
+		{PID: 4, CPU: 1, Start: 7, Stop: 8},
+	}
+	if !reflect.DeepEqual(got.Gantt, wantGantt) {
+		t.Errorf("gantt = %+v, want %+v", got.Gantt, wantGantt)
+	}
+	cpu := got.Resources.Usage[0]
+	if math.Abs(cpu.Utilization-8.0/18) > 1e-9 || math.Abs(cpu.Reserved-8.0/18) > 1e-9 {
+		t.Errorf("cpu usage = %+v, want 8/18 used and 8/18 reserved", cpu)
+	}
+
+	// EASY backfills P4 before the window instead.
+	a = BatchEASY(Resources{2, 0})
+	got = a.Simulate(a.Title, processes, opts...)
+	if p := got.Processes[2]; p.ID != 4 || p.Exit != 3 {
+		t.Errorf("third completion = %+v, want P4 at 3", p)
+	}
+	if p := got.Processes[3]; p.ID != 3 || p.Exit != 9 {
+		t.Errorf("last completion = %+v, want P3 at 9", p)
+	}
+}
+
+func TestBatchReservationsIdle(t *testing.T) {
+	t.Parallel()
+	// A window before the only process arrives is drawn, and nothing is idle beneath it.
+	a := BatchFCFS(Resources{1, 0})
+	got := a.Simulate(a.Title, []Process{{ProcessID: 1, ArrivalTime: 4, BurstDuration: 1}},
+		WithReservations(Reservation{Start: 1, Duration: 2, CPUs: 1}))
+	want := []TimeSlice{
+		{Start: 0, Stop: 1, Idle: true},
+		{Start: 1, Stop: 3, Idle: true, Reserved: true},
+		{Start: 3, Stop: 4, Idle: true},
+		{PID: 1, Start: 4, Stop: 5},
+	}
+	if !reflect.DeepEqual(got.Gantt, want) {
+		t.Errorf("gantt = %+v, want %+v", got.Gantt, want)
+	}
+}
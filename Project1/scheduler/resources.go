@@ -0,0 +1,395 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Resource indexes a Resources vector.
+type Resource int
+
+// The resources a process can need. The batch schedulers hold a process's whole demand of
+// every resource from when it starts until it completes.
+const (
+	ResourceCPU Resource = iota
+	ResourceGPU
+	numResources
+)
+
+var resourceNames = [numResources]string{"cpu", "gpu"}
+
+func (r Resource) String() string {
+	return resourceNames[r]
+}
+
+// Resources is an amount of each resource, indexed by Resource: what a process needs to run,
+// or what a pool of machines has.
+type Resources [numResources]int64
+
+var ErrInvalidResources = fmt.Errorf("%w: resources must be given as cpu=n,gpu=n", ErrInvalidArgs)
+
+// ParseResources parses amounts of resources given as name=n pairs separated by commas, such as
+// cpu=4,gpu=2. Resources left out are 0.
+func ParseResources(s string) (Resources, error) {
+	var r Resources
+	for _, pair := range strings.Split(s, ",") {
+		name, amount, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			return r, fmt.Errorf("%w: got %q", ErrInvalidResources, s)
+		}
+		kind := -1
+		for i, known := range resourceNames {
+			if strings.EqualFold(name, known) {
+				kind = i
+			}
+		}
+		n, err := strconv.ParseInt(amount, 10, 64)
+		if kind < 0 || err != nil || n < 0 {
+			return r, fmt.Errorf("%w: got %q", ErrInvalidResources, pair)
+		}
+		r[kind] = n
+	}
+
+	return r, nil
+}
+
+// String formats r as ParseResources reads it, e.g. cpu=4,gpu=2.
+func (r Resources) String() string {
+	pairs := make([]string, numResources)
+	for i, n := range r {
+		pairs[i] = resourceNames[i] + "=" + strconv.FormatInt(n, 10)
+	}
+
+	return strings.Join(pairs, ",")
+}
+
+// fits reports whether r is no more than free of every resource.
+func (r Resources) fits(free Resources) bool {
+	for i := range r {
+		if r[i] > free[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r Resources) plus(o Resources) Resources {
+	for i := range r {
+		r[i] += o[i]
+	}
+
+	return r
+}
+
+func (r Resources) minus(o Resources) Resources {
+	for i := range r {
+		r[i] -= o[i]
+	}
+
+	return r
+}
+
+// Demand is what the process needs to run on a batch scheduler: its Resources, with at least
+// one CPU.
+func (p Process) Demand() Resources {
+	d := p.Resources
+	if d[ResourceCPU] < 1 {
+		d[ResourceCPU] = 1
+	}
+
+	return d
+}
+
+type (
+	// ResourceReport is how a batch scheduler used its pool.
+	ResourceReport struct {
+		Usage []ResourceUsage `json:"usage"`
+		// BlockedWait is the total time processes spent queued while too little of some resource
+		// was free for them, as opposed to waiting behind other work the policy chose to run first.
+		BlockedWait int64 `json:"blocked_wait"`
+		// Shares are each user's dominant share of the pool over the run, by user.
+		Shares []UserShare `json:"shares,omitempty"`
+		// Backfilled is how many processes a backfilling scheduler started ahead of one that
+		// arrived earlier but did not fit.
+		Backfilled int `json:"backfilled,omitempty"`
+	}
+	// ResourceUsage is how one resource of a pool was used.
+	ResourceUsage struct {
+		Resource string `json:"resource"`
+		Capacity int64  `json:"capacity"`
+		// Utilization is the share of the capacity in use by processes, from time 0 to the last
+		// completion, and Reserved the share blocked out by reservations.
+		Utilization float64 `json:"utilization"`
+		Reserved    float64 `json:"reserved,omitempty"`
+		// Wait is the total time processes spent queued while too little of the resource was free.
+		Wait int64 `json:"wait"`
+	}
+)
+
+// BatchFCFS is first-come, first-served batch scheduling on a pool of resources: each process
+// starts, in arrival order, once all of its Demand is free, holds it until it completes and is
+// never preempted. A process that cannot start holds back every process that arrived after it.
+// Processes are assigned the lowest-numbered free CPUs, with a Gantt slice on each, and one that
+// needs more than the whole pool is rejected as it arrives.
+func BatchFCFS(pool Resources) Algorithm {
+	return Algorithm{
+		Name:    "batch-fcfs",
+		Version: "1",
+		Title:   "Batch first-come, first-served (" + pool.String() + ")",
+		Simulate: func(title string, processes []Process, opts ...Option) Result {
+			return batch(title, processes, newOptions(opts), pool, func(s *batchState) int {
+				if len(s.queue) > 0 && s.fits(s.queue[0]) {
+					return s.queue[0]
+				}
+				return -1
+			})
+		},
+	}
+}
+
+// batchPolicy chooses which queued process the batch engine starts now, returning -1 to start
+// nothing more until the next arrival or completion. It is called until it returns -1.
+type batchPolicy func(s *batchState) int
+
+// batchState is what a batch policy sees of a run.
+type batchState struct {
+	processes    []Process
+	demand       []Resources
+	users        []string // each process's user, its ProcessClass
+	now          int64
+	pool         Resources
+	free         Resources
+	queue        []int         // processes waiting to start, in arrival order
+	running      []batchJob    // in the order they started
+	reservations []Reservation // in start order
+	backfilled   int           // processes a backfilling policy started ahead of the head of the queue
+}
+
+// batchJob is a process holding its demand of the pool until end.
+type batchJob struct {
+	p     int
+	end   int64
+	cores []int
+}
+
+// fits reports whether process p's demand is free now and, with reservations, until it would
+// complete.
+func (s *batchState) fits(p int) bool {
+	if len(s.reservations) == 0 {
+		return s.demand[p].fits(s.free)
+	}
+
+	return s.demand[p].fits(s.free) && s.fitsFrom(p, s.now)
+}
+
+// byArrivalOnly orders by arrival time alone, so the batch schedulers queue processes arriving
+// together in input order, as FCFS serves them.
+func byArrivalOnly(t *processTable, a, b int) bool {
+	return t.arrival[a] < t.arrival[b]
+}
+
+// batch simulates a non-preemptive scheduler that runs processes on a pool of resources, each
+// holding its whole demand from start to completion, as long as pick chooses from the queue.
+// Like preemptive, the clock jumps from event to event.
+func batch(title string, processes []Process, o options, pool Resources, pick batchPolicy) Result {
+	var (
+		t        = newProcessTable(processes)
+		progress = newProgressReporter(o.progress, title, t.len())
+		rec      = newRecorder(o, t.len())
+		arrivals = newArrivalIndexBy(t, byArrivalOnly)
+		s        = &batchState{processes: processes, demand: make([]Resources, t.len()), users: make([]string, t.len()), pool: pool, free: pool}
+		busyCPU  = make([]bool, pool[ResourceCPU])
+		count    int
+		used     Resources // resource-time in use
+		wait     Resources // queued time short of each resource
+		blocked  int64
+	)
+	for i, p := range processes {
+		s.demand[i], s.users[i] = p.Demand(), ProcessClass(p)
+	}
+	s.reservations = poolReservations(o.reservations, pool)
+	var (
+		nextReservation int
+		holding         []heldReservation
+		reserved        int64 // CPUs the holding reservations have
+		reservedTime    int64 // CPU-time reserved
+	)
+	shares := newShareTracker(s.users)
+	complete := func(p int) {
+		row := t.result(p, s.now)
+		rec.process(row)
+		o.hooks.complete(s.now, row)
+		count++
+		progress.update(s.now, count)
+	}
+	admit := func(p int) {
+		o.hooks.arrival(t.arrival[p], processes[p])
+		switch {
+		case !s.demand[p].fits(pool):
+			row := ProcessResult{
+				ID:       t.pid[p],
+				Priority: t.priority[p],
+				Burst:    t.burst[p],
+				Arrival:  t.arrival[p],
+				Exit:     t.arrival[p],
+				Status:   StatusRejected,
+			}
+			rec.process(row)
+			count++
+			progress.update(s.now, count)
+		case t.burst[p] == 0:
+			complete(p)
+		default:
+			s.queue = append(s.queue, p)
+		}
+	}
+	start := func(p int) {
+		for i, q := range s.queue {
+			if q == p {
+				s.queue = append(s.queue[:i:i], s.queue[i+1:]...)
+				break
+			}
+		}
+		s.free = s.free.minus(s.demand[p])
+		job := batchJob{p: p, end: s.now + t.burst[p]}
+		for cpu := range busyCPU {
+			if int64(len(job.cores)) < s.demand[p][ResourceCPU] && !busyCPU[cpu] {
+				busyCPU[cpu] = true
+				job.cores = append(job.cores, cpu)
+			}
+		}
+		t.firstRun[p] = s.now
+		o.hooks.dispatch(s.now, processes[p], job.cores[0])
+		for _, cpu := range job.cores {
+			rec.slice(TimeSlice{PID: t.pid[p], CPU: cpu, Start: s.now, Stop: job.end})
+		}
+		s.running = append(s.running, job)
+	}
+
+	reserve := func() {
+		for ; nextReservation < len(s.reservations) && s.reservations[nextReservation].Start <= s.now; nextReservation++ {
+			r := s.reservations[nextReservation]
+			if r.End() <= s.now {
+				continue
+			}
+			h := heldReservation{end: r.End()}
+			for cpu := len(busyCPU) - 1; cpu >= 0 && int64(len(h.cores)) < r.CPUs; cpu-- { // from the top, clear of processes
+				if !busyCPU[cpu] {
+					busyCPU[cpu] = true
+					h.cores = append(h.cores, cpu)
+					rec.slice(TimeSlice{CPU: cpu, Start: s.now, Stop: h.end, Idle: true, Reserved: true})
+				}
+			}
+			s.free[ResourceCPU] -= int64(len(h.cores))
+			reserved += int64(len(h.cores))
+			holding = append(holding, h)
+		}
+	}
+
+	for len(s.queue) > 0 || len(s.running) > 0 || arrivals.pending() {
+		arrivals.admit(s.now, admit)
+		reserve()
+		for p := pick(s); p >= 0; p = pick(s) {
+			start(p)
+		}
+		shares.record(s)
+
+		next, ok := arrivals.peek()
+		for _, j := range s.running {
+			if !ok || j.end < next {
+				next, ok = j.end, true
+			}
+		}
+		for _, h := range holding {
+			if !ok || h.end < next {
+				next, ok = h.end, true
+			}
+		}
+		if nextReservation < len(s.reservations) {
+			if start := s.reservations[nextReservation].Start; !ok || start < next {
+				next, ok = start, true
+			}
+		}
+		if !ok {
+			break // nothing left that can start
+		}
+		if len(s.running) == 0 && len(holding) == 0 {
+			rec.slice(TimeSlice{Idle: true, Start: s.now, Stop: next})
+			o.hooks.idle(s.now, next)
+		}
+		elapsed := next - s.now
+		for r := range used {
+			used[r] += (pool[r] - s.free[r]) * elapsed
+		}
+		used[ResourceCPU] -= reserved * elapsed
+		reservedTime += reserved * elapsed
+		for _, p := range s.queue {
+			short := false
+			for r := range wait {
+				if s.demand[p][r] > s.free[r] {
+					wait[r] += elapsed
+					short = true
+				}
+			}
+			if short {
+				blocked += elapsed
+			}
+		}
+		s.now = next
+
+		running := s.running[:0]
+		for _, j := range s.running {
+			if j.end > s.now {
+				running = append(running, j)
+				continue
+			}
+			s.free = s.free.plus(s.demand[j.p])
+			for _, cpu := range j.cores {
+				busyCPU[cpu] = false
+			}
+			t.remaining[j.p] = 0
+			complete(j.p)
+		}
+		s.running = running
+		kept := holding[:0]
+		for _, h := range holding {
+			if h.end > s.now {
+				kept = append(kept, h)
+				continue
+			}
+			for _, cpu := range h.cores {
+				busyCPU[cpu] = false
+			}
+			s.free[ResourceCPU] += int64(len(h.cores))
+			reserved -= int64(len(h.cores))
+		}
+		holding = kept
+	}
+	rec.flush()
+	progress.finish(s.now, count)
+
+	shares.record(s)
+	r := Result{Title: title, Gantt: rec.gantt, Processes: rec.processes, Resources: &ResourceReport{
+		BlockedWait: blocked,
+		Shares:      shares.finish(s.now),
+		Backfilled:  s.backfilled,
+	}}
+	for kind, capacity := range pool {
+		if capacity == 0 {
+			continue
+		}
+		u := ResourceUsage{Resource: resourceNames[kind], Capacity: capacity, Wait: wait[kind]}
+		if s.now > 0 {
+			u.Utilization = float64(used[kind]) / float64(capacity*s.now)
+			if Resource(kind) == ResourceCPU {
+				u.Reserved = float64(reservedTime) / float64(capacity*s.now)
+			}
+		}
+		r.Resources.Usage = append(r.Resources.Usage, u)
+	}
+	rec.totals.apply(&r)
+
+	return r
+}
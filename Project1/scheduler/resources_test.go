@@ -0,0 +1,88 @@
+package scheduler
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestParseResources(t *testing.T) {
+	t.Parallel()
+	got, err := ParseResources("cpu=4, GPU=2")
+	if err != nil || got != (Resources{4, 2}) {
+		t.Errorf("ParseResources() = %v, %v", got, err)
+	}
+	if got.String() != "cpu=4,gpu=2" {
+		t.Errorf("String() = %q", got.String())
+	}
+	for _, bad := range []string{"", "cpu", "tpu=1", "cpu=-1", "cpu=x"} {
+		if _, err := ParseResources(bad); !errors.Is(err, ErrInvalidResources) {
+			t.Errorf("ParseResources(%q) error = %v", bad, err)
+		}
+	}
+	if d := (Process{}).Demand(); d != (Resources{1, 0}) {
+		t.Errorf("Demand() = %v, want one CPU", d)
+	}
+}
+
+func TestBatchFCFS(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 4, Resources: Resources{1, 1}},
+		{ProcessID: 2, ArrivalTime: 0, BurstDuration: 3, Resources: Resources{2, 0}},
+		{ProcessID: 3, ArrivalTime: 1, BurstDuration: 2},
+		{ProcessID: 4, ArrivalTime: 1, BurstDuration: 1, Resources: Resources{3, 0}},
+	}
+	a := BatchFCFS(Resources{2, 1})
+	got := a.Simulate(a.Title, processes)
+
+	// P2 waits for both CPUs until P1 completes, and P3, which would fit alongside P1, waits behind it.
+	want := []ProcessResult{
+		{ID: 4, Burst: 1, Arrival: 1, Exit: 1, Status: StatusRejected},
+		{ID: 1, Burst: 4, Arrival: 0, Turnaround: 4, Exit: 4},
+		{ID: 2, Burst: 3, Arrival: 0, Wait: 4, Turnaround: 7, Response: 4, Exit: 7},
+		{ID: 3, Burst: 2, Arrival: 1, Wait: 6, Turnaround: 8, Response: 6, Exit: 9},
+	}
+	if !reflect.DeepEqual(got.Processes, want) {
+		t.Errorf("processes = %+v, want %+v", got.Processes, want)
+	}
+	wantGantt := []TimeSlice{
+		{PID: 1, CPU: 0, Start: 0, Stop: 4},
+		{PID: 2, CPU: 0, Start: 4, Stop: 7},
+		{PID: 2, CPU: 1, Start: 4, Stop: 7},
+		{PID: 3, CPU: 0, Start: 7, Stop: 9},
+	}
+	if !reflect.DeepEqual(got.Gantt, wantGantt) {
+		t.Errorf("gantt = %+v, want %+v", got.Gantt, wantGantt)
+	}
+	if got.Incomplete != 1 {
+		t.Errorf("Incomplete = %d, want 1", got.Incomplete)
+	}
+
+	// P2 is short of CPUs from 0 to 4 and P3 from 4 to 7; the GPU is never short.
+	r := got.Resources
+	if r == nil || r.BlockedWait != 7 || len(r.Usage) != 2 {
+		t.Fatalf("Resources = %+v", r)
+	}
+	cpu, gpu := r.Usage[0], r.Usage[1]
+	if cpu.Resource != "cpu" || cpu.Capacity != 2 || cpu.Wait != 7 || math.Abs(cpu.Utilization-12.0/18) > 1e-9 {
+		t.Errorf("cpu usage = %+v", cpu)
+	}
+	if gpu.Resource != "gpu" || gpu.Capacity != 1 || gpu.Wait != 0 || math.Abs(gpu.Utilization-4.0/9) > 1e-9 {
+		t.Errorf("gpu usage = %+v", gpu)
+	}
+}
+
+func TestBatchFCFSIdle(t *testing.T) {
+	t.Parallel()
+	a := BatchFCFS(Resources{1, 0})
+	got := a.Simulate(a.Title, []Process{{ProcessID: 1, ArrivalTime: 2, BurstDuration: 1}, {ProcessID: 2, ArrivalTime: 3}})
+	wantGantt := []TimeSlice{{Idle: true, Start: 0, Stop: 2}, {PID: 1, Start: 2, Stop: 3}}
+	if !reflect.DeepEqual(got.Gantt, wantGantt) {
+		t.Errorf("gantt = %+v, want %+v", got.Gantt, wantGantt)
+	}
+	if len(got.Processes) != 2 || got.Processes[1].Exit != 3 {
+		t.Errorf("processes = %+v", got.Processes)
+	}
+}
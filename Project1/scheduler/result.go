@@ -0,0 +1,149 @@
+package scheduler
+
+type (
+	// Result is the outcome of running one scheduler over a workload.
+	Result struct {
+		Algorithm     string          `json:"algorithm,omitempty"`
+		Input         string          `json:"input,omitempty"`
+		Title         string          `json:"title"`
+		Gantt         []TimeSlice     `json:"gantt,omitempty"`
+		Processes     []ProcessResult `json:"processes"`
+		AveWait       float64         `json:"average_wait"`
+		AveTurnaround float64         `json:"average_turnaround"`
+		AveResponse   float64         `json:"average_response"`
+		AveThroughput float64         `json:"throughput"`
+		// Incomplete counts the processes that never completed; they are left out of the averages.
+		Incomplete int `json:"incomplete,omitempty"`
+		// Prediction is the burst predictor's accuracy, for schedulers that predict bursts.
+		Prediction *PredictionAccuracy `json:"prediction,omitempty"`
+		// Overload is what the Overload middleware did to the workload, when it wrapped the scheduler.
+		Overload *OverloadReport `json:"overload,omitempty"`
+		// SLA is how the run did against its processes' SLA targets, when CheckSLA wrapped the scheduler.
+		SLA *SLAReport `json:"sla,omitempty"`
+		// Resources is how a batch scheduler used its pool of resources.
+		Resources *ResourceReport `json:"resources,omitempty"`
+		// Preemption is what the minimum granularity and preemption budget did to the run, when
+		// PreemptionEffect wrapped the scheduler.
+		Preemption *PreemptionReport `json:"preemption,omitempty"`
+		// Tickets is each process's entitled and achieved CPU share, for the proportional-share
+		// schedulers.
+		Tickets *TicketReport `json:"tickets,omitempty"`
+		// Bandwidth is how each process with a CPU reservation used it, for CBS.
+		Bandwidth *BandwidthReport `json:"bandwidth,omitempty"`
+		// Slack is how much of the hard processes' slack the soft ones reclaimed, for Slack.
+		Slack *SlackReport `json:"slack,omitempty"`
+		// Memory is how long processes waited for memory, for MemoryAdmission.
+		Memory *MemoryReport `json:"memory,omitempty"`
+		// Sporadic is how a sporadic server served the aperiodic processes, for SporadicServer.
+		Sporadic *SporadicReport `json:"sporadic,omitempty"`
+		// Promotions are how many times each process was promoted, for AgingPriorityRR.
+		Promotions []Promotion `json:"promotions,omitempty"`
+		// Lags are the range of each process's lag, for EEVDF.
+		Lags []ProcessLag `json:"lags,omitempty"`
+		// Levels are the level each process completed in, for MLFQ.
+		Levels []QueueLevel `json:"levels,omitempty"`
+		// Cycles are the quantum of every cycle of a dynamic-quantum round-robin run.
+		Cycles     []RRCycle   `json:"cycles,omitempty"`
+		Provenance *Provenance `json:"provenance,omitempty"`
+	}
+	// ProcessResult is one row of the schedule table.
+	ProcessResult struct {
+		ID         int64 `json:"id"`
+		Priority   int64 `json:"priority"`
+		Burst      int64 `json:"burst"`
+		Arrival    int64 `json:"arrival"`
+		Wait       int64 `json:"wait"`
+		Turnaround int64 `json:"turnaround"`
+		// Response is the time from arrival to first running.
+		Response int64 `json:"response"`
+		// Exit is when the process completed or, if Status is set, when it left the system.
+		Exit   int64  `json:"exit"`
+		Status Status `json:"status,omitempty"`
+		// Deadline is the absolute time the process had to complete by, 0 for none.
+		Deadline int64 `json:"deadline,omitempty"`
+	}
+)
+
+// Status is why a process left the system without completing; empty means it completed.
+type Status string
+
+const (
+	StatusKilled   Status = "killed"
+	StatusRejected Status = "rejected"
+	StatusAborted  Status = "aborted"
+	StatusShed     Status = "shed"
+)
+
+// Completed reports whether the process ran to completion.
+func (p ProcessResult) Completed() bool {
+	return p.Status == ""
+}
+
+// Lateness is how long after its deadline the process completed or left the system, or 0 if
+// it met its deadline or had none.
+func (p ProcessResult) Lateness() int64 {
+	if p.Deadline > 0 && p.Exit > p.Deadline {
+		return p.Exit - p.Deadline
+	}
+
+	return 0
+}
+
+// MissedDeadline reports whether the process had a deadline and did not complete by it.
+func (p ProcessResult) MissedDeadline() bool {
+	return p.Deadline > 0 && (p.Exit > p.Deadline || !p.Completed())
+}
+
+// runTotals accumulates a run's averages as its process rows are produced, including rows
+// that are streamed rather than kept. Only completed processes count toward the averages and
+// throughput; killed, rejected and aborted ones are counted apart, so every scheduler defines
+// its averages the same way.
+type runTotals struct {
+	wait, turnaround      float64
+	response              float64
+	completed, incomplete int
+	lastCompletion        int64
+}
+
+func (t *runTotals) add(p ProcessResult) {
+	if !p.Completed() {
+		t.incomplete++
+		return
+	}
+	t.completed++
+	t.wait += float64(p.Wait)
+	t.turnaround += float64(p.Turnaround)
+	t.response += float64(p.Response)
+	if p.Exit > t.lastCompletion {
+		t.lastCompletion = p.Exit
+	}
+}
+
+// apply sets the result's averages, throughput and incomplete count.
+func (t runTotals) apply(r *Result) {
+	r.AveWait = average(t.wait, t.completed)
+	r.AveTurnaround = average(t.turnaround, t.completed)
+	r.AveResponse = average(t.response, t.completed)
+	r.AveThroughput = throughput(t.completed, float64(t.lastCompletion))
+	r.Incomplete = t.incomplete
+}
+
+// average is the mean of n values summing to sum. An empty workload averages 0 rather than NaN,
+// so its results still encode as JSON and compare in thresholds.
+func average(sum float64, n int) float64 {
+	if n == 0 {
+		return 0
+	}
+
+	return sum / float64(n)
+}
+
+// throughput is n completions per unit of time up to the last completion, or 0 when nothing
+// completed or everything completed at time 0 (e.g. only zero-burst processes), which has no rate.
+func throughput(n int, lastCompletion float64) float64 {
+	if n == 0 || lastCompletion <= 0 {
+		return 0
+	}
+
+	return float64(n) / lastCompletion
+}
@@ -0,0 +1,24 @@
+package scheduler
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_runTotals_incomplete(t *testing.T) {
+	t.Parallel()
+	var totals runTotals
+	for _, p := range []ProcessResult{
+		{ID: 1, Burst: 2, Wait: 0, Turnaround: 2, Exit: 2},
+		{ID: 2, Burst: 3, Wait: 5, Turnaround: 6, Exit: 7, Status: StatusKilled},
+		{ID: 3, Burst: 1, Wait: 2, Turnaround: 3, Exit: 4},
+	} {
+		totals.add(p)
+	}
+	var r Result
+	totals.apply(&r)
+	want := Result{AveWait: 1, AveTurnaround: 2.5, AveThroughput: 2.0 / 4, Incomplete: 1}
+	if !reflect.DeepEqual(r, want) {
+		t.Errorf("apply() = %+v, want %+v", r, want)
+	}
+}
@@ -0,0 +1,97 @@
+// Package scheduler simulates CPU scheduling algorithms over a workload of processes.
+//
+// Each algorithm in Algorithms turns a []Process into a Result: the schedule table of
+// every process, the Gantt chart of the time slices it ran in, and the run's averages.
+// Options control what a run reports on the side, such as progress lines or a Sink that
+// receives rows as they are produced instead of buffering them in the Result. Loading
+// workloads lives in package workload and rendering results in package render.
+package scheduler
+
+import (
+	"errors"
+	"math"
+)
+
+type (
+	// Process is one job in a workload. Name is optional and only set by importers whose
+	// source format names its jobs; SLA is optional too. Resources is what the process needs on
+	// the batch schedulers, which run on a pool of resources rather than a single CPU. Queue
+	// names the multilevel queue the process belongs to, in place of the one its Priority maps to.
+	// Tickets are its share of the CPU for the proportional-share schedulers; see TicketCount.
+	// Deadline, if set, is how long after arriving it must complete by. Runtime and Period, if
+	// set, reserve Runtime of CPU time every Period for CBS; see Reserved. Hard marks a process
+	// whose Deadline must be met, for Slack. Memory is what it holds while admitted, for
+	// MemoryAdmission. Nice is its Linux nice value, -20 to 19, for Nice; see NiceWeight.
+	// IOInterval, if set, is how much CPU time the process runs between I/O requests, each of
+	// which blocks it for IODuration, under the schedulers that model I/O, such as MLFQ; the
+	// others treat every process as CPU-bound.
+	Process struct {
+		ProcessID     int64
+		Name          string
+		ArrivalTime   int64
+		BurstDuration int64
+		Priority      int64
+		SLA           SLA
+		Resources     Resources
+		Queue         string
+		Tickets       int64
+		Deadline      int64
+		Runtime       int64
+		Period        int64
+		Hard          bool
+		Memory        int64
+		Nice          int64
+		IOInterval    int64
+		IODuration    int64
+	}
+	// TimeSlice is a span of time a process ran, or, for an Idle slice, a span in which
+	// no process was ready. CPU is the core it ran on; the built-in schedulers simulate a
+	// single CPU, numbered 0. A Reserved slice is an Idle one in which the CPU was blocked
+	// out by a Reservation. Queue is the multilevel queue that served the slice, if any.
+	TimeSlice struct {
+		PID      int64  `json:"pid"`
+		CPU      int    `json:"cpu,omitempty"`
+		Start    int64  `json:"start"`
+		Stop     int64  `json:"stop"`
+		Idle     bool   `json:"idle,omitempty"`
+		Reserved bool   `json:"reserved,omitempty"`
+		Queue    string `json:"queue,omitempty"`
+	}
+)
+
+// AbsoluteDeadline is the time the process must complete by, or 0 if it has no Deadline. A
+// deadline past the largest int64 time is that time, which no schedule reaches.
+func (p Process) AbsoluteDeadline() int64 {
+	if p.Deadline <= 0 {
+		return 0
+	}
+	if p.Deadline > math.MaxInt64-p.ArrivalTime {
+		return math.MaxInt64
+	}
+
+	return p.ArrivalTime + p.Deadline
+}
+
+func minimum(x, y int64) int64 {
+	if x < y {
+		return x
+	}
+	return y
+}
+
+// grow is x plus rate*d, for non-negative x, rate and d, stopping at the largest int64 rather
+// than wrapping. Schedulers whose keys grow faster than the clock, such as stride passes, use it
+// so a workload whose times fit still orders its processes.
+func grow(x, rate, d int64) int64 {
+	if rate > 0 && d > (math.MaxInt64-x)/rate {
+		return math.MaxInt64
+	}
+
+	return x + rate*d
+}
+
+// ErrInvalidArgs is wrapped by every error about invalid arguments or settings.
+var ErrInvalidArgs = errors.New("invalid args")
+
+// DefaultQuantum is the round-robin time quantum.
+const DefaultQuantum = 2
@@ -0,0 +1,34 @@
+package schedulertest_test
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+	"github.com/jh125486/CSCE4600/Project1/scheduler/schedulertest"
+)
+
+func ExampleScripted() {
+	p1 := scheduler.Process{ProcessID: 1, BurstDuration: 3}
+	simulate := scheduler.Chain(schedulertest.Scripted(scheduler.Result{},
+		scheduler.Event{Time: 0, Kind: scheduler.EventArrival, Process: p1},
+		scheduler.Event{Time: 0, Kind: scheduler.EventDispatch, Process: p1},
+		scheduler.Event{Time: 3, Kind: scheduler.EventComplete, Result: scheduler.ProcessResult{ID: 1, Exit: 3}},
+	), scheduler.Logging(os.Stdout))
+	simulate("Scripted", nil)
+	// Output:
+	// Scripted @0: P1 arrived
+	// Scripted @0: P1 dispatched on CPU 0
+	// Scripted @3: P1 completed
+}
+
+func ExampleClock() {
+	var clock schedulertest.Clock
+	scheduler.FCFS("FCFS", []scheduler.Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5},
+		{ProcessID: 2, ArrivalTime: 3, BurstDuration: 9},
+	}, scheduler.WithClock(&clock))
+	fmt.Println(clock.Times(), "ends at", clock.Now())
+	// Output:
+	// [0 0 3 5 5 14] ends at 14
+}
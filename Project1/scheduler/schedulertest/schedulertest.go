@@ -0,0 +1,84 @@
+// Package schedulertest provides in-memory fakes of the scheduler package's clock and event
+// queue, and a scripted scheduler, so programs embedding the library can unit test their
+// hooks, middleware and schedulers deterministically without running full simulations.
+package schedulertest
+
+import (
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+// Clock is a fake scheduler.Clock that remembers every time it was advanced to.
+// The zero value starts at time 0.
+type Clock struct {
+	now   int64
+	times []int64
+}
+
+// Now is the time the clock was last set or advanced to.
+func (c *Clock) Now() int64 {
+	return c.now
+}
+
+// AdvanceTo moves the clock to t and records t. It does not check that t is later than Now,
+// so that tests can assert that whatever drives the clock never moves it backwards.
+func (c *Clock) AdvanceTo(t int64) {
+	c.now = t
+	c.times = append(c.times, t)
+}
+
+// Set moves the clock to t without recording it, to put it at a starting time.
+func (c *Clock) Set(t int64) {
+	c.now = t
+}
+
+// Times are the times the clock was advanced to, in order, including repeats.
+func (c *Clock) Times() []int64 {
+	return append([]int64(nil), c.times...)
+}
+
+// Queue is a fake scheduler.EventQueue that replays events in the order they were pushed.
+// The zero value is an empty queue.
+type Queue struct {
+	events []scheduler.Event
+}
+
+// NewQueue returns a queue holding events.
+func NewQueue(events ...scheduler.Event) *Queue {
+	return &Queue{events: append([]scheduler.Event(nil), events...)}
+}
+
+func (q *Queue) Push(e scheduler.Event) {
+	q.events = append(q.events, e)
+}
+
+func (q *Queue) Pop() (scheduler.Event, bool) {
+	if len(q.events) == 0 {
+		return scheduler.Event{}, false
+	}
+	e := q.events[0]
+	q.events = q.events[1:]
+
+	return e, true
+}
+
+func (q *Queue) Len() int {
+	return len(q.events)
+}
+
+// Events are the events still queued, in order.
+func (q *Queue) Events() []scheduler.Event {
+	return append([]scheduler.Event(nil), q.events...)
+}
+
+// Scripted returns a scheduler that reports events to the run's hooks and clock, as
+// scheduler.Replay does, then returns result with the title it was given, whatever the
+// processes. It stands in for a real scheduler under middleware being tested.
+func Scripted(result scheduler.Result, events ...scheduler.Event) scheduler.SimulateFunc {
+	return func(title string, _ []scheduler.Process, opts ...scheduler.Option) scheduler.Result {
+		scheduler.Replay(NewQueue(events...), opts...)
+		r := result
+		r.Title = title
+
+		return r
+	}
+}
@@ -0,0 +1,69 @@
+package schedulertest
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+func TestQueue(t *testing.T) {
+	t.Parallel()
+	a := scheduler.Event{Time: 1, Kind: scheduler.EventArrival}
+	b := scheduler.Event{Time: 2, Kind: scheduler.EventIdle, Until: 4}
+	q := NewQueue(a)
+	q.Push(b)
+	if got := q.Events(); !reflect.DeepEqual(got, []scheduler.Event{a, b}) {
+		t.Errorf("Events() = %v", got)
+	}
+	for _, want := range []scheduler.Event{a, b} {
+		if got, ok := q.Pop(); !ok || got != want {
+			t.Errorf("Pop() = %v, %t, want %v", got, ok, want)
+		}
+	}
+	if _, ok := q.Pop(); ok || q.Len() != 0 {
+		t.Errorf("Pop() of an empty queue = %t, Len() = %d", ok, q.Len())
+	}
+}
+
+func TestClock(t *testing.T) {
+	t.Parallel()
+	var c Clock
+	c.Set(5)
+	c.AdvanceTo(7)
+	c.AdvanceTo(6)
+	if c.Now() != 6 {
+		t.Errorf("Now() = %d, want 6", c.Now())
+	}
+	if got, want := c.Times(), []int64{7, 6}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Times() = %v, want %v", got, want)
+	}
+}
+
+func TestScripted(t *testing.T) {
+	t.Parallel()
+	var (
+		clock Clock
+		seen  Queue
+	)
+	events := []scheduler.Event{
+		{Time: 0, Kind: scheduler.EventIdle, Until: 2},
+		{Time: 2, Kind: scheduler.EventArrival, Process: scheduler.Process{ProcessID: 1}},
+		{Time: 2, Kind: scheduler.EventDispatch, Process: scheduler.Process{ProcessID: 1}},
+		{Time: 3, Kind: scheduler.EventPreempt, Process: scheduler.Process{ProcessID: 1}, Remaining: 1},
+		{Time: 4, Kind: scheduler.EventComplete, Result: scheduler.ProcessResult{ID: 1}},
+	}
+	want := scheduler.Result{AveWait: 1.5}
+	got := Scripted(want, events...)("title", nil, scheduler.WithClock(&clock), scheduler.WithHooks(scheduler.Record(&seen)))
+
+	want.Title = "title"
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Scripted() = %+v, want %+v", got, want)
+	}
+	if !reflect.DeepEqual(seen.Events(), events) {
+		t.Errorf("hooks saw %v, want %v", seen.Events(), events)
+	}
+	if want := []int64{0, 2, 2, 3, 4}; !reflect.DeepEqual(clock.Times(), want) {
+		t.Errorf("clock advanced to %v, want %v", clock.Times(), want)
+	}
+}
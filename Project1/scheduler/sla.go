@@ -0,0 +1,95 @@
+package scheduler
+
+type (
+	// SLA is a process's service-level targets: the turnaround and response times it should
+	// finish and start within. A target of 0 means none.
+	SLA struct {
+		Turnaround int64
+		Response   int64
+	}
+	// SLAReport is how a run did against its processes' SLA targets.
+	SLAReport struct {
+		// Targets counts the processes with at least one target.
+		Targets int `json:"targets"`
+		// Violations counts the processes that missed at least one target, including those that
+		// never completed.
+		Violations int `json:"violations"`
+		// Missed counts the processes with a target that never completed.
+		Missed int `json:"missed,omitempty"`
+		// Magnitude is the total time by which completed processes overshot their targets.
+		Magnitude int64 `json:"magnitude"`
+		// Worst is the completed process that overshot a target by the most, lowest PID first.
+		Worst *SLAViolation `json:"worst,omitempty"`
+	}
+	// SLAViolation is one process missing one of its targets.
+	SLAViolation struct {
+		PID    int64  `json:"pid"`
+		Metric string `json:"metric"` // turnaround or response
+		Target int64  `json:"target"`
+		Actual int64  `json:"actual"`
+	}
+)
+
+// Overshoot is how far the process missed its target by.
+func (v SLAViolation) Overshoot() int64 {
+	return v.Actual - v.Target
+}
+
+// CheckSLA compares every process row against the SLA targets of its process and reports the
+// violations in the Result's SLA, so schedulers can be judged by service objectives rather than
+// averages. A process that never completed misses its targets. It leaves a workload without
+// targets alone.
+func CheckSLA() Middleware {
+	return func(next SimulateFunc) SimulateFunc {
+		return func(title string, processes []Process, opts ...Option) Result {
+			targets := make(map[int64]SLA)
+			for _, p := range processes {
+				if p.SLA != (SLA{}) {
+					targets[p.ProcessID] = p.SLA
+				}
+			}
+			if len(targets) == 0 {
+				return next(title, processes, opts...)
+			}
+
+			report := &SLAReport{Targets: len(targets)}
+			r := rewriteRows(next, title, processes, opts, func(row ProcessResult) ProcessResult {
+				report.add(targets[row.ID], row)
+				return row
+			}, nil)
+			r.SLA = report
+
+			return r
+		}
+	}
+}
+
+// add checks one process row against its targets.
+func (s *SLAReport) add(target SLA, row ProcessResult) {
+	if target == (SLA{}) {
+		return
+	}
+	if !row.Completed() {
+		s.Violations++
+		s.Missed++
+		return
+	}
+	violated := false
+	for _, v := range []SLAViolation{
+		{PID: row.ID, Metric: "turnaround", Target: target.Turnaround, Actual: row.Turnaround},
+		{PID: row.ID, Metric: "response", Target: target.Response, Actual: row.Response},
+	} {
+		if v.Target == 0 || v.Overshoot() <= 0 {
+			continue
+		}
+		violated = true
+		s.Magnitude += v.Overshoot()
+		if w := s.Worst; w == nil || v.Overshoot() > w.Overshoot() || v.Overshoot() == w.Overshoot() && v.PID < w.PID {
+			v := v
+			s.Worst = &v
+		}
+	}
+	if violated {
+		s.Violations++
+	}
+}
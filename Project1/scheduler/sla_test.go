@@ -0,0 +1,45 @@
+package scheduler
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCheckSLA(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5, SLA: SLA{Turnaround: 10}},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 9, SLA: SLA{Turnaround: 10, Response: 2}},
+		{ProcessID: 3, ArrivalTime: 2, BurstDuration: 2},
+		{ProcessID: 4, ArrivalTime: 3, BurstDuration: 1, SLA: SLA{Response: 1}},
+	}
+	// FCFS: P1 0-5, P2 5-14 (turnaround 13, response 4), P3 14-16, P4 16-17 (response 13).
+	got := Chain(FCFS, CheckSLA())("test", processes)
+	want := SLAReport{
+		Targets:    3,
+		Violations: 2,
+		Magnitude:  (13 - 10) + (4 - 2) + (13 - 1),
+		Worst:      &SLAViolation{PID: 4, Metric: "response", Target: 1, Actual: 13},
+	}
+	if got.SLA == nil || !reflect.DeepEqual(*got.SLA, want) {
+		t.Errorf("SLA = %+v, want %+v", got.SLA, want)
+	}
+	if plain := FCFS("test", processes); !reflect.DeepEqual(got.Processes, plain.Processes) {
+		t.Errorf("processes = %+v, want them unchanged: %+v", got.Processes, plain.Processes)
+	}
+
+	t.Run("never completed", func(t *testing.T) {
+		t.Parallel()
+		got := Chain(FCFS, CheckSLA(), Admission(func(p Process) bool { return p.ProcessID != 1 }))("test", processes)
+		// P2 now runs 1-10 within its targets; P4 still starts 9 late.
+		if got.SLA.Missed != 1 || got.SLA.Violations != 2 || got.SLA.Magnitude != 8 {
+			t.Errorf("SLA = %+v, want 1 missed of 2 violations, overshooting by 8", got.SLA)
+		}
+	})
+	t.Run("no targets", func(t *testing.T) {
+		t.Parallel()
+		if got := Chain(FCFS, CheckSLA())("test", exampleProcesses); got.SLA != nil {
+			t.Errorf("SLA = %+v, want nil", got.SLA)
+		}
+	})
+}
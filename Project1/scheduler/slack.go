@@ -0,0 +1,102 @@
+package scheduler
+
+import "sort"
+
+// SlackReport is how much of the hard processes' slack a Slack run gave to the soft ones.
+type SlackReport struct {
+	// Reclaimed is the CPU time soft processes ran while hard processes were ready and waiting.
+	Reclaimed int64 `json:"reclaimed"`
+}
+
+// Slack simulates slack stealing for a mix of hard and soft processes: hard processes (Hard,
+// with a Deadline) are scheduled by EDF, but only as late as their deadlines allow. Whenever the
+// hard processes that have not completed, including those still to arrive, could all give up the
+// CPU and still meet their deadlines, the soft processes run in arrival order in that slack; the
+// moment the slack reaches 0 the hard ones take over. Processes not hard are soft, whatever their
+// deadline. Its Result reports how much slack the soft processes reclaimed.
+func Slack(title string, processes []Process, opts ...Option) Result {
+	return slack(title, processes, newOptions(opts))
+}
+
+func slack(title string, processes []Process, o options) Result {
+	var (
+		e         = newQuantumEngine(title, processes, o)
+		t         = e.t
+		hard      []int // ready hard processes
+		soft      []int // ready soft processes, in arrival order
+		byDue     []int // every hard process, by deadline
+		stealing  bool  // whether the process picked last is soft, run while hard ones wait
+		reclaimed int64
+	)
+	isHard := func(p int) bool { return processes[p].Hard && processes[p].Deadline > 0 }
+	for i := range processes {
+		if isHard(i) {
+			byDue = append(byDue, i)
+		}
+	}
+	sort.SliceStable(byDue, func(i, j int) bool { return t.deadline[byDue[i]] < t.deadline[byDue[j]] })
+	// spare is the slack of the hard processes left: the least time to spare before any deadline,
+	// after all the hard work due by it, if any hard work is left.
+	spare := func() (int64, bool) {
+		var (
+			least, due int64
+			ok         bool
+		)
+		for _, p := range byDue {
+			if t.remaining[p] == 0 {
+				continue
+			}
+			due += t.remaining[p]
+			if s := t.deadline[p] - e.now - due; !ok || s < least {
+				least, ok = s, true
+			}
+		}
+		return least, ok
+	}
+
+	r := e.run(quantumPolicy{
+		admit: func(p int) {
+			if isHard(p) {
+				hard = append(hard, p)
+			} else {
+				soft = append(soft, p)
+			}
+		},
+		pick: func() (int, int64, string) {
+			s, _ := spare()
+			switch {
+			case len(hard) == 0:
+				// Hard processes yet to arrive cannot use the CPU before they do anyway.
+				stealing = false
+				return soft[0], t.remaining[soft[0]], ""
+			case len(soft) > 0 && s > 0:
+				stealing = true
+				return soft[0], s, ""
+			}
+			stealing = false
+			i := 0
+			for j, p := range hard {
+				if byDeadline(t, p, hard[i]) {
+					i = j
+				}
+			}
+			return hard[i], t.remaining[hard[i]], ""
+		},
+		ran: func(_ int, run int64) {
+			if stealing {
+				reclaimed += run
+			}
+		},
+		done: func(p int) {
+			if isHard(p) {
+				hard, _ = without(hard, p)
+			} else {
+				soft, _ = without(soft, p)
+			}
+		},
+		preemptOnArrival: true,
+	})
+	r.Slack = &SlackReport{Reclaimed: reclaimed}
+
+	return r
+}
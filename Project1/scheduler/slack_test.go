@@ -0,0 +1,33 @@
+package scheduler
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSlack(t *testing.T) {
+	t.Parallel()
+	r := Slack("slack", []Process{
+		{ProcessID: 1, BurstDuration: 3, Deadline: 6, Hard: true},
+		{ProcessID: 2, BurstDuration: 2, Deadline: 1}, // a soft deadline is not guaranteed
+		{ProcessID: 3, ArrivalTime: 1, BurstDuration: 2},
+	})
+	// P1 has 3 to spare, which the soft processes take before it runs just in time.
+	want := []TimeSlice{
+		{PID: 2, Start: 0, Stop: 2},
+		{PID: 3, Start: 2, Stop: 3},
+		{PID: 1, Start: 3, Stop: 6},
+		{PID: 3, Start: 6, Stop: 7},
+	}
+	if !reflect.DeepEqual(r.Gantt, want) {
+		t.Errorf("Slack() gantt = %+v, want %+v", r.Gantt, want)
+	}
+	if r.Slack.Reclaimed != 3 {
+		t.Errorf("Slack() reclaimed %d, want 3", r.Slack.Reclaimed)
+	}
+	for _, p := range r.Processes {
+		if missed := p.ID == 2; p.MissedDeadline() != missed {
+			t.Errorf("P%d missed deadline: %v, want %v", p.ID, p.MissedDeadline(), missed)
+		}
+	}
+}
@@ -0,0 +1,254 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidSporadic is wrapped by every error in a sporadic server configuration.
+var ErrInvalidSporadic = fmt.Errorf("%w: invalid sporadic server", ErrInvalidArgs)
+
+type (
+	// SporadicConfig configures a sporadic server: it may serve aperiodic processes for Budget
+	// of CPU time, which is replenished Period after it started being spent.
+	SporadicConfig struct {
+		Budget int64
+		Period int64
+	}
+	// SporadicReport is how the aperiodic processes of a sporadic server run did against
+	// background-only service of the same workload, which runs them only when no periodic task
+	// is ready. Response times are from arrival to completion.
+	SporadicReport struct {
+		Budget                int64               `json:"budget"`
+		Period                int64               `json:"period"`
+		AveResponse           float64             `json:"average_response"`
+		AveBackgroundResponse float64             `json:"average_background_response"`
+		Processes             []AperiodicResponse `json:"processes"`
+	}
+	// AperiodicResponse is one aperiodic process's part of a SporadicReport: its response time
+	// with the server and with background-only service.
+	AperiodicResponse struct {
+		ID         int64 `json:"id"`
+		Response   int64 `json:"response"`
+		Background int64 `json:"background"`
+	}
+)
+
+// ParseSporadic parses a sporadic server configuration given as the budget and the period
+// separated by a colon, as SporadicConfig.String formats it: e.g. 2:10.
+func ParseSporadic(s string) (SporadicConfig, error) {
+	var c SporadicConfig
+	budget, period, ok := strings.Cut(s, ":")
+	if !ok {
+		return c, fmt.Errorf("%w: want budget:period, got %q", ErrInvalidSporadic, s)
+	}
+	var err error
+	if c.Budget, err = strconv.ParseInt(strings.TrimSpace(budget), 10, 64); err != nil {
+		return c, fmt.Errorf("%w: the budget must be an integer, got %q", ErrInvalidSporadic, budget)
+	}
+	if c.Period, err = strconv.ParseInt(strings.TrimSpace(period), 10, 64); err != nil {
+		return c, fmt.Errorf("%w: the period must be an integer, got %q", ErrInvalidSporadic, period)
+	}
+
+	return c, c.check()
+}
+
+func (c SporadicConfig) check() error {
+	if c.Budget <= 0 || c.Period <= 0 {
+		return fmt.Errorf("%w: the budget and period must be positive, got %s", ErrInvalidSporadic, c)
+	}
+	if c.Budget > c.Period {
+		return fmt.Errorf("%w: the budget must not exceed the period, got %s", ErrInvalidSporadic, c)
+	}
+
+	return nil
+}
+
+// String formats c as ParseSporadic reads it, e.g. 2:10.
+func (c SporadicConfig) String() string {
+	return strconv.FormatInt(c.Budget, 10) + ":" + strconv.FormatInt(c.Period, 10)
+}
+
+// SporadicServer simulates a sporadic server in a periodic system. Processes with a Runtime and
+// Period (see Reserved) are periodic tasks: from their arrival, each needs Runtime of CPU time
+// in every Period until its burst is done, and they run at fixed rate-monotonic priority, the
+// shortest period first. The other processes are aperiodic and are served in arrival order by a
+// server of priority c.Period (below a task of the same period) for up to c.Budget of CPU time;
+// each stretch of budget the server spends is replenished c.Period after the stretch started.
+// With the budget spent, aperiodic processes still run in the background whenever no periodic
+// task is ready. Each Gantt slice's Queue is "periodic", "server" or "background". Its Result
+// reports every aperiodic process's response time against background-only service, and its
+// name is ss-<config>, such as ss-2:10.
+func SporadicServer(c SporadicConfig) (Algorithm, error) {
+	if err := c.check(); err != nil {
+		return Algorithm{}, err
+	}
+
+	return Algorithm{
+		Name:    "ss-" + c.String(),
+		Version: "2",
+		Title:   "Sporadic server (" + c.String() + ")",
+		Simulate: func(title string, processes []Process, opts ...Option) Result {
+			// A server without budget leaves the aperiodic processes only the background.
+			base := sporadic(title, processes, newOptions(append(opts[:len(opts):len(opts)], withoutOutput())), SporadicConfig{Period: c.Period})
+			r := sporadic(title, processes, newOptions(opts), c)
+			for i := range r.Sporadic.Processes {
+				a := &r.Sporadic.Processes[i]
+				a.Background = base.Sporadic.Processes[i].Response
+				r.Sporadic.AveBackgroundResponse += float64(a.Background)
+			}
+			if n := len(r.Sporadic.Processes); n > 0 {
+				r.Sporadic.AveBackgroundResponse /= float64(n)
+			}
+
+			return r
+		},
+	}, nil
+}
+
+// replenishment is budget a sporadic server gets back at a time.
+type replenishment struct {
+	at, amount int64
+}
+
+func sporadic(title string, processes []Process, o options, c SporadicConfig) Result {
+	var (
+		e         = newQuantumEngine(title, processes, o)
+		t         = e.t
+		periodic  []int                    // periodic tasks with work left
+		aperiodic []int                    // ready aperiodic processes, in arrival order
+		release   = make([]int64, t.len()) // when each periodic task's next job is released
+		job       = make([]int64, t.len()) // what is left of each periodic task's current job
+		budget    = c.Budget
+		pending   []replenishment
+		spending  int64  = -1 // when the server started its current stretch of spending, if it has
+		spent     int64       // the budget spent in that stretch
+		queue     string      // what the process picked last runs as
+		next      int64  = -1 // the soonest release or replenishment after the last pick, if any
+		exit             = make([]int64, t.len())
+	)
+	isPeriodic := func(p int) bool { return processes[p].Reserved() }
+	// before reports whether periodic task a has a higher rate-monotonic priority than b.
+	before := func(a, b int) bool {
+		if processes[a].Period != processes[b].Period {
+			return processes[a].Period < processes[b].Period
+		}
+		return byArrivalOnly(t, a, b)
+	}
+	// stopSpending ends the server's stretch of spending, scheduling its replenishment.
+	stopSpending := func() {
+		if spending >= 0 && spent > 0 {
+			pending = append(pending, replenishment{at: spending + c.Period, amount: spent})
+		}
+		spending, spent = -1, 0
+	}
+	soonest := func(at int64) {
+		if next < 0 || at < next {
+			next = at
+		}
+	}
+
+	r := e.run(quantumPolicy{
+		admit: func(p int) {
+			if isPeriodic(p) {
+				release[p] = t.arrival[p]
+				periodic = append(periodic, p)
+			} else {
+				aperiodic = append(aperiodic, p)
+			}
+		},
+		pick: func() (int, int64, string) {
+			for _, p := range periodic {
+				if job[p] <= 0 && release[p] <= e.now {
+					job[p] = minimum(processes[p].Runtime, t.remaining[p])
+					release[p] += processes[p].Period
+				}
+			}
+			kept := pending[:0]
+			for _, r := range pending {
+				if r.at <= e.now {
+					budget += r.amount
+					continue
+				}
+				kept = append(kept, r)
+			}
+			pending = kept
+
+			// next is the soonest event besides arrivals that may change what should run: a
+			// release or, once the server has stopped spending, a replenishment.
+			next = -1
+			task := -1
+			for _, p := range periodic {
+				if job[p] <= 0 {
+					soonest(release[p])
+				} else if task < 0 || before(p, task) {
+					task = p
+				}
+			}
+			running := -1
+			queue = ""
+			switch {
+			case len(aperiodic) > 0 && budget > 0 && (task < 0 || c.Period < processes[task].Period):
+				running, queue = aperiodic[0], "server"
+			case task >= 0:
+				running, queue = task, "periodic"
+			case len(aperiodic) > 0:
+				running, queue = aperiodic[0], "background"
+			}
+			if queue != "server" {
+				stopSpending()
+			}
+			for _, r := range pending {
+				soonest(r.at)
+			}
+			switch {
+			case running < 0:
+				// Idle until the next arrival or release: a periodic task waiting for its next
+				// job leaves the CPU.
+				return -1, 0, ""
+			case queue == "periodic":
+				return running, job[running], queue
+			case queue == "server":
+				if spending < 0 {
+					spending = e.now
+				}
+				return running, budget, queue
+			}
+			return running, t.remaining[running], queue
+		},
+		wake: func(int) (int64, bool) { return next, next >= 0 },
+		ran: func(p int, run int64) {
+			switch queue {
+			case "periodic":
+				job[p] -= run
+			case "server":
+				budget -= run
+				spent += run
+			}
+		},
+		done: func(p int) {
+			if isPeriodic(p) {
+				periodic, _ = without(periodic, p)
+			} else {
+				aperiodic, _ = without(aperiodic, p)
+			}
+		},
+		completed:        func(p int, _ *ProcessResult) { exit[p] = e.now },
+		preemptOnArrival: true,
+	})
+	r.Sporadic = &SporadicReport{Budget: c.Budget, Period: c.Period}
+	for i, p := range processes {
+		if isPeriodic(i) {
+			continue
+		}
+		a := AperiodicResponse{ID: p.ProcessID, Response: exit[i] - t.arrival[i]}
+		r.Sporadic.Processes = append(r.Sporadic.Processes, a)
+		r.Sporadic.AveResponse += float64(a.Response)
+	}
+	if n := len(r.Sporadic.Processes); n > 0 {
+		r.Sporadic.AveResponse /= float64(n)
+	}
+
+	return r
+}
@@ -0,0 +1,86 @@
+package scheduler
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseSporadic(t *testing.T) {
+	t.Parallel()
+	c, err := ParseSporadic("2:10")
+	if err != nil || c != (SporadicConfig{Budget: 2, Period: 10}) || c.String() != "2:10" {
+		t.Errorf("ParseSporadic(2:10) = %+v, %v", c, err)
+	}
+	for _, s := range []string{"2", "a:10", "2:b", "0:10", "11:10"} {
+		if _, err := ParseSporadic(s); !errors.Is(err, ErrInvalidSporadic) {
+			t.Errorf("ParseSporadic(%q) error = %v, want %v", s, err, ErrInvalidSporadic)
+		}
+	}
+	if _, err := SporadicServer(SporadicConfig{Budget: 3, Period: 2}); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("SporadicServer() error = %v, want %v", err, ErrInvalidArgs)
+	}
+}
+
+func TestSporadicServer(t *testing.T) {
+	t.Parallel()
+	a, err := SporadicServer(SporadicConfig{Budget: 1, Period: 4})
+	if err != nil || a.Name != "ss-1:4" {
+		t.Fatalf("SporadicServer() = %q, %v", a.Name, err)
+	}
+	r := a.Simulate("ss", []Process{
+		{ProcessID: 1, BurstDuration: 8, Runtime: 4, Period: 5},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 1},
+	})
+	// The server's period is shorter than P1's, so it preempts P1 for P2, which in the
+	// background would have waited for P1's first job to finish at 4.
+	want := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 1, Queue: "periodic"},
+		{PID: 2, Start: 1, Stop: 2, Queue: "server"},
+		{PID: 1, Start: 2, Stop: 9, Queue: "periodic"},
+	}
+	if !reflect.DeepEqual(r.Gantt, want) {
+		t.Errorf("SporadicServer() Gantt = %+v, want %+v", r.Gantt, want)
+	}
+	wantReport := &SporadicReport{Budget: 1, Period: 4, AveResponse: 1, AveBackgroundResponse: 4,
+		Processes: []AperiodicResponse{{ID: 2, Response: 1, Background: 4}}}
+	if !reflect.DeepEqual(r.Sporadic, wantReport) {
+		t.Errorf("SporadicServer() report = %+v, want %+v", r.Sporadic, wantReport)
+	}
+}
+
+func TestSporadicServer_replenish(t *testing.T) {
+	t.Parallel()
+	a, _ := SporadicServer(SporadicConfig{Budget: 1, Period: 3})
+	r := a.Simulate("ss", []Process{
+		{ProcessID: 1, BurstDuration: 6, Runtime: 3, Period: 4},
+		{ProcessID: 2, BurstDuration: 3},
+	})
+	// The server spends its unit of budget at 0, 3 and 6, each time getting it back 3 later, so
+	// P2 completes at 7 rather than after both of P1's jobs, at 9, as in the background.
+	if want := map[int64]int64{1: 9, 2: 7}; !reflect.DeepEqual(exits(r), want) {
+		t.Errorf("SporadicServer() exits = %v, want %v", exits(r), want)
+	}
+	if r.Sporadic.AveBackgroundResponse != 9 {
+		t.Errorf("SporadicServer() background response = %v, want 9", r.Sporadic.AveBackgroundResponse)
+	}
+}
+
+func TestSporadicServer_idle(t *testing.T) {
+	t.Parallel()
+	a, _ := SporadicServer(SporadicConfig{Budget: 1, Period: 4})
+	// Between its jobs P1 leaves the CPU idle, and each job is a slice of its own.
+	processes := []Process{{ProcessID: 1, BurstDuration: 3, Runtime: 1, Period: 5}}
+	r := a.Simulate("ss", processes)
+	checkGantt(t, r, processes)
+	want := []TimeSlice{
+		{PID: 1, Start: 0, Stop: 1, Queue: "periodic"},
+		{Idle: true, Start: 1, Stop: 5},
+		{PID: 1, Start: 5, Stop: 6, Queue: "periodic"},
+		{Idle: true, Start: 6, Stop: 10},
+		{PID: 1, Start: 10, Stop: 11, Queue: "periodic"},
+	}
+	if !reflect.DeepEqual(r.Gantt, want) {
+		t.Errorf("SporadicServer() Gantt = %+v, want %+v", r.Gantt, want)
+	}
+}
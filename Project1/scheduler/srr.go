@@ -0,0 +1,153 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidSRR is wrapped by every error in a selfish round-robin configuration.
+var ErrInvalidSRR = fmt.Errorf("%w: invalid selfish round-robin", ErrInvalidArgs)
+
+// SRRConfig configures selfish round-robin: a new process's priority grows by A every time unit
+// it waits to be accepted, and an accepted process's by B.
+type SRRConfig struct {
+	A, B int64
+}
+
+// DefaultSRR is the selfish round-robin of the "srr" scheduler, the textbook a = 2, b = 1.
+var DefaultSRR = SRRConfig{A: 2, B: 1}
+
+// ParseSRR parses a selfish round-robin configuration given as the rates a and b separated by a
+// comma, as SRRConfig.String formats it: e.g. 2,1.
+func ParseSRR(s string) (SRRConfig, error) {
+	var c SRRConfig
+	a, b, ok := strings.Cut(s, ",")
+	if !ok {
+		return c, fmt.Errorf("%w: want the rates a,b, got %q", ErrInvalidSRR, s)
+	}
+	var err error
+	if c.A, err = strconv.ParseInt(strings.TrimSpace(a), 10, 64); err != nil {
+		return c, fmt.Errorf("%w: the rate a must be an integer, got %q", ErrInvalidSRR, a)
+	}
+	if c.B, err = strconv.ParseInt(strings.TrimSpace(b), 10, 64); err != nil {
+		return c, fmt.Errorf("%w: the rate b must be an integer, got %q", ErrInvalidSRR, b)
+	}
+
+	return c, c.check()
+}
+
+func (c SRRConfig) check() error {
+	if c.A < 0 || c.B < 0 {
+		return fmt.Errorf("%w: the rates must not be negative, got %s", ErrInvalidSRR, c)
+	}
+
+	return nil
+}
+
+// String formats c as ParseSRR reads it, e.g. 2,1.
+func (c SRRConfig) String() string {
+	return strconv.FormatInt(c.A, 10) + "," + strconv.FormatInt(c.B, 10)
+}
+
+// SRR simulates selfish round-robin scheduling of processes with DefaultSRR.
+func SRR(title string, processes []Process, opts ...Option) Result {
+	return srr(title, processes, newOptions(opts), DefaultSRR)
+}
+
+// SelfishRR is selfish round-robin scheduling: arrivals wait in a new queue, their priority
+// growing from 0 by A every time unit, while the accepted queue runs round-robin with a quantum of
+// DefaultQuantum, the priority of all its processes growing by B. A new process is accepted, at
+// the back of the accepted queue, once its priority has caught up with theirs, or as soon as the
+// accepted queue is empty (the earliest arrival of the highest priority first). With B at or
+// above A, new processes only get in when the accepted queue empties, as in FCFS; with B at 0,
+// it is round-robin. Its name is srr-<a,b>, such as srr-2,1.
+func SelfishRR(c SRRConfig) (Algorithm, error) {
+	if err := c.check(); err != nil {
+		return Algorithm{}, err
+	}
+
+	return Algorithm{
+		Name:    "srr-" + c.String(),
+		Version: "1",
+		Title:   "Selfish round-robin (" + c.String() + ")",
+		Simulate: func(title string, processes []Process, opts ...Option) Result {
+			return srr(title, processes, newOptions(opts), c)
+		},
+	}, nil
+}
+
+// srr simulates selfish round-robin: the clock jumps between arrivals, completions, quantum
+// boundaries and the times new processes catch up with the accepted ones.
+func srr(title string, processes []Process, o options, c SRRConfig) Result {
+	var (
+		e        = newQuantumEngine(title, processes, o)
+		t        = e.t
+		fresh    []int // the new queue, in arrival order
+		accepted []int
+		priority = make([]int64, t.len())
+		used     = make([]int64, t.len()) // time used of the current quantum
+	)
+	accept := func() {
+		if len(accepted) == 0 && len(fresh) > 0 {
+			best := 0
+			for i, p := range fresh {
+				if priority[p] > priority[fresh[best]] {
+					best = i
+				}
+			}
+			accepted = append(accepted, fresh[best])
+			fresh = append(fresh[:best:best], fresh[best+1:]...)
+		}
+		waiting := fresh[:0]
+		for _, p := range fresh {
+			if len(accepted) > 0 && priority[p] >= priority[accepted[0]] {
+				priority[p] = priority[accepted[0]] // all accepted processes share a priority
+				accepted = append(accepted, p)
+				continue
+			}
+			waiting = append(waiting, p)
+		}
+		fresh = waiting
+	}
+
+	return e.run(quantumPolicy{
+		admit: func(p int) { fresh = append(fresh, p) },
+		pick: func() (int, int64, string) {
+			accept()
+			return accepted[0], DefaultQuantum - used[accepted[0]], ""
+		},
+		// The clock stops when the first new process catches up with the accepted ones.
+		wake: func(int) (int64, bool) {
+			var (
+				soonest int64
+				ok      bool
+			)
+			if len(accepted) == 0 || c.A <= c.B {
+				return 0, false
+			}
+			for _, p := range fresh {
+				gap := priority[accepted[0]] - priority[p]
+				if d := (gap + c.A - c.B - 1) / (c.A - c.B); !ok || d < soonest {
+					soonest, ok = d, true
+				}
+			}
+			return grow(e.now, 1, soonest), ok
+		},
+		ran: func(p int, run int64) {
+			used[p] += run
+			for _, q := range fresh {
+				priority[q] = grow(priority[q], c.A, run)
+			}
+			for _, q := range accepted {
+				priority[q] = grow(priority[q], c.B, run)
+			}
+			if t.remaining[p] > 0 && used[p] >= DefaultQuantum {
+				used[p] = 0
+				accepted = rotate(accepted, p)
+			}
+		},
+		done:             func(p int) { accepted, _ = without(accepted, p) },
+		preemptOnArrival: true,
+	})
+}
@@ -0,0 +1,51 @@
+package scheduler
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseSRR(t *testing.T) {
+	t.Parallel()
+	c, err := ParseSRR("2, 1")
+	if err != nil || c != (SRRConfig{A: 2, B: 1}) || c.String() != "2,1" {
+		t.Errorf("ParseSRR(2, 1) = %+v, %v", c, err)
+	}
+	for _, bad := range []string{"2", "x,1", "2,y", "-1,0"} {
+		if _, err := ParseSRR(bad); !errors.Is(err, ErrInvalidSRR) {
+			t.Errorf("ParseSRR(%q) error = %v, want ErrInvalidSRR", bad, err)
+		}
+	}
+}
+
+func TestSelfishRR(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 6},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 2},
+	}
+	tests := []struct {
+		c    SRRConfig
+		want []TimeSlice
+	}{
+		// P2 catches up with P1 at 2 and joins the accepted queue behind it.
+		{c: SRRConfig{A: 2, B: 1}, want: []TimeSlice{{PID: 1, Stop: 4}, {PID: 2, Start: 4, Stop: 6}, {PID: 1, Start: 6, Stop: 8}}},
+		// P2 never catches up, so it waits for P1 to complete.
+		{c: SRRConfig{A: 1, B: 1}, want: []TimeSlice{{PID: 1, Stop: 6}, {PID: 2, Start: 6, Stop: 8}}},
+		// P1's priority never grows, so P2 is accepted on arrival: round-robin.
+		{c: SRRConfig{A: 2, B: 0}, want: []TimeSlice{{PID: 1, Stop: 2}, {PID: 2, Start: 2, Stop: 4}, {PID: 1, Start: 4, Stop: 8}}},
+	}
+	for _, tt := range tests {
+		a, err := SelfishRR(tt.c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := a.Simulate(a.Name, processes); !reflect.DeepEqual(got.Gantt, tt.want) {
+			t.Errorf("%s gantt = %+v, want %+v", a.Name, got.Gantt, tt.want)
+		}
+	}
+	if _, err := SelfishRR(SRRConfig{A: -1}); !errors.Is(err, ErrInvalidSRR) {
+		t.Errorf("SelfishRR(-1, 0) error = %v", err)
+	}
+}
@@ -0,0 +1,29 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// SwitchAwareSJF is preemptive shortest-job-first that counts the cost of a context switch in
+// its decisions: a process with less remaining time only preempts the running one if it has
+// more than cost less, since running it first saves the pair that much waiting in total, and
+// otherwise waits for the running process to complete. A cost of 0 is plain SJF. Its name is
+// sjf-switch-<cost>, such as sjf-switch-2.
+func SwitchAwareSJF(cost int64) (Algorithm, error) {
+	if cost < 0 {
+		return Algorithm{}, fmt.Errorf("%w: the switch cost must not be negative, got %d", ErrInvalidArgs, cost)
+	}
+	name := strconv.FormatInt(cost, 10)
+
+	return Algorithm{
+		Name:    "sjf-switch-" + name,
+		Version: "2",
+		Title:   "Switch-aware shortest-job-first (cost " + name + ")",
+		Simulate: func(title string, processes []Process, opts ...Option) Result {
+			o := newOptions(opts)
+			o.preemption.switchCost = cost
+			return preemptive(o.tieBreak.title(title), processes, o, byRemainingThen(o.tieBreak))
+		},
+	}, nil
+}
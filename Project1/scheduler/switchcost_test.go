@@ -0,0 +1,38 @@
+package scheduler
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSwitchAwareSJF(t *testing.T) {
+	t.Parallel()
+	processes := []Process{
+		{ProcessID: 1, BurstDuration: 10},
+		{ProcessID: 2, ArrivalTime: 1, BurstDuration: 7},
+		{ProcessID: 3, ArrivalTime: 12, BurstDuration: 1},
+	}
+	tests := []struct {
+		cost int64
+		want map[int64]int64
+	}{
+		// At 1 P2 has 2 less to run than P1's 9, so it preempts P1 only if that beats the cost;
+		// P3 is far enough below P2's remaining 5 at 12 to preempt it either way.
+		{cost: 0, want: map[int64]int64{1: 18, 2: 8, 3: 13}},
+		{cost: 1, want: map[int64]int64{1: 18, 2: 8, 3: 13}},
+		{cost: 2, want: map[int64]int64{1: 10, 2: 18, 3: 13}},
+	}
+	for _, tt := range tests {
+		a, err := SwitchAwareSJF(tt.cost)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := exits(a.Simulate(a.Title, processes)); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s exits = %v, want %v", a.Name, got, tt.want)
+		}
+	}
+	if _, err := SwitchAwareSJF(-1); !errors.Is(err, ErrInvalidArgs) {
+		t.Errorf("SwitchAwareSJF(-1) error = %v, want %v", err, ErrInvalidArgs)
+	}
+}
@@ -0,0 +1,90 @@
+package scheduler
+
+import "fmt"
+
+// TieBreak is the rule FCFS, SJF and SJFNonPreemptive order processes by when their policy
+// ranks them equal: arriving together for FCFS, or with the same remaining time for SJF.
+type TieBreak string
+
+const (
+	// TieBreakDefault keeps each scheduler's own rule: input order for FCFS and the earliest
+	// arrival, then the lowest PID, for SJF.
+	TieBreakDefault TieBreak = ""
+	// TieBreakPID puts the lowest PID first.
+	TieBreakPID TieBreak = "pid"
+	// TieBreakPriority puts the highest priority (the lowest value) first, then the lowest PID.
+	TieBreakPriority TieBreak = "priority"
+	// TieBreakInput puts the process earlier in the input first.
+	TieBreakInput TieBreak = "input"
+)
+
+var ErrUnknownTieBreak = fmt.Errorf("%w: tie-break must be pid, priority or input", ErrInvalidArgs)
+
+// ParseTieBreak parses a TieBreak.
+func ParseTieBreak(s string) (TieBreak, error) {
+	switch tb := TieBreak(s); tb {
+	case TieBreakPID, TieBreakPriority, TieBreakInput:
+		return tb, nil
+	}
+
+	return "", fmt.Errorf("%w, got %q", ErrUnknownTieBreak, s)
+}
+
+// WithTieBreak breaks ties in FCFS, SJF and SJFNonPreemptive by tb, and names the rule in
+// their result's title, as "(ties: lowest PID)" for TieBreakPID, so the output records it.
+func WithTieBreak(tb TieBreak) Option {
+	return func(o *options) {
+		o.tieBreak = tb
+	}
+}
+
+// Describe is how the rule is named in titles, such as "lowest PID".
+func (tb TieBreak) Describe() string {
+	switch tb {
+	case TieBreakPID:
+		return "lowest PID"
+	case TieBreakPriority:
+		return "highest priority"
+	case TieBreakInput:
+		return "input order"
+	default:
+		return "default"
+	}
+}
+
+// title is title with the rule named, unless it is the default.
+func (tb TieBreak) title(title string) string {
+	if tb == TieBreakDefault {
+		return title
+	}
+
+	return title + " (ties: " + tb.Describe() + ")"
+}
+
+// less orders two processes the policy ranks equal.
+func (tb TieBreak) less(t *processTable, a, b int) bool {
+	switch tb {
+	case TieBreakPriority:
+		if t.priority[a] != t.priority[b] {
+			return t.priority[a] < t.priority[b]
+		}
+	case TieBreakInput:
+		return a < b
+	}
+
+	return t.pid[a] < t.pid[b]
+}
+
+// byRemainingThen orders by shortest remaining time, then by tb, or as byRemaining does by default.
+func byRemainingThen(tb TieBreak) less {
+	if tb == TieBreakDefault {
+		return byRemaining
+	}
+
+	return func(t *processTable, a, b int) bool {
+		if t.remaining[a] != t.remaining[b] {
+			return t.remaining[a] < t.remaining[b]
+		}
+		return tb.less(t, a, b)
+	}
+}
@@ -0,0 +1,56 @@
+package scheduler
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseTieBreak(t *testing.T) {
+	t.Parallel()
+	if tb, err := ParseTieBreak("priority"); err != nil || tb != TieBreakPriority {
+		t.Errorf("ParseTieBreak(priority) = %q, %v", tb, err)
+	}
+	if _, err := ParseTieBreak("random"); !errors.Is(err, ErrUnknownTieBreak) {
+		t.Errorf("ParseTieBreak(random) error = %v", err)
+	}
+}
+
+func TestWithTieBreak(t *testing.T) {
+	t.Parallel()
+	// Every process arrives at 0 with the same burst, so only the tie-break orders them.
+	processes := []Process{
+		{ProcessID: 3, BurstDuration: 2, Priority: 1},
+		{ProcessID: 1, BurstDuration: 2, Priority: 2},
+		{ProcessID: 2, BurstDuration: 2, Priority: 0},
+	}
+	order := func(r Result) []int64 { // by exit time; the bursts are equal, so this is the run order
+		pids := make([]int64, len(r.Processes))
+		for _, p := range r.Processes {
+			pids[p.Exit/2-1] = p.ID
+		}
+		return pids
+	}
+	tests := []struct {
+		tb        TieBreak
+		title     string
+		fcfs, sjf []int64
+	}{
+		{tb: TieBreakDefault, title: "test", fcfs: []int64{3, 1, 2}, sjf: []int64{1, 2, 3}},
+		{tb: TieBreakPID, title: "test (ties: lowest PID)", fcfs: []int64{1, 2, 3}, sjf: []int64{1, 2, 3}},
+		{tb: TieBreakPriority, title: "test (ties: highest priority)", fcfs: []int64{2, 3, 1}, sjf: []int64{2, 3, 1}},
+		{tb: TieBreakInput, title: "test (ties: input order)", fcfs: []int64{3, 1, 2}, sjf: []int64{3, 1, 2}},
+	}
+	for _, tt := range tests {
+		for name, simulate := range map[string]SimulateFunc{"fcfs": FCFS, "sjf": SJF, "sjf-np": SJFNonPreemptive} {
+			r := simulate("test", processes, WithTieBreak(tt.tb))
+			want := tt.sjf
+			if name == "fcfs" {
+				want = tt.fcfs
+			}
+			if got := order(r); !reflect.DeepEqual(got, want) || r.Title != tt.title {
+				t.Errorf("%s with tie-break %q: %q ran %v, want %q running %v", name, tt.tb, r.Title, got, tt.title, want)
+			}
+		}
+	}
+}
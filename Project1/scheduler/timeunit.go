@@ -0,0 +1,56 @@
+package scheduler
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TimeUnit is the unit that process times in the input are measured in.
+// It only affects how times are labeled in the output; the simulation itself is unit-less.
+type TimeUnit string
+
+const (
+	TimeUnitTicks   TimeUnit = "ticks"
+	TimeUnitMillis  TimeUnit = "ms"
+	TimeUnitSeconds TimeUnit = "s"
+)
+
+var ErrInvalidTimeUnit = fmt.Errorf("%w: time unit must be one of ticks, ms, s", ErrInvalidArgs)
+
+// ParseTimeUnit parses a time unit name, ignoring case.
+func ParseTimeUnit(s string) (TimeUnit, error) {
+	switch u := TimeUnit(strings.ToLower(s)); u {
+	case TimeUnitTicks, TimeUnitMillis, TimeUnitSeconds:
+		return u, nil
+	default:
+		return "", fmt.Errorf("%w: got %q", ErrInvalidTimeUnit, s)
+	}
+}
+
+// TimeUnitOf is the time unit set by a WithTimeUnit option in opts, or "" (unlabeled ticks).
+func TimeUnitOf(opts ...Option) TimeUnit {
+	return newOptions(opts).timeUnit
+}
+
+// Label suffixes a column or chart label with the unit, e.g. "Wait (ms)".
+// Ticks are the historical default and are left unlabeled.
+func (u TimeUnit) Label(s string) string {
+	if u == "" || u == TimeUnitTicks {
+		return s
+	}
+
+	return fmt.Sprintf("%s (%s)", s, u)
+}
+
+// Throughput formats a completed-processes-per-time-unit rate. Rates per millisecond are scaled up
+// to the second, so every labeled rate is in jobs/sec.
+func (u TimeUnit) Throughput(v float64) string {
+	switch u {
+	case TimeUnitMillis:
+		return fmt.Sprintf("%.2f jobs/sec", v*1000)
+	case TimeUnitSeconds:
+		return fmt.Sprintf("%.2f jobs/sec", v)
+	default:
+		return fmt.Sprintf("%.2f/t", v)
+	}
+}
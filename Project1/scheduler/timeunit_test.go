@@ -0,0 +1,58 @@
+package scheduler
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseTimeUnit(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		in      string
+		want    TimeUnit
+		wantErr error
+	}{
+		{in: "ticks", want: TimeUnitTicks},
+		{in: "ms", want: TimeUnitMillis},
+		{in: "S", want: TimeUnitSeconds},
+		{in: "minutes", wantErr: ErrInvalidArgs},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.in, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseTimeUnit(tt.in)
+			if got != tt.want {
+				t.Errorf("ParseTimeUnit() = %v, want %v", got, tt.want)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTimeUnit_formatting(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		unit           TimeUnit
+		wantLabel      string
+		wantThroughput string
+	}{
+		{unit: TimeUnitTicks, wantLabel: "Wait", wantThroughput: "0.15/t"},
+		{unit: TimeUnitMillis, wantLabel: "Wait (ms)", wantThroughput: "150.00 jobs/sec"},
+		{unit: TimeUnitSeconds, wantLabel: "Wait (s)", wantThroughput: "0.15 jobs/sec"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(string(tt.unit), func(t *testing.T) {
+			t.Parallel()
+			if got := tt.unit.Label("Wait"); got != tt.wantLabel {
+				t.Errorf("label() = %q, want %q", got, tt.wantLabel)
+			}
+			if got := tt.unit.Throughput(0.15); got != tt.wantThroughput {
+				t.Errorf("throughput() = %q, want %q", got, tt.wantThroughput)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+// Package schedulerbench provides deterministic workloads and testing.B helpers for
+// benchmarking process schedulers, so custom schedulers can be compared with the
+// built-in ones on exactly the same inputs.
+package schedulerbench
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+type (
+	// Job is one process in a benchmark workload.
+	Job struct {
+		ID       int64
+		Arrival  int64
+		Burst    int64
+		Priority int64
+	}
+	// Workload is a named, reproducible set of jobs ordered by arrival.
+	Workload struct {
+		Name string
+		Jobs []Job
+	}
+)
+
+const (
+	maxBurst    = 20
+	maxPriority = 50
+)
+
+// Batch returns n jobs that all arrive at time 0 with uniformly random bursts.
+func Batch(n int, seed int64) Workload {
+	r := rand.New(rand.NewSource(seed))
+	jobs := make([]Job, n)
+	for i := range jobs {
+		jobs[i] = Job{
+			ID:       int64(i + 1),
+			Burst:    1 + r.Int63n(maxBurst),
+			Priority: 1 + r.Int63n(maxPriority),
+		}
+	}
+
+	return Workload{Name: "batch", Jobs: jobs}
+}
+
+// Staggered returns n jobs arriving at a fixed interval with uniformly random bursts.
+func Staggered(n int, interval int64, seed int64) Workload {
+	w := Batch(n, seed)
+	for i := range w.Jobs {
+		w.Jobs[i].Arrival = int64(i) * interval
+	}
+	w.Name = "staggered"
+
+	return w
+}
+
+// Poisson returns n jobs with exponentially distributed inter-arrival gaps and bursts,
+// the classic M/M/1 workload.
+func Poisson(n int, meanGap, meanBurst float64, seed int64) Workload {
+	r := rand.New(rand.NewSource(seed))
+	jobs := make([]Job, n)
+	var t float64
+	for i := range jobs {
+		t += r.ExpFloat64() * meanGap
+		jobs[i] = Job{
+			ID:       int64(i + 1),
+			Arrival:  int64(t),
+			Burst:    int64(math.Max(1, math.Round(r.ExpFloat64()*meanBurst))),
+			Priority: 1 + r.Int63n(maxPriority),
+		}
+	}
+
+	return Workload{Name: "poisson", Jobs: jobs}
+}
+
+// LogNormal returns n jobs with exponentially distributed inter-arrival gaps and log-normal
+// bursts of the given mean and coefficient of variation (standard deviation / mean), so burst
+// variability can be varied independently of the load. A cv of 0 gives constant bursts.
+func LogNormal(n int, meanGap, meanBurst, cv float64, seed int64) Workload {
+	r := rand.New(rand.NewSource(seed))
+	sigma := math.Sqrt(math.Log1p(cv * cv))
+	mu := math.Log(meanBurst) - sigma*sigma/2
+	jobs := make([]Job, n)
+	var t float64
+	for i := range jobs {
+		t += r.ExpFloat64() * meanGap
+		jobs[i] = Job{
+			ID:       int64(i + 1),
+			Arrival:  int64(t),
+			Burst:    int64(math.Max(1, math.Round(math.Exp(mu+sigma*r.NormFloat64())))),
+			Priority: 1 + r.Int63n(maxPriority),
+		}
+	}
+
+	return Workload{Name: "lognormal", Jobs: jobs}
+}
+
+// Standard is the fixed set of n-job workloads the built-in benchmarks use.
+func Standard(n int) []Workload {
+	return []Workload{
+		Batch(n, 1),
+		Staggered(n, 5, 1),
+		Poisson(n, 10, 8, 1),
+	}
+}
+
+// Measure runs simulate b.N times, reporting allocations and jobs simulated per second.
+func Measure(b *testing.B, jobs int, simulate func()) {
+	b.Helper()
+	b.ReportAllocs()
+	b.ResetTimer()
+	start := time.Now()
+	for i := 0; i < b.N; i++ {
+		simulate()
+	}
+	b.StopTimer()
+	if elapsed := time.Since(start).Seconds(); elapsed > 0 {
+		b.ReportMetric(float64(jobs*b.N)/elapsed, "jobs/s")
+	}
+}
+
+// RunAll runs a sub-benchmark per workload. convert turns the jobs into the scheduler's
+// own process type once, outside the timed loop.
+func RunAll[T any](b *testing.B, workloads []Workload, convert func(jobs []Job) T, simulate func(T)) {
+	b.Helper()
+	for _, w := range workloads {
+		w := w
+		in := convert(w.Jobs)
+		b.Run(w.Name, func(b *testing.B) {
+			Measure(b, len(w.Jobs), func() { simulate(in) })
+		})
+	}
+}
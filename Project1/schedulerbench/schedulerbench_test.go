@@ -0,0 +1,66 @@
+package schedulerbench
+
+import (
+	"math"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestWorkloads(t *testing.T) {
+	t.Parallel()
+	for _, w := range Standard(100) {
+		w := w
+		t.Run(w.Name, func(t *testing.T) {
+			t.Parallel()
+			if len(w.Jobs) != 100 {
+				t.Fatalf("len = %d, want 100", len(w.Jobs))
+			}
+			if !sort.SliceIsSorted(w.Jobs, func(i, j int) bool { return w.Jobs[i].Arrival < w.Jobs[j].Arrival }) {
+				t.Error("jobs are not ordered by arrival")
+			}
+			for i, j := range w.Jobs {
+				if j.ID != int64(i+1) || j.Burst < 1 || j.Arrival < 0 {
+					t.Fatalf("invalid job %+v", j)
+				}
+			}
+		})
+	}
+}
+
+func TestWorkloadsAreReproducible(t *testing.T) {
+	t.Parallel()
+	if a, b := Poisson(50, 3, 4, 7), Poisson(50, 3, 4, 7); !reflect.DeepEqual(a, b) {
+		t.Error("same seed produced different workloads")
+	}
+	if a, b := Batch(50, 1), Batch(50, 2); reflect.DeepEqual(a, b) {
+		t.Error("different seeds produced the same workload")
+	}
+}
+
+func TestLogNormal(t *testing.T) {
+	t.Parallel()
+	w := LogNormal(20000, 2, 50, 1.5, 3)
+	var sum, sq float64
+	for _, j := range w.Jobs {
+		sum += float64(j.Burst)
+		sq += float64(j.Burst) * float64(j.Burst)
+	}
+	mean := sum / float64(len(w.Jobs))
+	cv := math.Sqrt(sq/float64(len(w.Jobs))-mean*mean) / mean
+	if math.Abs(mean-50) > 3 || math.Abs(cv-1.5) > 0.3 {
+		t.Errorf("mean = %.2f, cv = %.2f, want about 50 and 1.5", mean, cv)
+	}
+	for _, j := range LogNormal(10, 2, 7, 0, 1).Jobs {
+		if j.Burst != 7 {
+			t.Fatalf("cv 0 burst = %d, want 7", j.Burst)
+		}
+	}
+}
+
+func BenchmarkSortByBurst(b *testing.B) {
+	RunAll(b, Standard(1000), func(jobs []Job) []Job { return jobs }, func(jobs []Job) {
+		sorted := append([]Job(nil), jobs...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i].Burst < sorted[j].Burst })
+	})
+}
@@ -0,0 +1,193 @@
+package workload
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jh125486/CSCE4600/Project1/internal/arrow"
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+// arrowProcessColumns maps the column names loadArrow accepts to Process fields.
+var arrowProcessColumns = map[string]string{
+	"id": "id", "pid": "id", "process_id": "id", "processid": "id",
+	"arrival": "arrival", "arrival_time": "arrival", "arrivaltime": "arrival",
+	"burst": "burst", "burst_duration": "burst", "burstduration": "burst",
+	"priority": "priority",
+	"name":     "name",
+}
+
+// arrowBufferCounts is how many buffers each flat Arrow type has in a record batch, so
+// columns loadArrow ignores can be skipped. Nested types are not supported.
+var arrowBufferCounts = map[int64]int{
+	// Null
+	1: 0,
+	// Int, FloatingPoint, Bool, Decimal, Date, Time, Timestamp, Interval, FixedSizeBinary, Duration
+	2: 2, 3: 2, 6: 2, 7: 2, 8: 2, 9: 2, 10: 2, 11: 2, 12: 2, 18: 2,
+	// Binary, Utf8, LargeBinary, LargeUtf8
+	4: 3, 5: 3, 19: 3, 20: 3,
+}
+
+// arrowField is how loadArrow decodes one schema column.
+type arrowField struct {
+	name     string
+	process  string // Process field, "" if ignored
+	typ      int64
+	bitWidth int64
+	signed   bool
+}
+
+// loadArrow imports processes from an Arrow IPC stream or file. Columns are matched by name
+// (id, arrival, burst, priority and optionally name, case-insensitively); others are ignored,
+// so a results stream written by --format arrow replays as a workload.
+func loadArrow(r io.Reader) (processes []scheduler.Process, err error) {
+	br := bufio.NewReader(r)
+	if magic, _ := br.Peek(len(arrow.FileMagic)); string(magic) == arrow.FileMagic {
+		_, _ = br.Discard(8) // magic and padding; the file's stream follows
+	}
+	defer func() {
+		if v := recover(); v != nil {
+			if v != arrow.ErrBounds {
+				panic(v)
+			}
+			err = fmt.Errorf("%w: malformed Arrow IPC data", ErrInvalidProcess)
+		}
+	}()
+
+	var fields []arrowField
+	for {
+		meta, body, err := arrow.ReadMessage(br)
+		if errors.Is(err, io.EOF) {
+			break
+		} else if errors.Is(err, arrow.ErrMalformed) {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidProcess, err)
+		} else if err != nil {
+			return nil, err
+		}
+		m := arrow.Reader(meta)
+		msg := m.Root()
+		header := m.Ref(m.Field(msg, 2))
+		switch m.Scalar(msg, 1, 1) {
+		case arrow.HeaderSchema:
+			if fields, err = arrowSchema(m, header); err != nil {
+				return nil, err
+			}
+		case arrow.HeaderBatch:
+			if fields == nil {
+				return nil, fmt.Errorf("%w: Arrow record batch before schema", ErrInvalidProcess)
+			}
+			if processes, err = appendArrowBatch(processes, m, header, body, fields); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if processes == nil {
+		processes = []scheduler.Process{}
+	}
+
+	return processes, nil
+}
+
+func arrowSchema(m arrow.Reader, schema int) ([]arrowField, error) {
+	start, n := m.Vector(schema, 1)
+	fields := make([]arrowField, n)
+	for i := range fields {
+		field := m.Ref(start + 4*i)
+		f := arrowField{name: m.Text(field, 0), typ: m.Scalar(field, 2, 1)}
+		f.process = arrowProcessColumns[strings.ToLower(f.name)]
+		if _, ok := arrowBufferCounts[f.typ]; !ok {
+			return nil, fmt.Errorf("%w: Arrow column %q has an unsupported type", ErrInvalidProcess, f.name)
+		}
+		switch {
+		case f.process == "":
+		case f.process == "name" && f.typ != arrow.TypeUtf8:
+			return nil, fmt.Errorf("%w: Arrow column %q must be utf8", ErrInvalidProcess, f.name)
+		case f.process != "name" && f.typ != arrow.TypeInt:
+			return nil, fmt.Errorf("%w: Arrow column %q must be an integer", ErrInvalidProcess, f.name)
+		}
+		if f.typ == arrow.TypeInt {
+			typ := m.Ref(m.Field(field, 3))
+			f.bitWidth, f.signed = m.Scalar(typ, 0, 4), m.Scalar(typ, 1, 1) != 0
+			if f.bitWidth != 8 && f.bitWidth != 16 && f.bitWidth != 32 && f.bitWidth != 64 {
+				return nil, fmt.Errorf("%w: Arrow column %q has a %d-bit integer type", ErrInvalidProcess, f.name, f.bitWidth)
+			}
+		}
+		fields[i] = f
+	}
+
+	return fields, nil
+}
+
+// appendArrowBatch decodes one record batch into processes.
+func appendArrowBatch(processes []scheduler.Process, m arrow.Reader, batch int, body []byte, fields []arrowField) ([]scheduler.Process, error) {
+	if m.Field(batch, 3) != 0 {
+		return nil, fmt.Errorf("%w: compressed Arrow record batches are not supported", ErrInvalidProcess)
+	}
+	rows := int(m.Scalar(batch, 0, 8))
+	nodes, nNodes := m.Vector(batch, 1)
+	buffers, nBuffers := m.Vector(batch, 2)
+	if nNodes != len(fields) {
+		return nil, fmt.Errorf("%w: Arrow record batch has %d columns, schema has %d", ErrInvalidProcess, nNodes, len(fields))
+	}
+	buffer := func(i int) arrow.Reader {
+		if i >= nBuffers {
+			panic(arrow.ErrBounds)
+		}
+		off, n := int(m.Int64(buffers+16*i)), int(m.Int64(buffers+16*i+8))
+		arrow.Reader(body).Check(off, n)
+		return arrow.Reader(body[off : off+n])
+	}
+
+	start := len(processes)
+	for i := 0; i < rows; i++ {
+		processes = append(processes, scheduler.Process{ProcessID: int64(start + i + 1)})
+	}
+	b := 0
+	for c, f := range fields {
+		first := b
+		b += arrowBufferCounts[f.typ]
+		if f.process == "" {
+			continue
+		}
+		if m.Int64(nodes+16*c+8) != 0 {
+			return nil, fmt.Errorf("%w: Arrow column %q has nulls", ErrInvalidProcess, f.name)
+		}
+		if f.process == "name" {
+			offsets, data := buffer(first+1), buffer(first+2)
+			for i := 0; i < rows; i++ {
+				lo, hi := offsets.Uint32(4*i), offsets.Uint32(4*i+4)
+				data.Check(lo, hi-lo)
+				processes[start+i].Name = string(data[lo:hi])
+			}
+			continue
+		}
+		values, width := buffer(first+1), int(f.bitWidth/8)
+		for i := 0; i < rows; i++ {
+			values.Check(i*width, width)
+			var u uint64
+			for j := 0; j < width; j++ {
+				u |= uint64(values[i*width+j]) << (8 * j)
+			}
+			n := int64(u)
+			if shift := 64 - 8*width; f.signed && shift > 0 {
+				n = int64(u<<shift) >> shift
+			}
+			p := &processes[start+i]
+			switch f.process {
+			case "id":
+				p.ProcessID = n
+			case "arrival":
+				p.ArrivalTime = n
+			case "burst":
+				p.BurstDuration = n
+			case "priority":
+				p.Priority = n
+			}
+		}
+	}
+
+	return processes, nil
+}
@@ -0,0 +1,68 @@
+package workload
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jh125486/CSCE4600/Project1/internal/arrow"
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+func Test_loadArrow_names(t *testing.T) {
+	t.Parallel()
+	var b bytes.Buffer
+	aw := arrow.NewWriter(&b,
+		arrow.Column{Name: "PID"},
+		arrow.Column{Name: "Name", UTF8: true},
+		arrow.Column{Name: "burst_duration"},
+		arrow.Column{Name: "comment", UTF8: true},
+	)
+	require.NoError(t, aw.WriteBatch([]arrow.Column{
+		{Ints: []int64{4, 5}},
+		{Strings: []string{"web", ""}, UTF8: true},
+		{Ints: []int64{10, 20}},
+		{Strings: []string{"ignored", "too"}, UTF8: true},
+	}))
+	require.NoError(t, aw.Close())
+
+	got, err := loadArrow(&b)
+	require.NoError(t, err)
+	assert.Equal(t, []scheduler.Process{
+		{ProcessID: 4, Name: "web", BurstDuration: 10},
+		{ProcessID: 5, BurstDuration: 20},
+	}, got)
+}
+
+func Test_loadArrow_errors(t *testing.T) {
+	t.Parallel()
+	var valid bytes.Buffer
+	aw := arrow.NewWriter(&valid, arrow.Column{Name: "id"}, arrow.Column{Name: "burst"})
+	require.NoError(t, aw.WriteBatch([]arrow.Column{{Ints: []int64{1}}, {Ints: []int64{2}}}))
+	require.NoError(t, aw.Close())
+
+	var wrongType bytes.Buffer
+	aw = arrow.NewWriter(&wrongType, arrow.Column{Name: "burst", UTF8: true})
+	require.NoError(t, aw.Close())
+
+	tests := []struct {
+		name  string
+		input []byte
+		want  error
+	}{
+		{name: "truncated", input: valid.Bytes()[:valid.Len()-20], want: io.ErrUnexpectedEOF},
+		{name: "garbage metadata", input: []byte{0xff, 0xff, 0xff, 0xff, 8, 0, 0, 0, 0xf0, 0xff, 0xff, 0x7f, 1, 2, 3, 4}, want: ErrInvalidProcess},
+		{name: "wrong column type", input: wrongType.Bytes(), want: ErrInvalidProcess},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := loadArrow(bytes.NewReader(tt.input))
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package workload
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/jh125486/CSCE4600/Project1/schedulerbench"
+)
+
+func BenchmarkLoadProcesses(b *testing.B) {
+	var csv bytes.Buffer
+	for _, j := range schedulerbench.Poisson(100000, 10, 8, 1).Jobs {
+		_, _ = fmt.Fprintf(&csv, "%d,%d,%d,%d\n", j.ID, j.Burst, j.Arrival, j.Priority)
+	}
+	b.ReportAllocs()
+	b.SetBytes(int64(csv.Len()))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := LoadCSV(bytes.NewReader(csv.Bytes())); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,240 @@
+package workload
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+var (
+	ErrInvalidProcess = errors.New("invalid process")
+	ErrDuplicatePID   = fmt.Errorf("%w: duplicate process ID", ErrInvalidProcess)
+	// ErrFractionalTime rejects fields such as 2.5: the engine counts whole ticks.
+	ErrFractionalTime = fmt.Errorf("%w: times must be whole numbers (scale fractional times to a finer unit, e.g. seconds to --time-unit ms)", ErrInvalidProcess)
+)
+
+// csvColumns are the columns of a CSV workload in the order they are read without a header row,
+// by the names a header row gives them.
+var csvColumns = [...]string{"id", "burst", "arrival", "priority", "sla_turnaround", "sla_response", "cpus", "gpus",
+	"tickets", "deadline", "runtime", "period", "hard", "memory", "nice", "io_interval", "io_duration"}
+
+// csvColumnAliases are the other names a header row may give a column, such as those in the
+// README's record format.
+var csvColumnAliases = map[string]string{
+	"pid":            "id",
+	"process_id":     "id",
+	"processid":      "id",
+	"burst_duration": "burst",
+	"arrival_time":   "arrival",
+}
+
+// LoadCSV parses <ProcessID>,<Burst Duration>,<Arrival Time>[,<Priority>[,<SLA Turnaround>[,<SLA Response>[,<CPUs>[,<GPUs>[,<Tickets>[,<Deadline>[,<Runtime>[,<Period>[,<Hard>[,<Memory>[,<Nice>[,<IO Interval>[,<IO Duration>]]]]]]]]]]]]]]
+// records. Surrounding whitespace and quotes are ignored, blank lines are skipped and a missing
+// priority, SLA target, resource, ticket count, deadline or I/O interval defaults to 0, which for a target,
+// deadline or I/O interval means none, for CPUs 1 and for tickets those its priority earns (see scheduler.Process.TicketCount).
+// A deadline is relative to the arrival time. A first record that starts with a letter is a header
+// row naming the columns (see csvColumns), in any order and case, which must include the id, burst
+// and arrival; every record then has its fields. A record with more fields than are understood is
+// rejected rather than cut short. Lines are scanned into a reused buffer and integers are parsed
+// straight from the bytes, so loading large traces allocates little beyond the result.
+func LoadCSV(r io.Reader) ([]scheduler.Process, error) {
+	var (
+		sc        = bufio.NewScanner(r)
+		processes = make([]scheduler.Process, 0)
+		fields    [][]byte
+		line      int
+		columns   []int // the column of each field, from a header row
+	)
+	sc.Buffer(make([]byte, 0, 64*1024), MaxLineLength)
+	for sc.Scan() {
+		line++
+		fields = SplitFields(fields[:0], sc.Bytes())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(processes) == 0 && columns == nil && isHeader(fields[0]) {
+			var err error
+			if columns, err = parseCSVHeader(fields); err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			continue
+		}
+		switch {
+		case columns != nil && len(fields) != len(columns):
+			return nil, fmt.Errorf("%w: line %d: want the header's %d fields, got %d", ErrInvalidProcess, line, len(columns), len(fields))
+		case len(fields) < 3:
+			return nil, fmt.Errorf("%w: line %d: want at least 3 fields, got %d", ErrInvalidProcess, line, len(fields))
+		case len(fields) > len(csvColumns):
+			return nil, fmt.Errorf("%w: line %d: want at most %d fields, got %d", ErrInvalidProcess, line, len(csvColumns), len(fields))
+		}
+		var (
+			vals [len(csvColumns)]int64
+			err  error
+		)
+		for i, f := range fields {
+			c := i
+			if columns != nil {
+				c = columns[i]
+			}
+			if vals[c], err = ParseInt(f); err != nil {
+				if bytes.IndexByte(f, '.') >= 0 {
+					return nil, fmt.Errorf("%w: line %d: field %d %q", ErrFractionalTime, line, i+1, f)
+				}
+				return nil, fmt.Errorf("%w: line %d: field %d %q: %v", ErrInvalidProcess, line, i+1, f, err)
+			}
+		}
+		processes = append(processes, scheduler.Process{
+			ProcessID:     vals[0],
+			BurstDuration: vals[1],
+			ArrivalTime:   vals[2],
+			Priority:      vals[3],
+			SLA:           scheduler.SLA{Turnaround: vals[4], Response: vals[5]},
+			Resources:     scheduler.Resources{scheduler.ResourceCPU: vals[6], scheduler.ResourceGPU: vals[7]},
+			Tickets:       vals[8],
+			Deadline:      vals[9],
+			Runtime:       vals[10],
+			Period:        vals[11],
+			Hard:          vals[12] != 0,
+			Memory:        vals[13],
+			Nice:          vals[14],
+			IOInterval:    vals[15],
+			IODuration:    vals[16],
+		})
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("%w: reading CSV", err)
+	}
+
+	return processes, nil
+}
+
+// isHeader reports whether a record starting with field is a header row, as no value starts
+// with a letter.
+func isHeader(field []byte) bool {
+	if len(field) == 0 {
+		return false
+	}
+	c := field[0] | 0x20 // lower case
+	return c >= 'a' && c <= 'z'
+}
+
+// parseCSVHeader returns the column of each field of a header row. Names are matched ignoring
+// case, with spaces and hyphens read as underscores.
+func parseCSVHeader(fields [][]byte) ([]int, error) {
+	var (
+		columns = make([]int, len(fields))
+		seen    [len(csvColumns)]bool
+	)
+	for i, f := range fields {
+		name := strings.NewReplacer(" ", "_", "-", "_").Replace(strings.ToLower(string(f)))
+		if alias, ok := csvColumnAliases[name]; ok {
+			name = alias
+		}
+		c := -1
+		for j, column := range csvColumns {
+			if column == name {
+				c = j
+			}
+		}
+		switch {
+		case c < 0:
+			return nil, fmt.Errorf("%w: unknown column %q, want some of %s", ErrInvalidProcess, f, strings.Join(csvColumns[:], ", "))
+		case seen[c]:
+			return nil, fmt.Errorf("%w: column %q given twice", ErrInvalidProcess, f)
+		}
+		columns[i], seen[c] = c, true
+	}
+	for c := 0; c < 3; c++ {
+		if !seen[c] {
+			return nil, fmt.Errorf("%w: the header has no %s column", ErrInvalidProcess, csvColumns[c])
+		}
+	}
+
+	return columns, nil
+}
+
+// CheckUniquePIDs rejects a workload in which two processes share an ID, naming the first repeat
+// and the processes (by position, from 1) that use it.
+func CheckUniquePIDs(processes []scheduler.Process) error {
+	seen := make(map[int64]int, len(processes))
+	for i, p := range processes {
+		if first, ok := seen[p.ProcessID]; ok {
+			return fmt.Errorf("%w: %d is used by processes %d and %d (--renumber-pids numbers them 1..n)",
+				ErrDuplicatePID, p.ProcessID, first+1, i+1)
+		}
+		seen[p.ProcessID] = i
+	}
+
+	return nil
+}
+
+// Renumber replaces the process IDs with 1..n in input order.
+func Renumber(processes []scheduler.Process) {
+	for i := range processes {
+		processes[i].ProcessID = int64(i + 1)
+	}
+}
+
+// MaxLineLength bounds a single CSV record.
+const MaxLineLength = 1 << 20
+
+// SplitFields appends the comma separated fields of line to fields, trimming whitespace
+// and a surrounding pair of double quotes. A blank line has no fields.
+func SplitFields(fields [][]byte, line []byte) [][]byte {
+	if len(bytes.TrimSpace(line)) == 0 {
+		return fields
+	}
+	for {
+		i := bytes.IndexByte(line, ',')
+		field := line
+		if i >= 0 {
+			field = line[:i]
+		}
+		field = bytes.TrimSpace(field)
+		if len(field) >= 2 && field[0] == '"' && field[len(field)-1] == '"' {
+			field = bytes.TrimSpace(field[1 : len(field)-1])
+		}
+		fields = append(fields, field)
+		if i < 0 {
+			return fields
+		}
+		line = line[i+1:]
+	}
+}
+
+// ParseInt parses a base 10 int64 from b without converting it to a string first.
+func ParseInt(b []byte) (int64, error) {
+	neg := false
+	if len(b) > 0 && (b[0] == '-' || b[0] == '+') {
+		neg = b[0] == '-'
+		b = b[1:]
+	}
+	if len(b) == 0 {
+		return 0, strconv.ErrSyntax
+	}
+	const cutoff = uint64(1 << 63)
+	var n uint64
+	for _, c := range b {
+		if c < '0' || c > '9' {
+			return 0, strconv.ErrSyntax
+		}
+		if n > (cutoff-uint64(c-'0'))/10 {
+			return 0, strconv.ErrRange
+		}
+		n = n*10 + uint64(c-'0')
+	}
+	if neg {
+		return -int64(n), nil
+	}
+	if n == cutoff {
+		return 0, strconv.ErrRange
+	}
+
+	return int64(n), nil
+}
@@ -0,0 +1,308 @@
+package workload
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+func TestLoadCSV(t *testing.T) {
+	t.Parallel()
+	type args struct {
+		r io.Reader
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []scheduler.Process
+		wantErr error
+	}{
+		{
+			name: "bad CSV",
+			args: args{
+				r: iotest.ErrReader(io.ErrUnexpectedEOF),
+			},
+			wantErr: io.ErrUnexpectedEOF,
+		},
+		{
+			name: "success",
+			args: args{
+				r: strings.NewReader(`1,5,0,2
+2,9,3,1
+3,6,3,3`),
+			},
+			want: []scheduler.Process{
+				{
+					ProcessID:     1,
+					ArrivalTime:   0,
+					BurstDuration: 5,
+					Priority:      2,
+				},
+				{
+					ProcessID:     2,
+					ArrivalTime:   3,
+					BurstDuration: 9,
+					Priority:      1,
+				},
+				{
+					ProcessID:     3,
+					ArrivalTime:   3,
+					BurstDuration: 6,
+					Priority:      3,
+				},
+			},
+		},
+		{
+			name: "whitespace and missing priority",
+			args: args{
+				r: strings.NewReader(" 1, 5 ,0\n2,9,3, 1\n"),
+			},
+			want: []scheduler.Process{
+				{
+					ProcessID:     1,
+					ArrivalTime:   0,
+					BurstDuration: 5,
+				},
+				{
+					ProcessID:     2,
+					ArrivalTime:   3,
+					BurstDuration: 9,
+					Priority:      1,
+				},
+			},
+		},
+		{
+			name: "blank lines, quotes and CRLF",
+			args: args{
+				r: strings.NewReader("\"1\",5,0,2\r\n\r\n  \n2,9,3,1\r\n"),
+			},
+			want: []scheduler.Process{
+				{
+					ProcessID:     1,
+					ArrivalTime:   0,
+					BurstDuration: 5,
+					Priority:      2,
+				},
+				{
+					ProcessID:     2,
+					ArrivalTime:   3,
+					BurstDuration: 9,
+					Priority:      1,
+				},
+			},
+		},
+		{
+			name: "SLA targets and resources",
+			args: args{
+				r: strings.NewReader("1,5,0,2,20\n2,9,3,1,0,4,2,1\n"),
+			},
+			want: []scheduler.Process{
+				{
+					ProcessID:     1,
+					ArrivalTime:   0,
+					BurstDuration: 5,
+					Priority:      2,
+					SLA:           scheduler.SLA{Turnaround: 20},
+				},
+				{
+					ProcessID:     2,
+					ArrivalTime:   3,
+					BurstDuration: 9,
+					Priority:      1,
+					SLA:           scheduler.SLA{Response: 4},
+					Resources:     scheduler.Resources{2, 1},
+				},
+			},
+		},
+		{
+			name: "tickets and deadlines",
+			args: args{
+				r: strings.NewReader("1,5,0,2,0,0,0,0,300\n2,3,1,0,0,0,0,0,0,9\n"),
+			},
+			want: []scheduler.Process{
+				{
+					ProcessID:     1,
+					BurstDuration: 5,
+					Priority:      2,
+					Tickets:       300,
+				},
+				{
+					ProcessID:     2,
+					BurstDuration: 3,
+					ArrivalTime:   1,
+					Deadline:      9,
+				},
+			},
+		},
+		{
+			name: "reservations",
+			args: args{
+				r: strings.NewReader("1,5,0,0,0,0,0,0,0,4,2,5,1,64\n"),
+			},
+			want: []scheduler.Process{
+				{
+					ProcessID:     1,
+					BurstDuration: 5,
+					Deadline:      4,
+					Runtime:       2,
+					Period:        5,
+					Hard:          true,
+					Memory:        64,
+				},
+			},
+		},
+		{
+			name: "nice",
+			args: args{
+				r: strings.NewReader("1,5,0,0,0,0,0,0,0,0,0,0,0,0,-5\n"),
+			},
+			want: []scheduler.Process{
+				{
+					ProcessID:     1,
+					BurstDuration: 5,
+					Nice:          -5,
+				},
+			},
+		},
+		{
+			name: "I/O",
+			args: args{
+				r: strings.NewReader("1,5,0,0,0,0,0,0,0,0,0,0,0,0,0,2,3\n"),
+			},
+			want: []scheduler.Process{
+				{
+					ProcessID:     1,
+					BurstDuration: 5,
+					IOInterval:    2,
+					IODuration:    3,
+				},
+			},
+		},
+		{
+			name: "header",
+			args: args{
+				r: strings.NewReader("Arrival Time, process_id ,burst,deadline\n3,1,5,9\n0,2,4,0\n"),
+			},
+			want: []scheduler.Process{
+				{ProcessID: 1, BurstDuration: 5, ArrivalTime: 3, Deadline: 9},
+				{ProcessID: 2, BurstDuration: 4},
+			},
+		},
+		{
+			name: "header without arrival",
+			args: args{
+				r: strings.NewReader("id,burst,priority\n1,5,0\n"),
+			},
+			wantErr: ErrInvalidProcess,
+		},
+		{
+			name: "unknown column",
+			args: args{
+				r: strings.NewReader("id,burst,arrival,weight\n1,5,0,3\n"),
+			},
+			wantErr: ErrInvalidProcess,
+		},
+		{
+			name: "fields beyond the header",
+			args: args{
+				r: strings.NewReader("id,burst,arrival\n1,5,0,3\n"),
+			},
+			wantErr: ErrInvalidProcess,
+		},
+		{
+			name: "too many fields",
+			args: args{
+				r: strings.NewReader("1,5,0,0,0,0,0,0,0,0,0,0,0,0,0,0,0,7\n"),
+			},
+			wantErr: ErrInvalidProcess,
+		},
+		{
+			name: "bad integer",
+			args: args{
+				r: strings.NewReader("1,5,0\n2,nine,3\n"),
+			},
+			wantErr: ErrInvalidProcess,
+		},
+		{
+			name: "too few fields",
+			args: args{
+				r: strings.NewReader("1,5,0\n2,9\n"),
+			},
+			wantErr: ErrInvalidProcess,
+		},
+		{
+			name: "fractional time",
+			args: args{
+				r: strings.NewReader("1,2.5,0\n"),
+			},
+			wantErr: ErrFractionalTime,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := LoadCSV(tt.args.r)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LoadCSV() = %v, want %v", got, tt.want)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckUniquePIDs(t *testing.T) {
+	t.Parallel()
+	unique := []scheduler.Process{{ProcessID: 7}, {ProcessID: 1000}, {ProcessID: 3}}
+	if err := CheckUniquePIDs(unique); err != nil {
+		t.Errorf("CheckUniquePIDs() error = %v, want nil", err)
+	}
+	err := CheckUniquePIDs([]scheduler.Process{{ProcessID: 7}, {ProcessID: 3}, {ProcessID: 7}})
+	if !errors.Is(err, ErrDuplicatePID) || !strings.Contains(err.Error(), "7 is used by processes 1 and 3") {
+		t.Errorf("CheckUniquePIDs() error = %v, want %v naming processes 1 and 3", err, ErrDuplicatePID)
+	}
+
+	Renumber(unique)
+	if want := []scheduler.Process{{ProcessID: 1}, {ProcessID: 2}, {ProcessID: 3}}; !reflect.DeepEqual(unique, want) {
+		t.Errorf("Renumber() = %+v, want %+v", unique, want)
+	}
+}
+
+func TestParseInt(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		in      string
+		want    int64
+		wantErr error
+	}{
+		{in: "0", want: 0},
+		{in: "42", want: 42},
+		{in: "+7", want: 7},
+		{in: "-13", want: -13},
+		{in: "9223372036854775807", want: 9223372036854775807},
+		{in: "-9223372036854775808", want: -9223372036854775808},
+		{in: "9223372036854775808", wantErr: strconv.ErrRange},
+		{in: "99999999999999999999", wantErr: strconv.ErrRange},
+		{in: "", wantErr: strconv.ErrSyntax},
+		{in: "-", wantErr: strconv.ErrSyntax},
+		{in: "1.5", wantErr: strconv.ErrSyntax},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.in, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseInt([]byte(tt.in))
+			if got != tt.want || !errors.Is(err, tt.wantErr) {
+				t.Errorf("parseInt(%q) = %d, %v, want %d, %v", tt.in, got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package workload
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+// dockerColumnSep separates columns in docker's table output, where values themselves may hold single spaces.
+var dockerColumnSep = regexp.MustCompile(`\s{2,}`)
+
+// dockerContainer is one line of `docker stats`/`docker ps` output, in either the default table
+// layout or `--format '{{json .}}'`.
+type dockerContainer struct {
+	ID      string `json:"ID"`
+	Name    string `json:"Name"`
+	Names   string `json:"Names"`
+	CPUPerc string `json:"CPUPerc"`
+}
+
+// loadDockerStats imports `docker stats --no-stream` or `docker ps` output, table or JSON lines.
+// Each container becomes a process whose burst is its CPU usage in percent of one CPU, rounded up
+// (at least 1). docker ps has no CPU usage, so every burst is 1 and containers arrive in the order
+// they were started (docker lists the newest first). Priorities are all 1.
+func loadDockerStats(r io.Reader) ([]scheduler.Process, error) {
+	var (
+		containers []dockerContainer
+		columns    map[string]int
+		hasCPU     bool
+		scanner    = bufio.NewScanner(r)
+		line       int
+	)
+	scanner.Buffer(make([]byte, 0, 4096), MaxLineLength)
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		switch {
+		case text == "":
+			continue
+		case strings.HasPrefix(text, "{"):
+			var c dockerContainer
+			if err := json.Unmarshal([]byte(text), &c); err != nil {
+				return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidProcess, line, err)
+			}
+			hasCPU = hasCPU || c.CPUPerc != ""
+			containers = append(containers, c)
+		case strings.HasPrefix(text, "CONTAINER"):
+			columns = make(map[string]int)
+			for i, h := range dockerColumnSep.Split(text, -1) {
+				columns[h] = i
+			}
+			_, hasCPU = columns["CPU %"]
+		case columns == nil:
+			return nil, fmt.Errorf("%w: line %d: expected a docker stats or docker ps header", ErrInvalidProcess, line)
+		default:
+			fields := dockerColumnSep.Split(text, -1)
+			field := func(name string) string {
+				if i, ok := columns[name]; ok && i < len(fields) {
+					return fields[i]
+				}
+				return ""
+			}
+			containers = append(containers, dockerContainer{
+				ID:      field("CONTAINER ID"),
+				Name:    field("NAME"),
+				Names:   field("NAMES"),
+				CPUPerc: field("CPU %"),
+			})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: reading docker output", err)
+	}
+
+	processes := make([]scheduler.Process, len(containers))
+	for i, c := range containers {
+		p := scheduler.Process{ProcessID: int64(i + 1), Name: c.Name, BurstDuration: 1, Priority: 1}
+		if p.Name == "" {
+			p.Name = c.Names
+		}
+		if p.Name == "" {
+			p.Name = c.ID
+		}
+		if hasCPU {
+			burst, err := parseCPUPercent(c.CPUPerc)
+			if err != nil {
+				return nil, fmt.Errorf("%w: container %s: CPU %q: %v", ErrInvalidProcess, p.Name, c.CPUPerc, err)
+			}
+			p.BurstDuration = burst
+		} else {
+			p.ArrivalTime = int64(len(containers) - 1 - i)
+		}
+		processes[i] = p
+	}
+
+	return processes, nil
+}
+
+// parseCPUPercent parses a docker CPU percentage ("12.34%") into a burst of at least 1.
+func parseCPUPercent(s string) (int64, error) {
+	pct, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(s), "%"), 64)
+	if err != nil {
+		return 0, err
+	}
+	if burst := int64(math.Ceil(pct)); burst > 1 {
+		return burst, nil
+	}
+
+	return 1, nil
+}
@@ -0,0 +1,82 @@
+package workload
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+func Test_loadDockerStats(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		input   string
+		want    []scheduler.Process
+		wantErr bool
+	}{
+		{
+			name: "stats table",
+			input: `CONTAINER ID   NAME      CPU %     MEM USAGE / LIMIT     MEM %     NET I/O          BLOCK I/O   PIDS
+1a2b3c4d5e6f   web       12.34%    25.1MiB / 7.6GiB      0.32%     1.2kB / 0B       0B / 0B     5
+6f5e4d3c2b1a   db        0.00%     180MiB / 7.6GiB       2.31%     3.4kB / 1.1kB    8MB / 0B    31
+`,
+			want: []scheduler.Process{
+				{ProcessID: 1, Name: "web", BurstDuration: 13, Priority: 1},
+				{ProcessID: 2, Name: "db", BurstDuration: 1, Priority: 1},
+			},
+		},
+		{
+			name: "ps table",
+			input: `CONTAINER ID   IMAGE          COMMAND                  CREATED         STATUS         PORTS     NAMES
+1a2b3c4d5e6f   nginx:latest   "/docker-entrypoint.…"   2 minutes ago   Up 2 minutes   80/tcp    web
+6f5e4d3c2b1a   postgres:16    "docker-entrypoint.s…"   3 hours ago     Up 3 hours     5432/tcp  db
+`,
+			want: []scheduler.Process{
+				{ProcessID: 1, Name: "web", ArrivalTime: 1, BurstDuration: 1, Priority: 1},
+				{ProcessID: 2, Name: "db", BurstDuration: 1, Priority: 1},
+			},
+		},
+		{
+			name: "stats json",
+			input: `{"BlockIO":"0B / 0B","CPUPerc":"150.5%","Container":"1a2b","ID":"1a2b","Name":"build"}
+{"BlockIO":"0B / 0B","CPUPerc":"3%","Container":"3c4d","ID":"3c4d","Name":"cache"}
+`,
+			want: []scheduler.Process{
+				{ProcessID: 1, Name: "build", BurstDuration: 151, Priority: 1},
+				{ProcessID: 2, Name: "cache", BurstDuration: 3, Priority: 1},
+			},
+		},
+		{
+			name:    "missing header",
+			input:   "1a2b3c4d5e6f   web   12.34%\n",
+			wantErr: true,
+		},
+		{
+			name:    "bad cpu",
+			input:   "CONTAINER ID   NAME   CPU %\n1a2b   web   busy\n",
+			wantErr: true,
+		},
+		{
+			name:    "bad json",
+			input:   `{"Name":`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := loadDockerStats(strings.NewReader(tt.input))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package workload_test
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jh125486/CSCE4600/Project1/workload"
+)
+
+func ExampleLoadCSV() {
+	processes, err := workload.LoadCSV(strings.NewReader("1,5,0,2\n2, 9, 3, 1\n\"3\",6,6\n"))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, p := range processes {
+		fmt.Printf("P%d burst %d arrives %d priority %d\n", p.ProcessID, p.BurstDuration, p.ArrivalTime, p.Priority)
+	}
+	// Output:
+	// P1 burst 5 arrives 0 priority 2
+	// P2 burst 9 arrives 3 priority 1
+	// P3 burst 6 arrives 6 priority 0
+}
+
+func ExampleGenerate() {
+	processes, _, err := workload.Generate("gen:poisson?n=5&seed=7")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	again, _, _ := workload.Generate("gen:poisson?n=5&seed=7")
+	fmt.Println(len(processes), "processes, the same every time:", processes[4] == again[4])
+	// Output:
+	// 5 processes, the same every time: true
+}
+
+func ExampleSanitize() {
+	processes, err := workload.LoadCSV(strings.NewReader("1,5,0\n2,-3,4\n"))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	var warnings strings.Builder
+	processes, err = workload.Sanitize(&warnings, "jobs.csv", processes, workload.InvalidSkip)
+	fmt.Println(len(processes), err)
+	fmt.Print(warnings.String())
+	// Output:
+	// 1 <nil>
+	// warning: jobs.csv: row 2 (ID 2): burst -3 skipped
+}
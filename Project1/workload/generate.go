@@ -0,0 +1,163 @@
+package workload
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+	"github.com/jh125486/CSCE4600/Project1/schedulerbench"
+)
+
+// generatedScheme prefixes workload arguments that are generated rather than read from a file,
+// e.g. gen:poisson?n=200&gap=10&burst=8&seed=3.
+const generatedScheme = "gen:"
+
+// generator builds a workload from a generated workload's parameters.
+type generator struct {
+	params   map[string]float64 // accepted parameters and their defaults
+	generate func(p map[string]float64, seed int64) schedulerbench.Workload
+}
+
+var generators = map[string]generator{
+	"batch": {
+		params: map[string]float64{"n": 100},
+		generate: func(p map[string]float64, seed int64) schedulerbench.Workload {
+			return schedulerbench.Batch(int(p["n"]), seed)
+		},
+	},
+	"staggered": {
+		params: map[string]float64{"n": 100, "interval": 5},
+		generate: func(p map[string]float64, seed int64) schedulerbench.Workload {
+			return schedulerbench.Staggered(int(p["n"]), int64(p["interval"]), seed)
+		},
+	},
+	"lognormal": {
+		params: map[string]float64{"n": 100, "gap": 10, "burst": 8, "cv": 1},
+		generate: func(p map[string]float64, seed int64) schedulerbench.Workload {
+			return schedulerbench.LogNormal(int(p["n"]), p["gap"], p["burst"], p["cv"], seed)
+		},
+	},
+	"poisson": {
+		params: map[string]float64{"n": 100, "gap": 10, "burst": 8},
+		generate: func(p map[string]float64, seed int64) schedulerbench.Workload {
+			return schedulerbench.Poisson(int(p["n"]), p["gap"], p["burst"], seed)
+		},
+	},
+}
+
+var ErrInvalidGenerator = fmt.Errorf("%w: invalid generated workload", scheduler.ErrInvalidArgs)
+
+// GeneratedSpec is a parsed generated workload argument.
+type GeneratedSpec struct {
+	Kind   string
+	Params map[string]float64
+	Seed   int64
+	seeded bool // the argument gave its own seed
+}
+
+// IsGenerated reports whether a workload argument names a generated workload (gen:family?params).
+func IsGenerated(arg string) bool {
+	return strings.HasPrefix(arg, generatedScheme)
+}
+
+// ParseGeneratedSpec parses a gen:family?params argument, checking the family and its parameters.
+func ParseGeneratedSpec(arg string) (GeneratedSpec, error) {
+	kind, query, _ := strings.Cut(strings.TrimPrefix(arg, generatedScheme), "?")
+	g, ok := generators[kind]
+	if !ok {
+		names := make([]string, 0, len(generators))
+		for name := range generators {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return GeneratedSpec{}, fmt.Errorf("%w: %q: unknown generator %q (known: %s)", ErrInvalidGenerator, arg, kind, strings.Join(names, ", "))
+	}
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return GeneratedSpec{}, fmt.Errorf("%w: %q: %v", ErrInvalidGenerator, arg, err)
+	}
+
+	spec := GeneratedSpec{Kind: kind, Params: make(map[string]float64, len(g.params)), Seed: 1}
+	for name, def := range g.params {
+		spec.Params[name] = def
+	}
+	for name, vs := range values {
+		v := vs[len(vs)-1]
+		if name == "seed" {
+			if spec.Seed, err = strconv.ParseInt(v, 10, 64); err != nil {
+				return GeneratedSpec{}, fmt.Errorf("%w: %q: seed: %v", ErrInvalidGenerator, arg, err)
+			}
+			spec.seeded = true
+			continue
+		}
+		if _, ok := g.params[name]; !ok {
+			return GeneratedSpec{}, fmt.Errorf("%w: %q: %s has no parameter %q", ErrInvalidGenerator, arg, kind, name)
+		}
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil || f < 0 {
+			return GeneratedSpec{}, fmt.Errorf("%w: %q: %s must be a non-negative number", ErrInvalidGenerator, arg, name)
+		}
+		spec.Params[name] = f
+	}
+
+	return spec, nil
+}
+
+// Workload names the generated workload without its seed, grouping the runs of each seed.
+func (s GeneratedSpec) Workload() string {
+	names := make([]string, 0, len(s.Params))
+	for name := range s.Params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		pairs[i] = name + "=" + strconv.FormatFloat(s.Params[name], 'g', -1, 64)
+	}
+
+	return generatedScheme + s.Kind + "?" + strings.Join(pairs, "&")
+}
+
+// String is the canonical argument for the spec, including its seed, so a run's input
+// regenerates exactly the same workload.
+func (s GeneratedSpec) String() string {
+	return s.Workload() + "&seed=" + strconv.FormatInt(s.Seed, 10)
+}
+
+// Generate builds a generated workload; its "SHA-256" hashes the canonical argument.
+func Generate(arg string) ([]scheduler.Process, string, error) {
+	spec, err := ParseGeneratedSpec(arg)
+	if err != nil {
+		return nil, "", err
+	}
+	w := generators[spec.Kind].generate(spec.Params, spec.Seed)
+	processes := make([]scheduler.Process, len(w.Jobs))
+	for i, j := range w.Jobs {
+		processes[i] = scheduler.Process{ProcessID: j.ID, ArrivalTime: j.Arrival, BurstDuration: j.Burst, Priority: j.Priority}
+	}
+	if err := CheckTimeRange(processes); err != nil {
+		return nil, "", fmt.Errorf("%s: %w", arg, err)
+	}
+	sum := sha256.Sum256([]byte(spec.String()))
+
+	return processes, hex.EncodeToString(sum[:]), nil
+}
+
+// Group is the workload an input belongs to when comparing seeds: generated
+// workloads without their seed, files as they are.
+func Group(input string) string {
+	if !IsGenerated(input) {
+		return input
+	}
+	spec, err := ParseGeneratedSpec(input)
+	if err != nil {
+		return input
+	}
+
+	return spec.Workload()
+}
@@ -0,0 +1,63 @@
+package workload
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGeneratedSpec(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		arg     string
+		want    string
+		wantErr bool
+	}{
+		{arg: "gen:batch", want: "gen:batch?n=100&seed=1"},
+		{arg: "gen:poisson?seed=9&n=20&gap=2.5", want: "gen:poisson?burst=8&gap=2.5&n=20&seed=9"},
+		{arg: "gen:lognormal?cv=2", want: "gen:lognormal?burst=8&cv=2&gap=10&n=100&seed=1"},
+		{arg: "gen:staggered?interval=3", want: "gen:staggered?interval=3&n=100&seed=1"},
+		{arg: "gen:uniform", wantErr: true},
+		{arg: "gen:batch?gap=1", wantErr: true},
+		{arg: "gen:batch?n=-1", wantErr: true},
+		{arg: "gen:batch?seed=x", wantErr: true},
+		{arg: "gen:batch?n=%zz", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.arg, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseGeneratedSpec(tt.arg)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidGenerator)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+	a, sumA, err := Generate("gen:staggered?n=4&interval=3&seed=2")
+	require.NoError(t, err)
+	b, sumB, err := Generate("gen:staggered?seed=2&interval=3&n=4")
+	require.NoError(t, err)
+	assert.Equal(t, a, b, "the same spec generates the same workload")
+	assert.Equal(t, sumA, sumB)
+	require.Len(t, a, 4)
+	assert.Equal(t, int64(9), a[3].ArrivalTime)
+
+	c, sumC, err := Generate("gen:staggered?n=4&interval=3&seed=3")
+	require.NoError(t, err)
+	assert.NotEqual(t, a, c)
+	assert.NotEqual(t, sumA, sumC)
+}
+
+func TestGroup(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "gen:batch?n=5", Group("gen:batch?n=5&seed=4"))
+	assert.Equal(t, "processes.csv", Group("processes.csv"))
+}
@@ -0,0 +1,30 @@
+package workload
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"io"
+)
+
+// Hasher hashes a workload as it is read.
+type Hasher struct {
+	r io.Reader
+	h hash.Hash
+}
+
+// NewHasher returns a Hasher reading from r.
+func NewHasher(r io.Reader) *Hasher {
+	h := sha256.New()
+	return &Hasher{r: io.TeeReader(r, h), h: h}
+}
+
+// Read reads from the underlying reader, hashing what it returns.
+func (ih *Hasher) Read(p []byte) (int, error) {
+	return ih.r.Read(p)
+}
+
+// Sum is the hex SHA-256 of everything read so far.
+func (ih *Hasher) Sum() string {
+	return hex.EncodeToString(ih.h.Sum(nil))
+}
@@ -0,0 +1,19 @@
+package workload
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestHasher(t *testing.T) {
+	t.Parallel()
+	ih := NewHasher(strings.NewReader("1,5,0,2\n"))
+	if _, err := io.ReadAll(ih); err != nil {
+		t.Fatal(err)
+	}
+	const want = "abada81e73b5e6e793d6de226201d4094d5a3647b947cb2c8d2ec697ee6ffa25"
+	if got := ih.Sum(); got != want {
+		t.Errorf("sum() = %s, want %s", got, want)
+	}
+}
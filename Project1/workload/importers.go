@@ -0,0 +1,103 @@
+// Package workload reads the processes a scheduler simulation runs: CSV records, Kubernetes
+// manifests, docker stats, Slurm accounting, Standard Workload Format traces and Arrow streams,
+// or generated workloads such as gen:poisson?n=100. It also validates workloads, clamping or
+// skipping values that cannot be simulated.
+package workload
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+// Importer parses a workload in some format into processes.
+type Importer func(r io.Reader) ([]scheduler.Process, error)
+
+// importers are the workload formats accepted by --input-format.
+var importers = map[string]Importer{
+	"csv":    LoadCSV,
+	"k8s":    loadKubernetes,
+	"docker": loadDockerStats,
+	"slurm":  loadSlurm,
+	"swf":    loadSWF,
+	"arrow":  loadArrow,
+}
+
+// StdinPath is the workload path that means standard input.
+const StdinPath = "-"
+
+// FormatAuto picks the importer from the workload file's extension.
+const FormatAuto = "auto"
+
+// extensionFormats maps file extensions to input formats for --input-format=auto.
+var extensionFormats = map[string]string{
+	".yaml":   "k8s",
+	".yml":    "k8s",
+	".arrow":  "arrow",
+	".arrows": "arrow",
+	".swf":    "swf",
+}
+
+var ErrInvalidInputFormat = fmt.Errorf("%w: unknown input format", scheduler.ErrInvalidArgs)
+
+// ParseFormat parses an --input-format name, ignoring case.
+func ParseFormat(s string) (string, error) {
+	s = strings.ToLower(s)
+	if _, ok := importers[s]; ok || s == FormatAuto {
+		return s, nil
+	}
+
+	return "", fmt.Errorf("%w: %q (known: %s, %s)", ErrInvalidInputFormat, s, FormatAuto, KnownFormats())
+}
+
+// KnownFormats lists the input format names, sorted and comma separated.
+func KnownFormats() string {
+	names := make([]string, 0, len(importers))
+	for name := range importers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return strings.Join(names, ", ")
+}
+
+// ImporterFor resolves the importer for a workload file.
+func ImporterFor(format, path string) Importer {
+	if format == FormatAuto {
+		format = "csv"
+		if f, ok := extensionFormats[strings.ToLower(filepath.Ext(path))]; ok {
+			format = f
+		}
+	}
+
+	return importers[format]
+}
+
+// rankPriorities maps scheduler priority values, where higher is more important (as in Kubernetes
+// and Slurm), to this simulator's priorities, where 1 is most important: equal values share a rank.
+func rankPriorities(values []int64) []int64 {
+	distinct := make([]int64, 0, len(values))
+	seen := make(map[int64]bool, len(values))
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			distinct = append(distinct, v)
+		}
+	}
+	sort.Slice(distinct, func(i, j int) bool { return distinct[i] > distinct[j] })
+	rank := make(map[int64]int64, len(distinct))
+	for i, v := range distinct {
+		rank[v] = int64(i + 1)
+	}
+
+	ranks := make([]int64, len(values))
+	for i, v := range values {
+		ranks[i] = rank[v]
+	}
+
+	return ranks
+}
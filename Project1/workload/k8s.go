@@ -0,0 +1,218 @@
+package workload
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+// Annotations that override the values derived from a Kubernetes object.
+const (
+	k8sBurstAnnotation   = "scheduler-sim/burst"
+	k8sArrivalAnnotation = "scheduler-sim/arrival"
+)
+
+// k8sBuiltinPriorityClasses are the classes every cluster defines.
+var k8sBuiltinPriorityClasses = map[string]int64{
+	"system-node-critical":    2000001000,
+	"system-cluster-critical": 2000000000,
+}
+
+type (
+	k8sObject struct {
+		Kind     string `yaml:"kind"`
+		Value    int64  `yaml:"value"` // PriorityClass
+		Metadata struct {
+			Name        string            `yaml:"name"`
+			Annotations map[string]string `yaml:"annotations"`
+		} `yaml:"metadata"`
+		Spec struct {
+			k8sPodSpec  `yaml:",inline"`
+			Completions *int64 `yaml:"completions"`
+			Template    struct {
+				Spec k8sPodSpec `yaml:"spec"`
+			} `yaml:"template"`
+		} `yaml:"spec"`
+		Items []k8sObject `yaml:"items"` // List
+	}
+	k8sPodSpec struct {
+		PriorityClassName string         `yaml:"priorityClassName"`
+		Priority          *int64         `yaml:"priority"`
+		Containers        []k8sContainer `yaml:"containers"`
+	}
+	k8sContainer struct {
+		Resources struct {
+			Requests map[string]string `yaml:"requests"`
+			Limits   map[string]string `yaml:"limits"`
+		} `yaml:"resources"`
+	}
+)
+
+// loadKubernetes imports Pods and Jobs from (multi-document) Kubernetes YAML:
+//   - burst is the total CPU request in millicores (falling back to limits), or the
+//     scheduler-sim/burst annotation;
+//   - arrival is 0, as for a batch submitted at once, or the scheduler-sim/arrival annotation;
+//   - priority ranks the objects' Kubernetes priority values, highest first, as 1, 2, 3, ...
+//     PriorityClass objects in the same input define the value of their class names.
+//
+// A Job with completions > 1 becomes that many processes. Other kinds are ignored.
+func loadKubernetes(r io.Reader) ([]scheduler.Process, error) {
+	var (
+		dec     = yaml.NewDecoder(r)
+		objects []k8sObject
+		classes = make(map[string]int64, len(k8sBuiltinPriorityClasses))
+	)
+	for name, v := range k8sBuiltinPriorityClasses {
+		classes[name] = v
+	}
+	for {
+		var obj k8sObject
+		if err := dec.Decode(&obj); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("%w: reading Kubernetes YAML", err)
+		}
+		for _, o := range flattenK8sList(obj) {
+			if o.Kind == "PriorityClass" {
+				classes[o.Metadata.Name] = o.Value
+				continue
+			}
+			objects = append(objects, o)
+		}
+	}
+
+	type job struct {
+		name     string
+		burst    int64
+		arrival  int64
+		priority int64 // Kubernetes priority value: higher is more important
+	}
+	var jobs []job
+	for _, o := range objects {
+		var (
+			spec  k8sPodSpec
+			count int64 = 1
+		)
+		switch o.Kind {
+		case "Pod":
+			spec = o.Spec.k8sPodSpec
+		case "Job":
+			spec = o.Spec.Template.Spec
+			if o.Spec.Completions != nil && *o.Spec.Completions > 1 {
+				count = *o.Spec.Completions
+			}
+		default:
+			continue
+		}
+		burst, err := k8sBurst(o, spec)
+		if err != nil {
+			return nil, err
+		}
+		arrival, err := k8sAnnotationInt(o, k8sArrivalAnnotation, 0)
+		if err != nil {
+			return nil, err
+		}
+		priority := classes[spec.PriorityClassName]
+		if spec.Priority != nil {
+			priority = *spec.Priority
+		}
+		for i := int64(0); i < count; i++ {
+			name := o.Metadata.Name
+			if count > 1 {
+				name = fmt.Sprintf("%s-%d", name, i)
+			}
+			jobs = append(jobs, job{name: name, burst: burst, arrival: arrival, priority: priority})
+		}
+	}
+
+	values := make([]int64, len(jobs))
+	for i, j := range jobs {
+		values[i] = j.priority
+	}
+	rank := rankPriorities(values)
+
+	processes := make([]scheduler.Process, len(jobs))
+	for i, j := range jobs {
+		processes[i] = scheduler.Process{
+			ProcessID:     int64(i + 1),
+			Name:          j.name,
+			BurstDuration: j.burst,
+			ArrivalTime:   j.arrival,
+			Priority:      rank[i],
+		}
+	}
+
+	return processes, nil
+}
+
+func flattenK8sList(obj k8sObject) []k8sObject {
+	if !strings.HasSuffix(obj.Kind, "List") {
+		return []k8sObject{obj}
+	}
+	var objs []k8sObject
+	for _, item := range obj.Items {
+		objs = append(objs, flattenK8sList(item)...)
+	}
+
+	return objs
+}
+
+func k8sBurst(o k8sObject, spec k8sPodSpec) (int64, error) {
+	if _, ok := o.Metadata.Annotations[k8sBurstAnnotation]; ok {
+		return k8sAnnotationInt(o, k8sBurstAnnotation, 0)
+	}
+	var total int64
+	for _, c := range spec.Containers {
+		cpu, ok := c.Resources.Requests["cpu"]
+		if !ok {
+			cpu, ok = c.Resources.Limits["cpu"]
+		}
+		if !ok {
+			continue
+		}
+		m, err := parseMillicores(cpu)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %s %s: cpu %q: %v", ErrInvalidProcess, o.Kind, o.Metadata.Name, cpu, err)
+		}
+		total += m
+	}
+	if total < 1 {
+		total = 1
+	}
+
+	return total, nil
+}
+
+func k8sAnnotationInt(o k8sObject, key string, def int64) (int64, error) {
+	v, ok := o.Metadata.Annotations[key]
+	if !ok {
+		return def, nil
+	}
+	i, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s %s: annotation %s: %v", ErrInvalidProcess, o.Kind, o.Metadata.Name, key, err)
+	}
+
+	return i, nil
+}
+
+// parseMillicores parses a Kubernetes CPU quantity ("500m", "2", "0.25") into millicores.
+func parseMillicores(q string) (int64, error) {
+	q = strings.TrimSpace(q)
+	if strings.HasSuffix(q, "m") {
+		return strconv.ParseInt(strings.TrimSuffix(q, "m"), 10, 64)
+	}
+	cores, err := strconv.ParseFloat(q, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(math.Ceil(cores * 1000)), nil
+}
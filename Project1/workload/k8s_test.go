@@ -0,0 +1,182 @@
+package workload
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+func Test_loadKubernetes(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		yaml    string
+		want    []scheduler.Process
+		wantErr bool
+	}{
+		{
+			name: "pods and classes",
+			yaml: `apiVersion: scheduling.k8s.io/v1
+kind: PriorityClass
+metadata:
+  name: high
+value: 1000
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: web
+spec:
+  priorityClassName: high
+  containers:
+  - name: app
+    resources:
+      requests:
+        cpu: 250m
+  - name: sidecar
+    resources:
+      limits:
+        cpu: "0.5"
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: batch
+  annotations:
+    scheduler-sim/arrival: "4"
+spec:
+  containers:
+  - name: app
+    resources:
+      requests:
+        cpu: "1"
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: ignored
+`,
+			want: []scheduler.Process{
+				{ProcessID: 1, Name: "web", BurstDuration: 750, Priority: 1},
+				{ProcessID: 2, Name: "batch", BurstDuration: 1000, ArrivalTime: 4, Priority: 2},
+			},
+		},
+		{
+			name: "job completions and builtin class",
+			yaml: `kind: List
+items:
+- kind: Job
+  metadata:
+    name: etl
+    annotations:
+      scheduler-sim/burst: "7"
+  spec:
+    completions: 2
+    template:
+      spec:
+        priorityClassName: system-cluster-critical
+        containers:
+        - name: etl
+- kind: Pod
+  metadata:
+    name: idle
+  spec:
+    priority: 5
+    containers:
+    - name: sleep
+`,
+			want: []scheduler.Process{
+				{ProcessID: 1, Name: "etl-0", BurstDuration: 7, Priority: 1},
+				{ProcessID: 2, Name: "etl-1", BurstDuration: 7, Priority: 1},
+				{ProcessID: 3, Name: "idle", BurstDuration: 1, Priority: 2},
+			},
+		},
+		{
+			name: "bad cpu",
+			yaml: `kind: Pod
+metadata:
+  name: web
+spec:
+  containers:
+  - resources:
+      requests:
+        cpu: lots
+`,
+			wantErr: true,
+		},
+		{
+			name: "bad annotation",
+			yaml: `kind: Pod
+metadata:
+  name: web
+  annotations:
+    scheduler-sim/burst: soon
+`,
+			wantErr: true,
+		},
+		{
+			name:    "bad yaml",
+			yaml:    "kind: [Pod",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := loadKubernetes(strings.NewReader(tt.yaml))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_parseMillicores(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		q       string
+		want    int64
+		wantErr bool
+	}{
+		{q: "500m", want: 500},
+		{q: "2", want: 2000},
+		{q: "0.1", want: 100},
+		{q: "x", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.q, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseMillicores(tt.q)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestImporterFor(t *testing.T) {
+	t.Parallel()
+	csv := strings.NewReader("1,5,0,2\n")
+	got, err := ImporterFor(FormatAuto, "workload.csv")(csv)
+	require.NoError(t, err)
+	assert.Equal(t, []scheduler.Process{{ProcessID: 1, BurstDuration: 5, Priority: 2}}, got)
+
+	got, err = ImporterFor(FormatAuto, "pods.YAML")(strings.NewReader("kind: Pod\nmetadata:\n  name: a\n"))
+	require.NoError(t, err)
+	assert.Equal(t, []scheduler.Process{{ProcessID: 1, Name: "a", BurstDuration: 1, Priority: 1}}, got)
+
+	_, err = ImporterFor("csv", "pods.yaml")(strings.NewReader("kind: Pod\n"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,54 @@
+package workload
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+var ErrInvalidReservation = errors.New("invalid reservation")
+
+// LoadReservations parses <Start>,<Duration>,<CPUs> records of CPUs blocked out in advance, as
+// CSV workloads are parsed. Starts must not be negative, durations and CPUs must be positive, and
+// every reservation must end by the largest int64 time.
+func LoadReservations(r io.Reader) ([]scheduler.Reservation, error) {
+	var (
+		sc           = bufio.NewScanner(r)
+		reservations []scheduler.Reservation
+		fields       [][]byte
+		line         int
+	)
+	sc.Buffer(make([]byte, 0, 4096), MaxLineLength)
+	for sc.Scan() {
+		line++
+		fields = SplitFields(fields[:0], sc.Bytes())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("%w: line %d: want start, duration and CPUs, got %d fields", ErrInvalidReservation, line, len(fields))
+		}
+		var vals [3]int64
+		for i, f := range fields {
+			v, err := ParseInt(f)
+			if err != nil {
+				return nil, fmt.Errorf("%w: line %d: field %d %q: %v", ErrInvalidReservation, line, i+1, f, err)
+			}
+			vals[i] = v
+		}
+		res := scheduler.Reservation{Start: vals[0], Duration: vals[1], CPUs: vals[2]}
+		if res.Start < 0 || res.Duration <= 0 || res.CPUs <= 0 || res.Duration > math.MaxInt64-res.Start {
+			return nil, fmt.Errorf("%w: line %d: %s", ErrInvalidReservation, line, res)
+		}
+		reservations = append(reservations, res)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("%w: reading reservations", err)
+	}
+
+	return reservations, nil
+}
@@ -0,0 +1,23 @@
+package workload
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+func TestLoadReservations(t *testing.T) {
+	t.Parallel()
+	got, err := LoadReservations(strings.NewReader("100, 20, 4\n\n\"0\",5,1\n"))
+	require.NoError(t, err)
+	assert.Equal(t, []scheduler.Reservation{{Start: 100, Duration: 20, CPUs: 4}, {Duration: 5, CPUs: 1}}, got)
+
+	for _, bad := range []string{"1,2", "1,2,3,4", "a,2,3", "-1,2,3", "1,0,3", "1,2,0", "9223372036854775800,8,1"} {
+		_, err := LoadReservations(strings.NewReader(bad))
+		assert.ErrorIs(t, err, ErrInvalidReservation, bad)
+	}
+}
@@ -0,0 +1,183 @@
+package workload
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+// slurmTimeLayout is how sacct prints Submit and Start.
+const slurmTimeLayout = "2006-01-02T15:04:05"
+
+// slurmJob is one job allocation from a sacct export.
+type slurmJob struct {
+	name     string
+	arrival  time.Time
+	elapsed  int64
+	cpus     int64
+	priority int64
+}
+
+// loadSlurm imports Slurm job accounting, as exported by
+//
+//	sacct --parsable2 --allocations --format=JobID,JobName,Submit,Start,Elapsed,Priority,ReqCPUS
+//
+// Columns are found by header name, so extra columns and any order are fine; only JobID and
+// Elapsed are required, plus Submit or Start for the arrival. Times are in seconds:
+//   - arrival is the job's submit time (falling back to its start) relative to the earliest job;
+//   - burst is elapsed seconds times the requested CPUs, i.e. the job's CPU-seconds on one CPU;
+//   - priority ranks the Slurm priorities, highest first, as 1, 2, 3, ...
+//
+// Job steps (IDs such as 1234.batch) and jobs that never started are skipped.
+func loadSlurm(r io.Reader) ([]scheduler.Process, error) {
+	var (
+		jobs    []slurmJob
+		columns map[string]int
+		scanner = bufio.NewScanner(r)
+		line    int
+	)
+	scanner.Buffer(make([]byte, 0, 4096), MaxLineLength)
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		fields := strings.Split(strings.TrimSuffix(text, "|"), "|")
+		if columns == nil {
+			columns = make(map[string]int, len(fields))
+			for i, h := range fields {
+				columns[strings.ToLower(strings.TrimSpace(h))] = i
+			}
+			for _, required := range []string{"jobid", "elapsed"} {
+				if _, ok := columns[required]; !ok {
+					return nil, fmt.Errorf("%w: line %d: missing sacct column %q", ErrInvalidProcess, line, required)
+				}
+			}
+			continue
+		}
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(fields) {
+				return strings.TrimSpace(fields[i])
+			}
+			return ""
+		}
+
+		id := field("jobid")
+		if strings.Contains(id, ".") {
+			continue
+		}
+		job, ok, err := parseSlurmJob(id, field)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: job %s: %v", ErrInvalidProcess, line, id, err)
+		}
+		if ok {
+			jobs = append(jobs, job)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: reading sacct export", err)
+	}
+
+	var first time.Time
+	values := make([]int64, len(jobs))
+	for i, j := range jobs {
+		if i == 0 || j.arrival.Before(first) {
+			first = j.arrival
+		}
+		values[i] = j.priority
+	}
+	rank := rankPriorities(values)
+
+	processes := make([]scheduler.Process, len(jobs))
+	for i, j := range jobs {
+		burst := j.elapsed * j.cpus
+		if burst < 1 {
+			burst = 1
+		}
+		processes[i] = scheduler.Process{
+			ProcessID:     int64(i + 1),
+			Name:          j.name,
+			ArrivalTime:   int64(j.arrival.Sub(first) / time.Second),
+			BurstDuration: burst,
+			Priority:      rank[i],
+		}
+	}
+
+	return processes, nil
+}
+
+// parseSlurmJob parses one sacct row; ok is false for jobs that never started.
+func parseSlurmJob(id string, field func(string) string) (job slurmJob, ok bool, err error) {
+	start, started := parseSlurmTime(field("start"))
+	if field("start") != "" && !started {
+		return job, false, nil
+	}
+	arrival, submitted := parseSlurmTime(field("submit"))
+	switch {
+	case submitted:
+		job.arrival = arrival
+	case started:
+		job.arrival = start
+	default:
+		return job, false, fmt.Errorf("no submit or start time")
+	}
+
+	job.name = field("jobname")
+	if job.name == "" {
+		job.name = id
+	}
+	if job.elapsed, err = parseSlurmDuration(field("elapsed")); err != nil {
+		return job, false, fmt.Errorf("elapsed: %w", err)
+	}
+	job.cpus = 1
+	if s := field("reqcpus"); s != "" {
+		if job.cpus, err = strconv.ParseInt(s, 10, 64); err != nil {
+			return job, false, fmt.Errorf("reqcpus: %w", err)
+		}
+	}
+	if s := field("priority"); s != "" {
+		if job.priority, err = strconv.ParseInt(s, 10, 64); err != nil {
+			return job, false, fmt.Errorf("priority: %w", err)
+		}
+	}
+
+	return job, true, nil
+}
+
+// parseSlurmTime parses a sacct timestamp; "Unknown", "None" and blanks are not times.
+func parseSlurmTime(s string) (time.Time, bool) {
+	t, err := time.Parse(slurmTimeLayout, s)
+	return t, err == nil
+}
+
+// parseSlurmDuration parses a Slurm duration, [DD-[HH:]]MM:SS, into seconds.
+func parseSlurmDuration(s string) (int64, error) {
+	var days int64
+	if d, rest, ok := strings.Cut(s, "-"); ok {
+		var err error
+		if days, err = strconv.ParseInt(d, 10, 64); err != nil {
+			return 0, err
+		}
+		s = rest
+	}
+	parts := strings.Split(s, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, fmt.Errorf("%q: %w", s, strconv.ErrSyntax)
+	}
+	var secs int64
+	for _, p := range parts {
+		n, err := strconv.ParseInt(p, 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		secs = secs*60 + n
+	}
+
+	return days*24*60*60 + secs, nil
+}
@@ -0,0 +1,104 @@
+package workload
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+func Test_loadSlurm(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		input   string
+		want    []scheduler.Process
+		wantErr bool
+	}{
+		{
+			name: "parsable2",
+			input: `JobID|JobName|Submit|Start|Elapsed|Priority|ReqCPUS
+1001|sim|2024-03-01T08:00:00|2024-03-01T08:00:05|00:10:00|4000|2
+1001.batch|batch|2024-03-01T08:00:05|2024-03-01T08:00:05|00:10:00||2
+1002|post|2024-03-01T08:01:30|2024-03-01T08:20:00|1-00:00:01|9000|1
+1003|queued|2024-03-01T08:02:00|Unknown|00:00:00|100|4
+1004|tiny|2024-03-01T07:59:00|2024-03-01T07:59:00|00:00|4000|1
+`,
+			want: []scheduler.Process{
+				{ProcessID: 1, Name: "sim", ArrivalTime: 60, BurstDuration: 1200, Priority: 2},
+				{ProcessID: 2, Name: "post", ArrivalTime: 150, BurstDuration: 86401, Priority: 1},
+				{ProcessID: 3, Name: "tiny", BurstDuration: 1, Priority: 2},
+			},
+		},
+		{
+			name: "parsable with trailing bar and minimal columns",
+			input: `JobID|Start|Elapsed|
+7|2024-03-01T08:00:00|05:00|
+8|2024-03-01T08:00:10|01:00:00|
+`,
+			want: []scheduler.Process{
+				{ProcessID: 1, Name: "7", BurstDuration: 300, Priority: 1},
+				{ProcessID: 2, Name: "8", ArrivalTime: 10, BurstDuration: 3600, Priority: 1},
+			},
+		},
+		{
+			name:    "missing elapsed column",
+			input:   "JobID|Submit\n1|2024-03-01T08:00:00\n",
+			wantErr: true,
+		},
+		{
+			name:    "bad elapsed",
+			input:   "JobID|Submit|Elapsed\n1|2024-03-01T08:00:00|soon\n",
+			wantErr: true,
+		},
+		{
+			name:    "no times",
+			input:   "JobID|Elapsed\n1|00:01\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := loadSlurm(strings.NewReader(tt.input))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_parseSlurmDuration(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		in      string
+		want    int64
+		wantErr bool
+	}{
+		{in: "00:30", want: 30},
+		{in: "01:02:03", want: 3723},
+		{in: "2-00:00:00", want: 172800},
+		{in: "12", wantErr: true},
+		{in: "x-01:00", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.in, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseSlurmDuration(tt.in)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
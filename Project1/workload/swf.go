@@ -0,0 +1,107 @@
+package workload
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+// The Standard Workload Format fields loadSWF reads, numbered from 0; -1 marks a missing value.
+const (
+	swfJob = iota
+	swfSubmit
+	swfWait
+	swfRun
+	swfAllocated
+	swfAverageCPU
+	swfMemory
+	swfRequested
+	swfRequestedTime
+	swfRequestedMemory
+	swfStatus
+	swfUser
+)
+
+// loadSWF imports a trace in the Standard Workload Format of the Parallel Workloads Archive:
+// one job per line of whitespace-separated fields, with ; starting a header comment. Times are
+// in seconds:
+//   - arrival is the job's submit time relative to the earliest job;
+//   - burst is its run time, for which it holds all its processors, so it is wall time rather
+//     than CPU-seconds as for Slurm;
+//   - Resources ask for its allocated processors (falling back to the requested ones) as CPUs;
+//   - the name is its user, e.g. "user 3", so the batch schedulers share the pool between users;
+//   - priority is 1, as SWF records none.
+//
+// Jobs without a run time, such as those cancelled before they started, are skipped.
+func loadSWF(r io.Reader) ([]scheduler.Process, error) {
+	var (
+		processes []scheduler.Process
+		first     int64
+		scanner   = bufio.NewScanner(r)
+		line      int
+	)
+	scanner.Buffer(make([]byte, 0, 4096), MaxLineLength)
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, ";") {
+			continue
+		}
+		fields := strings.Fields(text)
+		if len(fields) <= swfAllocated {
+			return nil, fmt.Errorf("%w: line %d: want at least %d SWF fields, got %d", ErrInvalidProcess, line, swfAllocated+1, len(fields))
+		}
+		values := make([]int64, len(fields))
+		for i, f := range fields {
+			// Some archive traces record the average CPU time and memory as decimals.
+			v, err := strconv.ParseFloat(f, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%w: line %d: field %d %q: %v", ErrInvalidProcess, line, i+1, f, err)
+			}
+			values[i] = int64(v)
+		}
+		field := func(i int) int64 {
+			if i < len(values) {
+				return values[i]
+			}
+			return -1
+		}
+		if field(swfRun) < 0 || field(swfSubmit) < 0 {
+			continue
+		}
+
+		p := scheduler.Process{
+			ProcessID:     int64(len(processes) + 1),
+			Name:          "job " + fields[swfJob],
+			ArrivalTime:   field(swfSubmit),
+			BurstDuration: field(swfRun),
+			Priority:      1,
+		}
+		if user := field(swfUser); user >= 0 {
+			p.Name = "user " + strconv.FormatInt(user, 10)
+		}
+		p.Resources[scheduler.ResourceCPU] = field(swfAllocated)
+		if p.Resources[scheduler.ResourceCPU] <= 0 {
+			p.Resources[scheduler.ResourceCPU] = field(swfRequested)
+		}
+		if p.Resources[scheduler.ResourceCPU] <= 0 {
+			p.Resources[scheduler.ResourceCPU] = 1
+		}
+		if len(processes) == 0 || p.ArrivalTime < first {
+			first = p.ArrivalTime
+		}
+		processes = append(processes, p)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: reading SWF trace", err)
+	}
+	for i := range processes {
+		processes[i].ArrivalTime -= first
+	}
+
+	return processes, nil
+}
@@ -0,0 +1,68 @@
+package workload
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+func Test_loadSWF(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		input   string
+		want    []scheduler.Process
+		wantErr bool
+	}{
+		{
+			name: "archive trace",
+			input: `; Version: 2.2
+; MaxProcs: 128
+    1   100   5  3600   64  3590.5  -1   64  7200  -1  1   3  1  -1  1  -1  -1  -1
+    2   160   0    60   -1  -1      -1    8   120  -1  1   7  1  -1  1  -1  -1  -1
+    3   170  -1    -1   16  -1      -1   16   600  -1  5   3  1  -1  1  -1  -1  -1
+
+    4    90   0    10    1  -1      -1    1    60  -1  1  -1  1  -1  1  -1  -1  -1
+`,
+			want: []scheduler.Process{
+				{ProcessID: 1, Name: "user 3", ArrivalTime: 10, BurstDuration: 3600, Priority: 1, Resources: scheduler.Resources{64, 0}},
+				{ProcessID: 2, Name: "user 7", ArrivalTime: 70, BurstDuration: 60, Priority: 1, Resources: scheduler.Resources{8, 0}},
+				{ProcessID: 3, Name: "job 4", BurstDuration: 10, Priority: 1, Resources: scheduler.Resources{1, 0}},
+			},
+		},
+		{
+			name:  "only the first five fields",
+			input: "1 0 0 30 -1\n",
+			want: []scheduler.Process{
+				{ProcessID: 1, Name: "job 1", BurstDuration: 30, Priority: 1, Resources: scheduler.Resources{1, 0}},
+			},
+		},
+		{
+			name:    "too few fields",
+			input:   "1 0 0 30\n",
+			wantErr: true,
+		},
+		{
+			name:    "not a number",
+			input:   "1 0 0 soon 4\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := loadSWF(strings.NewReader(tt.input))
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidProcess)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
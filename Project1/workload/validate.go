@@ -0,0 +1,232 @@
+package workload
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+// InvalidPolicy is what loading does with a process whose values cannot be simulated.
+type InvalidPolicy string
+
+const (
+	// InvalidError rejects the workload, listing every invalid row.
+	InvalidError InvalidPolicy = "error"
+	// InvalidClamp moves each invalid value to the nearest valid one.
+	InvalidClamp InvalidPolicy = "clamp"
+	// InvalidSkip drops the invalid rows.
+	InvalidSkip InvalidPolicy = "skip"
+)
+
+// MaxPriority is the largest priority accepted. Priorities rank processes (1 is most important),
+// so anything larger is taken to be a data error rather than a rank.
+const MaxPriority = 1 << 20
+
+var (
+	ErrTimeOverflow      = fmt.Errorf("%w: times overflow int64", ErrInvalidProcess)
+	ErrInvalidPolicyName = fmt.Errorf("%w: invalid values must be handled by error, clamp or skip", scheduler.ErrInvalidArgs)
+	ErrInvalidValue      = fmt.Errorf("%w: value out of range", ErrInvalidProcess)
+)
+
+// ParseInvalidPolicy parses an --invalid policy name, ignoring case.
+func ParseInvalidPolicy(s string) (InvalidPolicy, error) {
+	switch p := InvalidPolicy(strings.ToLower(s)); p {
+	case InvalidError, InvalidClamp, InvalidSkip:
+		return p, nil
+	default:
+		return "", fmt.Errorf("%w: got %q", ErrInvalidPolicyName, s)
+	}
+}
+
+// valueProblem is one out-of-range field of a process.
+type valueProblem struct {
+	field        string
+	value, clamp int64
+}
+
+// processProblems lists the fields of p that cannot be simulated: negative arrival or burst times,
+// priorities outside 0..MaxPriority, negative deadlines, runtimes, periods or I/O times and
+// negative SLA targets or resources.
+func processProblems(p scheduler.Process) []valueProblem {
+	var problems []valueProblem
+	if p.ArrivalTime < 0 {
+		problems = append(problems, valueProblem{"arrival", p.ArrivalTime, 0})
+	}
+	if p.BurstDuration < 0 {
+		problems = append(problems, valueProblem{"burst", p.BurstDuration, 0})
+	}
+	if p.Priority < 0 {
+		problems = append(problems, valueProblem{"priority", p.Priority, 0})
+	} else if p.Priority > MaxPriority {
+		problems = append(problems, valueProblem{"priority", p.Priority, MaxPriority})
+	}
+	for _, f := range [...]struct {
+		field string
+		value int64
+	}{{"deadline", p.Deadline}, {"runtime", p.Runtime}, {"period", p.Period},
+		{"I/O interval", p.IOInterval}, {"I/O duration", p.IODuration}} {
+		if f.value < 0 {
+			problems = append(problems, valueProblem{f.field, f.value, 0})
+		}
+	}
+	if p.SLA.Turnaround < 0 {
+		problems = append(problems, valueProblem{"SLA turnaround", p.SLA.Turnaround, 0})
+	}
+	if p.SLA.Response < 0 {
+		problems = append(problems, valueProblem{"SLA response", p.SLA.Response, 0})
+	}
+	for r, n := range p.Resources {
+		if n < 0 {
+			problems = append(problems, valueProblem{scheduler.Resource(r).String() + "s", n, 0})
+		}
+	}
+
+	return problems
+}
+
+// Sanitize handles every process with invalid values by the policy, writing a warning
+// naming the workload path to w for each row clamped or skipped. Rows are numbered by their
+// position in the workload, from 1. Under InvalidError the workload is rejected with every
+// invalid row named.
+func Sanitize(w io.Writer, path string, processes []scheduler.Process, policy InvalidPolicy) ([]scheduler.Process, error) {
+	var (
+		kept     = processes[:0]
+		rejected []string
+	)
+	for i, p := range processes {
+		problems := processProblems(p)
+		if len(problems) == 0 {
+			kept = append(kept, p)
+			continue
+		}
+		descriptions := make([]string, len(problems))
+		for j, pr := range problems {
+			descriptions[j] = fmt.Sprintf("%s %d", pr.field, pr.value)
+		}
+		row := fmt.Sprintf("row %d (ID %d): %s", i+1, p.ProcessID, strings.Join(descriptions, ", "))
+		switch policy {
+		case InvalidClamp:
+			for _, pr := range problems {
+				switch pr.field {
+				case "arrival":
+					p.ArrivalTime = pr.clamp
+				case "burst":
+					p.BurstDuration = pr.clamp
+				case "priority":
+					p.Priority = pr.clamp
+				case "deadline":
+					p.Deadline = pr.clamp
+				case "runtime":
+					p.Runtime = pr.clamp
+				case "period":
+					p.Period = pr.clamp
+				case "I/O interval":
+					p.IOInterval = pr.clamp
+				case "I/O duration":
+					p.IODuration = pr.clamp
+				case "SLA turnaround":
+					p.SLA.Turnaround = pr.clamp
+				case "SLA response":
+					p.SLA.Response = pr.clamp
+				case "cpus":
+					p.Resources[scheduler.ResourceCPU] = pr.clamp
+				case "gpus":
+					p.Resources[scheduler.ResourceGPU] = pr.clamp
+				}
+			}
+			kept = append(kept, p)
+			_, _ = fmt.Fprintf(w, "warning: %s: %s clamped\n", path, row)
+		case InvalidSkip:
+			_, _ = fmt.Fprintf(w, "warning: %s: %s skipped\n", path, row)
+		default:
+			rejected = append(rejected, row)
+		}
+	}
+	if len(rejected) > 0 {
+		return nil, fmt.Errorf("%w: %s (--invalid clamp or skip loads them anyway)", ErrInvalidValue, strings.Join(rejected, "; "))
+	}
+
+	return kept, nil
+}
+
+// CheckTimeRange rejects a workload whose schedule could run past the largest int64 time. No
+// process of a work-conserving schedule completes later than the last arrival plus the total
+// burst time, but the CPU may also idle with work left: while processes with a reservation (see
+// Reserved) wait for their next period under CBS or the sporadic server, for at most a period
+// per runtime of their burst, while every process is blocked for I/O, for at most an IODuration
+// per IOInterval of its burst, and while the batch schedulers wait for reservations to end, until
+// the last one does. If the last arrival plus the total burst and that idle time fits, every exit,
+// turnaround and wait does too. Each process's arrival plus its deadline must fit as well. Times
+// must already be non-negative.
+func CheckTimeRange(processes []scheduler.Process, reservations ...scheduler.Reservation) error {
+	var lastArrival, total, idle int64
+	for i, p := range processes {
+		if p.Deadline > math.MaxInt64-p.ArrivalTime {
+			return fmt.Errorf("%w: arrival %d plus deadline %d passes %d at row %d (ID %d)", ErrTimeOverflow, p.ArrivalTime, p.Deadline, int64(math.MaxInt64), i+1, p.ProcessID)
+		}
+		if p.BurstDuration > math.MaxInt64-total {
+			return fmt.Errorf("%w: the total burst time passes %d at row %d (ID %d)", ErrTimeOverflow, int64(math.MaxInt64), i+1, p.ProcessID)
+		}
+		total += p.BurstDuration
+		if p.Reserved() {
+			// A period for every runtime of the burst, and one for the part of a runtime left.
+			periods := p.BurstDuration/p.Runtime + 1
+			if periods > math.MaxInt64/p.Period || periods*p.Period > math.MaxInt64-idle {
+				return fmt.Errorf("%w: waiting %d periods of %d for a runtime of %d passes %d at row %d (ID %d)", ErrTimeOverflow,
+					periods, p.Period, p.Runtime, int64(math.MaxInt64), i+1, p.ProcessID)
+			}
+			idle += periods * p.Period
+		}
+		if p.IOInterval > 0 {
+			blocks := p.BurstDuration/p.IOInterval + 1
+			if blocks > 0 && p.IODuration > (math.MaxInt64-idle)/blocks {
+				return fmt.Errorf("%w: blocking %d times for I/O of %d passes %d at row %d (ID %d)", ErrTimeOverflow,
+					blocks, p.IODuration, int64(math.MaxInt64), i+1, p.ProcessID)
+			}
+			idle += blocks * p.IODuration
+		}
+		if p.ArrivalTime > lastArrival {
+			lastArrival = p.ArrivalTime
+		}
+	}
+	if total > math.MaxInt64-lastArrival {
+		return fmt.Errorf("%w: the last arrival %d plus the total burst time %d passes %d", ErrTimeOverflow, lastArrival, total, int64(math.MaxInt64))
+	}
+	var lastEnd int64
+	for _, r := range reservations {
+		if r.End() > lastEnd {
+			lastEnd = r.End()
+		}
+	}
+	if lastEnd > math.MaxInt64-idle || idle+lastEnd > math.MaxInt64-lastArrival-total {
+		return fmt.Errorf("%w: the last arrival %d plus the total burst time %d and up to %d idle waiting for periods and reservations passes %d",
+			ErrTimeOverflow, lastArrival, total, idle+lastEnd, int64(math.MaxInt64))
+	}
+
+	return nil
+}
+
+var ErrInvalidTimescale = fmt.Errorf("%w: timescale must be a positive number", scheduler.ErrInvalidArgs)
+
+// ScaleArrivals multiplies every arrival time by factor, rounding to the nearest tick, so a long
+// trace can be compressed (factor < 1) or stretched (factor > 1) while keeping its relative
+// arrival pattern. Bursts are left alone. Arrival times must already be non-negative.
+func ScaleArrivals(processes []scheduler.Process, factor float64) error {
+	if !(factor > 0) || math.IsInf(factor, 0) {
+		return fmt.Errorf("%w: got %g", ErrInvalidTimescale, factor)
+	}
+	for i := range processes {
+		// float64(math.MaxInt64) rounds up to 2^63, which no int64 reaches.
+		scaled := math.Round(float64(processes[i].ArrivalTime) * factor)
+		if scaled >= float64(math.MaxInt64) {
+			return fmt.Errorf("%w: arrival %d scaled by %g at row %d (ID %d)", ErrTimeOverflow,
+				processes[i].ArrivalTime, factor, i+1, processes[i].ProcessID)
+		}
+		processes[i].ArrivalTime = int64(scaled)
+	}
+
+	return nil
+}
@@ -0,0 +1,129 @@
+package workload
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/jh125486/CSCE4600/Project1/scheduler"
+)
+
+func TestParseInvalidPolicy(t *testing.T) {
+	t.Parallel()
+	p, err := ParseInvalidPolicy("Skip")
+	require.NoError(t, err)
+	assert.Equal(t, InvalidSkip, p)
+	_, err = ParseInvalidPolicy("ignore")
+	assert.ErrorIs(t, err, ErrInvalidPolicyName)
+}
+
+func TestSanitize(t *testing.T) {
+	t.Parallel()
+	workload := func() []scheduler.Process {
+		return []scheduler.Process{
+			{ProcessID: 1, ArrivalTime: 0, BurstDuration: 3, Priority: 1},
+			{ProcessID: 2, ArrivalTime: -4, BurstDuration: -1, Priority: 2},
+			{ProcessID: 3, ArrivalTime: 2, BurstDuration: 1, Priority: MaxPriority + 1, Period: -3, IODuration: -2},
+			{ProcessID: 4, ArrivalTime: 2, BurstDuration: 1, SLA: scheduler.SLA{Turnaround: 9, Response: -5}},
+		}
+	}
+	tests := []struct {
+		name     string
+		policy   InvalidPolicy
+		want     []scheduler.Process
+		wantErr  string
+		wantWarn string
+	}{
+		{
+			name:    "error",
+			policy:  InvalidError,
+			wantErr: "row 2 (ID 2): arrival -4, burst -1; row 3 (ID 3): priority 1048577, period -3, I/O duration -2; row 4 (ID 4): SLA response -5",
+		},
+		{
+			name:   "clamp",
+			policy: InvalidClamp,
+			want: []scheduler.Process{
+				{ProcessID: 1, ArrivalTime: 0, BurstDuration: 3, Priority: 1},
+				{ProcessID: 2, ArrivalTime: 0, BurstDuration: 0, Priority: 2},
+				{ProcessID: 3, ArrivalTime: 2, BurstDuration: 1, Priority: MaxPriority},
+				{ProcessID: 4, ArrivalTime: 2, BurstDuration: 1, SLA: scheduler.SLA{Turnaround: 9}},
+			},
+			wantWarn: "warning: w.csv: row 2 (ID 2): arrival -4, burst -1 clamped\nwarning: w.csv: row 3 (ID 3): priority 1048577, period -3, I/O duration -2 clamped\n" +
+				"warning: w.csv: row 4 (ID 4): SLA response -5 clamped\n",
+		},
+		{
+			name:   "skip",
+			policy: InvalidSkip,
+			want:   []scheduler.Process{{ProcessID: 1, ArrivalTime: 0, BurstDuration: 3, Priority: 1}},
+			wantWarn: "warning: w.csv: row 2 (ID 2): arrival -4, burst -1 skipped\nwarning: w.csv: row 3 (ID 3): priority 1048577, period -3, I/O duration -2 skipped\n" +
+				"warning: w.csv: row 4 (ID 4): SLA response -5 skipped\n",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var warnings strings.Builder
+			got, err := Sanitize(&warnings, "w.csv", workload(), tt.policy)
+			if tt.wantErr != "" {
+				assert.ErrorIs(t, err, ErrInvalidValue)
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantWarn, warnings.String())
+		})
+	}
+}
+
+func TestCheckTimeRange(t *testing.T) {
+	t.Parallel()
+	const limit = math.MaxInt64
+	assert.NoError(t, CheckTimeRange([]scheduler.Process{{ProcessID: 1, ArrivalTime: limit - 3, BurstDuration: 2}, {ProcessID: 2, BurstDuration: 1}}))
+	assert.ErrorIs(t, CheckTimeRange([]scheduler.Process{{ProcessID: 1, BurstDuration: limit}, {ProcessID: 2, BurstDuration: 1}}), ErrTimeOverflow)
+	assert.ErrorIs(t, CheckTimeRange([]scheduler.Process{{ProcessID: 1, ArrivalTime: limit - 1, BurstDuration: 2}}), ErrTimeOverflow)
+	assert.NoError(t, CheckTimeRange([]scheduler.Process{{ProcessID: 1, ArrivalTime: 3, BurstDuration: 1, Deadline: limit - 3}}))
+	assert.ErrorIs(t, CheckTimeRange([]scheduler.Process{{ProcessID: 1, ArrivalTime: 3, BurstDuration: 1, Deadline: limit - 2}}), ErrTimeOverflow)
+
+	// A reserved process may wait a period for each runtime of its burst, and the batch
+	// schedulers until the last reservation ends.
+	reserved := []scheduler.Process{{ProcessID: 1, BurstDuration: 10, Runtime: 2, Period: limit / 8}}
+	assert.NoError(t, CheckTimeRange(reserved))
+	reserved[0].Period = limit / 5
+	assert.ErrorIs(t, CheckTimeRange(reserved), ErrTimeOverflow)
+	// A process blocked for I/O may idle the CPU for an IODuration per IOInterval of its burst.
+	io := []scheduler.Process{{ProcessID: 1, BurstDuration: 10, IOInterval: 5, IODuration: limit / 4}}
+	assert.NoError(t, CheckTimeRange(io))
+	io[0].IODuration = limit / 2
+	assert.ErrorIs(t, CheckTimeRange(io), ErrTimeOverflow)
+	short := []scheduler.Process{{ProcessID: 1, ArrivalTime: 10, BurstDuration: 5}}
+	assert.NoError(t, CheckTimeRange(short, scheduler.Reservation{Start: limit - 30, Duration: 10, CPUs: 1}))
+	assert.ErrorIs(t, CheckTimeRange(short, scheduler.Reservation{Start: limit - 12, Duration: 10, CPUs: 1}), ErrTimeOverflow)
+}
+
+func TestScaleArrivals(t *testing.T) {
+	t.Parallel()
+	processes := []scheduler.Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5},
+		{ProcessID: 2, ArrivalTime: 86_400, BurstDuration: 7},
+		{ProcessID: 3, ArrivalTime: 150, BurstDuration: 1},
+	}
+	require.NoError(t, ScaleArrivals(processes, 0.01))
+	assert.Equal(t, []scheduler.Process{
+		{ProcessID: 1, ArrivalTime: 0, BurstDuration: 5},
+		{ProcessID: 2, ArrivalTime: 864, BurstDuration: 7},
+		{ProcessID: 3, ArrivalTime: 2, BurstDuration: 1},
+	}, processes, "arrivals round to the nearest tick and bursts are kept")
+
+	require.NoError(t, ScaleArrivals(processes, 2.5))
+	assert.Equal(t, int64(2160), processes[1].ArrivalTime)
+
+	for _, factor := range []float64{0, -1, math.NaN(), math.Inf(1)} {
+		assert.ErrorIs(t, ScaleArrivals(processes, factor), ErrInvalidTimescale, factor)
+	}
+	assert.ErrorIs(t, ScaleArrivals([]scheduler.Process{{ArrivalTime: math.MaxInt64 / 2}}, 3), ErrTimeOverflow)
+}